@@ -0,0 +1,296 @@
+package fwprovider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV1"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/utils"
+)
+
+const (
+	syntheticsTriggerDefaultTimeout = 30 * time.Minute
+	syntheticsTriggerPollInterval   = 5 * time.Second
+	syntheticsTriggerSearchPageSize = 100
+)
+
+var (
+	_ action.ActionWithConfigure      = &syntheticsTriggerAction{}
+	_ action.ActionWithValidateConfig = &syntheticsTriggerAction{}
+)
+
+type syntheticsTriggerAction struct {
+	Api          *datadogV1.SyntheticsApi
+	Auth         context.Context
+	pollInterval time.Duration
+}
+
+type syntheticsTriggerModel struct {
+	PublicIDs      types.List   `tfsdk:"public_ids"`
+	Search         types.String `tfsdk:"search"`
+	StartURL       types.String `tfsdk:"start_url"`
+	Variables      types.Map    `tfsdk:"variables"`
+	Locations      types.List   `tfsdk:"locations"`
+	TimeoutSeconds types.Int64  `tfsdk:"timeout_seconds"`
+}
+
+func NewSyntheticsTriggerAction() action.Action {
+	return &syntheticsTriggerAction{pollInterval: syntheticsTriggerPollInterval}
+}
+
+func (a *syntheticsTriggerAction) Configure(_ context.Context, request action.ConfigureRequest, _ *action.ConfigureResponse) {
+	providerData := request.ProviderData.(*FrameworkProvider)
+	a.Api = providerData.DatadogApiInstances.GetSyntheticsApiV1()
+	a.Auth = providerData.Auth
+}
+
+func (a *syntheticsTriggerAction) Metadata(_ context.Context, _ action.MetadataRequest, response *action.MetadataResponse) {
+	response.TypeName = "synthetics_trigger"
+}
+
+func (a *syntheticsTriggerAction) Schema(_ context.Context, _ action.SchemaRequest, response *action.SchemaResponse) {
+	response.Schema = schema.Schema{
+		Description: "Triggers Datadog Synthetics tests and waits for their results. The action fails if any test with a `blocking` execution rule fails; failures of non-blocking tests are reported as warnings. Requires Terraform 1.14 or later.",
+		Attributes: map[string]schema.Attribute{
+			"public_ids": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Public IDs of the Synthetics tests to trigger. Exactly one of `public_ids` or `search` must be set.",
+			},
+			"search": schema.StringAttribute{
+				Optional:    true,
+				Description: "Synthetics search query selecting the tests to trigger, for example `tag:team:checkout`. Exactly one of `public_ids` or `search` must be set.",
+			},
+			"start_url": schema.StringAttribute{
+				Optional:    true,
+				Description: "Starting URL overriding the one defined in each triggered test.",
+			},
+			"variables": schema.MapAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Variables overriding the ones defined in each triggered test.",
+			},
+			"locations": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Locations overriding the ones defined in each triggered test.",
+			},
+			"timeout_seconds": schema.Int64Attribute{
+				Optional:    true,
+				Validators:  []validator.Int64{int64validator.AtLeast(1)},
+				Description: "Maximum number of seconds to wait for the results. Defaults to `1800`.",
+			},
+		},
+	}
+}
+
+func (a *syntheticsTriggerAction) ValidateConfig(ctx context.Context, request action.ValidateConfigRequest, response *action.ValidateConfigResponse) {
+	var config syntheticsTriggerModel
+	response.Diagnostics.Append(request.Config.Get(ctx, &config)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	if config.PublicIDs.IsUnknown() || config.Search.IsUnknown() {
+		return
+	}
+	if config.PublicIDs.IsNull() == config.Search.IsNull() {
+		response.Diagnostics.AddAttributeError(path.Root("public_ids"), "invalid test selection", "exactly one of `public_ids` or `search` must be set")
+	}
+}
+
+func (a *syntheticsTriggerAction) Invoke(ctx context.Context, request action.InvokeRequest, response *action.InvokeResponse) {
+	var config syntheticsTriggerModel
+	response.Diagnostics.Append(request.Config.Get(ctx, &config)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	publicIDs, diags := a.resolveTests(ctx, &config)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	if len(publicIDs) == 0 {
+		response.Diagnostics.AddError("no synthetics tests to trigger", fmt.Sprintf("search %q did not match any test", config.Search.ValueString()))
+		return
+	}
+
+	body, diags := buildSyntheticsTriggerBody(ctx, &config, publicIDs)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	triggerResp, httpResp, err := a.Api.TriggerCITests(a.Auth, *body)
+	if err != nil {
+		response.Diagnostics.Append(utils.FrameworkErrorDiag(utils.TranslateClientError(err, httpResp, "error triggering synthetics tests"), ""))
+		return
+	}
+	batchID := triggerResp.GetBatchId()
+	if batchID == "" {
+		response.Diagnostics.AddError("error triggering synthetics tests", "response did not contain a batch ID")
+		return
+	}
+	sendSyntheticsTriggerProgress(response, fmt.Sprintf("Triggered %d synthetics test(s) in batch %s", len(publicIDs), batchID))
+
+	timeout := syntheticsTriggerDefaultTimeout
+	if !config.TimeoutSeconds.IsNull() {
+		timeout = time.Duration(config.TimeoutSeconds.ValueInt64()) * time.Second
+	}
+
+	batch, diags := a.waitForBatch(ctx, response, batchID, timeout)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	response.Diagnostics.Append(evaluateSyntheticsBatch(batchID, batch)...)
+}
+
+// resolveTests returns the configured public IDs, or the public IDs of every
+// test matching the search query.
+func (a *syntheticsTriggerAction) resolveTests(ctx context.Context, config *syntheticsTriggerModel) ([]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if !config.PublicIDs.IsNull() {
+		var publicIDs []string
+		diags.Append(config.PublicIDs.ElementsAs(ctx, &publicIDs, false)...)
+		return publicIDs, diags
+	}
+
+	var publicIDs []string
+	var start int64
+	for {
+		params := datadogV1.NewSearchTestsOptionalParameters().
+			WithText(config.Search.ValueString()).
+			WithStart(start).
+			WithCount(syntheticsTriggerSearchPageSize)
+		resp, httpResp, err := a.Api.SearchTests(a.Auth, *params)
+		if err != nil {
+			diags.Append(utils.FrameworkErrorDiag(utils.TranslateClientError(err, httpResp, "error searching synthetics tests"), ""))
+			return nil, diags
+		}
+		tests := resp.GetTests()
+		for _, test := range tests {
+			publicIDs = append(publicIDs, test.GetPublicId())
+		}
+		if len(tests) < syntheticsTriggerSearchPageSize {
+			return publicIDs, diags
+		}
+		start += syntheticsTriggerSearchPageSize
+	}
+}
+
+func (a *syntheticsTriggerAction) waitForBatch(ctx context.Context, response *action.InvokeResponse, batchID string, timeout time.Duration) (*datadogV1.SyntheticsBatchDetailsData, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	deadline := time.Now().Add(timeout)
+	lastCompleted := -1
+	for {
+		// The batch status is not part of the documented enum while tests are
+		// still running, so the response is not checked for unparsed fields.
+		resp, httpResp, err := a.Api.GetSyntheticsCIBatch(a.Auth, batchID)
+		if err != nil {
+			diags.Append(utils.FrameworkErrorDiag(utils.TranslateClientError(err, httpResp, "error getting synthetics batch"), ""))
+			return nil, diags
+		}
+		data := resp.GetData()
+		if isSyntheticsBatchDone(&data) {
+			return &data, diags
+		}
+
+		completed := 0
+		for _, result := range data.GetResults() {
+			if result.Status != nil && result.Status.IsValid() {
+				completed++
+			}
+		}
+		if completed != lastCompleted {
+			sendSyntheticsTriggerProgress(response, fmt.Sprintf("Batch %s: %d/%d result(s) received", batchID, completed, len(data.GetResults())))
+			lastCompleted = completed
+		}
+
+		if time.Now().After(deadline) {
+			diags.AddError("timeout waiting for synthetics results", fmt.Sprintf("batch %s did not complete within %s", batchID, timeout))
+			return nil, diags
+		}
+		select {
+		case <-ctx.Done():
+			diags.AddError("interrupted while waiting for synthetics results", ctx.Err().Error())
+			return nil, diags
+		case <-time.After(a.pollInterval):
+		}
+	}
+}
+
+func isSyntheticsBatchDone(data *datadogV1.SyntheticsBatchDetailsData) bool {
+	return data.Status != nil && data.Status.IsValid()
+}
+
+// evaluateSyntheticsBatch turns failed results into diagnostics: an error for
+// tests with a blocking execution rule and a warning otherwise.
+func evaluateSyntheticsBatch(batchID string, data *datadogV1.SyntheticsBatchDetailsData) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var blocking, nonBlocking []string
+	for _, result := range data.GetResults() {
+		if result.GetStatus() != datadogV1.SYNTHETICSBATCHSTATUS_FAILED {
+			continue
+		}
+		description := fmt.Sprintf("%s (%s) from %s, result %s", result.GetTestName(), result.GetTestPublicId(), result.GetLocation(), result.GetResultId())
+		if result.GetExecutionRule() == datadogV1.SYNTHETICSTESTEXECUTIONRULE_BLOCKING {
+			blocking = append(blocking, description)
+		} else {
+			nonBlocking = append(nonBlocking, description)
+		}
+	}
+	if len(nonBlocking) > 0 {
+		diags.AddWarning("non-blocking synthetics tests failed", fmt.Sprintf("batch %s:\n- %s", batchID, strings.Join(nonBlocking, "\n- ")))
+	}
+	if len(blocking) > 0 {
+		diags.AddError("blocking synthetics tests failed", fmt.Sprintf("batch %s:\n- %s", batchID, strings.Join(blocking, "\n- ")))
+	} else if data.GetStatus() == datadogV1.SYNTHETICSBATCHSTATUS_FAILED && len(nonBlocking) == 0 {
+		diags.AddError("synthetics batch failed", fmt.Sprintf("batch %s has status failed", batchID))
+	}
+	return diags
+}
+
+func buildSyntheticsTriggerBody(ctx context.Context, config *syntheticsTriggerModel, publicIDs []string) (*datadogV1.SyntheticsCITestBody, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var variables map[string]string
+	if !config.Variables.IsNull() {
+		diags.Append(config.Variables.ElementsAs(ctx, &variables, false)...)
+	}
+	var locations []string
+	if !config.Locations.IsNull() {
+		diags.Append(config.Locations.ElementsAs(ctx, &locations, false)...)
+	}
+
+	body := datadogV1.NewSyntheticsCITestBodyWithDefaults()
+	for _, publicID := range publicIDs {
+		test := datadogV1.NewSyntheticsCITest(publicID)
+		if !config.StartURL.IsNull() {
+			test.SetStartUrl(config.StartURL.ValueString())
+		}
+		if len(variables) > 0 {
+			test.SetVariables(variables)
+		}
+		if len(locations) > 0 {
+			test.SetLocations(locations)
+		}
+		body.Tests = append(body.Tests, *test)
+	}
+	return body, diags
+}
+
+func sendSyntheticsTriggerProgress(response *action.InvokeResponse, message string) {
+	if response.SendProgress != nil {
+		response.SendProgress(action.InvokeProgressEvent{Message: message})
+	}
+}
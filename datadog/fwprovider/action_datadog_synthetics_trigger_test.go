@@ -0,0 +1,63 @@
+package fwprovider
+
+import (
+	"testing"
+
+	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV1"
+	"github.com/stretchr/testify/assert"
+)
+
+func newSyntheticsBatchResult(publicID string, status datadogV1.SyntheticsBatchStatus, rule datadogV1.SyntheticsTestExecutionRule) datadogV1.SyntheticsBatchResult {
+	result := datadogV1.SyntheticsBatchResult{}
+	result.SetTestPublicId(publicID)
+	result.SetTestName("test " + publicID)
+	result.SetStatus(status)
+	result.SetExecutionRule(rule)
+	return result
+}
+
+func TestEvaluateSyntheticsBatch(t *testing.T) {
+	t.Run("passed batch", func(t *testing.T) {
+		data := datadogV1.SyntheticsBatchDetailsData{}
+		data.SetStatus(datadogV1.SYNTHETICSBATCHSTATUS_PASSED)
+		data.SetResults([]datadogV1.SyntheticsBatchResult{
+			newSyntheticsBatchResult("aaa-aaa-aaa", datadogV1.SYNTHETICSBATCHSTATUS_PASSED, datadogV1.SYNTHETICSTESTEXECUTIONRULE_BLOCKING),
+		})
+		assert.Empty(t, evaluateSyntheticsBatch("batch", &data))
+	})
+
+	t.Run("non-blocking failure is a warning", func(t *testing.T) {
+		data := datadogV1.SyntheticsBatchDetailsData{}
+		data.SetStatus(datadogV1.SYNTHETICSBATCHSTATUS_PASSED)
+		data.SetResults([]datadogV1.SyntheticsBatchResult{
+			newSyntheticsBatchResult("aaa-aaa-aaa", datadogV1.SYNTHETICSBATCHSTATUS_FAILED, datadogV1.SYNTHETICSTESTEXECUTIONRULE_NON_BLOCKING),
+		})
+		diags := evaluateSyntheticsBatch("batch", &data)
+		assert.False(t, diags.HasError())
+		assert.Equal(t, 1, diags.WarningsCount())
+		assert.Contains(t, diags[0].Detail(), "aaa-aaa-aaa")
+	})
+
+	t.Run("blocking failure is an error", func(t *testing.T) {
+		data := datadogV1.SyntheticsBatchDetailsData{}
+		data.SetStatus(datadogV1.SYNTHETICSBATCHSTATUS_FAILED)
+		data.SetResults([]datadogV1.SyntheticsBatchResult{
+			newSyntheticsBatchResult("aaa-aaa-aaa", datadogV1.SYNTHETICSBATCHSTATUS_PASSED, datadogV1.SYNTHETICSTESTEXECUTIONRULE_BLOCKING),
+			newSyntheticsBatchResult("bbb-bbb-bbb", datadogV1.SYNTHETICSBATCHSTATUS_FAILED, datadogV1.SYNTHETICSTESTEXECUTIONRULE_BLOCKING),
+		})
+		diags := evaluateSyntheticsBatch("batch", &data)
+		assert.Equal(t, 1, diags.ErrorsCount())
+		assert.Contains(t, diags.Errors()[0].Detail(), "bbb-bbb-bbb")
+		assert.NotContains(t, diags.Errors()[0].Detail(), "aaa-aaa-aaa")
+	})
+}
+
+func TestIsSyntheticsBatchDone(t *testing.T) {
+	// Batches in progress report a status outside of the documented enum,
+	// which the client leaves unset.
+	data := datadogV1.SyntheticsBatchDetailsData{}
+	assert.False(t, isSyntheticsBatchDone(&data))
+
+	data.SetStatus(datadogV1.SYNTHETICSBATCHSTATUS_SKIPPED)
+	assert.True(t, isSyntheticsBatchDone(&data))
+}
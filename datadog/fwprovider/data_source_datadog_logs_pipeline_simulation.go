@@ -0,0 +1,148 @@
+package fwprovider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/fwutils"
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/logspipeline"
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/utils"
+)
+
+var (
+	_ datasource.DataSource = &datadogLogsPipelineSimulationDataSource{}
+)
+
+type logsPipelineSimulationResultModel struct {
+	Matched types.Bool   `tfsdk:"matched"`
+	Log     types.String `tfsdk:"log"`
+}
+
+type datadogLogsPipelineSimulationDataSourceModel struct {
+	// Query Parameters
+	Pipeline types.Dynamic  `tfsdk:"pipeline"`
+	Logs     []types.String `tfsdk:"logs"`
+
+	// Results
+	ID      types.String                         `tfsdk:"id"`
+	Results []*logsPipelineSimulationResultModel `tfsdk:"results"`
+}
+
+type datadogLogsPipelineSimulationDataSource struct{}
+
+func NewLogsPipelineSimulationDataSource() datasource.DataSource {
+	return &datadogLogsPipelineSimulationDataSource{}
+}
+
+func (d *datadogLogsPipelineSimulationDataSource) Metadata(_ context.Context, _ datasource.MetadataRequest, response *datasource.MetadataResponse) {
+	response.TypeName = "logs_pipeline_simulation"
+}
+
+func (d *datadogLogsPipelineSimulationDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, response *datasource.SchemaResponse) {
+	response.Schema = schema.Schema{
+		Description: "Use this data source to run sample logs through a logs pipeline locally, without calling the API, for example to test pipeline changes with `check` blocks. Tags are read from and written to the `ddtags` attribute of the logs, as a comma-separated string. Reserved attributes set by remappers are written to `date`, `message`, `service`, `span_id`, `status` and `trace_id`. Processors which depend on data not available locally (GeoIP parser, reference table lookup, array, array map and schema processors) are skipped and reported as warnings. The user-agent parser only recognizes common clients.",
+		Attributes: map[string]schema.Attribute{
+			"id": utils.ResourceIDAttribute(),
+			"pipeline": schema.DynamicAttribute{
+				Required:    true,
+				Description: "Pipeline definition, with the schema of the `datadog_logs_custom_pipeline` resource, for example `datadog_logs_custom_pipeline.main`. As with the resource, the pipeline and its processors only run when `is_enabled` is `true`.",
+			},
+			"logs": schema.ListAttribute{
+				Required:    true,
+				ElementType: types.StringType,
+				Description: "Sample logs, as JSON objects.",
+			},
+
+			// computed values
+			"results": schema.ListAttribute{
+				Computed:    true,
+				Description: "Result of the simulation for each sample log, in order.",
+				ElementType: types.ObjectType{
+					AttrTypes: map[string]attr.Type{
+						"matched": types.BoolType,
+						"log":     types.StringType,
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *datadogLogsPipelineSimulationDataSource) Read(ctx context.Context, request datasource.ReadRequest, response *datasource.ReadResponse) {
+	var state datadogLogsPipelineSimulationDataSourceModel
+	response.Diagnostics.Append(request.Config.Get(ctx, &state)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	definition, err := logsPipelineDefinition(ctx, state.Pipeline)
+	if err != nil {
+		response.Diagnostics.AddAttributeError(path.Root("pipeline"), "invalid pipeline", err.Error())
+		return
+	}
+	pipeline, warnings, err := logspipeline.Compile(definition)
+	for _, warning := range warnings {
+		response.Diagnostics.AddAttributeWarning(path.Root("pipeline"), "processor not simulated", warning)
+	}
+	if err != nil {
+		response.Diagnostics.AddAttributeError(path.Root("pipeline"), "invalid pipeline", err.Error())
+		return
+	}
+
+	hashingData := make([]string, 0, len(state.Logs)+1)
+	hashingData = append(hashingData, state.Pipeline.String())
+	state.Results = make([]*logsPipelineSimulationResultModel, 0, len(state.Logs))
+	for i, raw := range state.Logs {
+		var log map[string]any
+		if err := json.Unmarshal([]byte(raw.ValueString()), &log); err != nil {
+			response.Diagnostics.AddAttributeError(path.Root("logs").AtListIndex(i), "invalid log", fmt.Sprintf("logs must be JSON objects: %s", err))
+			continue
+		}
+		processed, matched := pipeline.Process(log)
+		encoded, err := json.Marshal(processed)
+		if err != nil {
+			response.Diagnostics.AddAttributeError(path.Root("logs").AtListIndex(i), "error encoding log", err.Error())
+			continue
+		}
+		hashingData = append(hashingData, raw.ValueString())
+		state.Results = append(state.Results, &logsPipelineSimulationResultModel{
+			Matched: types.BoolValue(matched),
+			Log:     types.StringValue(string(encoded)),
+		})
+	}
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	state.ID = types.StringValue(utils.ConvertToSha256(strings.Join(hashingData, "\n")))
+	response.Diagnostics.Append(response.State.Set(ctx, &state)...)
+}
+
+// logsPipelineDefinition converts the pipeline attribute into the layout
+// expected by the simulator.
+func logsPipelineDefinition(ctx context.Context, pipeline types.Dynamic) (map[string]any, error) {
+	if pipeline.IsUnderlyingValueNull() {
+		return nil, fmt.Errorf("the pipeline must not be null")
+	}
+	value, err := pipeline.UnderlyingValue().ToTerraformValue(ctx)
+	if err != nil {
+		return nil, err
+	}
+	converted, err := fwutils.TerraformValueToGo(value)
+	if err != nil {
+		return nil, err
+	}
+	definition, ok := converted.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("the pipeline must be an object")
+	}
+	return definition, nil
+}
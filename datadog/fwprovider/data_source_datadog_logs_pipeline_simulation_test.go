@@ -0,0 +1,86 @@
+package fwprovider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogsPipelineSimulationDataSourceRead(t *testing.T) {
+	ctx := context.Background()
+	dataSource := NewLogsPipelineSimulationDataSource()
+	schemaResp := datasource.SchemaResponse{}
+	dataSource.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+
+	remapperType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"is_enabled":  tftypes.Bool,
+		"sources":     tftypes.List{ElementType: tftypes.String},
+		"source_type": tftypes.String,
+		"target":      tftypes.String,
+		"target_type": tftypes.String,
+	}}
+	processorType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"attribute_remapper": tftypes.List{ElementType: remapperType},
+		"geo_ip_parser":      tftypes.List{ElementType: tftypes.Object{AttributeTypes: map[string]tftypes.Type{"is_enabled": tftypes.Bool}}},
+	}}
+	filterType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{"query": tftypes.String}}
+	pipelineType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"is_enabled": tftypes.Bool,
+		"filter":     tftypes.List{ElementType: filterType},
+		"processor":  tftypes.List{ElementType: processorType},
+	}}
+	pipeline := tftypes.NewValue(pipelineType, map[string]tftypes.Value{
+		"is_enabled": tftypes.NewValue(tftypes.Bool, true),
+		"filter": tftypes.NewValue(tftypes.List{ElementType: filterType}, []tftypes.Value{
+			tftypes.NewValue(filterType, map[string]tftypes.Value{"query": tftypes.NewValue(tftypes.String, "service:web")}),
+		}),
+		"processor": tftypes.NewValue(tftypes.List{ElementType: processorType}, []tftypes.Value{
+			tftypes.NewValue(processorType, map[string]tftypes.Value{
+				"attribute_remapper": tftypes.NewValue(tftypes.List{ElementType: remapperType}, []tftypes.Value{
+					tftypes.NewValue(remapperType, map[string]tftypes.Value{
+						"is_enabled":  tftypes.NewValue(tftypes.Bool, true),
+						"sources":     tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{tftypes.NewValue(tftypes.String, "code")}),
+						"source_type": tftypes.NewValue(tftypes.String, "attribute"),
+						"target":      tftypes.NewValue(tftypes.String, "http.status_code"),
+						"target_type": tftypes.NewValue(tftypes.String, "attribute"),
+					}),
+				}),
+				"geo_ip_parser": tftypes.NewValue(tftypes.List{ElementType: tftypes.Object{AttributeTypes: map[string]tftypes.Type{"is_enabled": tftypes.Bool}}}, []tftypes.Value{}),
+			}),
+		}),
+	})
+
+	configType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	config := tfsdk.Config{
+		Schema: schemaResp.Schema,
+		Raw: tftypes.NewValue(configType, map[string]tftypes.Value{
+			"id":       tftypes.NewValue(tftypes.String, nil),
+			"pipeline": pipeline,
+			"logs": tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{
+				tftypes.NewValue(tftypes.String, `{"service": "web", "code": 200}`),
+				tftypes.NewValue(tftypes.String, `{"service": "api", "code": 500}`),
+			}),
+			"results": tftypes.NewValue(configType.AttributeTypes["results"], nil),
+		}),
+	}
+	response := datasource.ReadResponse{State: tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(configType, nil),
+	}}
+	dataSource.Read(ctx, datasource.ReadRequest{Config: config}, &response)
+	require.False(t, response.Diagnostics.HasError(), "%v", response.Diagnostics)
+
+	var state datadogLogsPipelineSimulationDataSourceModel
+	require.False(t, response.State.Get(ctx, &state).HasError())
+	require.Len(t, state.Results, 2)
+	assert.True(t, state.Results[0].Matched.ValueBool())
+	assert.JSONEq(t, `{"service": "web", "http": {"status_code": 200}}`, state.Results[0].Log.ValueString())
+	assert.False(t, state.Results[1].Matched.ValueBool())
+	assert.JSONEq(t, `{"service": "api", "code": 500}`, state.Results[1].Log.ValueString())
+	assert.False(t, state.ID.IsNull())
+}
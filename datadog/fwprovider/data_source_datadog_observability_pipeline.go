@@ -0,0 +1,147 @@
+package fwprovider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV2"
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/utils"
+)
+
+var (
+	_ datasource.DataSourceWithConfigValidators = &observabilityPipelineDataSource{}
+)
+
+type observabilityPipelineDataSource struct {
+	Api  *datadogV2.ObservabilityPipelinesApi
+	Auth context.Context
+}
+
+type observabilityPipelineDataSourceModel struct {
+	ID           types.String         `tfsdk:"id"`
+	Name         types.String         `tfsdk:"name"`
+	PipelineType types.String         `tfsdk:"pipeline_type"`
+	ConfigJSON   jsontypes.Normalized `tfsdk:"config_json"`
+}
+
+func NewObservabilityPipelineDataSource() datasource.DataSource {
+	return &observabilityPipelineDataSource{}
+}
+
+func (d *observabilityPipelineDataSource) Configure(_ context.Context, request datasource.ConfigureRequest, response *datasource.ConfigureResponse) {
+	providerData, _ := request.ProviderData.(*FrameworkProvider)
+	d.Api = providerData.DatadogApiInstances.GetObsPipelinesV2()
+	d.Auth = providerData.Auth
+}
+
+func (d *observabilityPipelineDataSource) Metadata(_ context.Context, request datasource.MetadataRequest, response *datasource.MetadataResponse) {
+	response.TypeName = "observability_pipeline"
+}
+
+func (d *observabilityPipelineDataSource) ConfigValidators(_ context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(
+			path.MatchRoot("id"),
+			path.MatchRoot("name"),
+		),
+	}
+}
+
+func (d *observabilityPipelineDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, response *datasource.SchemaResponse) {
+	response.Schema = schema.Schema{
+		Description: "Use this data source to retrieve an existing Datadog Observability Pipeline by ID or name.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "ID of the pipeline. Exactly one of `id` and `name` must be set.",
+			},
+			"name": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Name of the pipeline. Exactly one of `id` and `name` must be set, and the name must match a single pipeline.",
+			},
+			"pipeline_type": schema.StringAttribute{
+				Computed:    true,
+				Description: "The type of data processed by the pipeline, `logs` or `metrics`.",
+			},
+			"config_json": schema.StringAttribute{
+				Computed:    true,
+				CustomType:  jsontypes.NormalizedType{},
+				Description: "The JSON configuration of the pipeline, as the `config` attribute of the Observability Pipelines API. It can be used as the `config_json` of a `datadog_observability_pipeline_json` resource.",
+			},
+		},
+	}
+}
+
+func (d *observabilityPipelineDataSource) Read(ctx context.Context, request datasource.ReadRequest, response *datasource.ReadResponse) {
+	var state observabilityPipelineDataSourceModel
+	response.Diagnostics.Append(request.Config.Get(ctx, &state)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	var pipeline datadogV2.ObservabilityPipelineData
+	if !state.ID.IsNull() {
+		res, httpResp, err := d.Api.GetPipeline(d.Auth, state.ID.ValueString())
+		if err != nil {
+			response.Diagnostics.Append(utils.FrameworkErrorDiag(utils.TranslateClientError(err, httpResp, "error getting pipeline"), ""))
+			return
+		}
+		pipeline = res.Data
+	} else {
+		matches, err := d.findPipelinesByName(state.Name.ValueString())
+		if err != nil {
+			response.Diagnostics.Append(utils.FrameworkErrorDiag(err, "error listing pipelines"))
+			return
+		}
+		if len(matches) != 1 {
+			response.Diagnostics.AddError(fmt.Sprintf("%d pipelines found with name %q", len(matches), state.Name.ValueString()), "The name must match exactly one pipeline, use `id` to select a pipeline whose name is not unique.")
+			return
+		}
+		pipeline = matches[0]
+	}
+
+	attributes := pipeline.GetAttributes()
+	config := attributes.GetConfig()
+	configJSON, err := json.Marshal(config)
+	if err != nil {
+		response.Diagnostics.AddError("Failed to marshal pipeline config", err.Error())
+		return
+	}
+	state.ID = types.StringValue(pipeline.GetId())
+	state.Name = types.StringValue(attributes.GetName())
+	state.PipelineType = types.StringValue("logs")
+	if pipelineType, ok := config.GetPipelineTypeOk(); ok {
+		state.PipelineType = types.StringValue(string(*pipelineType))
+	}
+	state.ConfigJSON = jsontypes.NewNormalizedValue(string(configJSON))
+	response.Diagnostics.Append(response.State.Set(ctx, &state)...)
+}
+
+func (d *observabilityPipelineDataSource) findPipelinesByName(name string) ([]datadogV2.ObservabilityPipelineData, error) {
+	var matches []datadogV2.ObservabilityPipelineData
+	pageSize := int64(50)
+	for pageNumber := int64(0); ; pageNumber++ {
+		res, httpResp, err := d.Api.ListPipelines(d.Auth, *datadogV2.NewListPipelinesOptionalParameters().WithPageSize(pageSize).WithPageNumber(pageNumber))
+		if err != nil {
+			return nil, utils.TranslateClientError(err, httpResp, "")
+		}
+		for _, pipeline := range res.Data {
+			if pipeline.Attributes.Name == name {
+				matches = append(matches, pipeline)
+			}
+		}
+		if int64(len(res.Data)) < pageSize {
+			return matches, nil
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package fwprovider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/synthetics"
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/utils"
+)
+
+var (
+	_ datasource.DataSource = &datadogSyntheticsBrowserStepsDataSource{}
+)
+
+type syntheticsBrowserStepModel struct {
+	Name   types.String                      `tfsdk:"name"`
+	Type   types.String                      `tfsdk:"type"`
+	Params *syntheticsBrowserStepParamsModel `tfsdk:"params"`
+}
+
+type syntheticsBrowserStepParamsModel struct {
+	Attribute          types.String                       `tfsdk:"attribute"`
+	Check              types.String                       `tfsdk:"check"`
+	ClickType          types.String                       `tfsdk:"click_type"`
+	Code               types.String                       `tfsdk:"code"`
+	Modifiers          []types.String                     `tfsdk:"modifiers"`
+	Value              types.String                       `tfsdk:"value"`
+	X                  types.Int64                        `tfsdk:"x"`
+	Y                  types.Int64                        `tfsdk:"y"`
+	ElementUserLocator *syntheticsElementUserLocatorModel `tfsdk:"element_user_locator"`
+}
+
+type syntheticsElementUserLocatorModel struct {
+	FailTestOnCannotLocate types.Bool                              `tfsdk:"fail_test_on_cannot_locate"`
+	Value                  *syntheticsElementUserLocatorValueModel `tfsdk:"value"`
+}
+
+type syntheticsElementUserLocatorValueModel struct {
+	Type  types.String `tfsdk:"type"`
+	Value types.String `tfsdk:"value"`
+}
+
+type datadogSyntheticsBrowserStepsDataSourceModel struct {
+	// Query Parameters
+	Recording types.String `tfsdk:"recording"`
+
+	// Results
+	ID          types.String                  `tfsdk:"id"`
+	StartURL    types.String                  `tfsdk:"start_url"`
+	BrowserStep []*syntheticsBrowserStepModel `tfsdk:"browser_step"`
+}
+
+var syntheticsElementUserLocatorAttrTypes = map[string]attr.Type{
+	"fail_test_on_cannot_locate": types.BoolType,
+	"value": types.ObjectType{
+		AttrTypes: map[string]attr.Type{
+			"type":  types.StringType,
+			"value": types.StringType,
+		},
+	},
+}
+
+var syntheticsBrowserStepParamsAttrTypes = map[string]attr.Type{
+	"attribute":            types.StringType,
+	"check":                types.StringType,
+	"click_type":           types.StringType,
+	"code":                 types.StringType,
+	"modifiers":            types.ListType{ElemType: types.StringType},
+	"value":                types.StringType,
+	"x":                    types.Int64Type,
+	"y":                    types.Int64Type,
+	"element_user_locator": types.ObjectType{AttrTypes: syntheticsElementUserLocatorAttrTypes},
+}
+
+type datadogSyntheticsBrowserStepsDataSource struct{}
+
+func NewDatadogSyntheticsBrowserStepsDataSource() datasource.DataSource {
+	return &datadogSyntheticsBrowserStepsDataSource{}
+}
+
+func (d *datadogSyntheticsBrowserStepsDataSource) Metadata(_ context.Context, _ datasource.MetadataRequest, response *datasource.MetadataResponse) {
+	response.TypeName = "synthetics_browser_steps"
+}
+
+func (d *datadogSyntheticsBrowserStepsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, response *datasource.SchemaResponse) {
+	response.Schema = schema.Schema{
+		Description: "Use this data source to convert a browser recording into steps for the `browser_step` blocks of a `datadog_synthetics_test` resource. Chrome DevTools Recorder JSON exports and Playwright codegen recordings generated with `--target=jsonl` are supported. Elements are located with user locators built from the recorded selectors. Recorded actions that cannot be converted are reported as warnings.",
+		Attributes: map[string]schema.Attribute{
+			"id": utils.ResourceIDAttribute(),
+			"recording": schema.StringAttribute{
+				Required:    true,
+				Description: "Content of the recording, for example `file(\"checkout.json\")`.",
+			},
+
+			// computed values
+			"start_url": schema.StringAttribute{
+				Computed:    true,
+				Description: "URL of the first navigation of the recording, to use as the `request_definition.url` of the test. This navigation is not converted into a step.",
+			},
+			"browser_step": schema.ListAttribute{
+				Computed:    true,
+				Description: "Steps converted from the recording, following the schema of the `browser_step` blocks of the `datadog_synthetics_test` resource.",
+				ElementType: types.ObjectType{
+					AttrTypes: map[string]attr.Type{
+						"name":   types.StringType,
+						"type":   types.StringType,
+						"params": types.ObjectType{AttrTypes: syntheticsBrowserStepParamsAttrTypes},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *datadogSyntheticsBrowserStepsDataSource) Read(ctx context.Context, request datasource.ReadRequest, response *datasource.ReadResponse) {
+	var state datadogSyntheticsBrowserStepsDataSourceModel
+	response.Diagnostics.Append(request.Config.Get(ctx, &state)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	recording, err := synthetics.ConvertRecording(state.Recording.ValueString())
+	if err != nil {
+		response.Diagnostics.AddAttributeError(path.Root("recording"), "invalid recording", err.Error())
+		return
+	}
+	for _, warning := range recording.Warnings {
+		response.Diagnostics.AddAttributeWarning(path.Root("recording"), "recorded action not converted", warning)
+	}
+
+	state.ID = types.StringValue(utils.ConvertToSha256(state.Recording.ValueString()))
+	state.StartURL = types.StringValue(recording.StartURL)
+	state.BrowserStep = buildSyntheticsBrowserStepModels(recording.Steps)
+	response.Diagnostics.Append(response.State.Set(ctx, &state)...)
+}
+
+// buildSyntheticsBrowserStepModels converts steps into their Terraform
+// representation. Unset parameters are null so that they can be passed as is
+// to the `browser_step` blocks.
+func buildSyntheticsBrowserStepModels(steps []synthetics.BrowserStep) []*syntheticsBrowserStepModel {
+	models := make([]*syntheticsBrowserStepModel, 0, len(steps))
+	for _, step := range steps {
+		params := &syntheticsBrowserStepParamsModel{
+			Attribute: optionalStringValue(step.Params.Attribute),
+			Check:     optionalStringValue(string(step.Params.Check)),
+			ClickType: optionalStringValue(step.Params.ClickType),
+			Code:      optionalStringValue(step.Params.Code),
+			Value:     optionalStringValue(step.Params.Value),
+			X:         types.Int64PointerValue(step.Params.X),
+			Y:         types.Int64PointerValue(step.Params.Y),
+		}
+		for _, modifier := range step.Params.Modifiers {
+			params.Modifiers = append(params.Modifiers, types.StringValue(modifier))
+		}
+		if element := step.Params.Element; element != nil {
+			params.ElementUserLocator = &syntheticsElementUserLocatorModel{
+				FailTestOnCannotLocate: types.BoolValue(false),
+				Value: &syntheticsElementUserLocatorValueModel{
+					Type:  types.StringValue(element.Type),
+					Value: types.StringValue(element.Value),
+				},
+			}
+		}
+		models = append(models, &syntheticsBrowserStepModel{
+			Name:   types.StringValue(step.Name),
+			Type:   types.StringValue(string(step.Type)),
+			Params: params,
+		})
+	}
+	return models
+}
+
+func optionalStringValue(s string) types.String {
+	if s == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(s)
+}
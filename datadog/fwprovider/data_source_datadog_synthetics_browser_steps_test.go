@@ -0,0 +1,81 @@
+package fwprovider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV1"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/synthetics"
+)
+
+func TestBuildSyntheticsBrowserStepModels(t *testing.T) {
+	models := buildSyntheticsBrowserStepModels([]synthetics.BrowserStep{
+		{
+			Name: "Click on #submit",
+			Type: datadogV1.SYNTHETICSSTEPTYPE_CLICK,
+			Params: synthetics.BrowserStepParams{
+				ClickType: "primary",
+				Element:   &synthetics.ElementLocator{Type: synthetics.LocatorTypeCSS, Value: "#submit"},
+			},
+		},
+		{
+			Name:   "Press Enter",
+			Type:   datadogV1.SYNTHETICSSTEPTYPE_PRESS_KEY,
+			Params: synthetics.BrowserStepParams{Value: "Enter", Modifiers: []string{"Shift"}},
+		},
+	})
+	require.Len(t, models, 2)
+
+	click := models[0]
+	assert.Equal(t, "click", click.Type.ValueString())
+	assert.Equal(t, "primary", click.Params.ClickType.ValueString())
+	assert.True(t, click.Params.Value.IsNull())
+	assert.True(t, click.Params.X.IsNull())
+	require.NotNil(t, click.Params.ElementUserLocator)
+	assert.Equal(t, "css", click.Params.ElementUserLocator.Value.Type.ValueString())
+	assert.Equal(t, "#submit", click.Params.ElementUserLocator.Value.Value.ValueString())
+
+	press := models[1]
+	assert.Equal(t, "Enter", press.Params.Value.ValueString())
+	assert.Len(t, press.Params.Modifiers, 1)
+	assert.Nil(t, press.Params.ElementUserLocator)
+}
+
+func TestSyntheticsBrowserStepsDataSourceState(t *testing.T) {
+	ctx := context.Background()
+	schemaResp := datasource.SchemaResponse{}
+	NewDatadogSyntheticsBrowserStepsDataSource().Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+	state := tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+
+	recording, err := synthetics.ConvertRecording(`{"steps": [
+		{"type": "navigate", "url": "https://example.com"},
+		{"type": "click", "selectors": [["#submit"]]},
+		{"type": "scroll", "x": 0, "y": 400}
+	]}`)
+	require.NoError(t, err)
+	model := datadogSyntheticsBrowserStepsDataSourceModel{
+		Recording:   types.StringValue("recording"),
+		ID:          types.StringValue("id"),
+		StartURL:    types.StringValue(recording.StartURL),
+		BrowserStep: buildSyntheticsBrowserStepModels(recording.Steps),
+	}
+	diags := state.Set(ctx, &model)
+	require.False(t, diags.HasError(), diags)
+
+	var roundTrip datadogSyntheticsBrowserStepsDataSourceModel
+	diags = state.Get(ctx, &roundTrip)
+	require.False(t, diags.HasError(), diags)
+	require.Len(t, roundTrip.BrowserStep, 2)
+	assert.Nil(t, roundTrip.BrowserStep[1].Params.ElementUserLocator)
+	assert.Equal(t, int64(400), roundTrip.BrowserStep[1].Params.Y.ValueInt64())
+}
@@ -0,0 +1,260 @@
+package fwprovider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV1"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/utils"
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/validators"
+)
+
+var (
+	_ datasource.DataSourceWithConfigure = &datadogSyntheticsTestsDataSource{}
+)
+
+type syntheticsTestSummaryModel struct {
+	PublicID  types.String `tfsdk:"public_id"`
+	Name      types.String `tfsdk:"name"`
+	Type      types.String `tfsdk:"type"`
+	Subtype   types.String `tfsdk:"subtype"`
+	Status    types.String `tfsdk:"status"`
+	MonitorID types.Int64  `tfsdk:"monitor_id"`
+	Tags      types.List   `tfsdk:"tags"`
+	Locations types.List   `tfsdk:"locations"`
+}
+
+type datadogSyntheticsTestsDataSourceModel struct {
+	// Query Parameters
+	NameFilter types.String `tfsdk:"name_filter"`
+	TagsFilter types.List   `tfsdk:"tags_filter"`
+	Type       types.String `tfsdk:"type"`
+	Subtype    types.String `tfsdk:"subtype"`
+	Location   types.String `tfsdk:"location"`
+	Status     types.String `tfsdk:"status"`
+
+	// Results
+	ID         types.String                  `tfsdk:"id"`
+	PublicIDs  types.List                    `tfsdk:"public_ids"`
+	MonitorIDs types.List                    `tfsdk:"monitor_ids"`
+	Tests      []*syntheticsTestSummaryModel `tfsdk:"tests"`
+}
+
+// syntheticsTestsFilter holds the filters of the `datadog_synthetics_tests`
+// data source. Empty fields match every test.
+type syntheticsTestsFilter struct {
+	Name     string
+	Tags     []string
+	Type     string
+	Subtype  string
+	Location string
+	Status   string
+}
+
+type datadogSyntheticsTestsDataSource struct {
+	Api  *datadogV1.SyntheticsApi
+	Auth context.Context
+}
+
+func NewDatadogSyntheticsTestsDataSource() datasource.DataSource {
+	return &datadogSyntheticsTestsDataSource{}
+}
+
+func (d *datadogSyntheticsTestsDataSource) Configure(_ context.Context, request datasource.ConfigureRequest, response *datasource.ConfigureResponse) {
+	providerData, _ := request.ProviderData.(*FrameworkProvider)
+	d.Api = providerData.DatadogApiInstances.GetSyntheticsApiV1()
+	d.Auth = providerData.Auth
+}
+
+func (d *datadogSyntheticsTestsDataSource) Metadata(_ context.Context, _ datasource.MetadataRequest, response *datasource.MetadataResponse) {
+	response.TypeName = "synthetics_tests"
+}
+
+func (d *datadogSyntheticsTestsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, response *datasource.SchemaResponse) {
+	response.Schema = schema.Schema{
+		Description: "Use this data source to list Datadog Synthetics tests matching a set of filters, for use in other resources such as `datadog_synthetics_suite` or composite monitors.",
+		Attributes: map[string]schema.Attribute{
+			"id": utils.ResourceIDAttribute(),
+			"name_filter": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return tests whose name contains this string. The match is case-insensitive.",
+			},
+			"tags_filter": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Only return tests having all of these tags, for example `team:checkout`.",
+			},
+			"type": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return tests of this type.",
+				Validators:  []validator.String{validators.NewEnumValidator[validator.String](datadogV1.NewSyntheticsTestDetailsTypeFromValue)},
+			},
+			"subtype": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return tests of this subtype.",
+				Validators:  []validator.String{validators.NewEnumValidator[validator.String](datadogV1.NewSyntheticsTestDetailsSubTypeFromValue)},
+			},
+			"location": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return tests running from this location, for example `aws:eu-central-1` or the ID of a private location.",
+			},
+			"status": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return tests with this status.",
+				Validators:  []validator.String{validators.NewEnumValidator[validator.String](datadogV1.NewSyntheticsTestPauseStatusFromValue)},
+			},
+
+			// computed values
+			"public_ids": schema.ListAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "Public IDs of the matching tests.",
+			},
+			"monitor_ids": schema.ListAttribute{
+				Computed:    true,
+				ElementType: types.Int64Type,
+				Description: "IDs of the monitors associated with the matching tests.",
+			},
+			"tests": schema.ListAttribute{
+				Computed:    true,
+				Description: "List of matching tests.",
+				ElementType: types.ObjectType{
+					AttrTypes: map[string]attr.Type{
+						"public_id":  types.StringType,
+						"name":       types.StringType,
+						"type":       types.StringType,
+						"subtype":    types.StringType,
+						"status":     types.StringType,
+						"monitor_id": types.Int64Type,
+						"tags":       types.ListType{ElemType: types.StringType},
+						"locations":  types.ListType{ElemType: types.StringType},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *datadogSyntheticsTestsDataSource) Read(ctx context.Context, request datasource.ReadRequest, response *datasource.ReadResponse) {
+	var state datadogSyntheticsTestsDataSourceModel
+	response.Diagnostics.Append(request.Config.Get(ctx, &state)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	filter := syntheticsTestsFilter{
+		Name:     state.NameFilter.ValueString(),
+		Type:     state.Type.ValueString(),
+		Subtype:  state.Subtype.ValueString(),
+		Location: state.Location.ValueString(),
+		Status:   state.Status.ValueString(),
+	}
+	if !state.TagsFilter.IsNull() {
+		response.Diagnostics.Append(state.TagsFilter.ElementsAs(ctx, &filter.Tags, false)...)
+		if response.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	pageSize := int64(100)
+	pageNumber := int64(0)
+	var tests []datadogV1.SyntheticsTestDetailsWithoutSteps
+	for {
+		params := datadogV1.NewListTestsOptionalParameters().WithPageSize(pageSize).WithPageNumber(pageNumber)
+		ddResp, httpResp, err := d.Api.ListTests(d.Auth, *params)
+		if err != nil {
+			response.Diagnostics.Append(utils.FrameworkErrorDiag(utils.TranslateClientError(err, httpResp, "error listing synthetics tests"), ""))
+			return
+		}
+
+		for _, test := range ddResp.GetTests() {
+			if filter.matches(&test) {
+				tests = append(tests, test)
+			}
+		}
+		if int64(len(ddResp.GetTests())) < pageSize {
+			break
+		}
+		pageNumber++
+	}
+
+	response.Diagnostics.Append(d.updateState(ctx, &state, filter, tests)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	response.Diagnostics.Append(response.State.Set(ctx, &state)...)
+}
+
+func (d *datadogSyntheticsTestsDataSource) updateState(ctx context.Context, state *datadogSyntheticsTestsDataSourceModel, filter syntheticsTestsFilter, tests []datadogV1.SyntheticsTestDetailsWithoutSteps) diag.Diagnostics {
+	var diags diag.Diagnostics
+	summaries := make([]*syntheticsTestSummaryModel, 0, len(tests))
+	publicIDs := make([]string, 0, len(tests))
+	monitorIDs := make([]int64, 0, len(tests))
+	for _, test := range tests {
+		summary := syntheticsTestSummaryModel{
+			PublicID:  types.StringValue(test.GetPublicId()),
+			Name:      types.StringValue(test.GetName()),
+			Type:      types.StringValue(string(test.GetType())),
+			Subtype:   types.StringValue(string(test.GetSubtype())),
+			Status:    types.StringValue(string(test.GetStatus())),
+			MonitorID: types.Int64Null(),
+		}
+		var listDiags diag.Diagnostics
+		summary.Tags, listDiags = types.ListValueFrom(ctx, types.StringType, test.GetTags())
+		diags.Append(listDiags...)
+		summary.Locations, listDiags = types.ListValueFrom(ctx, types.StringType, test.GetLocations())
+		diags.Append(listDiags...)
+		summaries = append(summaries, &summary)
+
+		publicIDs = append(publicIDs, test.GetPublicId())
+		if monitorID, ok := test.GetMonitorIdOk(); ok {
+			summary.MonitorID = types.Int64Value(*monitorID)
+			monitorIDs = append(monitorIDs, *monitorID)
+		}
+	}
+
+	var listDiags diag.Diagnostics
+	state.PublicIDs, listDiags = types.ListValueFrom(ctx, types.StringType, publicIDs)
+	diags.Append(listDiags...)
+	state.MonitorIDs, listDiags = types.ListValueFrom(ctx, types.Int64Type, monitorIDs)
+	diags.Append(listDiags...)
+	state.Tests = summaries
+
+	hashingData := fmt.Sprintf("%s:%s:%s:%s:%s:%s", filter.Name, strings.Join(filter.Tags, ","), filter.Type, filter.Subtype, filter.Location, filter.Status)
+	state.ID = types.StringValue(utils.ConvertToSha256(hashingData))
+	return diags
+}
+
+func (f syntheticsTestsFilter) matches(test *datadogV1.SyntheticsTestDetailsWithoutSteps) bool {
+	if f.Name != "" && !strings.Contains(strings.ToLower(test.GetName()), strings.ToLower(f.Name)) {
+		return false
+	}
+	if f.Type != "" && string(test.GetType()) != f.Type {
+		return false
+	}
+	if f.Subtype != "" && string(test.GetSubtype()) != f.Subtype {
+		return false
+	}
+	if f.Status != "" && string(test.GetStatus()) != f.Status {
+		return false
+	}
+	if f.Location != "" && !slices.Contains(test.GetLocations(), f.Location) {
+		return false
+	}
+	tags := test.GetTags()
+	for _, tag := range f.Tags {
+		if !slices.Contains(tags, tag) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,62 @@
+package fwprovider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyntheticsTestsFilterMatches(t *testing.T) {
+	test := datadogV1.SyntheticsTestDetailsWithoutSteps{}
+	test.SetName("Checkout API health")
+	test.SetType(datadogV1.SYNTHETICSTESTDETAILSTYPE_API)
+	test.SetSubtype(datadogV1.SYNTHETICSTESTDETAILSSUBTYPE_HTTP)
+	test.SetStatus(datadogV1.SYNTHETICSTESTPAUSESTATUS_LIVE)
+	test.SetTags([]string{"team:checkout", "env:prod"})
+	test.SetLocations([]string{"aws:eu-central-1", "pl:private-abc"})
+
+	cases := map[string]struct {
+		filter   syntheticsTestsFilter
+		expected bool
+	}{
+		"no filter":            {syntheticsTestsFilter{}, true},
+		"name substring":       {syntheticsTestsFilter{Name: "checkout api"}, true},
+		"name mismatch":        {syntheticsTestsFilter{Name: "login"}, false},
+		"all tags present":     {syntheticsTestsFilter{Tags: []string{"team:checkout", "env:prod"}}, true},
+		"one tag missing":      {syntheticsTestsFilter{Tags: []string{"team:checkout", "env:staging"}}, false},
+		"type and subtype":     {syntheticsTestsFilter{Type: "api", Subtype: "http"}, true},
+		"subtype mismatch":     {syntheticsTestsFilter{Type: "api", Subtype: "dns"}, false},
+		"status mismatch":      {syntheticsTestsFilter{Status: "paused"}, false},
+		"location":             {syntheticsTestsFilter{Location: "pl:private-abc"}, true},
+		"location mismatch":    {syntheticsTestsFilter{Location: "aws:us-east-2"}, false},
+		"type mismatch":        {syntheticsTestsFilter{Type: "browser"}, false},
+		"combined all match":   {syntheticsTestsFilter{Name: "health", Tags: []string{"env:prod"}, Status: "live", Location: "aws:eu-central-1"}, true},
+		"combined one differs": {syntheticsTestsFilter{Name: "health", Tags: []string{"env:prod"}, Status: "live", Location: "aws:us-east-2"}, false},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, c.expected, c.filter.matches(&test))
+		})
+	}
+}
+
+func TestSyntheticsTestsUpdateState(t *testing.T) {
+	monitored := datadogV1.SyntheticsTestDetailsWithoutSteps{}
+	monitored.SetPublicId("abc-def-ghi")
+	monitored.SetMonitorId(12345)
+	unmonitored := datadogV1.SyntheticsTestDetailsWithoutSteps{}
+	unmonitored.SetPublicId("jkl-mno-pqr")
+
+	var state datadogSyntheticsTestsDataSourceModel
+	d := &datadogSyntheticsTestsDataSource{}
+	diags := d.updateState(context.Background(), &state, syntheticsTestsFilter{}, []datadogV1.SyntheticsTestDetailsWithoutSteps{monitored, unmonitored})
+	require.False(t, diags.HasError(), diags)
+
+	require.Len(t, state.Tests, 2)
+	assert.Equal(t, int64(12345), state.Tests[0].MonitorID.ValueInt64())
+	assert.True(t, state.Tests[1].MonitorID.IsNull())
+	assert.Len(t, state.MonitorIDs.Elements(), 1)
+}
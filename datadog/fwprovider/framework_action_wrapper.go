@@ -0,0 +1,87 @@
+package fwprovider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+)
+
+// Interface assertions for FrameworkActionWrapper
+var (
+	_ action.Action                     = &FrameworkActionWrapper{}
+	_ action.ActionWithConfigure        = &FrameworkActionWrapper{}
+	_ action.ActionWithValidateConfig   = &FrameworkActionWrapper{}
+	_ action.ActionWithConfigValidators = &FrameworkActionWrapper{}
+	_ action.ActionWithModifyPlan       = &FrameworkActionWrapper{}
+)
+
+// NewFrameworkActionWrapper creates a new action wrapper following the same
+// pattern as the existing FrameworkResourceWrapper
+func NewFrameworkActionWrapper(i *action.Action) action.Action {
+	return &FrameworkActionWrapper{
+		innerAction: i,
+	}
+}
+
+// FrameworkActionWrapper wraps actions to provide consistent behavior across
+// all actions, following the existing FrameworkResourceWrapper pattern
+type FrameworkActionWrapper struct {
+	innerAction *action.Action
+}
+
+// Metadata implements the core action.Action interface
+// Adds provider type name prefix to the action type name, following existing pattern
+func (a *FrameworkActionWrapper) Metadata(ctx context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	(*a.innerAction).Metadata(ctx, req, resp)
+	resp.TypeName = req.ProviderTypeName + resp.TypeName
+}
+
+// Schema implements the core action.Action interface
+func (a *FrameworkActionWrapper) Schema(ctx context.Context, req action.SchemaRequest, resp *action.SchemaResponse) {
+	(*a.innerAction).Schema(ctx, req, resp)
+}
+
+// Invoke implements the core action.Action interface
+func (a *FrameworkActionWrapper) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	(*a.innerAction).Invoke(ctx, req, resp)
+}
+
+// Configure implements the optional action.ActionWithConfigure interface
+// Uses interface detection to only call if the inner action supports configuration
+func (a *FrameworkActionWrapper) Configure(ctx context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	aCasted, ok := (*a.innerAction).(action.ActionWithConfigure)
+	if ok {
+		if req.ProviderData == nil {
+			return
+		}
+		_, ok := req.ProviderData.(*FrameworkProvider)
+		if !ok {
+			resp.Diagnostics.AddError("Unexpected Action Configure Type", "")
+			return
+		}
+
+		aCasted.Configure(ctx, req, resp)
+	}
+}
+
+// ValidateConfig implements the optional action.ActionWithValidateConfig interface
+func (a *FrameworkActionWrapper) ValidateConfig(ctx context.Context, req action.ValidateConfigRequest, resp *action.ValidateConfigResponse) {
+	if aCasted, ok := (*a.innerAction).(action.ActionWithValidateConfig); ok {
+		aCasted.ValidateConfig(ctx, req, resp)
+	}
+}
+
+// ConfigValidators implements the optional action.ActionWithConfigValidators interface
+func (a *FrameworkActionWrapper) ConfigValidators(ctx context.Context) []action.ConfigValidator {
+	if aCasted, ok := (*a.innerAction).(action.ActionWithConfigValidators); ok {
+		return aCasted.ConfigValidators(ctx)
+	}
+	return nil
+}
+
+// ModifyPlan implements the optional action.ActionWithModifyPlan interface
+func (a *FrameworkActionWrapper) ModifyPlan(ctx context.Context, req action.ModifyPlanRequest, resp *action.ModifyPlanResponse) {
+	if aCasted, ok := (*a.innerAction).(action.ActionWithModifyPlan); ok {
+		aCasted.ModifyPlan(ctx, req, resp)
+	}
+}
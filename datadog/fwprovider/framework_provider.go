@@ -14,8 +14,10 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/action"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -28,7 +30,11 @@ import (
 	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/utils"
 )
 
-var _ provider.Provider = &FrameworkProvider{}
+var (
+	_ provider.Provider              = &FrameworkProvider{}
+	_ provider.ProviderWithActions   = &FrameworkProvider{}
+	_ provider.ProviderWithFunctions = &FrameworkProvider{}
+)
 
 var Resources = []func() resource.Resource{
 	NewAgentlessScanningAwsScanOptionsResource,
@@ -62,6 +68,7 @@ var Resources = []func() resource.Resource{
 	NewMonitorNotificationRuleResource,
 	NewSecurityNotificationRuleResource,
 	NewRestrictionPolicyResource,
+	NewRestrictionPolicyBindingResource,
 	NewRumApplicationResource,
 	NewRumExclusionFilterResource,
 	NewRumMetricResource,
@@ -84,8 +91,10 @@ var Resources = []func() resource.Resource{
 	NewSyntheticsGlobalVariableResource,
 	NewSyntheticsPrivateLocationResource,
 	NewSyntheticsSuiteResource,
+	NewSyntheticsTestJSONResource,
 	NewTeamLinkResource,
 	NewTeamMembershipResource,
+	NewTeamMembershipsResource,
 	NewTeamNotificationRuleResource,
 	NewTeamPermissionSettingResource,
 	NewTeamResource,
@@ -93,6 +102,9 @@ var Resources = []func() resource.Resource{
 	NewTeamConnectionResource,
 	NewTeamSyncResource,
 	NewUserRoleResource,
+	NewRolePermissionsResource,
+	NewRoleUsersResource,
+	NewUsersResource,
 	NewSecurityMonitoringSuppressionResource,
 	NewSecurityMonitoringCriticalAssetResource,
 	NewServiceAccountResource,
@@ -101,6 +113,7 @@ var Resources = []func() resource.Resource{
 	NewWebhookOauth2ClientCredentialsResource,
 	NewLogsCustomDestinationResource,
 	NewLogsRestrictionQueryResource,
+	NewLogsIndexExclusionFilterResource,
 	NewTenantBasedHandleResource,
 	NewAppsecWafExclusionFilterResource,
 	NewAppsecWafCustomRuleResource,
@@ -109,6 +122,8 @@ var Resources = []func() resource.Resource{
 	NewWorkflowAutomationResource,
 	NewAppBuilderAppResource,
 	NewObservabilitPipelineResource,
+	NewObservabilityPipelineJSONResource,
+	NewObservabilityPipelineProcessorGroupResource,
 	NewOnCallEscalationPolicyResource,
 	NewOnCallScheduleResource,
 	NewOnCallTeamRoutingRulesResource,
@@ -121,6 +136,7 @@ var Resources = []func() resource.Resource{
 	NewOrgGroupPolicyOverrideResource,
 	NewComplianceResourceEvaluationFilter,
 	NewSecurityMonitoringDefaultRuleResource,
+	NewSecurityMonitoringDefaultRulesResource,
 	NewSecurityMonitoringFilterResource,
 	NewSecurityMonitoringRuleResource,
 	NewSecurityMonitoringRuleJSONResource,
@@ -189,11 +205,15 @@ var Datasources = []func() datasource.DataSource{
 	NewSecurityMonitoringCriticalAssetDataSource,
 	NewSecurityMonitoringCriticalAssetsDataSource,
 	NewLogsPipelinesOrderDataSource,
+	NewLogsPipelineSimulationDataSource,
 	NewDatadogTeamsDataSource,
 	NewDatadogActionConnectionDataSource,
 	NewDatadogSyntheticsGlobalVariableDataSource,
+	NewDatadogSyntheticsBrowserStepsDataSource,
 	NewDatadogSyntheticsLocationsDataSource,
+	NewDatadogSyntheticsTestsDataSource,
 	NewWorkflowAutomationDataSource,
+	NewObservabilityPipelineDataSource,
 	NewDatadogAppBuilderAppDataSource,
 	NewCostBudgetDataSource,
 	NewCostCustomForecastDataSource,
@@ -215,6 +235,15 @@ var Datasources = []func() datasource.DataSource{
 	NewDatastoreItemDataSource,
 }
 
+var Actions = []func() action.Action{
+	NewSyntheticsTriggerAction,
+}
+
+var Functions = []func() function.Function{
+	NewSigmaToRuleFunction,
+	NewObservabilityPipelineWorkerConfigFunction,
+}
+
 // FrameworkProvider struct
 type FrameworkProvider struct {
 	CommunityClient     *datadogCommunity.Client
@@ -295,6 +324,20 @@ func (p *FrameworkProvider) DataSources(_ context.Context) []func() datasource.D
 	return wrappedDatasources
 }
 
+func (p *FrameworkProvider) Actions(_ context.Context) []func() action.Action {
+	var wrappedActions []func() action.Action
+	for _, f := range Actions {
+		a := f()
+		wrappedActions = append(wrappedActions, func() action.Action { return NewFrameworkActionWrapper(&a) })
+	}
+
+	return wrappedActions
+}
+
+func (p *FrameworkProvider) Functions(_ context.Context) []func() function.Function {
+	return Functions
+}
+
 func (p *FrameworkProvider) Metadata(_ context.Context, _ provider.MetadataRequest, response *provider.MetadataResponse) {
 	response.TypeName = "datadog_"
 }
@@ -418,9 +461,10 @@ func (p *FrameworkProvider) Configure(ctx context.Context, request provider.Conf
 		return
 	}
 
-	// Make config available for data sources and resources
+	// Make config available for data sources, resources and actions
 	response.DataSourceData = p
 	response.ResourceData = p
+	response.ActionData = p
 }
 
 func (p *FrameworkProvider) ConfigureConfigDefaults(ctx context.Context, config *ProviderSchema) diag.Diagnostics {
@@ -0,0 +1,132 @@
+package fwprovider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"gopkg.in/yaml.v3"
+)
+
+var _ function.Function = &observabilityPipelineWorkerConfigFunction{}
+
+type observabilityPipelineWorkerConfigFunction struct{}
+
+func NewObservabilityPipelineWorkerConfigFunction() function.Function {
+	return &observabilityPipelineWorkerConfigFunction{}
+}
+
+func (f *observabilityPipelineWorkerConfigFunction) Metadata(_ context.Context, _ function.MetadataRequest, response *function.MetadataResponse) {
+	response.Name = "observability_pipeline_worker_config"
+}
+
+func (f *observabilityPipelineWorkerConfigFunction) Definition(_ context.Context, _ function.DefinitionRequest, response *function.DefinitionResponse) {
+	response.Definition = function.Definition{
+		Summary:     "Renders a `datadog_observability_pipeline` as an Observability Pipelines Worker configuration file.",
+		Description: "Renders the `config` of a `datadog_observability_pipeline` resource as the YAML pipeline configuration sent to Observability Pipelines Workers, with the sources, processor groups and destinations, including their TLS, buffer and compression options. Keys are sorted so the output can be diffed. The result is unknown until the whole `config` of the pipeline is known.",
+		Parameters: []function.Parameter{
+			function.DynamicParameter{
+				Name:        "pipeline",
+				Description: "`datadog_observability_pipeline` resource to render.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *observabilityPipelineWorkerConfigFunction) Run(ctx context.Context, request function.RunRequest, response *function.RunResponse) {
+	var pipeline types.Dynamic
+	response.Error = request.Arguments.Get(ctx, &pipeline)
+	if response.Error != nil {
+		return
+	}
+
+	object, ok := pipeline.UnderlyingValue().(types.Object)
+	if !ok || object.IsNull() {
+		response.Error = function.NewArgumentFuncError(0, "pipeline must be a datadog_observability_pipeline resource")
+		return
+	}
+	if object.IsUnknown() || !isFullyKnown(ctx, object.Attributes()["config"]) {
+		response.Error = response.Result.Set(ctx, types.StringUnknown())
+		return
+	}
+
+	// Read the pipeline through the resource schema, which holds the custom
+	// types of its attributes.
+	schemaResponse := &resource.SchemaResponse{}
+	NewObservabilitPipelineResource().Schema(ctx, resource.SchemaRequest{}, schemaResponse)
+	raw, err := object.ToTerraformValue(ctx)
+	if err != nil || !raw.Type().Equal(schemaResponse.Schema.Type().TerraformType(ctx)) {
+		response.Error = function.NewArgumentFuncError(0, "pipeline must be a datadog_observability_pipeline resource")
+		return
+	}
+	var state observabilityPipelineModel
+	if diags := (tfsdk.State{Schema: schemaResponse.Schema, Raw: raw}).Get(ctx, &state); diags.HasError() {
+		response.Error = function.NewArgumentFuncError(0, "pipeline must be a datadog_observability_pipeline resource: "+function.FuncErrorFromDiags(ctx, diags).Text)
+		return
+	}
+	if len(state.Config) == 0 {
+		response.Error = function.NewArgumentFuncError(0, "pipeline has no config block")
+		return
+	}
+
+	body, diags := expandPipeline(ctx, &state)
+	if diags.HasError() {
+		response.Error = function.FuncErrorFromDiags(ctx, diags)
+		return
+	}
+	workerConfig, err := renderWorkerConfig(body.Data.Attributes.Config)
+	if err != nil {
+		response.Error = function.NewFuncError("error rendering the worker configuration: " + err.Error())
+		return
+	}
+	response.Error = response.Result.Set(ctx, types.StringValue(workerConfig))
+}
+
+func isFullyKnown(ctx context.Context, value attr.Value) bool {
+	if value == nil {
+		return true
+	}
+	terraformValue, err := value.ToTerraformValue(ctx)
+	return err == nil && terraformValue.IsFullyKnown()
+}
+
+// renderWorkerConfig converts the API representation of a pipeline config to
+// YAML. Going through a node keeps the JSON types of the values, and the keys
+// sorted as the API client marshals them.
+func renderWorkerConfig(config interface{}) (string, error) {
+	data, err := json.Marshal(config)
+	if err != nil {
+		return "", err
+	}
+	var document yaml.Node
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return "", err
+	}
+	resetYAMLStyle(&document)
+
+	var buffer bytes.Buffer
+	encoder := yaml.NewEncoder(&buffer)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&document); err != nil {
+		return "", err
+	}
+	if err := encoder.Close(); err != nil {
+		return "", err
+	}
+	return buffer.String(), nil
+}
+
+// resetYAMLStyle drops the flow style and quotes of the JSON input, so that
+// the document is written in block style.
+func resetYAMLStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		resetYAMLStyle(child)
+	}
+}
@@ -0,0 +1,100 @@
+package fwprovider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/fwprovider/observability_pipeline"
+)
+
+func runObservabilityPipelineWorkerConfig(t *testing.T, pipeline *observabilityPipelineModel) attr.Value {
+	ctx := context.Background()
+	schemaResponse := &resource.SchemaResponse{}
+	NewObservabilitPipelineResource().Schema(ctx, resource.SchemaRequest{}, schemaResponse)
+	state := tfsdk.State{Schema: schemaResponse.Schema, Raw: tftypes.NewValue(schemaResponse.Schema.Type().TerraformType(ctx), nil)}
+	diags := state.Set(ctx, pipeline)
+	require.False(t, diags.HasError(), diags)
+	object, err := schemaResponse.Schema.Type().ValueFromTerraform(ctx, state.Raw)
+	require.NoError(t, err)
+
+	response := &function.RunResponse{Result: function.NewResultData(types.StringUnknown())}
+	NewObservabilityPipelineWorkerConfigFunction().Run(ctx, function.RunRequest{Arguments: function.NewArgumentsData([]attr.Value{types.DynamicValue(object)})}, response)
+	require.Nil(t, response.Error)
+	return response.Result.Value()
+}
+
+func TestObservabilityPipelineWorkerConfigFunction(t *testing.T) {
+	inputs := func(ids ...string) types.List {
+		list, _ := types.ListValueFrom(context.Background(), types.StringType, ids)
+		return list
+	}
+	pipeline := &observabilityPipelineModel{
+		ID:   types.StringUnknown(),
+		Name: types.StringValue("main"),
+		Config: []configModel{{
+			PipelineType: types.StringValue("logs"),
+			Sources: []*sourceModel{{
+				Id: types.StringValue("agent"),
+				DatadogAgentSource: []*datadogAgentSourceModel{{
+					Tls: []observability_pipeline.TlsModel{{CrtFile: types.StringValue("/etc/certs/agent.crt")}},
+				}},
+			}},
+			ProcessorGroups: []*processorGroupModel{{
+				Id:      types.StringValue("group"),
+				Enabled: types.BoolValue(true),
+				Include: types.StringValue("service:web"),
+				Inputs:  inputs("agent"),
+				Processors: []*processorModel{{
+					Id:                 types.StringValue("parse"),
+					Enabled:            types.BoolValue(true),
+					Include:            types.StringValue("*"),
+					ParseJsonProcessor: []*parseJsonProcessorModel{{Field: types.StringValue("message")}},
+				}},
+			}},
+			Destinations: []*destinationModel{{
+				Id:                     types.StringValue("logs"),
+				Inputs:                 inputs("group"),
+				DatadogLogsDestination: []*datadogLogsDestinationModel{{}},
+			}},
+		}},
+	}
+
+	assert.Equal(t, types.StringValue(`destinations:
+  - id: logs
+    inputs:
+      - group
+    type: datadog_logs
+pipeline_type: logs
+processor_groups:
+  - enabled: true
+    id: group
+    include: service:web
+    inputs:
+      - agent
+    processors:
+      - enabled: true
+        field: message
+        id: parse
+        include: '*'
+        type: parse_json
+sources:
+  - id: agent
+    tls:
+      crt_file: /etc/certs/agent.crt
+    type: datadog_agent
+`), runObservabilityPipelineWorkerConfig(t, pipeline))
+
+	t.Run("unknown config", func(t *testing.T) {
+		pipeline.Config[0].ProcessorGroups[0].Include = types.StringUnknown()
+		assert.Equal(t, types.StringUnknown(), runObservabilityPipelineWorkerConfig(t, pipeline))
+	})
+}
@@ -0,0 +1,155 @@
+package fwprovider
+
+import (
+	"context"
+	"errors"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/sigma"
+)
+
+var _ function.Function = &sigmaToRuleFunction{}
+
+type sigmaToRuleFunction struct{}
+
+type sigmaRuleModel struct {
+	Name    string               `tfsdk:"name"`
+	Message string               `tfsdk:"message"`
+	Tags    []string             `tfsdk:"tags"`
+	Query   []sigmaRuleQuery     `tfsdk:"query"`
+	Case    []sigmaRuleCase      `tfsdk:"case"`
+	Options sigmaRuleOptionModel `tfsdk:"options"`
+}
+
+type sigmaRuleQuery struct {
+	Name           string   `tfsdk:"name"`
+	Query          string   `tfsdk:"query"`
+	Aggregation    string   `tfsdk:"aggregation"`
+	GroupByFields  []string `tfsdk:"group_by_fields"`
+	DistinctFields []string `tfsdk:"distinct_fields"`
+}
+
+type sigmaRuleCase struct {
+	Status    string `tfsdk:"status"`
+	Condition string `tfsdk:"condition"`
+}
+
+type sigmaRuleOptionModel struct {
+	DetectionMethod   string `tfsdk:"detection_method"`
+	EvaluationWindow  int64  `tfsdk:"evaluation_window"`
+	KeepAlive         int64  `tfsdk:"keep_alive"`
+	MaxSignalDuration int64  `tfsdk:"max_signal_duration"`
+}
+
+func NewSigmaToRuleFunction() function.Function {
+	return &sigmaToRuleFunction{}
+}
+
+func (f *sigmaToRuleFunction) Metadata(_ context.Context, _ function.MetadataRequest, response *function.MetadataResponse) {
+	response.Name = "sigma_to_rule"
+}
+
+func (f *sigmaToRuleFunction) Definition(_ context.Context, _ function.DefinitionRequest, response *function.DefinitionResponse) {
+	stringList := types.ListType{ElemType: types.StringType}
+	response.Definition = function.Definition{
+		Summary:     "Converts a Sigma rule into a Datadog security monitoring rule.",
+		Description: "Converts a Sigma detection rule written in YAML into the `name`, `message`, `tags`, `query`, `case` and `options` of a `datadog_security_monitoring_rule` log detection rule. Sigma fields are mapped to Datadog attributes with `field_mappings`. The function fails, listing them, when the rule uses constructs that cannot be translated, such as regular expressions, base64 modifiers or correlation rules.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "rule",
+				Description: "Sigma rule, in YAML.",
+			},
+			function.MapParameter{
+				Name:           "field_mappings",
+				ElementType:    types.MapType{ElemType: types.StringType},
+				AllowNullValue: true,
+				Description:    "Datadog attributes of the Sigma fields, per log source. Keys are `*` for every rule, a log source `product`, `category` or `service`, `product/category` or `product/service`; more specific keys take precedence. The `logsource` field holds the Datadog query selecting the logs of the log source, which defaults to `source:<service or product>`. Unmapped fields are searched as `@<field>`.",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: map[string]attr.Type{
+				"name":    types.StringType,
+				"message": types.StringType,
+				"tags":    stringList,
+				"query": types.ListType{ElemType: types.ObjectType{AttrTypes: map[string]attr.Type{
+					"name":            types.StringType,
+					"query":           types.StringType,
+					"aggregation":     types.StringType,
+					"group_by_fields": stringList,
+					"distinct_fields": stringList,
+				}}},
+				"case": types.ListType{ElemType: types.ObjectType{AttrTypes: map[string]attr.Type{
+					"status":    types.StringType,
+					"condition": types.StringType,
+				}}},
+				"options": types.ObjectType{AttrTypes: map[string]attr.Type{
+					"detection_method":    types.StringType,
+					"evaluation_window":   types.Int64Type,
+					"keep_alive":          types.Int64Type,
+					"max_signal_duration": types.Int64Type,
+				}},
+			},
+		},
+	}
+}
+
+func (f *sigmaToRuleFunction) Run(ctx context.Context, request function.RunRequest, response *function.RunResponse) {
+	var source string
+	var fieldMappings types.Map
+	response.Error = request.Arguments.Get(ctx, &source, &fieldMappings)
+	if response.Error != nil {
+		return
+	}
+
+	mappings := sigma.FieldMappings{}
+	if !fieldMappings.IsNull() && !fieldMappings.IsUnknown() {
+		if diags := fieldMappings.ElementsAs(ctx, &mappings, false); diags.HasError() {
+			response.Error = function.FuncErrorFromDiags(ctx, diags)
+			return
+		}
+	}
+
+	rule, err := sigma.Convert(source, mappings)
+	if err != nil {
+		var unsupported *sigma.UnsupportedError
+		if errors.As(err, &unsupported) {
+			response.Error = function.NewFuncError(err.Error())
+		} else {
+			response.Error = function.NewArgumentFuncError(0, err.Error())
+		}
+		return
+	}
+	response.Error = response.Result.Set(ctx, buildSigmaRuleModel(rule))
+}
+
+func buildSigmaRuleModel(rule *sigma.Rule) sigmaRuleModel {
+	return sigmaRuleModel{
+		Name:    rule.Name,
+		Message: rule.Message,
+		Tags:    emptyIfNil(rule.Tags),
+		Query: []sigmaRuleQuery{{
+			Name:           rule.Query.Name,
+			Query:          rule.Query.Query,
+			Aggregation:    rule.Query.Aggregation,
+			GroupByFields:  emptyIfNil(rule.Query.GroupByFields),
+			DistinctFields: emptyIfNil(rule.Query.DistinctFields),
+		}},
+		Case: []sigmaRuleCase{{Status: rule.Case.Status, Condition: rule.Case.Condition}},
+		Options: sigmaRuleOptionModel{
+			DetectionMethod:   "threshold",
+			EvaluationWindow:  rule.EvaluationWindow,
+			KeepAlive:         rule.KeepAlive,
+			MaxSignalDuration: rule.MaxSignalDuration,
+		},
+	}
+}
+
+func emptyIfNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
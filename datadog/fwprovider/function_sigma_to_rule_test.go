@@ -0,0 +1,77 @@
+package fwprovider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func runSigmaToRule(t *testing.T, rule string, fieldMappings attr.Value) (*sigmaRuleModel, *function.FuncError) {
+	ctx := context.Background()
+	f := NewSigmaToRuleFunction()
+	definition := &function.DefinitionResponse{}
+	f.Definition(ctx, function.DefinitionRequest{}, definition)
+
+	response := &function.RunResponse{
+		Result: function.NewResultData(types.ObjectUnknown(definition.Definition.Return.GetType().(types.ObjectType).AttrTypes)),
+	}
+	f.Run(ctx, function.RunRequest{Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(rule), fieldMappings})}, response)
+	if response.Error != nil {
+		return nil, response.Error
+	}
+	var model sigmaRuleModel
+	diags := response.Result.Value().(types.Object).As(ctx, &model, basetypes.ObjectAsOptions{})
+	assert.False(t, diags.HasError(), diags)
+	return &model, nil
+}
+
+func TestSigmaToRuleFunction(t *testing.T) {
+	mappingType := types.MapType{ElemType: types.StringType}
+	rule := `
+title: Root login
+logsource:
+  product: linux
+  service: auth
+detection:
+  selection:
+    user: root
+  condition: selection
+level: medium
+`
+
+	t.Run("default mappings", func(t *testing.T) {
+		model, err := runSigmaToRule(t, rule, types.MapNull(mappingType))
+		assert.Nil(t, err)
+		assert.Equal(t, "Root login", model.Name)
+		assert.Equal(t, []string{}, model.Tags)
+		assert.Equal(t, []sigmaRuleQuery{{Name: "sigma", Query: "source:auth AND @user:root", Aggregation: "count", GroupByFields: []string{}, DistinctFields: []string{}}}, model.Query)
+		assert.Equal(t, []sigmaRuleCase{{Status: "medium", Condition: "sigma > 0"}}, model.Case)
+		assert.Equal(t, sigmaRuleOptionModel{DetectionMethod: "threshold", EvaluationWindow: 300, KeepAlive: 3600, MaxSignalDuration: 86400}, model.Options)
+	})
+
+	t.Run("field mappings", func(t *testing.T) {
+		mappings, _ := types.MapValueFrom(context.Background(), mappingType, map[string]map[string]string{
+			"linux/auth": {"logsource": "service:sshd", "user": "@usr.name"},
+		})
+		model, err := runSigmaToRule(t, rule, mappings)
+		assert.Nil(t, err)
+		assert.Equal(t, "service:sshd AND @usr.name:root", model.Query[0].Query)
+	})
+
+	t.Run("unsupported constructs", func(t *testing.T) {
+		_, err := runSigmaToRule(t, "title: t\ndetection:\n  selection:\n    user|re: '^r'\n  condition: selection", types.MapNull(mappingType))
+		assert.Contains(t, err.Text, "modifier re of user in selection")
+		assert.Nil(t, err.FunctionArgument)
+	})
+
+	t.Run("invalid rule", func(t *testing.T) {
+		_, err := runSigmaToRule(t, "title: [", types.MapNull(mappingType))
+		assert.Contains(t, err.Text, "invalid Sigma rule")
+		assert.Equal(t, int64(0), *err.FunctionArgument)
+	})
+}
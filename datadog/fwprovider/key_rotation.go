@@ -0,0 +1,245 @@
+package fwprovider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/utils"
+)
+
+var keyRotationDurationRegexp = regexp.MustCompile(`^([0-9]+)([dhm])$`)
+
+// keyRotationModel holds the rotation attributes of the API and application
+// key resources.
+type keyRotationModel struct {
+	RotationPeriod       types.String `tfsdk:"rotation_period"`
+	RotateWhenChanged    types.Map    `tfsdk:"rotate_when_changed"`
+	GracePeriod          types.String `tfsdk:"grace_period"`
+	RotatedAt            types.String `tfsdk:"rotated_at"`
+	PreviousKeyId        types.String `tfsdk:"previous_key_id"`
+	PreviousKey          types.String `tfsdk:"previous_key"`
+	PreviousKeyExpiresAt types.String `tfsdk:"previous_key_expires_at"`
+}
+
+// addKeyRotationAttributes adds the rotation attributes to the schema of a
+// key resource.
+func addKeyRotationAttributes(attributes map[string]schema.Attribute) {
+	durationValidators := []validator.String{
+		stringvalidator.RegexMatches(keyRotationDurationRegexp, "must be a number of days, hours or minutes, for example `90d`, `12h` or `30m`"),
+	}
+	attributes["rotation_period"] = schema.StringAttribute{
+		Description: "Time after which the key is rotated, as a number of days, hours or minutes, for example `90d`. Terraform only rotates the key when it runs: the key is rotated by the first apply after the period elapsed. The rotation creates a new key before the previous one is deleted, see `grace_period`.",
+		Optional:    true,
+		Validators:  durationValidators,
+	}
+	attributes["rotate_when_changed"] = schema.MapAttribute{
+		Description: "Arbitrary map of values that rotates the key when it changes, for example the ID of a `time_rotating` resource. Setting or removing the map does not rotate the key.",
+		Optional:    true,
+		ElementType: types.StringType,
+	}
+	attributes["grace_period"] = schema.StringAttribute{
+		Description: "Time during which the previous key is kept after a rotation, as a number of days, hours or minutes, for example `7d`. The previous key is deleted by the first apply after the grace period elapsed. When unset, the previous key is deleted as soon as the new key is created.",
+		Optional:    true,
+		Validators:  durationValidators,
+	}
+	attributes["rotated_at"] = schema.StringAttribute{
+		Description:   "Creation date of the current key, which `rotation_period` is counted from.",
+		Computed:      true,
+		PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+	}
+	attributes["previous_key_id"] = schema.StringAttribute{
+		Description:   "ID of the key replaced by the last rotation, while its grace period is not over.",
+		Computed:      true,
+		PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+	}
+	attributes["previous_key"] = schema.StringAttribute{
+		Description:   "Value of the key replaced by the last rotation, while its grace period is not over.",
+		Computed:      true,
+		Sensitive:     true,
+		PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+	}
+	attributes["previous_key_expires_at"] = schema.StringAttribute{
+		Description:   "Date after which the key replaced by the last rotation is deleted.",
+		Computed:      true,
+		PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+	}
+}
+
+// parseKeyRotationDuration parses a number of days, hours or minutes.
+func parseKeyRotationDuration(value string) (time.Duration, error) {
+	match := keyRotationDurationRegexp.FindStringSubmatch(value)
+	if match == nil {
+		return 0, fmt.Errorf("invalid duration %q", value)
+	}
+	n, err := strconv.Atoi(match[1])
+	if err != nil {
+		return 0, err
+	}
+	unit := map[string]time.Duration{"d": 24 * time.Hour, "h": time.Hour, "m": time.Minute}[match[2]]
+	return time.Duration(n) * unit, nil
+}
+
+type attributeGetter interface {
+	GetAttribute(context.Context, path.Path, interface{}) diag.Diagnostics
+}
+
+func getKeyRotation(ctx context.Context, data attributeGetter) (keyRotationModel, diag.Diagnostics) {
+	var m keyRotationModel
+	var diags diag.Diagnostics
+	diags.Append(data.GetAttribute(ctx, path.Root("rotation_period"), &m.RotationPeriod)...)
+	diags.Append(data.GetAttribute(ctx, path.Root("rotate_when_changed"), &m.RotateWhenChanged)...)
+	diags.Append(data.GetAttribute(ctx, path.Root("grace_period"), &m.GracePeriod)...)
+	diags.Append(data.GetAttribute(ctx, path.Root("rotated_at"), &m.RotatedAt)...)
+	diags.Append(data.GetAttribute(ctx, path.Root("previous_key_id"), &m.PreviousKeyId)...)
+	diags.Append(data.GetAttribute(ctx, path.Root("previous_key"), &m.PreviousKey)...)
+	diags.Append(data.GetAttribute(ctx, path.Root("previous_key_expires_at"), &m.PreviousKeyExpiresAt)...)
+	return m, diags
+}
+
+// modifyKeyRotationPlan plans the rotation of a key when it is due, and the
+// deletion of the previous key when its grace period is over. A rotation is
+// planned as an update with unknown key attributes: the key resources create
+// a new key when their key is unknown in the plan. The paths are the
+// attributes of the resource which change with the key. Nothing is planned
+// when the resource is destroyed.
+func modifyKeyRotationPlan(ctx context.Context, request resource.ModifyPlanRequest, response *resource.ModifyPlanResponse, paths ...path.Path) {
+	if request.Plan.Raw.IsNull() {
+		return
+	}
+	plan, diags := getKeyRotation(ctx, request.Plan)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	if request.State.Raw.IsNull() {
+		plan.clearPreviousKey()
+		response.Diagnostics.Append(setPreviousKeyPlan(ctx, response, plan)...)
+		return
+	}
+	state, diags := getKeyRotation(ctx, request.State)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	now := time.Now()
+	switch {
+	case keyRotationDue(state, plan, now):
+		paths = append(paths, path.Root("rotated_at"), path.Root("previous_key_id"), path.Root("previous_key"), path.Root("previous_key_expires_at"))
+		for _, p := range paths {
+			response.Diagnostics.Append(response.Plan.SetAttribute(ctx, p, types.StringUnknown())...)
+		}
+	case previousKeyExpired(state, now):
+		plan.clearPreviousKey()
+		response.Diagnostics.Append(setPreviousKeyPlan(ctx, response, plan)...)
+	}
+}
+
+func setPreviousKeyPlan(ctx context.Context, response *resource.ModifyPlanResponse, plan keyRotationModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	diags.Append(response.Plan.SetAttribute(ctx, path.Root("previous_key_id"), plan.PreviousKeyId)...)
+	diags.Append(response.Plan.SetAttribute(ctx, path.Root("previous_key"), plan.PreviousKey)...)
+	diags.Append(response.Plan.SetAttribute(ctx, path.Root("previous_key_expires_at"), plan.PreviousKeyExpiresAt)...)
+	return diags
+}
+
+// keyRotationDue returns whether rotate_when_changed changed or the rotation
+// period elapsed.
+func keyRotationDue(state, plan keyRotationModel, now time.Time) bool {
+	if !state.RotateWhenChanged.IsNull() && !plan.RotateWhenChanged.IsNull() && !plan.RotateWhenChanged.Equal(state.RotateWhenChanged) {
+		return true
+	}
+	if plan.RotationPeriod.IsNull() || plan.RotationPeriod.IsUnknown() {
+		return false
+	}
+	period, err := parseKeyRotationDuration(plan.RotationPeriod.ValueString())
+	if err != nil {
+		return false
+	}
+	rotatedAt, err := time.Parse(time.RFC3339, state.RotatedAt.ValueString())
+	if err != nil {
+		return false
+	}
+	return !now.Before(rotatedAt.Add(period))
+}
+
+func previousKeyExpired(state keyRotationModel, now time.Time) bool {
+	if state.PreviousKeyId.IsNull() {
+		return false
+	}
+	expiresAt, err := time.Parse(time.RFC3339, state.PreviousKeyExpiresAt.ValueString())
+	return err != nil || !now.Before(expiresAt)
+}
+
+func (m *keyRotationModel) clearPreviousKey() {
+	m.PreviousKeyId = types.StringNull()
+	m.PreviousKey = types.StringNull()
+	m.PreviousKeyExpiresAt = types.StringNull()
+}
+
+// setRotatedAt records the creation date of the current key.
+func (m *keyRotationModel) setRotatedAt(createdAt time.Time) {
+	m.RotatedAt = types.StringValue(createdAt.UTC().Format(time.RFC3339))
+}
+
+// deletePreviousKey deletes the previous key before a key is updated, when
+// it is replaced by a rotation or its grace period is over.
+func (m *keyRotationModel) deletePreviousKey(state keyRotationModel, rotated bool, deleteKey func(id string) error) diag.Diagnostics {
+	previousID := state.PreviousKeyId.ValueString()
+	if previousID == "" || !(rotated || m.PreviousKeyId.IsNull()) {
+		return nil
+	}
+	if err := deleteKey(previousID); err != nil {
+		return diag.Diagnostics{utils.FrameworkErrorDiag(err, "error deleting previous key")}
+	}
+	m.clearPreviousKey()
+	return nil
+}
+
+// keyRotated updates the rotation attributes after a key is created or
+// updated. When rotated is true, a new key was created, and oldID and oldKey
+// are the ID and the value of the key it replaces, which is kept for the
+// grace period. Failing to delete the replaced key is only a warning: it is
+// recorded as an expired previous key, deleted by the next apply.
+func (m *keyRotationModel) keyRotated(rotated bool, oldID, oldKey string, deleteKey func(id string) error) diag.Diagnostics {
+	now := time.Now()
+	if m.RotatedAt.IsUnknown() || m.RotatedAt.IsNull() {
+		m.setRotatedAt(now)
+	}
+	if !rotated {
+		if m.PreviousKeyId.IsUnknown() {
+			m.clearPreviousKey()
+		}
+		return nil
+	}
+
+	var grace time.Duration
+	if !m.GracePeriod.IsNull() {
+		grace, _ = parseKeyRotationDuration(m.GracePeriod.ValueString())
+	}
+	m.PreviousKeyId = types.StringValue(oldID)
+	m.PreviousKey = types.StringValue(oldKey)
+	m.PreviousKeyExpiresAt = types.StringValue(now.Add(grace).UTC().Format(time.RFC3339))
+	if grace == 0 {
+		if err := deleteKey(oldID); err != nil {
+			var diags diag.Diagnostics
+			diags.AddWarning("error deleting replaced key", fmt.Sprintf("The key %s will be deleted by the next apply: %s", oldID, err))
+			return diags
+		}
+		m.clearPreviousKey()
+	}
+	return nil
+}
@@ -0,0 +1,182 @@
+package fwprovider
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseKeyRotationDuration(t *testing.T) {
+	for value, expected := range map[string]time.Duration{"90d": 90 * 24 * time.Hour, "12h": 12 * time.Hour, "30m": 30 * time.Minute} {
+		d, err := parseKeyRotationDuration(value)
+		require.NoError(t, err)
+		assert.Equal(t, expected, d)
+	}
+	_, err := parseKeyRotationDuration("1w")
+	assert.Error(t, err)
+}
+
+func TestKeyRotationDue(t *testing.T) {
+	now := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+	trigger := func(value string) types.Map {
+		return types.MapValueMust(types.StringType, map[string]attr.Value{"date": types.StringValue(value)})
+	}
+	rotation := func(period string, rotatedAt time.Time, rotateWhenChanged types.Map) keyRotationModel {
+		m := keyRotationModel{RotationPeriod: types.StringValue(period), RotateWhenChanged: rotateWhenChanged}
+		if period == "" {
+			m.RotationPeriod = types.StringNull()
+		}
+		m.setRotatedAt(rotatedAt)
+		return m
+	}
+
+	cases := map[string]struct {
+		state, plan keyRotationModel
+		expected    bool
+	}{
+		"elapsed":     {rotation("90d", now.AddDate(0, 0, -91), types.MapNull(types.StringType)), rotation("90d", now.AddDate(0, 0, -91), types.MapNull(types.StringType)), true},
+		"not elapsed": {rotation("90d", now.AddDate(0, 0, -89), types.MapNull(types.StringType)), rotation("90d", now.AddDate(0, 0, -89), types.MapNull(types.StringType)), false},
+		"no period":   {rotation("", now.AddDate(-1, 0, 0), types.MapNull(types.StringType)), rotation("", now.AddDate(-1, 0, 0), types.MapNull(types.StringType)), false},
+		"changed":     {rotation("", now, trigger("2024-01-01")), rotation("", now, trigger("2024-04-01")), true},
+		"unchanged":   {rotation("", now, trigger("2024-01-01")), rotation("", now, trigger("2024-01-01")), false},
+		"added":       {rotation("", now, types.MapNull(types.StringType)), rotation("", now, trigger("2024-01-01")), false},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, c.expected, keyRotationDue(c.state, c.plan, now))
+		})
+	}
+}
+
+func TestKeyRotated(t *testing.T) {
+	var deleted []string
+	deleteKey := func(id string) error {
+		deleted = append(deleted, id)
+		return nil
+	}
+
+	t.Run("without grace period", func(t *testing.T) {
+		deleted = nil
+		m := keyRotationModel{GracePeriod: types.StringNull(), RotatedAt: types.StringUnknown()}
+		diags := m.keyRotated(true, "old", "secret", deleteKey)
+		assert.False(t, diags.HasError(), diags)
+		assert.Equal(t, []string{"old"}, deleted)
+		assert.True(t, m.PreviousKeyId.IsNull())
+		assert.False(t, m.RotatedAt.IsUnknown())
+	})
+
+	t.Run("with grace period", func(t *testing.T) {
+		deleted = nil
+		m := keyRotationModel{GracePeriod: types.StringValue("7d"), RotatedAt: types.StringUnknown()}
+		diags := m.keyRotated(true, "old", "secret", deleteKey)
+		assert.False(t, diags.HasError(), diags)
+		assert.Empty(t, deleted)
+		assert.Equal(t, "old", m.PreviousKeyId.ValueString())
+		assert.Equal(t, "secret", m.PreviousKey.ValueString())
+		assert.False(t, previousKeyExpired(m, time.Now().AddDate(0, 0, 6)))
+		assert.True(t, previousKeyExpired(m, time.Now().AddDate(0, 0, 8)))
+
+		// The previous key is deleted before the next rotation
+		next := keyRotationModel{PreviousKeyId: types.StringUnknown()}
+		diags = next.deletePreviousKey(m, true, deleteKey)
+		assert.False(t, diags.HasError(), diags)
+		assert.Equal(t, []string{"old"}, deleted)
+	})
+
+	t.Run("failed deletion", func(t *testing.T) {
+		m := keyRotationModel{GracePeriod: types.StringNull(), RotatedAt: types.StringUnknown()}
+		diags := m.keyRotated(true, "old", "secret", func(string) error { return errors.New("forbidden") })
+		assert.False(t, diags.HasError(), diags)
+		assert.Equal(t, diag.SeverityWarning, diags[0].Severity())
+		assert.Equal(t, "old", m.PreviousKeyId.ValueString())
+		assert.True(t, previousKeyExpired(m, time.Now()))
+	})
+}
+
+func TestAPIKeyRotationPlan(t *testing.T) {
+	ctx := context.Background()
+	r := NewAPIKeyResource().(*apiKeyResource)
+	schemaResp := resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	model := apiKeyResourceModel{
+		ID:           types.StringValue("id"),
+		Name:         types.StringValue("foo"),
+		Key:          types.StringValue("secret"),
+		RemoteConfig: types.BoolValue(false),
+		keyRotationModel: keyRotationModel{
+			RotationPeriod:    types.StringValue("90d"),
+			RotateWhenChanged: types.MapNull(types.StringType),
+			GracePeriod:       types.StringValue("7d"),
+		},
+	}
+	model.clearPreviousKey()
+	model.setRotatedAt(time.Now().AddDate(0, 0, -91))
+
+	state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+	diags := state.Set(ctx, &model)
+	require.False(t, diags.HasError(), diags)
+
+	request := resource.ModifyPlanRequest{State: state, Plan: tfsdk.Plan{Schema: state.Schema, Raw: state.Raw}}
+	response := resource.ModifyPlanResponse{Plan: request.Plan}
+	r.ModifyPlan(ctx, request, &response)
+	require.False(t, response.Diagnostics.HasError(), response.Diagnostics)
+
+	var plan apiKeyResourceModel
+	diags = response.Plan.Get(ctx, &plan)
+	require.False(t, diags.HasError(), diags)
+	assert.True(t, plan.Key.IsUnknown())
+	assert.True(t, plan.ID.IsUnknown())
+	assert.True(t, plan.PreviousKey.IsUnknown())
+	assert.Equal(t, "foo", plan.Name.ValueString())
+
+	var key types.String
+	diags = state.GetAttribute(ctx, path.Root("key"), &key)
+	require.False(t, diags.HasError(), diags)
+	assert.Equal(t, "secret", key.ValueString())
+}
+
+func TestAPIKeyRotationPlanDestroy(t *testing.T) {
+	ctx := context.Background()
+	r := NewAPIKeyResource().(*apiKeyResource)
+	schemaResp := resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	model := apiKeyResourceModel{
+		ID:           types.StringValue("id"),
+		Name:         types.StringValue("foo"),
+		Key:          types.StringValue("secret"),
+		RemoteConfig: types.BoolValue(false),
+		keyRotationModel: keyRotationModel{
+			RotationPeriod:       types.StringValue("90d"),
+			RotateWhenChanged:    types.MapNull(types.StringType),
+			GracePeriod:          types.StringValue("7d"),
+			PreviousKeyId:        types.StringValue("old"),
+			PreviousKey:          types.StringValue("old-secret"),
+			PreviousKeyExpiresAt: types.StringValue(time.Now().AddDate(0, 0, -1).UTC().Format(time.RFC3339)),
+		},
+	}
+	model.setRotatedAt(time.Now().AddDate(0, 0, -8))
+
+	state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+	diags := state.Set(ctx, &model)
+	require.False(t, diags.HasError(), diags)
+
+	plan := tfsdk.Plan{Schema: state.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+	request := resource.ModifyPlanRequest{State: state, Plan: plan}
+	response := resource.ModifyPlanResponse{Plan: request.Plan}
+	r.ModifyPlan(ctx, request, &response)
+	require.False(t, response.Diagnostics.HasError(), response.Diagnostics)
+	assert.True(t, response.Plan.Raw.IsNull(), "destroying a key keeps a null plan")
+}
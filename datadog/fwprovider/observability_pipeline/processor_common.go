@@ -106,3 +106,34 @@ func (v ExactlyOneProcessorValidator) ValidateObject(ctx context.Context, req va
 		))
 	}
 }
+
+// ProcessorsJSONConflictValidator validates that a processor group does not
+// define both processor blocks and processors from a processor group template.
+type ProcessorsJSONConflictValidator struct{}
+
+func (v ProcessorsJSONConflictValidator) Description(ctx context.Context) string {
+	return "validates that processor blocks and processors_json are not both specified"
+}
+
+func (v ProcessorsJSONConflictValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v ProcessorsJSONConflictValidator) ValidateObject(ctx context.Context, req validator.ObjectRequest, resp *validator.ObjectResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	attrs := req.ConfigValue.Attributes()
+	processorsJSON, hasJSON := attrs["processors_json"]
+	processors, _ := attrs["processor"].(types.List)
+	if !hasJSON || processorsJSON.IsNull() || len(processors.Elements()) == 0 {
+		return
+	}
+
+	resp.Diagnostics.Append(diag.NewAttributeErrorDiagnostic(
+		req.Path.AtName("processors_json"),
+		"Conflicting Processors",
+		"A processor group must define its processors either with processor blocks or with processors_json, not both.",
+	))
+}
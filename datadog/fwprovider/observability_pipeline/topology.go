@@ -0,0 +1,484 @@
+package observability_pipeline
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// Component kinds of a pipeline topology.
+const (
+	SourceComponent         = "source"
+	ProcessorGroupComponent = "processor group"
+	ProcessorComponent      = "processor"
+	DestinationComponent    = "destination"
+)
+
+// metricsOutputSuffix is appended to the ID of a `generate_metrics` processor
+// to reference the metrics it generates.
+const metricsOutputSuffix = ".metrics"
+
+// Component types handling a single kind of data. Any other component type,
+// including types added after this list, is assumed to handle both logs and
+// metrics.
+var (
+	logsOnlyProcessors = map[string]bool{
+		"parse_json": true, "parse_xml": true, "parse_grok": true, "add_fields": true, "add_hostname": true,
+		"rename_fields": true, "remove_fields": true, "quota": true, "sensitive_data_scanner": true,
+		"generate_datadog_metrics": true, "generate_metrics": true, "sample": true, "dedupe": true,
+		"reduce": true, "split_array": true, "add_env_vars": true, "enrichment_table": true, "ocsf_mapper": true,
+	}
+	metricsOnlyProcessors = map[string]bool{
+		"metric_tags": true, "add_metric_tags": true, "rename_metric_tags": true, "tag_cardinality_limit": true, "aggregate": true,
+	}
+	metricsOnlyDestinations = map[string]bool{
+		"datadog_metrics": true, "splunk_hec_metrics": true,
+	}
+	bothKindsDestinations = map[string]bool{
+		"http_client": true, "elasticsearch": true,
+	}
+)
+
+// Component is a source, processor group, processor or destination of a
+// pipeline, with the configuration path of its block.
+type Component struct {
+	Kind   string
+	ID     string
+	Type   string
+	Path   path.Path
+	Inputs []string
+	// Processors of a processor group.
+	Processors []*Component
+}
+
+// Topology is the component graph of a pipeline, wired by the `inputs` of
+// its processor groups and destinations.
+type Topology struct {
+	PipelineType    string
+	Sources         []*Component
+	ProcessorGroups []*Component
+	Destinations    []*Component
+}
+
+// TopologyValidator validates the component graph of each pipeline config:
+// duplicate IDs, dangling inputs, cycles, components which do not receive or
+// send data, and logs components wired to metrics ones.
+type TopologyValidator struct{}
+
+var _ resource.ConfigValidator = TopologyValidator{}
+
+func (v TopologyValidator) Description(ctx context.Context) string {
+	return "validates that the inputs of the pipeline components form a valid graph"
+}
+
+func (v TopologyValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v TopologyValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var configs types.List
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("config"), &configs)...)
+	if resp.Diagnostics.HasError() || configs.IsNull() || configs.IsUnknown() {
+		return
+	}
+	for i, element := range configs.Elements() {
+		config, ok := element.(types.Object)
+		if !ok || config.IsNull() || config.IsUnknown() {
+			continue
+		}
+		// The graph is only validated once fully known, to not report
+		// components wired by values computed at apply time.
+		if topology, ok := NewTopology(config, path.Root("config").AtListIndex(i)); ok {
+			resp.Diagnostics.Append(topology.Validate()...)
+		}
+	}
+}
+
+// NewTopology builds the topology of a pipeline config. It returns false when
+// an ID, an input or a component type is not known yet.
+func NewTopology(config types.Object, configPath path.Path) (*Topology, bool) {
+	attrs := config.Attributes()
+	topology := &Topology{PipelineType: "logs"}
+	if pipelineType, ok := attrs["pipeline_type"].(types.String); ok && !pipelineType.IsNull() {
+		if pipelineType.IsUnknown() {
+			return nil, false
+		}
+		topology.PipelineType = pipelineType.ValueString()
+	}
+
+	known := true
+	build := func(kind string, blockPath path.Path, block types.Object) *Component {
+		c, ok := newComponent(kind, blockPath, block)
+		known = known && ok
+		return c
+	}
+	for i, source := range nestedObjects(attrs["source"]) {
+		topology.Sources = append(topology.Sources, build(SourceComponent, configPath.AtName("source").AtListIndex(i), source))
+	}
+	for i, group := range nestedObjects(attrs["processor_group"]) {
+		groupPath := configPath.AtName("processor_group").AtListIndex(i)
+		component := build(ProcessorGroupComponent, groupPath, group)
+		for j, processor := range nestedObjects(group.Attributes()["processor"]) {
+			component.Processors = append(component.Processors, build(ProcessorComponent, groupPath.AtName("processor").AtListIndex(j), processor))
+		}
+		component.Processors = append(component.Processors, templateProcessors(group.Attributes()["processors_json"], groupPath.AtName("processors_json"))...)
+		topology.ProcessorGroups = append(topology.ProcessorGroups, component)
+	}
+	for i, destination := range nestedObjects(attrs["destination"]) {
+		topology.Destinations = append(topology.Destinations, build(DestinationComponent, configPath.AtName("destination").AtListIndex(i), destination))
+	}
+	return topology, known
+}
+
+// templateProcessors reads the processors set from a processor group template,
+// in the API format.
+func templateProcessors(value attr.Value, processorsPath path.Path) []*Component {
+	s, ok := value.(basetypes.StringValuable)
+	if !ok || value.IsNull() || value.IsUnknown() {
+		return nil
+	}
+	processorsJSON, diags := s.ToStringValue(context.Background())
+	var processors []struct {
+		ID   string `json:"id"`
+		Type string `json:"type"`
+	}
+	if diags.HasError() || json.Unmarshal([]byte(processorsJSON.ValueString()), &processors) != nil {
+		return nil
+	}
+	var components []*Component
+	for _, processor := range processors {
+		components = append(components, &Component{Kind: ProcessorComponent, ID: processor.ID, Type: processor.Type, Path: processorsPath})
+	}
+	return components
+}
+
+func nestedObjects(value attr.Value) []types.Object {
+	list, ok := value.(types.List)
+	if !ok || list.IsNull() || list.IsUnknown() {
+		return nil
+	}
+	var objects []types.Object
+	for _, element := range list.Elements() {
+		if object, ok := element.(types.Object); ok && !object.IsNull() && !object.IsUnknown() {
+			objects = append(objects, object)
+		}
+	}
+	return objects
+}
+
+// newComponent reads the ID, inputs and type of a component block. The type
+// is the name of its non-empty nested block, processor groups have none.
+func newComponent(kind string, blockPath path.Path, block types.Object) (*Component, bool) {
+	c := &Component{Kind: kind, Path: blockPath}
+	known := true
+	for name, value := range block.Attributes() {
+		if value.IsUnknown() {
+			known = false
+			continue
+		}
+		switch name {
+		case "id":
+			c.ID = value.(types.String).ValueString()
+		case "inputs":
+			for _, input := range value.(types.List).Elements() {
+				if input.IsUnknown() {
+					known = false
+				} else if s, ok := input.(types.String); ok && !s.IsNull() {
+					c.Inputs = append(c.Inputs, s.ValueString())
+				}
+			}
+		case "processor", "processors_json":
+		default:
+			if list, ok := value.(types.List); ok && len(list.Elements()) > 0 {
+				c.Type = name
+			}
+		}
+	}
+	return c, known
+}
+
+// Validate checks the topology, reporting errors at the path of the component
+// block, or of the input, at fault.
+func (t *Topology) Validate() diag.Diagnostics {
+	var diags diag.Diagnostics
+	v := &topologyValidation{
+		Topology:   t,
+		components: map[string]*Component{},
+		groupOf:    map[*Component]*Component{},
+		edges:      map[*Component][]edge{},
+	}
+	v.registerIDs(&diags)
+	v.resolveInputs(&diags)
+	if v.checkCycles(&diags) {
+		v.checkReachability(&diags)
+		v.checkKinds(&diags)
+	}
+	return diags
+}
+
+// edge is an input of a processor group or destination, resolved to the
+// source or processor group it comes from.
+type edge struct {
+	from    *Component
+	path    path.Path
+	metrics bool // the metrics generated by a `generate_metrics` processor
+}
+
+type topologyValidation struct {
+	*Topology
+	components map[string]*Component
+	groupOf    map[*Component]*Component
+	edges      map[*Component][]edge
+}
+
+func describe(c *Component) string {
+	if c.Type != "" {
+		return fmt.Sprintf("%s %q (`%s`)", c.Kind, c.ID, c.Type)
+	}
+	return fmt.Sprintf("%s %q", c.Kind, c.ID)
+}
+
+func (v *topologyValidation) registerIDs(diags *diag.Diagnostics) {
+	register := func(c *Component) {
+		if existing, ok := v.components[c.ID]; ok {
+			diags.AddAttributeError(c.Path.AtName("id"), "Duplicate pipeline component ID",
+				fmt.Sprintf("The ID %q of this %s is already used by the %s at %s. Component IDs must be unique in a pipeline.", c.ID, c.Kind, existing.Kind, existing.Path))
+			return
+		}
+		v.components[c.ID] = c
+	}
+	for _, source := range v.Sources {
+		register(source)
+	}
+	for _, group := range v.ProcessorGroups {
+		register(group)
+		for _, processor := range group.Processors {
+			v.groupOf[processor] = group
+			register(processor)
+		}
+	}
+	for _, destination := range v.Destinations {
+		register(destination)
+	}
+}
+
+func (v *topologyValidation) resolveInputs(diags *diag.Diagnostics) {
+	for _, c := range append(append([]*Component{}, v.ProcessorGroups...), v.Destinations...) {
+		if len(c.Inputs) == 0 {
+			diags.AddAttributeError(c.Path.AtName("inputs"), "Missing pipeline component inputs",
+				fmt.Sprintf("The %s has no inputs.", describe(c)))
+			continue
+		}
+		for k, input := range c.Inputs {
+			inputPath := c.Path.AtName("inputs").AtListIndex(k)
+			from, metrics, problem := v.resolve(input)
+			if problem != "" {
+				diags.AddAttributeError(inputPath, "Invalid pipeline component input",
+					fmt.Sprintf("The input %q of the %s %s.", input, describe(c), problem))
+				continue
+			}
+			v.edges[c] = append(v.edges[c], edge{from: from, path: inputPath, metrics: metrics})
+		}
+	}
+}
+
+// resolve returns the source or processor group an input comes from, or why
+// it is not a valid input.
+func (v *topologyValidation) resolve(input string) (*Component, bool, string) {
+	if c, ok := v.components[input]; ok {
+		switch c.Kind {
+		case SourceComponent, ProcessorGroupComponent:
+			return c, false, ""
+		case ProcessorComponent:
+			return nil, false, fmt.Sprintf("is a processor of processor group %q, reference the processor group instead", v.groupOf[c].ID)
+		}
+		return nil, false, "is a destination, which cannot be used as an input"
+	}
+	if id, ok := strings.CutSuffix(input, metricsOutputSuffix); ok {
+		if c, ok := v.components[id]; ok && c.Kind == ProcessorComponent && c.Type == "generate_metrics" {
+			return v.groupOf[c], true, ""
+		}
+		return nil, false, fmt.Sprintf("does not reference a `generate_metrics` processor, only their metrics can be used with the %s suffix", metricsOutputSuffix)
+	}
+	return nil, false, "does not match any source or processor group"
+}
+
+// checkCycles reports the processor groups whose inputs loop back to them,
+// and returns whether the graph has no cycle.
+func (v *topologyValidation) checkCycles(diags *diag.Diagnostics) bool {
+	const (
+		visiting = 1
+		done     = 2
+	)
+	state := map[*Component]int{}
+	var stack []*Component
+	acyclic := true
+	var visit func(c *Component)
+	visit = func(c *Component) {
+		state[c] = visiting
+		stack = append(stack, c)
+		for _, e := range v.edges[c] {
+			switch state[e.from] {
+			case visiting:
+				acyclic = false
+				var ids []string
+				for i := len(stack) - 1; i >= 0; i-- {
+					ids = append(ids, stack[i].ID)
+					if stack[i] == e.from {
+						break
+					}
+				}
+				ids = append(ids, c.ID)
+				diags.AddAttributeError(e.path, "Pipeline component cycle",
+					fmt.Sprintf("The inputs of processor group %q form a cycle: %s.", c.ID, strings.Join(ids, " ← ")))
+			case 0:
+				visit(e.from)
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[c] = done
+	}
+	for _, group := range v.ProcessorGroups {
+		if state[group] == 0 {
+			visit(group)
+		}
+	}
+	return acyclic
+}
+
+// checkReachability reports the processor groups which do not receive data
+// from a source, and the sources and processor groups whose data is not sent
+// to any destination.
+func (v *topologyValidation) checkReachability(diags *diag.Diagnostics) {
+	fed := map[*Component]bool{}
+	var feeds func(c *Component) bool
+	feeds = func(c *Component) bool {
+		if c.Kind == SourceComponent {
+			return true
+		}
+		if result, ok := fed[c]; ok {
+			return result
+		}
+		fed[c] = false
+		for _, e := range v.edges[c] {
+			if feeds(e.from) {
+				fed[c] = true
+			}
+		}
+		return fed[c]
+	}
+
+	used := map[*Component]bool{}
+	var use func(c *Component)
+	use = func(c *Component) {
+		if used[c] {
+			return
+		}
+		used[c] = true
+		for _, e := range v.edges[c] {
+			use(e.from)
+		}
+	}
+	for _, destination := range v.Destinations {
+		use(destination)
+	}
+
+	for _, group := range v.ProcessorGroups {
+		if len(v.edges[group]) > 0 && !feeds(group) {
+			diags.AddAttributeError(group.Path, "Unreachable pipeline component",
+				fmt.Sprintf("The processor group %q does not receive data from any source.", group.ID))
+		}
+	}
+	for _, c := range append(append([]*Component{}, v.Sources...), v.ProcessorGroups...) {
+		if !used[c] {
+			diags.AddAttributeError(c.Path, "Unused pipeline component",
+				fmt.Sprintf("The data of the %s is not sent to any destination.", describe(c)))
+		}
+	}
+}
+
+// checkKinds reports the processors and destinations which receive a kind of
+// data, logs or metrics, they do not handle.
+func (v *topologyValidation) checkKinds(diags *diag.Diagnostics) {
+	kinds := map[*Component]map[string]string{}
+	var kindsOf func(c *Component) map[string]string
+	// kindsOf returns the kinds of data a component outputs, with the ID of a
+	// source of each kind.
+	kindsOf = func(c *Component) map[string]string {
+		if c.Kind == SourceComponent {
+			return map[string]string{v.PipelineType: c.ID}
+		}
+		if result, ok := kinds[c]; ok {
+			return result
+		}
+		result := map[string]string{}
+		for _, e := range v.edges[c] {
+			for kind, origin := range v.edgeKinds(e, kindsOf) {
+				if _, ok := result[kind]; !ok {
+					result[kind] = origin
+				}
+			}
+		}
+		kinds[c] = result
+		return result
+	}
+
+	for _, group := range v.ProcessorGroups {
+		received := kindsOf(group)
+		for _, processor := range group.Processors {
+			if kind, origin, ok := unhandledKind(received, logsOnlyProcessors[processor.Type], metricsOnlyProcessors[processor.Type]); ok {
+				diags.AddAttributeError(processor.Path, "Incompatible pipeline component",
+					fmt.Sprintf("The %s only handles %s, but its processor group %q receives %s from %q.", describe(processor), otherKind(kind), group.ID, kind, origin))
+			}
+		}
+	}
+	for _, destination := range v.Destinations {
+		metricsOnly := metricsOnlyDestinations[destination.Type]
+		logsOnly := destination.Type != "" && !metricsOnly && !bothKindsDestinations[destination.Type]
+		for _, e := range v.edges[destination] {
+			if kind, origin, ok := unhandledKind(v.edgeKinds(e, kindsOf), logsOnly, metricsOnly); ok {
+				diags.AddAttributeError(e.path, "Incompatible pipeline component",
+					fmt.Sprintf("The %s only handles %s, but receives %s from %q.", describe(destination), otherKind(kind), kind, origin))
+			}
+		}
+	}
+}
+
+func (v *topologyValidation) edgeKinds(e edge, kindsOf func(*Component) map[string]string) map[string]string {
+	if e.metrics {
+		return map[string]string{"metrics": e.from.ID}
+	}
+	return kindsOf(e.from)
+}
+
+// unhandledKind returns a kind of data received by a component handling only
+// logs or only metrics which it does not handle, with its origin.
+func unhandledKind(received map[string]string, logsOnly, metricsOnly bool) (string, string, bool) {
+	var unhandled []string
+	for kind := range received {
+		if (logsOnly && kind != "logs") || (metricsOnly && kind != "metrics") {
+			unhandled = append(unhandled, kind)
+		}
+	}
+	if len(unhandled) == 0 {
+		return "", "", false
+	}
+	sort.Strings(unhandled)
+	return unhandled[0], received[unhandled[0]], true
+}
+
+func otherKind(kind string) string {
+	if kind == "logs" {
+		return "metrics"
+	}
+	return "logs"
+}
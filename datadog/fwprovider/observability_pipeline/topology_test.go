@@ -0,0 +1,135 @@
+package observability_pipeline
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/stretchr/testify/assert"
+)
+
+func testComponent(kind, block string, index int, id, componentType string, inputs ...string) *Component {
+	return &Component{Kind: kind, ID: id, Type: componentType, Path: path.Root("config").AtListIndex(0).AtName(block).AtListIndex(index), Inputs: inputs}
+}
+
+func testTopology(pipelineType string) *Topology {
+	group := testComponent(ProcessorGroupComponent, "processor_group", 0, "group", "", "agent")
+	group.Processors = []*Component{
+		{Kind: ProcessorComponent, ID: "filter", Type: "filter", Path: group.Path.AtName("processor").AtListIndex(0)},
+	}
+	return &Topology{
+		PipelineType:    pipelineType,
+		Sources:         []*Component{testComponent(SourceComponent, "source", 0, "agent", "datadog_agent")},
+		ProcessorGroups: []*Component{group},
+		Destinations:    []*Component{testComponent(DestinationComponent, "destination", 0, "logs", "datadog_logs", "group")},
+	}
+}
+
+func diagSummaries(diags diag.Diagnostics) map[string]string {
+	result := map[string]string{}
+	for _, d := range diags {
+		result[d.(diag.DiagnosticWithPath).Path().String()] = d.Summary()
+	}
+	return result
+}
+
+func TestTopologyValidate(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		assert.Empty(t, testTopology("logs").Validate())
+	})
+
+	t.Run("duplicate IDs", func(t *testing.T) {
+		topology := testTopology("logs")
+		topology.ProcessorGroups[0].Processors[0].ID = "agent"
+		assert.Equal(t, map[string]string{
+			"config[0].processor_group[0].processor[0].id": "Duplicate pipeline component ID",
+		}, diagSummaries(topology.Validate()))
+	})
+
+	t.Run("dangling inputs", func(t *testing.T) {
+		topology := testTopology("logs")
+		topology.ProcessorGroups[0].Inputs = []string{"agent", "filter", "unknown", "logs", "filter.metrics"}
+		diags := topology.Validate()
+		assert.Equal(t, map[string]string{
+			"config[0].processor_group[0].inputs[1]": "Invalid pipeline component input",
+			"config[0].processor_group[0].inputs[2]": "Invalid pipeline component input",
+			"config[0].processor_group[0].inputs[3]": "Invalid pipeline component input",
+			"config[0].processor_group[0].inputs[4]": "Invalid pipeline component input",
+		}, diagSummaries(diags))
+		assert.Contains(t, diags[0].Detail(), `reference the processor group instead`)
+	})
+
+	t.Run("missing inputs", func(t *testing.T) {
+		topology := testTopology("logs")
+		topology.Destinations[0].Inputs = nil
+		assert.Equal(t, map[string]string{
+			"config[0].destination[0].inputs": "Missing pipeline component inputs",
+			"config[0].source[0]":             "Unused pipeline component",
+			"config[0].processor_group[0]":    "Unused pipeline component",
+		}, diagSummaries(topology.Validate()))
+	})
+
+	t.Run("cycle", func(t *testing.T) {
+		topology := testTopology("logs")
+		topology.ProcessorGroups[0].Inputs = []string{"agent", "other"}
+		topology.ProcessorGroups = append(topology.ProcessorGroups, testComponent(ProcessorGroupComponent, "processor_group", 1, "other", "", "group"))
+		diags := topology.Validate()
+		assert.Equal(t, map[string]string{
+			"config[0].processor_group[1].inputs[0]": "Pipeline component cycle",
+		}, diagSummaries(diags))
+		assert.Contains(t, diags[0].Detail(), "other ← group ← other")
+	})
+
+	t.Run("unreachable and unused", func(t *testing.T) {
+		topology := testTopology("logs")
+		topology.Sources = append(topology.Sources, testComponent(SourceComponent, "source", 1, "unused", "fluentd"))
+		topology.ProcessorGroups = append(topology.ProcessorGroups,
+			testComponent(ProcessorGroupComponent, "processor_group", 1, "a", "", "b"),
+			testComponent(ProcessorGroupComponent, "processor_group", 2, "b", "", "a"),
+		)
+		topology.ProcessorGroups[1].Inputs = []string{"group"}
+		topology.Destinations[0].Inputs = []string{"group", "b"}
+		topology.ProcessorGroups[2].Inputs = []string{"a"}
+		assert.Equal(t, map[string]string{
+			"config[0].source[1]": "Unused pipeline component",
+		}, diagSummaries(topology.Validate()))
+
+		topology.ProcessorGroups[1].Inputs = []string{"c"}
+		topology.ProcessorGroups = append(topology.ProcessorGroups, testComponent(ProcessorGroupComponent, "processor_group", 3, "c", "", "b"))
+		topology.ProcessorGroups[2].Inputs = []string{"c"}
+		diags := topology.Validate()
+		assert.Equal(t, "Pipeline component cycle", diags[0].Summary())
+	})
+
+	t.Run("metrics pipeline", func(t *testing.T) {
+		topology := testTopology("metrics")
+		topology.ProcessorGroups[0].Processors = append(topology.ProcessorGroups[0].Processors,
+			&Component{Kind: ProcessorComponent, ID: "tags", Type: "add_metric_tags", Path: topology.ProcessorGroups[0].Path.AtName("processor").AtListIndex(1)},
+			&Component{Kind: ProcessorComponent, ID: "parse", Type: "parse_json", Path: topology.ProcessorGroups[0].Path.AtName("processor").AtListIndex(2)},
+		)
+		topology.Destinations = append(topology.Destinations,
+			testComponent(DestinationComponent, "destination", 1, "metrics", "datadog_metrics", "group"),
+			testComponent(DestinationComponent, "destination", 2, "http", "http_client", "group"),
+		)
+		diags := topology.Validate()
+		assert.Equal(t, map[string]string{
+			"config[0].processor_group[0].processor[2]": "Incompatible pipeline component",
+			"config[0].destination[0].inputs[0]":        "Incompatible pipeline component",
+		}, diagSummaries(diags))
+		assert.Contains(t, diags[0].Detail(), `The processor "parse" (`+"`parse_json`"+`) only handles logs, but its processor group "group" receives metrics from "agent".`)
+	})
+
+	t.Run("generated metrics", func(t *testing.T) {
+		topology := testTopology("logs")
+		topology.ProcessorGroups[0].Processors = append(topology.ProcessorGroups[0].Processors,
+			&Component{Kind: ProcessorComponent, ID: "generate", Type: "generate_metrics", Path: topology.ProcessorGroups[0].Path.AtName("processor").AtListIndex(1)},
+		)
+		topology.Destinations = append(topology.Destinations,
+			testComponent(DestinationComponent, "destination", 1, "metrics", "datadog_metrics", "generate.metrics"),
+			testComponent(DestinationComponent, "destination", 2, "wrong", "datadog_metrics", "group"),
+		)
+		assert.Equal(t, map[string]string{
+			"config[0].destination[2].inputs[0]": "Incompatible pipeline component",
+		}, diagSummaries(topology.Validate()))
+	})
+}
@@ -19,6 +19,7 @@ import (
 var (
 	_ resource.ResourceWithConfigure   = &apiKeyResource{}
 	_ resource.ResourceWithImportState = &apiKeyResource{}
+	_ resource.ResourceWithModifyPlan  = &apiKeyResource{}
 )
 
 func NewAPIKeyResource() resource.Resource {
@@ -30,6 +31,7 @@ type apiKeyResourceModel struct {
 	Name         types.String `tfsdk:"name"`
 	Key          types.String `tfsdk:"key"`
 	RemoteConfig types.Bool   `tfsdk:"remote_config_read_enabled"`
+	keyRotationModel
 }
 
 type apiKeyResource struct {
@@ -70,6 +72,11 @@ func (r *apiKeyResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 			"id": utils.ResourceIDAttribute(),
 		},
 	}
+	addKeyRotationAttributes(response.Schema.Attributes)
+}
+
+func (r *apiKeyResource) ModifyPlan(ctx context.Context, request resource.ModifyPlanRequest, response *resource.ModifyPlanResponse) {
+	modifyKeyRotationPlan(ctx, request, response, frameworkPath.Root("id"), frameworkPath.Root("key"))
 }
 
 func (r *apiKeyResource) Create(ctx context.Context, request resource.CreateRequest, response *resource.CreateResponse) {
@@ -91,6 +98,7 @@ func (r *apiKeyResource) Create(ctx context.Context, request resource.CreateRequ
 	if updateStateDiag != nil {
 		response.Diagnostics.Append(updateStateDiag)
 	}
+	response.Diagnostics.Append(state.keyRotated(false, "", "", r.deleteKey)...)
 	// Save data into Terraform state
 	response.Diagnostics.Append(response.State.Set(ctx, &state)...)
 }
@@ -124,25 +132,44 @@ func (r *apiKeyResource) Read(ctx context.Context, request resource.ReadRequest,
 }
 
 func (r *apiKeyResource) Update(ctx context.Context, request resource.UpdateRequest, response *resource.UpdateResponse) {
-	var state apiKeyResourceModel
+	var state, prior apiKeyResourceModel
 	response.Diagnostics.Append(request.Plan.Get(ctx, &state)...)
+	response.Diagnostics.Append(request.State.Get(ctx, &prior)...)
 	if response.Diagnostics.HasError() {
 		return
 	}
 
-	resp, _, err := r.Api.UpdateAPIKey(r.Auth, state.ID.ValueString(), *r.buildDatadogApiKeyUpdateV2Struct(&state))
-	if err != nil {
-		response.Diagnostics.Append(utils.FrameworkErrorDiag(err, "error updating api key"))
+	// The key is unknown when a rotation is planned
+	rotated := state.Key.IsUnknown()
+	response.Diagnostics.Append(state.deletePreviousKey(prior.keyRotationModel, rotated, r.deleteKey)...)
+	if response.Diagnostics.HasError() {
 		return
 	}
 
-	apiKeyData := resp.GetData()
+	var apiKeyData datadogV2.FullAPIKey
+	if rotated {
+		resp, _, err := r.Api.CreateAPIKey(r.Auth, *r.buildDatadogApiKeyCreateV2Struct(&state))
+		if err != nil {
+			response.Diagnostics.Append(utils.FrameworkErrorDiag(err, "error rotating api key"))
+			return
+		}
+		apiKeyData = resp.GetData()
+	} else {
+		resp, _, err := r.Api.UpdateAPIKey(r.Auth, state.ID.ValueString(), *r.buildDatadogApiKeyUpdateV2Struct(&state))
+		if err != nil {
+			response.Diagnostics.Append(utils.FrameworkErrorDiag(err, "error updating api key"))
+			return
+		}
+		apiKeyData = resp.GetData()
+	}
+
 	state.ID = types.StringValue(apiKeyData.GetId())
 	updateStateDiag := r.updateState(&state, &apiKeyData)
 	if updateStateDiag != nil {
 		response.Diagnostics.Append(updateStateDiag)
 		return
 	}
+	response.Diagnostics.Append(state.keyRotated(rotated, prior.ID.ValueString(), prior.Key.ValueString(), r.deleteKey)...)
 	// Save data into Terraform state
 	response.Diagnostics.Append(response.State.Set(ctx, &state)...)
 }
@@ -155,9 +182,24 @@ func (r *apiKeyResource) Delete(ctx context.Context, request resource.DeleteRequ
 	}
 	if _, err := r.Api.DeleteAPIKey(r.Auth, state.ID.ValueString()); err != nil {
 		response.Diagnostics.Append(utils.FrameworkErrorDiag(err, "error deleting api key"))
+		return
+	}
+	if !state.PreviousKeyId.IsNull() {
+		if err := r.deleteKey(state.PreviousKeyId.ValueString()); err != nil {
+			response.Diagnostics.Append(utils.FrameworkErrorDiag(err, "error deleting previous api key"))
+		}
 	}
 }
 
+// deleteKey deletes a key replaced by a rotation, which may already be gone.
+func (r *apiKeyResource) deleteKey(id string) error {
+	httpResp, err := r.Api.DeleteAPIKey(r.Auth, id)
+	if err != nil && (httpResp == nil || httpResp.StatusCode != 404) {
+		return utils.TranslateClientError(err, httpResp, "error deleting api key")
+	}
+	return nil
+}
+
 func (r *apiKeyResource) ImportState(ctx context.Context, request resource.ImportStateRequest, response *resource.ImportStateResponse) {
 	response.Diagnostics.AddWarning(
 		"Deprecated",
@@ -197,5 +239,8 @@ func (r *apiKeyResource) updateState(state *apiKeyResourceModel, apiKeyData *dat
 	if apiKeyAttributes.HasKey() {
 		state.Key = types.StringValue(apiKeyAttributes.GetKey())
 	}
+	if createdAt, ok := apiKeyAttributes.GetCreatedAtOk(); ok {
+		state.setRotatedAt(*createdAt)
+	}
 	return d
 }
@@ -97,6 +97,7 @@ func (r *ApmRetentionFilterResource) Schema(_ context.Context, _ resource.Schema
 						Computed:    true,
 						Description: "The search query - follow the span search syntax, use `AND` between tags and `\\` to escape special characters, use nanosecond for duration.",
 						Default:     stringdefault.StaticString("*"),
+						Validators:  []validator.String{validators.SearchQueryValidator()},
 					},
 				},
 				Validators: []validator.Object{objectvalidator.IsRequired()},
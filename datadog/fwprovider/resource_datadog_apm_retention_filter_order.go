@@ -10,12 +10,14 @@ import (
 	frameworkPath "github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 var (
-	_ resource.ResourceWithConfigure   = &ApmRetentionFiltersOrderResource{}
-	_ resource.ResourceWithImportState = &ApmRetentionFiltersOrderResource{}
+	_ resource.ResourceWithConfigure      = &ApmRetentionFiltersOrderResource{}
+	_ resource.ResourceWithImportState    = &ApmRetentionFiltersOrderResource{}
+	_ resource.ResourceWithValidateConfig = &ApmRetentionFiltersOrderResource{}
 )
 
 type ApmRetentionFiltersOrderResource struct {
@@ -25,6 +27,8 @@ type ApmRetentionFiltersOrderResource struct {
 type ApmRetentionFiltersOrderModel struct {
 	ID        types.String   `tfsdk:"id"`
 	FilterIds []types.String `tfsdk:"filter_ids"`
+	Partial   types.Bool     `tfsdk:"partial"`
+	After     types.String   `tfsdk:"after"`
 }
 
 func NewApmRetentionFiltersOrderResource() resource.Resource {
@@ -48,10 +52,24 @@ func (d *ApmRetentionFiltersOrderResource) Schema(_ context.Context, _ resource.
 				ElementType: types.StringType,
 				Required:    true,
 			},
+			"partial": schema.BoolAttribute{
+				Description: utils.PartialOrderDescription("filter_ids", "retention filters"),
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"after": schema.StringAttribute{
+				Description: utils.PartialOrderAfterDescription("ID of the retention filter", "retention filters"),
+				Optional:    true,
+			},
 			"id": utils.ResourceIDAttribute(),
 		}}
 }
 
+func (r *ApmRetentionFiltersOrderResource) ValidateConfig(ctx context.Context, request resource.ValidateConfigRequest, response *resource.ValidateConfigResponse) {
+	validatePartialOrderConfig(ctx, request.Config, &response.Diagnostics)
+}
+
 func (r *ApmRetentionFiltersOrderResource) ImportState(ctx context.Context, request resource.ImportStateRequest, response *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, frameworkPath.Root("id"), request, response)
 }
@@ -162,23 +180,46 @@ func GetApmFilterIds(apmRetentionFiltersOrder datadogV2.RetentionFiltersResponse
 }
 
 func (r *ApmRetentionFiltersOrderResource) updateState(ctx context.Context, state *ApmRetentionFiltersOrderModel, resp *datadogV2.RetentionFiltersResponse) {
-	filterIds := GetApmFilterIds(*resp)
+	filterIds := make([]string, len(resp.Data))
+	for i, rf := range resp.Data {
+		filterIds[i] = rf.Id
+	}
 	state.ID = types.StringValue("filtersOrderID")
-	state.FilterIds = filterIds
+	state.FilterIds, state.After = partialOrderState(state.Partial, state.After, state.FilterIds, filterIds)
+	if state.Partial.IsNull() {
+		state.Partial = types.BoolValue(false)
+	}
 }
 
 func (r *ApmRetentionFiltersOrderResource) buildRetentionFiltersOrderRequestBody(ctx context.Context, state *ApmRetentionFiltersOrderModel) (*datadogV2.ReorderRetentionFiltersRequest, diag.Diagnostics) {
 	diags := diag.Diagnostics{}
+	ids := stringValues(state.FilterIds)
+	if state.Partial.ValueBool() {
+		listData, _, err := r.Api.ListApmRetentionFilters(r.Auth)
+		if err != nil {
+			diags.Append(utils.FrameworkErrorDiag(err, "error retrieving retention filters order"))
+			return nil, diags
+		}
+		current := make([]string, len(listData.Data))
+		for i, rf := range listData.Data {
+			current[i] = rf.Id
+		}
+		ids, err = utils.MergePartialOrder(current, ids, state.After.ValueString())
+		if err != nil {
+			diags.AddAttributeError(frameworkPath.Root("filter_ids"), "error merging retention filters order", err.Error())
+			return nil, diags
+		}
+	}
 	filtersOrderReq := datadogV2.NewReorderRetentionFiltersRequestWithDefaults()
-	filtersOrderReq.SetData(getFilterIdList(state))
+	filtersOrderReq.SetData(getFilterIdList(ids))
 	return filtersOrderReq, diags
 }
 
-func getFilterIdList(state *ApmRetentionFiltersOrderModel) []datadogV2.RetentionFilterWithoutAttributes {
-	rfList := make([]datadogV2.RetentionFilterWithoutAttributes, len(state.FilterIds))
-	for i, id := range state.FilterIds {
+func getFilterIdList(ids []string) []datadogV2.RetentionFilterWithoutAttributes {
+	rfList := make([]datadogV2.RetentionFilterWithoutAttributes, len(ids))
+	for i, id := range ids {
 		rfList[i] = datadogV2.RetentionFilterWithoutAttributes{
-			Id:   id.ValueString(),
+			Id:   id,
 			Type: "apm_retention_filter",
 		}
 	}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV2"
 	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -16,7 +17,8 @@ import (
 )
 
 var (
-	_ resource.ResourceWithConfigure = &applicationKeyResource{}
+	_ resource.ResourceWithConfigure  = &applicationKeyResource{}
+	_ resource.ResourceWithModifyPlan = &applicationKeyResource{}
 )
 
 func NewApplicationKeyResource() resource.Resource {
@@ -28,6 +30,7 @@ type applicationKeyResourceModel struct {
 	Name   types.String `tfsdk:"name"`
 	Key    types.String `tfsdk:"key"`
 	Scopes types.Set    `tfsdk:"scopes"`
+	keyRotationModel
 }
 
 type applicationKeyResource struct {
@@ -62,6 +65,11 @@ func (r *applicationKeyResource) Schema(_ context.Context, _ resource.SchemaRequ
 			"id": utils.ResourceIDAttribute(),
 		},
 	}
+	addKeyRotationAttributes(response.Schema.Attributes)
+}
+
+func (r *applicationKeyResource) ModifyPlan(ctx context.Context, request resource.ModifyPlanRequest, response *resource.ModifyPlanResponse) {
+	modifyKeyRotationPlan(ctx, request, response, path.Root("id"), path.Root("key"))
 }
 
 func (r *applicationKeyResource) Configure(_ context.Context, request resource.ConfigureRequest, response *resource.ConfigureResponse) {
@@ -89,6 +97,7 @@ func (r *applicationKeyResource) Create(ctx context.Context, request resource.Cr
 	applicationKeyData := resp.GetData()
 	state.ID = types.StringValue(applicationKeyData.GetId())
 	r.updateState(ctx, &state, &applicationKeyData)
+	response.Diagnostics.Append(state.keyRotated(false, "", "", r.deleteKey)...)
 
 	response.Diagnostics.Append(response.State.Set(ctx, &state)...)
 
@@ -118,22 +127,41 @@ func (r *applicationKeyResource) Read(ctx context.Context, request resource.Read
 }
 
 func (r *applicationKeyResource) Update(ctx context.Context, request resource.UpdateRequest, response *resource.UpdateResponse) {
-	var state applicationKeyResourceModel
+	var state, prior applicationKeyResourceModel
 	response.Diagnostics.Append(request.Plan.Get(ctx, &state)...)
+	response.Diagnostics.Append(request.State.Get(ctx, &prior)...)
 	if response.Diagnostics.HasError() {
 		return
 	}
 
-	resp, _, err := r.Api.UpdateCurrentUserApplicationKey(r.Auth, state.ID.ValueString(), *r.buildDatadogApplicationKeyUpdateV2Struct(&state))
-
-	if err != nil {
-		response.Diagnostics.Append(utils.FrameworkErrorDiag(err, "error updating application key"))
+	// The key is unknown when a rotation is planned
+	rotated := state.Key.IsUnknown()
+	response.Diagnostics.Append(state.deletePreviousKey(prior.keyRotationModel, rotated, r.deleteKey)...)
+	if response.Diagnostics.HasError() {
 		return
 	}
 
-	applicationKeyData := resp.GetData()
+	var applicationKeyData datadogV2.FullApplicationKey
+	if rotated {
+		resp, _, err := r.Api.CreateCurrentUserApplicationKey(r.Auth, *r.buildDatadogApplicationKeyCreateV2Struct(&state))
+		if err != nil {
+			response.Diagnostics.Append(utils.FrameworkErrorDiag(err, "error rotating application key"))
+			return
+		}
+		applicationKeyData = resp.GetData()
+	} else {
+		resp, _, err := r.Api.UpdateCurrentUserApplicationKey(r.Auth, state.ID.ValueString(), *r.buildDatadogApplicationKeyUpdateV2Struct(&state))
+
+		if err != nil {
+			response.Diagnostics.Append(utils.FrameworkErrorDiag(err, "error updating application key"))
+			return
+		}
+		applicationKeyData = resp.GetData()
+	}
+
 	state.ID = types.StringValue(applicationKeyData.GetId())
 	r.updateState(ctx, &state, &applicationKeyData)
+	response.Diagnostics.Append(state.keyRotated(rotated, prior.ID.ValueString(), prior.Key.ValueString(), r.deleteKey)...)
 
 	response.Diagnostics.Append(response.State.Set(ctx, &state)...)
 }
@@ -147,9 +175,24 @@ func (r *applicationKeyResource) Delete(ctx context.Context, request resource.De
 
 	if _, err := r.Api.DeleteCurrentUserApplicationKey(r.Auth, state.ID.ValueString()); err != nil {
 		response.Diagnostics.Append(utils.FrameworkErrorDiag(err, "error deleting application key"))
+		return
+	}
+	if !state.PreviousKeyId.IsNull() {
+		if err := r.deleteKey(state.PreviousKeyId.ValueString()); err != nil {
+			response.Diagnostics.Append(utils.FrameworkErrorDiag(err, "error deleting previous application key"))
+		}
 	}
 }
 
+// deleteKey deletes a key replaced by a rotation, which may already be gone.
+func (r *applicationKeyResource) deleteKey(id string) error {
+	httpResp, err := r.Api.DeleteCurrentUserApplicationKey(r.Auth, id)
+	if err != nil && (httpResp == nil || httpResp.StatusCode != 404) {
+		return utils.TranslateClientError(err, httpResp, "error deleting application key")
+	}
+	return nil
+}
+
 func (r *applicationKeyResource) buildDatadogApplicationKeyCreateV2Struct(state *applicationKeyResourceModel) *datadogV2.ApplicationKeyCreateRequest {
 	applicationKeyAttributes := datadogV2.NewApplicationKeyCreateAttributes(state.Name.ValueString())
 	applicationKeyAttributes.SetScopes(getScopesFromStateAttribute(state.Scopes))
@@ -178,6 +221,9 @@ func (r *applicationKeyResource) updateState(ctx context.Context, state *applica
 	if applicationKeyAttributes.HasScopes() {
 		state.Scopes, _ = types.SetValueFrom(ctx, types.StringType, applicationKeyAttributes.GetScopes())
 	}
+	if createdAt, ok := applicationKeyAttributes.GetCreatedAtOk(); ok {
+		state.setRotatedAt(*createdAt)
+	}
 }
 
 func getScopesFromStateAttribute(scopes types.Set) []string {
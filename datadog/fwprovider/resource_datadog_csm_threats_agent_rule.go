@@ -13,12 +13,14 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"net/http"
 
 	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/customtypes"
 	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/utils"
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/validators"
 )
 
 var (
@@ -108,8 +110,9 @@ func (r *csmThreatsAgentRuleResource) Schema(_ context.Context, _ resource.Schem
 			},
 			"expression": schema.StringAttribute{
 				Required:    true,
-				Description: "The SECL expression of the Agent rule",
+				Description: "The SECL expression of the Agent rule. Syntax errors fail the plan, fields and values which do not match the Agent's event model are reported as warnings.",
 				CustomType:  customtypes.TrimSpaceStringType{},
+				Validators:  []validator.String{validators.SECLExpressionValidator()},
 			},
 			"product_tags": schema.SetAttribute{
 				Optional:    true,
@@ -0,0 +1,313 @@
+package fwprovider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV1"
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	frameworkPath "github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/utils"
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/validators"
+)
+
+var (
+	_ resource.ResourceWithConfigure   = &logsIndexExclusionFilterResource{}
+	_ resource.ResourceWithImportState = &logsIndexExclusionFilterResource{}
+)
+
+type logsIndexExclusionFilterResource struct {
+	Api  *datadogV1.LogsIndexesApi
+	Auth context.Context
+}
+
+type logsIndexExclusionFilterModel struct {
+	ID              types.String  `tfsdk:"id"`
+	Index           types.String  `tfsdk:"index"`
+	Name            types.String  `tfsdk:"name"`
+	IsEnabled       types.Bool    `tfsdk:"is_enabled"`
+	Query           types.String  `tfsdk:"query"`
+	SampleRate      types.Float64 `tfsdk:"sample_rate"`
+	SampleAttribute types.String  `tfsdk:"sample_attribute"`
+}
+
+func NewLogsIndexExclusionFilterResource() resource.Resource {
+	return &logsIndexExclusionFilterResource{}
+}
+
+func (r *logsIndexExclusionFilterResource) Configure(_ context.Context, request resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	providerData, _ := request.ProviderData.(*FrameworkProvider)
+	r.Api = providerData.DatadogApiInstances.GetLogsIndexesApiV1()
+	r.Auth = providerData.Auth
+}
+
+func (r *logsIndexExclusionFilterResource) Metadata(_ context.Context, _ resource.MetadataRequest, response *resource.MetadataResponse) {
+	response.TypeName = "logs_index_exclusion_filter"
+}
+
+func (r *logsIndexExclusionFilterResource) Schema(_ context.Context, _ resource.SchemaRequest, response *resource.SchemaResponse) {
+	response.Schema = schema.Schema{
+		Description: "Provides a Datadog resource to manage a single exclusion filter of a logs index, independently of the `datadog_logs_index` resource. The other exclusion filters of the index are preserved. When the index is also managed by Terraform, set `ignore_unmanaged_exclusion_filters` to `true` on the `datadog_logs_index` resource so that it does not remove this filter.",
+		Attributes: map[string]schema.Attribute{
+			"id": utils.ResourceIDAttribute(),
+			"index": schema.StringAttribute{
+				Description: "The name of the logs index the exclusion filter belongs to.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "The name of the exclusion filter, unique within the index.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"is_enabled": schema.BoolAttribute{
+				Description: "Whether the exclusion filter is active.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"query": schema.StringAttribute{
+				Description: "Only logs matching the filter criteria and the query of the parent index are considered for this exclusion filter.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("*"),
+				Validators:  []validator.String{validators.SearchQueryValidator()},
+			},
+			"sample_rate": schema.Float64Attribute{
+				Description: "The fraction of logs excluded by the exclusion filter, when active, between `0` and `1`.",
+				Required:    true,
+				Validators:  []validator.Float64{float64validator.Between(0, 1)},
+			},
+			"sample_attribute": schema.StringAttribute{
+				Description: "The log attribute used as the sampling key. When present, logs sharing the same value are excluded or kept together at the configured sample rate (a single attribute path, e.g. `@lambda.request_id`).",
+				Optional:    true,
+				Validators:  []validator.String{stringvalidator.LengthAtLeast(1)},
+			},
+		},
+	}
+}
+
+func (r *logsIndexExclusionFilterResource) ImportState(ctx context.Context, request resource.ImportStateRequest, response *resource.ImportStateResponse) {
+	index, name, ok := strings.Cut(request.ID, ":")
+	if !ok || index == "" || name == "" {
+		response.Diagnostics.AddError("invalid import ID", fmt.Sprintf("expected `<index>:<exclusion filter name>`, got %q", request.ID))
+		return
+	}
+	response.Diagnostics.Append(response.State.SetAttribute(ctx, frameworkPath.Root("id"), request.ID)...)
+	response.Diagnostics.Append(response.State.SetAttribute(ctx, frameworkPath.Root("index"), index)...)
+	response.Diagnostics.Append(response.State.SetAttribute(ctx, frameworkPath.Root("name"), name)...)
+}
+
+func (r *logsIndexExclusionFilterResource) Read(ctx context.Context, request resource.ReadRequest, response *resource.ReadResponse) {
+	var state logsIndexExclusionFilterModel
+	response.Diagnostics.Append(request.State.Get(ctx, &state)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	index, httpResp, err := r.Api.GetLogsIndex(r.Auth, state.Index.ValueString())
+	if err != nil {
+		if httpResp != nil && httpResp.StatusCode == http.StatusNotFound {
+			response.State.RemoveResource(ctx)
+			return
+		}
+		response.Diagnostics.Append(utils.FrameworkErrorDiag(err, "error getting logs index"))
+		return
+	}
+	if err := utils.CheckForUnparsed(index); err != nil {
+		response.Diagnostics.AddError("response contains unparsedObject", err.Error())
+		return
+	}
+
+	i := findLogsExclusionFilter(index.GetExclusionFilters(), state.Name.ValueString())
+	if i < 0 {
+		response.State.RemoveResource(ctx)
+		return
+	}
+	r.updateState(&state, index.GetExclusionFilters()[i])
+	response.Diagnostics.Append(response.State.Set(ctx, &state)...)
+}
+
+func (r *logsIndexExclusionFilterResource) Create(ctx context.Context, request resource.CreateRequest, response *resource.CreateResponse) {
+	var state logsIndexExclusionFilterModel
+	response.Diagnostics.Append(request.Plan.Get(ctx, &state)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	r.updateIndex(&state, &response.Diagnostics, func(filters []datadogV1.LogsExclusion) ([]datadogV1.LogsExclusion, error) {
+		if findLogsExclusionFilter(filters, state.Name.ValueString()) >= 0 {
+			return nil, fmt.Errorf("exclusion filter %q already exists on index %q, import it instead", state.Name.ValueString(), state.Index.ValueString())
+		}
+		return append(filters, r.buildExclusionFilter(&state)), nil
+	})
+	if response.Diagnostics.HasError() {
+		return
+	}
+	response.Diagnostics.Append(response.State.Set(ctx, &state)...)
+}
+
+func (r *logsIndexExclusionFilterResource) Update(ctx context.Context, request resource.UpdateRequest, response *resource.UpdateResponse) {
+	var state logsIndexExclusionFilterModel
+	response.Diagnostics.Append(request.Plan.Get(ctx, &state)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	r.updateIndex(&state, &response.Diagnostics, func(filters []datadogV1.LogsExclusion) ([]datadogV1.LogsExclusion, error) {
+		i := findLogsExclusionFilter(filters, state.Name.ValueString())
+		if i < 0 {
+			return nil, fmt.Errorf("exclusion filter %q not found on index %q", state.Name.ValueString(), state.Index.ValueString())
+		}
+		filters[i] = r.buildExclusionFilter(&state)
+		return filters, nil
+	})
+	if response.Diagnostics.HasError() {
+		return
+	}
+	response.Diagnostics.Append(response.State.Set(ctx, &state)...)
+}
+
+func (r *logsIndexExclusionFilterResource) Delete(ctx context.Context, request resource.DeleteRequest, response *resource.DeleteResponse) {
+	var state logsIndexExclusionFilterModel
+	response.Diagnostics.Append(request.State.Get(ctx, &state)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	r.updateIndex(&state, &response.Diagnostics, func(filters []datadogV1.LogsExclusion) ([]datadogV1.LogsExclusion, error) {
+		i := findLogsExclusionFilter(filters, state.Name.ValueString())
+		if i < 0 {
+			return nil, nil
+		}
+		return append(filters[:i], filters[i+1:]...), nil
+	})
+}
+
+// updateIndex replaces the exclusion filters of the index with the result of
+// update, which returns nil when there is nothing to update, and refreshes the
+// state from the updated index. It fails when a filter sent in the update is
+// missing from the updated index.
+func (r *logsIndexExclusionFilterResource) updateIndex(state *logsIndexExclusionFilterModel, diags *diag.Diagnostics, update func([]datadogV1.LogsExclusion) ([]datadogV1.LogsExclusion, error)) {
+	utils.LogsIndexMutex.Lock()
+	defer utils.LogsIndexMutex.Unlock()
+
+	index, httpResp, err := r.Api.GetLogsIndex(r.Auth, state.Index.ValueString())
+	if err != nil {
+		if httpResp != nil && httpResp.StatusCode == http.StatusNotFound && state.ID.ValueString() != "" {
+			// The index was deleted along with its exclusion filters.
+			return
+		}
+		diags.Append(utils.FrameworkErrorDiag(err, "error getting logs index"))
+		return
+	}
+	if err := utils.CheckForUnparsed(index); err != nil {
+		diags.AddError("response contains unparsedObject", err.Error())
+		return
+	}
+
+	filters, err := update(index.GetExclusionFilters())
+	if err != nil {
+		diags.AddError("error updating exclusion filters", err.Error())
+		return
+	}
+	if filters == nil {
+		return
+	}
+
+	updatedIndex, _, err := r.Api.UpdateLogsIndex(r.Auth, index.GetName(), *buildLogsIndexUpdateRequest(index, filters))
+	if err != nil {
+		diags.Append(utils.FrameworkErrorDiag(err, "error updating logs index"))
+		return
+	}
+	if err := utils.CheckForUnparsed(updatedIndex); err != nil {
+		diags.AddError("response contains unparsedObject", err.Error())
+		return
+	}
+	name := state.Name.ValueString()
+	if i := findLogsExclusionFilter(updatedIndex.GetExclusionFilters(), name); i >= 0 {
+		r.updateState(state, updatedIndex.GetExclusionFilters()[i])
+	} else if findLogsExclusionFilter(filters, name) >= 0 {
+		diags.AddError("error updating logs index", fmt.Sprintf("exclusion filter %q is missing from the updated index %q, it may have been changed concurrently", name, index.GetName()))
+	}
+}
+
+func (r *logsIndexExclusionFilterResource) updateState(state *logsIndexExclusionFilterModel, filter datadogV1.LogsExclusion) {
+	state.ID = types.StringValue(fmt.Sprintf("%s:%s", state.Index.ValueString(), filter.GetName()))
+	state.Name = types.StringValue(filter.GetName())
+	state.IsEnabled = types.BoolValue(filter.GetIsEnabled())
+
+	ddFilter := filter.GetFilter()
+	state.Query = types.StringValue(ddFilter.GetQuery())
+	state.SampleRate = types.Float64Value(ddFilter.GetSampleRate())
+	if sampleAttribute, ok := ddFilter.GetSampleAttributeOk(); ok && *sampleAttribute != "" {
+		state.SampleAttribute = types.StringValue(*sampleAttribute)
+	} else {
+		state.SampleAttribute = types.StringNull()
+	}
+}
+
+func (r *logsIndexExclusionFilterResource) buildExclusionFilter(state *logsIndexExclusionFilterModel) datadogV1.LogsExclusion {
+	ddFilter := datadogV1.NewLogsExclusionFilter(state.SampleRate.ValueFloat64())
+	ddFilter.SetQuery(state.Query.ValueString())
+	if !state.SampleAttribute.IsNull() {
+		ddFilter.SetSampleAttribute(state.SampleAttribute.ValueString())
+	}
+
+	filter := datadogV1.NewLogsExclusion(state.Name.ValueString())
+	filter.SetIsEnabled(state.IsEnabled.ValueBool())
+	filter.SetFilter(*ddFilter)
+	return *filter
+}
+
+func findLogsExclusionFilter(filters []datadogV1.LogsExclusion, name string) int {
+	for i, filter := range filters {
+		if filter.GetName() == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// buildLogsIndexUpdateRequest builds a request keeping the current settings
+// of the index, with the given exclusion filters.
+func buildLogsIndexUpdateRequest(index datadogV1.LogsIndex, filters []datadogV1.LogsExclusion) *datadogV1.LogsIndexUpdateRequest {
+	request := datadogV1.NewLogsIndexUpdateRequest(index.GetFilter())
+	if dailyLimit, ok := index.GetDailyLimitOk(); ok {
+		request.SetDailyLimit(*dailyLimit)
+	} else {
+		request.SetDisableDailyLimit(true)
+	}
+	if dailyLimitReset, ok := index.GetDailyLimitResetOk(); ok {
+		request.SetDailyLimitReset(*dailyLimitReset)
+	}
+	if threshold, ok := index.GetDailyLimitWarningThresholdPercentageOk(); ok {
+		request.SetDailyLimitWarningThresholdPercentage(*threshold)
+	}
+	if retentionDays, ok := index.GetNumRetentionDaysOk(); ok {
+		request.SetNumRetentionDays(*retentionDays)
+	}
+	if flexRetentionDays, ok := index.GetNumFlexLogsRetentionDaysOk(); ok {
+		request.SetNumFlexLogsRetentionDays(*flexRetentionDays)
+	}
+	request.SetTags(index.GetTags())
+	request.SetExclusionFilters(filters)
+	return request
+}
@@ -0,0 +1,183 @@
+package fwprovider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/DataDog/datadog-api-client-go/v2/api/datadog"
+	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV1"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const exclusionFilterTestIndex = `{
+	"name": "main",
+	"filter": {"query": "service:web"},
+	"daily_limit": 1000,
+	"num_retention_days": 15,
+	"exclusion_filters": [
+		{"name": "a", "is_enabled": true, "filter": {"query": "env:dev", "sample_rate": 1}},
+		{"name": "b", "is_enabled": false, "filter": {"query": "env:staging", "sample_rate": 0.5}},
+		{"name": "c", "is_enabled": true, "filter": {"query": "status:debug", "sample_rate": 0.1}}
+	]
+}`
+
+func TestLogsIndexExclusionFilterUpdateIndex(t *testing.T) {
+	var updates []datadogV1.LogsIndexUpdateRequest
+	// dropped is removed from the updated index, as a concurrent change would.
+	var dropped string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method + " " + r.URL.Path {
+		case "GET /api/v1/logs/config/indexes/main":
+			fmt.Fprint(w, exclusionFilterTestIndex)
+		case "PUT /api/v1/logs/config/indexes/main":
+			var body datadogV1.LogsIndexUpdateRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			updates = append(updates, body)
+			index := datadogV1.NewLogsIndex(body.Filter, "main")
+			var filters []datadogV1.LogsExclusion
+			for _, filter := range body.GetExclusionFilters() {
+				if filter.GetName() != dropped {
+					filters = append(filters, filter)
+				}
+			}
+			index.SetExclusionFilters(filters)
+			require.NoError(t, json.NewEncoder(w).Encode(index))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"errors": ["Not found"]}`)
+		}
+	}))
+	defer server.Close()
+
+	config := datadog.NewConfiguration()
+	config.Servers = datadog.ServerConfigurations{{URL: server.URL}}
+	r := &logsIndexExclusionFilterResource{Api: datadogV1.NewLogsIndexesApi(datadog.NewAPIClient(config)), Auth: context.Background()}
+
+	newState := func(index, name string, sampleRate float64) *logsIndexExclusionFilterModel {
+		return &logsIndexExclusionFilterModel{
+			Index:           types.StringValue(index),
+			Name:            types.StringValue(name),
+			IsEnabled:       types.BoolValue(true),
+			Query:           types.StringValue("service:api"),
+			SampleRate:      types.Float64Value(sampleRate),
+			SampleAttribute: types.StringNull(),
+		}
+	}
+	filterNames := func(filters []datadogV1.LogsExclusion) []string {
+		names := make([]string, len(filters))
+		for i, filter := range filters {
+			names[i] = filter.GetName()
+		}
+		return names
+	}
+	// lastUpdate checks that the settings of the index are kept, and returns
+	// the exclusion filters of the last update.
+	lastUpdate := func(t *testing.T) []datadogV1.LogsExclusion {
+		require.NotEmpty(t, updates)
+		update := updates[len(updates)-1]
+		assert.Equal(t, "service:web", update.Filter.GetQuery())
+		assert.Equal(t, int64(1000), update.GetDailyLimit())
+		assert.Equal(t, int64(15), update.GetNumRetentionDays())
+		return update.GetExclusionFilters()
+	}
+
+	t.Run("create", func(t *testing.T) {
+		updates = nil
+		state := newState("main", "d", 0.2)
+		var diags diag.Diagnostics
+		r.updateIndex(state, &diags, func(filters []datadogV1.LogsExclusion) ([]datadogV1.LogsExclusion, error) {
+			return append(filters, r.buildExclusionFilter(state)), nil
+		})
+		require.False(t, diags.HasError(), diags)
+		filters := lastUpdate(t)
+		assert.Equal(t, []string{"a", "b", "c", "d"}, filterNames(filters))
+		assert.Equal(t, "env:staging", filters[1].Filter.GetQuery())
+		assert.Equal(t, 0.5, filters[1].Filter.GetSampleRate())
+		assert.Equal(t, "main:d", state.ID.ValueString())
+		assert.Equal(t, 0.2, state.SampleRate.ValueFloat64())
+	})
+
+	t.Run("create concurrently removed", func(t *testing.T) {
+		updates = nil
+		dropped = "d"
+		defer func() { dropped = "" }()
+		state := newState("main", "d", 0.2)
+		var diags diag.Diagnostics
+		r.updateIndex(state, &diags, func(filters []datadogV1.LogsExclusion) ([]datadogV1.LogsExclusion, error) {
+			return append(filters, r.buildExclusionFilter(state)), nil
+		})
+		require.True(t, diags.HasError())
+		assert.Contains(t, diags[0].Detail(), `exclusion filter "d" is missing from the updated index "main"`)
+		assert.True(t, state.ID.IsNull())
+	})
+
+	t.Run("update", func(t *testing.T) {
+		updates = nil
+		state := newState("main", "b", 0.3)
+		var diags diag.Diagnostics
+		r.updateIndex(state, &diags, func(filters []datadogV1.LogsExclusion) ([]datadogV1.LogsExclusion, error) {
+			filters[findLogsExclusionFilter(filters, "b")] = r.buildExclusionFilter(state)
+			return filters, nil
+		})
+		require.False(t, diags.HasError(), diags)
+		filters := lastUpdate(t)
+		assert.Equal(t, []string{"a", "b", "c"}, filterNames(filters))
+		assert.Equal(t, "service:api", filters[1].Filter.GetQuery())
+		assert.Equal(t, "status:debug", filters[2].Filter.GetQuery())
+		assert.True(t, state.IsEnabled.ValueBool())
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		updates = nil
+		state := newState("main", "b", 0.5)
+		state.ID = types.StringValue("main:b")
+		var diags diag.Diagnostics
+		r.updateIndex(state, &diags, func(filters []datadogV1.LogsExclusion) ([]datadogV1.LogsExclusion, error) {
+			i := findLogsExclusionFilter(filters, "b")
+			return append(filters[:i], filters[i+1:]...), nil
+		})
+		require.False(t, diags.HasError(), diags)
+		assert.Equal(t, []string{"a", "c"}, filterNames(lastUpdate(t)))
+	})
+
+	t.Run("nothing to update", func(t *testing.T) {
+		updates = nil
+		var diags diag.Diagnostics
+		r.updateIndex(newState("main", "e", 0.5), &diags, func([]datadogV1.LogsExclusion) ([]datadogV1.LogsExclusion, error) {
+			return nil, nil
+		})
+		assert.False(t, diags.HasError(), diags)
+		assert.Empty(t, updates)
+	})
+
+	t.Run("deleted index", func(t *testing.T) {
+		state := newState("other", "a", 0.5)
+		var diags diag.Diagnostics
+		update := func(filters []datadogV1.LogsExclusion) ([]datadogV1.LogsExclusion, error) { return filters, nil }
+		r.updateIndex(state, &diags, update)
+		assert.True(t, diags.HasError(), "creating a filter on a missing index fails")
+
+		diags = nil
+		state.ID = types.StringValue("other:a")
+		r.updateIndex(state, &diags, update)
+		assert.False(t, diags.HasError(), diags)
+	})
+}
+
+func TestBuildLogsIndexUpdateRequest(t *testing.T) {
+	var index datadogV1.LogsIndex
+	require.NoError(t, json.Unmarshal([]byte(`{"name": "main", "filter": {"query": "*"}, "num_flex_logs_retention_days": 90, "tags": ["team:a"]}`), &index))
+	request := buildLogsIndexUpdateRequest(index, []datadogV1.LogsExclusion{*datadogV1.NewLogsExclusion("a")})
+	assert.True(t, request.GetDisableDailyLimit())
+	assert.Equal(t, int64(90), request.GetNumFlexLogsRetentionDays())
+	assert.Equal(t, []string{"team:a"}, request.GetTags())
+	assert.Len(t, request.GetExclusionFilters(), 1)
+}
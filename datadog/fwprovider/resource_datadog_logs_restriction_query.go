@@ -57,6 +57,7 @@ func (r *LogsRestrictionQueryResource) Schema(_ context.Context, _ resource.Sche
 			"restriction_query": schema.StringAttribute{
 				Description: "The query that defines the restriction. Only the content matching the query can be returned.",
 				Required:    true,
+				Validators:  []validator.String{validators.SearchQueryValidator()},
 			},
 			"role_ids": schema.SetAttribute{
 				Description: "An array of role IDs that have access to this restriction query.",
@@ -3,9 +3,12 @@ package fwprovider
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"log"
 	"os"
+	"reflect"
 
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
 	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
@@ -19,11 +22,13 @@ import (
 
 	"github.com/terraform-providers/terraform-provider-datadog/datadog/fwprovider/observability_pipeline"
 	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/utils"
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/validators"
 )
 
 var (
-	_ resource.ResourceWithConfigure   = &observabilityPipelineResource{}
-	_ resource.ResourceWithImportState = &observabilityPipelineResource{}
+	_ resource.ResourceWithConfigure        = &observabilityPipelineResource{}
+	_ resource.ResourceWithImportState      = &observabilityPipelineResource{}
+	_ resource.ResourceWithConfigValidators = &observabilityPipelineResource{}
 )
 
 type observabilityPipelineResource struct {
@@ -172,7 +177,8 @@ type processorGroupModel struct {
 	Include     types.String `tfsdk:"include"`
 	Inputs      types.List   `tfsdk:"inputs"`
 
-	Processors []*processorModel `tfsdk:"processor"`
+	Processors     []*processorModel    `tfsdk:"processor"`
+	ProcessorsJSON jsontypes.Normalized `tfsdk:"processors_json"`
 }
 
 type processorModel struct {
@@ -788,6 +794,12 @@ func (r *observabilityPipelineResource) Configure(_ context.Context, request res
 	r.Auth = providerData.Auth
 }
 
+func (r *observabilityPipelineResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		observability_pipeline.TopologyValidator{},
+	}
+}
+
 func (r *observabilityPipelineResource) Metadata(_ context.Context, request resource.MetadataRequest, response *resource.MetadataResponse) {
 	response.TypeName = "observability_pipeline"
 }
@@ -1236,6 +1248,9 @@ func (r *observabilityPipelineResource) Schema(_ context.Context, _ resource.Sch
 						"processor_group": schema.ListNestedBlock{
 							Description: "A processor group containing common configuration and nested processors.",
 							NestedObject: schema.NestedBlockObject{
+								Validators: []validator.Object{
+									observability_pipeline.ProcessorsJSONConflictValidator{},
+								},
 								Attributes: map[string]schema.Attribute{
 									"id": schema.StringAttribute{
 										Required:    true,
@@ -1258,1109 +1273,165 @@ func (r *observabilityPipelineResource) Schema(_ context.Context, _ resource.Sch
 										Optional:    true,
 										Description: "A human-friendly name of the processor group.",
 									},
+									"processors_json": schema.StringAttribute{
+										Optional:    true,
+										CustomType:  jsontypes.NormalizedType{},
+										Description: "The processors of this group, defined once in a `datadog_observability_pipeline_processor_group` and set from its `processors_json` attribute. Conflicts with `processor` blocks. Changes made outside of Terraform to these processors show as a difference on this attribute.",
+									},
+								},
+								Blocks: map[string]schema.Block{
+									"processor": processorBlock(),
+								},
+							},
+						},
+						"destination": schema.ListNestedBlock{
+							Description: "List of destinations.",
+							NestedObject: schema.NestedBlockObject{
+								Attributes: map[string]schema.Attribute{
+									"id": schema.StringAttribute{
+										Required:    true,
+										Description: "The unique identifier for this destination.",
+									},
+									"inputs": schema.ListAttribute{
+										Required:    true,
+										Description: "A list of component IDs whose output is used as the `input` for this component.",
+										ElementType: types.StringType,
+									},
 								},
 								Blocks: map[string]schema.Block{
-									"processor": schema.ListNestedBlock{
-										Description: "The processor contained in this group.",
+									"datadog_logs": schema.ListNestedBlock{
+										Description: "The `datadog_logs` destination forwards logs to Datadog Log Management.",
 										NestedObject: schema.NestedBlockObject{
-											Validators: []validator.Object{
-												observability_pipeline.ExactlyOneProcessorValidator{},
-											},
-											Attributes: map[string]schema.Attribute{
-												"id": schema.StringAttribute{
-													Required:    true,
-													Description: "The unique identifier for this processor.",
-												},
-												"enabled": schema.BoolAttribute{
-													Required:    true,
-													Description: "Whether this processor is enabled.",
-												},
-												"include": schema.StringAttribute{
-													Required:    true,
-													Description: "A Datadog search query used to determine which logs this processor targets.",
-												},
-												"display_name": schema.StringAttribute{
-													Optional:    true,
-													Description: "A human-friendly name for this processor.",
-												},
-											},
 											Blocks: map[string]schema.Block{
-												"filter": schema.ListNestedBlock{
-													Description: "The `filter` processor allows conditional processing of logs based on a Datadog search query. Logs that match the `include` query are passed through; others are discarded.",
-													Validators: []validator.List{
-														listvalidator.SizeAtMost(1),
-													},
-													NestedObject: schema.NestedBlockObject{
-														Attributes: map[string]schema.Attribute{},
-													},
-												},
-												"parse_json": schema.ListNestedBlock{
-													Description: "The `parse_json` processor extracts JSON from a specified field and flattens it into the event. This is useful when logs contain embedded JSON as a string.",
-													Validators: []validator.List{
-														listvalidator.SizeAtMost(1),
-													},
+												"buffer": observability_pipeline.BufferOptionsSchema(),
+												"routes": schema.ListNestedBlock{
+													Description: "A list of routing rules that forward matching logs to Datadog using dedicated API keys.",
 													NestedObject: schema.NestedBlockObject{
 														Attributes: map[string]schema.Attribute{
-															"field": schema.StringAttribute{
+															"route_id": schema.StringAttribute{
 																Required:    true,
-																Description: "The field to parse.",
+																Description: "Unique identifier for this route within the destination.",
 															},
-														},
-													},
-												},
-												"parse_xml": schema.ListNestedBlock{
-													Description: "The `parse_xml` processor parses XML from a specified field and extracts it into the event.",
-													Validators: []validator.List{
-														listvalidator.SizeAtMost(1),
-													},
-													NestedObject: schema.NestedBlockObject{
-														Attributes: map[string]schema.Attribute{
-															"field": schema.StringAttribute{
+															"include": schema.StringAttribute{
 																Required:    true,
-																Description: "The path to the log field on which you want to parse XML.",
-															},
-															"include_attr": schema.BoolAttribute{
-																Optional:    true,
-																Description: "Whether to include XML attributes in the parsed output.",
-															},
-															"always_use_text_key": schema.BoolAttribute{
-																Optional:    true,
-																Description: "Whether to always store text inside an object using the text key even when no attributes exist.",
-															},
-															"parse_number": schema.BoolAttribute{
-																Optional:    true,
-																Description: "Whether to parse numeric values from strings.",
-															},
-															"parse_bool": schema.BoolAttribute{
-																Optional:    true,
-																Description: "Whether to parse boolean values from strings.",
-															},
-															"parse_null": schema.BoolAttribute{
-																Optional:    true,
-																Description: "Whether to parse null values.",
+																Description: "A Datadog search query that determines which logs are forwarded using this route.",
 															},
-															"attr_prefix": schema.StringAttribute{
-																Optional:    true,
-																Description: "The prefix to use for XML attributes in the parsed output. If the field is left empty, the original attribute key is used.",
+															"site": schema.StringAttribute{
+																Required:    true,
+																Description: "Datadog site where matching logs are sent (for example, `us1`).",
 															},
-															"text_key": schema.StringAttribute{
-																Optional:    true,
-																Description: "The key name to use for the text node when XML attributes are appended.",
+															"api_key_key": schema.StringAttribute{
+																Required:    true,
+																Description: "Name of the environment variable or secret that stores the Datadog API key used by this route.",
 															},
 														},
-													},
-												},
-												"add_fields": schema.ListNestedBlock{
-													Description: "The `add_fields` processor adds static key-value fields to logs.",
-													Validators: []validator.List{
-														listvalidator.SizeAtMost(1),
-													},
-													NestedObject: schema.NestedBlockObject{
-														Attributes: map[string]schema.Attribute{},
 														Blocks: map[string]schema.Block{
-															"field": schema.ListNestedBlock{
-																Validators: []validator.List{
-																	listvalidator.IsRequired(),
-																},
-																Description: "A list of static fields (key-value pairs) that is added to each log event processed by this component.",
-																NestedObject: schema.NestedBlockObject{
-																	Attributes: map[string]schema.Attribute{
-																		"name": schema.StringAttribute{
-																			Required:    true,
-																			Description: "The field name to add.",
-																		},
-																		"value": schema.StringAttribute{
-																			Required:    true,
-																			Description: "The value to assign to the field.",
-																		},
-																	},
-																},
-															},
+															"buffer": observability_pipeline.BufferOptionsSchema(),
 														},
 													},
-												},
-												"add_hostname": schema.ListNestedBlock{
-													Description: "The `add_hostname` processor adds the hostname to log events.",
 													Validators: []validator.List{
-														listvalidator.SizeAtMost(1),
-													},
-													NestedObject: schema.NestedBlockObject{
-														Attributes: map[string]schema.Attribute{},
+														listvalidator.SizeAtMost(100),
 													},
 												},
-												"rename_fields": schema.ListNestedBlock{
-													Description: "The `rename_fields` processor changes field names.",
-													Validators: []validator.List{
-														listvalidator.SizeAtMost(1),
-													},
-													NestedObject: schema.NestedBlockObject{
-														Attributes: map[string]schema.Attribute{},
-														Blocks: map[string]schema.Block{
-															"field": schema.ListNestedBlock{
-																Validators: []validator.List{
-																	// this is the only way to make the list of fields required in Terraform
-																	listvalidator.IsRequired(),
-																},
-																Description: "List of fields to rename.",
-																NestedObject: schema.NestedBlockObject{
-																	Attributes: map[string]schema.Attribute{
-																		"source": schema.StringAttribute{
-																			Required:    true,
-																			Description: "Source field to rename.",
-																		},
-																		"destination": schema.StringAttribute{
-																			Required:    true,
-																			Description: "Destination field name.",
-																		},
-																		"preserve_source": schema.BoolAttribute{
-																			Required:    true,
-																			Description: "Whether to keep the original field.",
-																		},
-																	},
-																},
-															},
-														},
+											},
+										},
+									},
+									"datadog_metrics": schema.ListNestedBlock{
+										Description:  "The `datadog_metrics` destination forwards metrics to Datadog.",
+										NestedObject: schema.NestedBlockObject{},
+									},
+									"http_client": schema.ListNestedBlock{
+										Description: "The `http_client` destination sends data to an HTTP endpoint.",
+										NestedObject: schema.NestedBlockObject{
+											Attributes: map[string]schema.Attribute{
+												"encoding": schema.StringAttribute{
+													Required:    true,
+													Description: "Encoding format for events.",
+													Validators: []validator.String{
+														stringvalidator.OneOf("json"),
 													},
 												},
-												"remove_fields": schema.ListNestedBlock{
-													Description: "The `remove_fields` processor deletes specified fields from logs.",
-													Validators: []validator.List{
-														listvalidator.SizeAtMost(1),
-													},
-													NestedObject: schema.NestedBlockObject{
-														Attributes: map[string]schema.Attribute{
-															"fields": schema.ListAttribute{
-																Required:    true,
-																Description: "List of fields to remove from the events.",
-																ElementType: types.StringType,
-																Validators: []validator.List{
-																	listvalidator.SizeAtLeast(1),
-																},
-															},
-														},
-													},
+												"token_key": schema.StringAttribute{
+													Optional:    true,
+													Description: "Name of the environment variable or secret that holds the authentication token.",
 												},
-												"quota": schema.ListNestedBlock{
-													Description: "The `quota` processor measures logging traffic for logs that match a specified filter. When the configured daily quota is met, the processor can drop or alert.",
-													Validators: []validator.List{
-														listvalidator.SizeAtMost(1),
+												"password_key": schema.StringAttribute{
+													Optional:    true,
+													Description: "Name of the environment variable or secret that holds the password.",
+												},
+												"uri_key": schema.StringAttribute{
+													Optional:    true,
+													Description: "Name of the environment variable or secret that holds the request URI.",
+												},
+												"username_key": schema.StringAttribute{
+													Optional:    true,
+													Description: "Name of the environment variable or secret that holds the username.",
+												},
+												"auth_strategy": schema.StringAttribute{
+													Optional:    true,
+													Description: "HTTP authentication strategy.",
+													Validators: []validator.String{
+														stringvalidator.OneOf("none", "basic", "bearer"),
 													},
+												},
+											},
+											Blocks: map[string]schema.Block{
+												"compression": schema.ListNestedBlock{
+													Description: "Compression configuration for HTTP requests.",
 													NestedObject: schema.NestedBlockObject{
 														Attributes: map[string]schema.Attribute{
-															"name": schema.StringAttribute{
+															"algorithm": schema.StringAttribute{
 																Required:    true,
-																Description: "The name of the quota.",
-															},
-															"drop_events": schema.BoolAttribute{
-																Optional:    true,
-																Description: "Whether to drop events exceeding the limit.",
-															},
-															"ignore_when_missing_partitions": schema.BoolAttribute{
-																Optional:    true,
-																Description: "Whether to ignore when partition fields are missing.",
-															},
-															"partition_fields": schema.ListAttribute{
-																Optional:    true,
-																ElementType: types.StringType,
-																Description: "List of partition fields.",
-															},
-															"overflow_action": schema.StringAttribute{
-																Optional:    true,
-																Description: "The action to take when the quota is exceeded: `drop`, `no_action`, or `overflow_routing`.",
-															},
-															"too_many_buckets_action": schema.StringAttribute{
-																Optional:    true,
-																Description: "The action to take when the max number of buckets is exceeded: `drop`, `no_action`, or `overflow_routing`.",
-															},
-														},
-														Blocks: map[string]schema.Block{
-															"limit": schema.ListNestedBlock{
-																NestedObject: schema.NestedBlockObject{
-																	Attributes: map[string]schema.Attribute{
-																		"enforce": schema.StringAttribute{
-																			Required:    true,
-																			Description: "Whether to enforce by 'bytes' or 'events'.",
-																			Validators: []validator.String{
-																				stringvalidator.OneOf("bytes", "events"),
-																			},
-																		},
-																		"limit": schema.Int64Attribute{
-																			Required:    true,
-																			Description: "The daily quota limit.",
-																		},
-																	},
-																},
-																Validators: []validator.List{
-																	listvalidator.IsRequired(),
-																	listvalidator.SizeAtMost(1),
-																},
-															},
-															"override": schema.ListNestedBlock{
-																Description: "The overrides for field-specific quotas.",
-																NestedObject: schema.NestedBlockObject{
-																	Blocks: map[string]schema.Block{
-																		"limit": schema.ListNestedBlock{
-																			NestedObject: schema.NestedBlockObject{
-																				Attributes: map[string]schema.Attribute{
-																					"enforce": schema.StringAttribute{
-																						Required:    true,
-																						Description: "Whether to enforce by 'bytes' or 'events'.",
-																						Validators: []validator.String{
-																							stringvalidator.OneOf("bytes", "events"),
-																						},
-																					},
-																					"limit": schema.Int64Attribute{
-																						Required:    true,
-																						Description: "The daily quota limit.",
-																					},
-																				},
-																			},
-																			Validators: []validator.List{
-																				listvalidator.IsRequired(),
-																				listvalidator.SizeAtMost(1),
-																			},
-																		},
-																		"field": schema.ListNestedBlock{
-																			Description: "Fields that trigger this override.",
-																			NestedObject: schema.NestedBlockObject{
-																				Attributes: map[string]schema.Attribute{
-																					"name": schema.StringAttribute{
-																						Description: "The field name.",
-																						Required:    true,
-																					},
-																					"value": schema.StringAttribute{
-																						Description: "The field value.",
-																						Required:    true,
-																					},
-																				},
-																			},
-																		},
-																	},
+																Description: "Compression algorithm.",
+																Validators: []validator.String{
+																	stringvalidator.OneOf("gzip"),
 																},
 															},
 														},
 													},
-												},
-												"sensitive_data_scanner": schema.ListNestedBlock{
-													Description: "The `sensitive_data_scanner` processor detects and optionally redacts sensitive data in log events.",
 													Validators: []validator.List{
 														listvalidator.SizeAtMost(1),
 													},
-													NestedObject: schema.NestedBlockObject{
-														Attributes: map[string]schema.Attribute{},
-														Blocks: map[string]schema.Block{
-															"rule": schema.ListNestedBlock{
-																Description: "A list of rules for identifying and acting on sensitive data patterns.",
-																NestedObject: schema.NestedBlockObject{
-																	Attributes: map[string]schema.Attribute{
-																		"name": schema.StringAttribute{
-																			Required:    true,
-																			Description: "A name identifying the rule.",
-																		},
-																		"tags": schema.ListAttribute{
-																			Optional:    true,
-																			ElementType: types.StringType,
-																			Description: "Tags assigned to this rule for filtering and classification.",
-																		},
-																	},
-																	Blocks: map[string]schema.Block{
-																		"keyword_options": schema.ListNestedBlock{
-																			Description: "Keyword-based proximity matching for sensitive data.",
-																			NestedObject: schema.NestedBlockObject{
-																				Attributes: map[string]schema.Attribute{
-																					"keywords": schema.ListAttribute{
-																						Optional:    true,
-																						ElementType: types.StringType,
-																						Description: "A list of keywords to match near the sensitive pattern.",
-																					},
-																					"proximity": schema.Int64Attribute{
-																						Optional:    true,
-																						Description: "Maximum number of tokens between a keyword and a sensitive value match.",
-																					},
-																				},
-																			},
-																			Validators: []validator.List{
-																				listvalidator.SizeAtMost(1),
-																			},
-																		},
-																		"pattern": schema.ListNestedBlock{
-																			Description: "Pattern detection configuration for identifying sensitive data using either a custom regex or a library reference.",
-																			NestedObject: schema.NestedBlockObject{
-																				Blocks: map[string]schema.Block{
-																					"custom": schema.ListNestedBlock{
-																						Description: "Pattern detection using a custom regular expression.",
-																						NestedObject: schema.NestedBlockObject{
-																							Attributes: map[string]schema.Attribute{
-																								"rule": schema.StringAttribute{
-																									Optional:    true,
-																									Description: "A regular expression used to detect sensitive values. Must be a valid regex.",
-																								},
-																								"description": schema.StringAttribute{
-																									Optional:    true,
-																									Description: "Human-readable description providing context about a sensitive data scanner rule.",
-																								},
-																							},
-																						},
-																						Validators: []validator.List{
-																							listvalidator.SizeAtMost(1),
-																						},
-																					},
-																					"library": schema.ListNestedBlock{
-																						Description:         "Pattern detection using a predefined pattern from the sensitive data scanner pattern library.",
-																						MarkdownDescription: "Pattern detection using a predefined pattern from the Sensitive Data Scanner library. For Terraform setup (standard pattern data source and library rules), see the [Sensitive Data Scanner processor documentation](https://docs.datadoghq.com/observability_pipelines/processors/sensitive_data_scanner/?tab=libraryrules#set-up-the-processor-using-terraform).",
-																						NestedObject: schema.NestedBlockObject{
-																							Attributes: map[string]schema.Attribute{
-																								"id": schema.StringAttribute{
-																									Optional:    true,
-																									Description: "Identifier for a predefined pattern from the sensitive data scanner pattern library.",
-																								},
-																								"use_recommended_keywords": schema.BoolAttribute{
-																									Optional:    true,
-																									Description: "Whether to augment the pattern with recommended keywords (optional).",
-																								},
-																								"description": schema.StringAttribute{
-																									Optional:    true,
-																									Description: "Human-readable description providing context about a sensitive data scanner rule.",
-																								},
-																							},
-																						},
-																						Validators: []validator.List{
-																							listvalidator.SizeAtMost(1),
-																						},
-																					},
-																				},
-																			},
-																			Validators: []validator.List{
-																				listvalidator.SizeAtMost(1),
-																			},
-																		},
-																		"scope": schema.ListNestedBlock{
-																			Description: "Field-level targeting options that determine where the scanner should operate.",
-																			NestedObject: schema.NestedBlockObject{
-																				Blocks: map[string]schema.Block{
-																					"include": schema.ListNestedBlock{
-																						Description: "Explicitly include these fields for scanning.",
-																						NestedObject: schema.NestedBlockObject{
-																							Attributes: map[string]schema.Attribute{
-																								"fields": schema.ListAttribute{
-																									Optional:    true,
-																									ElementType: types.StringType,
-																									Description: "The fields to include in scanning.",
-																								},
-																							},
-																						},
-																						Validators: []validator.List{
-																							listvalidator.SizeAtMost(1),
-																						},
-																					},
-																					"exclude": schema.ListNestedBlock{
-																						Description: "Explicitly exclude these fields from scanning.",
-																						NestedObject: schema.NestedBlockObject{
-																							Attributes: map[string]schema.Attribute{
-																								"fields": schema.ListAttribute{
-																									Optional:    true,
-																									ElementType: types.StringType,
-																									Description: "The fields to exclude from scanning.",
-																								},
-																							},
-																						},
-																						Validators: []validator.List{
-																							listvalidator.SizeAtMost(1),
-																						},
-																					},
-																				},
-																				Attributes: map[string]schema.Attribute{
-																					"all": schema.BoolAttribute{
-																						Optional:    true,
-																						Description: "Scan all fields.",
-																					},
-																				},
-																			},
-																			Validators: []validator.List{
-																				listvalidator.SizeAtMost(1),
-																			},
-																		},
-																		"on_match": schema.ListNestedBlock{
-																			Description: "The action to take when a sensitive value is found.",
-																			NestedObject: schema.NestedBlockObject{
-																				Blocks: map[string]schema.Block{
-																					"redact": schema.ListNestedBlock{
-																						Description: "Redacts the matched value.",
-																						NestedObject: schema.NestedBlockObject{
-																							Attributes: map[string]schema.Attribute{
-																								"replace": schema.StringAttribute{
-																									Optional:    true,
-																									Description: "Replacement string for redacted values (e.g., `***`).",
-																								},
-																							},
-																						},
-																						Validators: []validator.List{
-																							listvalidator.SizeAtMost(1),
-																						},
-																					},
-																					"hash": schema.ListNestedBlock{
-																						Description: "Hashes the matched value.",
-																						NestedObject: schema.NestedBlockObject{
-																							Attributes: map[string]schema.Attribute{}, // empty options
-																						},
-																						Validators: []validator.List{
-																							listvalidator.SizeAtMost(1),
-																						},
-																					},
-																					"partial_redact": schema.ListNestedBlock{
-																						Description: "Redacts part of the matched value (e.g., keep last 4 characters).",
-																						NestedObject: schema.NestedBlockObject{
-																							Attributes: map[string]schema.Attribute{
-																								"characters": schema.Int64Attribute{
-																									Optional:    true,
-																									Description: "Number of characters to keep.",
-																								},
-																								"direction": schema.StringAttribute{
-																									Optional:    true,
-																									Description: "Direction from which to keep characters: `first` or `last`.",
-																								},
-																							},
-																						},
-																						Validators: []validator.List{
-																							listvalidator.SizeAtMost(1),
-																						},
-																					},
-																				},
-																			},
-																			Validators: []validator.List{
-																				listvalidator.SizeAtMost(1),
-																			},
-																		},
-																	},
-																},
-															},
-														},
-													},
 												},
-												"generate_datadog_metrics": schema.ListNestedBlock{
-													Description: "The `generate_datadog_metrics` processor creates custom metrics from logs. Metrics can be counters, gauges, or distributions and optionally grouped by log fields.",
-													Validators: []validator.List{
-														listvalidator.SizeAtMost(1),
-													},
-													NestedObject: schema.NestedBlockObject{
-														Attributes: map[string]schema.Attribute{},
-														Blocks: map[string]schema.Block{
-															"metric": schema.ListNestedBlock{
-																Description: "Configuration for generating individual metrics.",
-																NestedObject: schema.NestedBlockObject{
-																	Attributes: map[string]schema.Attribute{
-																		"name": schema.StringAttribute{
-																			Required:    true,
-																			Description: "Name of the custom metric to be created.",
-																		},
-																		"include": schema.StringAttribute{
-																			Required:    true,
-																			Description: "Datadog filter query to match logs for metric generation.",
-																		},
-																		"metric_type": schema.StringAttribute{
-																			Required:    true,
-																			Description: "Type of metric to create.",
-																		},
-																		"group_by": schema.ListAttribute{
-																			Optional:    true,
-																			ElementType: types.StringType,
-																			Description: "Optional fields used to group the metric series.",
-																		},
-																	},
-																	Blocks: map[string]schema.Block{
-																		"value": schema.ListNestedBlock{
-																			Description: "Specifies how the value of the generated metric is computed.",
-																			NestedObject: schema.NestedBlockObject{
-																				Attributes: map[string]schema.Attribute{
-																					"strategy": schema.StringAttribute{
-																						Required:    true,
-																						Description: "Metric value strategy: `increment_by_one` or `increment_by_field`.",
-																					},
-																					"field": schema.StringAttribute{
-																						Optional:    true,
-																						Description: "Name of the log field containing the numeric value to increment the metric by (used only for `increment_by_field`).",
-																					},
-																				},
-																			},
-																			Validators: []validator.List{
-																				listvalidator.IsRequired(),
-																				listvalidator.SizeAtMost(1),
-																			},
-																		},
-																	},
-																},
-															},
-														},
-													},
+												"tls":    observability_pipeline.ClientTlsSchema(),
+												"buffer": observability_pipeline.BufferOptionsSchema(),
+											},
+										},
+									},
+									"google_cloud_storage": schema.ListNestedBlock{
+										Description: "The `google_cloud_storage` destination stores logs in a Google Cloud Storage (GCS) bucket.",
+										NestedObject: schema.NestedBlockObject{
+											Attributes: map[string]schema.Attribute{
+												"bucket": schema.StringAttribute{
+													Required:    true,
+													Description: "Name of the GCS bucket.",
 												},
-												"parse_grok": observability_pipeline.ParseGrokProcessorSchema(),
-												"sample": schema.ListNestedBlock{
-													Description: "The `sample` processor allows probabilistic sampling of logs at a fixed rate.",
-													Validators: []validator.List{
-														listvalidator.SizeAtMost(1),
-													},
-													NestedObject: schema.NestedBlockObject{
-														Attributes: map[string]schema.Attribute{
-															"percentage": schema.Float64Attribute{
-																Required:    true,
-																Description: "The percentage of logs to sample.",
-															},
-															"group_by": schema.ListAttribute{
-																Optional:    true,
-																ElementType: types.StringType,
-																Description: "Optional list of fields to group events by. Each group is sampled independently.",
-															},
-														},
-													},
+												"key_prefix": schema.StringAttribute{
+													Optional:    true,
+													Description: "Optional prefix for object keys within the GCS bucket.",
 												},
-												"dedupe": schema.ListNestedBlock{
-													Description: "The `dedupe` processor removes duplicate fields in log events.",
-													Validators: []validator.List{
-														listvalidator.SizeAtMost(1),
-													},
+												"storage_class": schema.StringAttribute{
+													Required:    true,
+													Description: "Storage class used for objects stored in GCS.",
+												},
+												"acl": schema.StringAttribute{
+													Optional:    true,
+													Description: "Access control list setting for objects written to the bucket.",
+												},
+											},
+											Blocks: map[string]schema.Block{
+												"auth": gcpAuthSchema(),
+												"metadata": schema.ListNestedBlock{
+													Description: "Custom metadata key-value pairs added to each object.",
 													NestedObject: schema.NestedBlockObject{
 														Attributes: map[string]schema.Attribute{
-															"fields": schema.ListAttribute{
+															"name": schema.StringAttribute{
 																Required:    true,
-																ElementType: types.StringType,
-																Description: "A list of log field paths to check for duplicates.",
-																Validators: []validator.List{
-																	listvalidator.SizeAtLeast(1),
-																},
+																Description: "The metadata key.",
 															},
-															"mode": schema.StringAttribute{
+															"value": schema.StringAttribute{
 																Required:    true,
-																Description: "The deduplication mode to apply to the fields.",
-															},
-														},
-													},
-												},
-												"reduce": schema.ListNestedBlock{
-													Description: "The `reduce` processor aggregates and merges logs based on matching keys and merge strategies.",
-													Validators: []validator.List{
-														listvalidator.SizeAtMost(1),
-													},
-													NestedObject: schema.NestedBlockObject{
-														Attributes: map[string]schema.Attribute{
-															"group_by": schema.ListAttribute{
-																Required:    true,
-																ElementType: types.StringType,
-																Description: "A list of fields used to group log events for merging.",
-															},
-														},
-														Blocks: map[string]schema.Block{
-															"merge_strategy": schema.ListNestedBlock{
-																Description: "List of merge strategies defining how values from grouped events should be combined.",
-																Validators: []validator.List{
-																	listvalidator.IsRequired(),
-																	listvalidator.SizeAtLeast(1),
-																},
-																NestedObject: schema.NestedBlockObject{
-																	Attributes: map[string]schema.Attribute{
-																		"path": schema.StringAttribute{
-																			Required:    true,
-																			Description: "The field path in the log event.",
-																		},
-																		"strategy": schema.StringAttribute{
-																			Required:    true,
-																			Description: "The merge strategy to apply.",
-																		},
-																	},
-																},
-															},
-														},
-													},
-												},
-												"split_array": schema.ListNestedBlock{
-													Description: "The `split_array` processor splits array fields into separate events based on configured rules.",
-													Validators: []validator.List{
-														listvalidator.SizeAtMost(1),
-													},
-													NestedObject: schema.NestedBlockObject{
-														Attributes: map[string]schema.Attribute{},
-														Blocks: map[string]schema.Block{
-															"array": schema.ListNestedBlock{
-																Description: "A list of array split configurations.",
-																Validators: []validator.List{
-																	listvalidator.IsRequired(),
-																	listvalidator.SizeAtMost(15),
-																},
-																NestedObject: schema.NestedBlockObject{
-																	Attributes: map[string]schema.Attribute{
-																		"include": schema.StringAttribute{
-																			Required:    true,
-																			Description: "A Datadog search query used to determine which logs this array split operation targets.",
-																		},
-																		"field": schema.StringAttribute{
-																			Required:    true,
-																			Description: "The path to the array field to split.",
-																		},
-																	},
-																},
-															},
-														},
-													},
-												},
-												"throttle": schema.ListNestedBlock{
-													Description: "The `throttle` processor limits the number of events that pass through over a given time window.",
-													Validators: []validator.List{
-														listvalidator.SizeAtMost(1),
-													},
-													NestedObject: schema.NestedBlockObject{
-														Attributes: map[string]schema.Attribute{
-															"threshold": schema.Int64Attribute{
-																Required:    true,
-																Description: "The number of events to allow before throttling is applied.",
-															},
-															"window": schema.Float64Attribute{
-																Required:    true,
-																Description: "The time window in seconds over which the threshold applies.",
-															},
-															"group_by": schema.ListAttribute{
-																Optional:    true,
-																ElementType: types.StringType,
-																Description: "Optional list of fields used to group events before applying throttling.",
-															},
-														},
-													},
-												},
-												"add_env_vars": schema.ListNestedBlock{
-													Description: "The `add_env_vars` processor adds environment variable values to log events.",
-													Validators: []validator.List{
-														listvalidator.SizeAtMost(1),
-													},
-													NestedObject: schema.NestedBlockObject{
-														Attributes: map[string]schema.Attribute{},
-														Blocks: map[string]schema.Block{
-															"variable": schema.ListNestedBlock{
-																Description: "A list of environment variable mappings to apply to log fields.",
-																NestedObject: schema.NestedBlockObject{
-																	Attributes: map[string]schema.Attribute{
-																		"field": schema.StringAttribute{
-																			Required:    true,
-																			Description: "The target field in the log event.",
-																		},
-																		"name": schema.StringAttribute{
-																			Required:    true,
-																			Description: "The name of the environment variable to read.",
-																		},
-																	},
-																},
-															},
-														},
-													},
-												},
-												"enrichment_table": schema.ListNestedBlock{
-													Description: "The `enrichment_table` processor enriches logs using a static CSV file or GeoIP database.",
-													Validators: []validator.List{
-														listvalidator.SizeAtMost(1),
-													},
-													NestedObject: schema.NestedBlockObject{
-														Attributes: map[string]schema.Attribute{
-															"target": schema.StringAttribute{
-																Required:    true,
-																Description: "Path where enrichment results should be stored in the log.",
-															},
-														},
-														Blocks: map[string]schema.Block{
-															"file": schema.ListNestedBlock{
-																Description: "Defines a static enrichment table loaded from a CSV file.",
-																NestedObject: schema.NestedBlockObject{
-																	Attributes: map[string]schema.Attribute{
-																		"path": schema.StringAttribute{
-																			Optional:    true,
-																			Description: "Path to the CSV file.",
-																		},
-																	},
-																	Blocks: map[string]schema.Block{
-																		"encoding": schema.ListNestedBlock{
-																			NestedObject: schema.NestedBlockObject{
-																				Attributes: map[string]schema.Attribute{
-																					"type": schema.StringAttribute{
-																						Required:    true,
-																						Description: "File encoding format.",
-																					},
-																					"delimiter": schema.StringAttribute{
-																						Required:    true,
-																						Description: "The `encoding` `delimiter`.",
-																					},
-																					"includes_headers": schema.BoolAttribute{
-																						Optional:    true,
-																						Description: "The `encoding` `includes_headers`.",
-																					},
-																				},
-																			},
-																			Validators: []validator.List{
-																				listvalidator.IsRequired(),
-																				listvalidator.SizeAtMost(1),
-																			},
-																		},
-																		"key": schema.ListNestedBlock{
-																			Description: "Key fields used to look up enrichment values.",
-																			NestedObject: schema.NestedBlockObject{
-																				Attributes: map[string]schema.Attribute{
-																					"column": schema.StringAttribute{
-																						Optional:    true,
-																						Description: "The `items` `column`.",
-																					},
-																					"comparison": schema.StringAttribute{
-																						Optional:    true,
-																						Description: "The comparison method (e.g. equals).",
-																					},
-																				},
-																				Blocks: map[string]schema.Block{
-																					"field": schema.ListNestedBlock{
-																						Description: "Specifies the source of the key value for enrichment table lookups. Set exactly one of `string_path`, `event`, `vrl`, or `secret`.",
-																						NestedObject: schema.NestedBlockObject{
-																							Attributes: map[string]schema.Attribute{
-																								"string_path": schema.StringAttribute{
-																									Optional:    true,
-																									Description: "A plain field path in the log event (for example, `log.user.id`).",
-																								},
-																								"event": schema.StringAttribute{
-																									Optional:    true,
-																									Description: "The path to the field in the log event to use as the lookup key.",
-																								},
-																								"vrl": schema.StringAttribute{
-																									Optional:    true,
-																									Description: "A VRL expression that returns the value to use as the lookup key.",
-																								},
-																								"secret": schema.StringAttribute{
-																									Optional:    true,
-																									Description: "The name of the secret containing the lookup key value.",
-																								},
-																							},
-																						},
-																						Validators: []validator.List{
-																							listvalidator.SizeAtMost(1),
-																						},
-																					},
-																				},
-																			},
-																		},
-																	},
-																},
-																Validators: []validator.List{
-																	listvalidator.SizeAtMost(1),
-																},
-															},
-															"geoip": schema.ListNestedBlock{
-																Description: "Uses a GeoIP database to enrich logs based on an IP field.",
-																NestedObject: schema.NestedBlockObject{
-																	Attributes: map[string]schema.Attribute{
-																		"key_field": schema.StringAttribute{
-																			Optional:    true,
-																			Description: "Path to the IP field in the log.",
-																		},
-																		"locale": schema.StringAttribute{
-																			Optional:    true,
-																			Description: "Locale used to resolve geographical names.",
-																		},
-																		"path": schema.StringAttribute{
-																			Optional:    true,
-																			Description: "Path to the GeoIP database file.",
-																		},
-																	},
-																},
-																Validators: []validator.List{
-																	listvalidator.SizeAtMost(1),
-																},
-															},
-															"reference_table": schema.ListNestedBlock{
-																Description: "Uses a Datadog reference table to enrich logs.",
-																NestedObject: schema.NestedBlockObject{
-																	Attributes: map[string]schema.Attribute{
-																		"app_key_key": schema.StringAttribute{
-																			Optional:    true,
-																			Description: "Name of the environment variable or secret that holds the Datadog application key for the reference table.",
-																		},
-																		"key_field": schema.StringAttribute{
-																			Required:    true,
-																			Description: "Path to the field in the log event to match against the reference table.",
-																		},
-																		"table_id": schema.StringAttribute{
-																			Required:    true,
-																			Description: "The unique identifier of the reference table.",
-																		},
-																		"columns": schema.ListAttribute{
-																			Optional:    true,
-																			ElementType: types.StringType,
-																			Description: "List of column names to include from the reference table. If not provided, all columns are included.",
-																		},
-																	},
-																},
-																Validators: []validator.List{
-																	listvalidator.SizeAtMost(1),
-																},
-															},
-														},
-													},
-												},
-												"ocsf_mapper":           observability_pipeline.OcsfMapperProcessorSchema(),
-												"datadog_tags":          observability_pipeline.DatadogTagsProcessorSchema(),
-												"custom_processor":      observability_pipeline.CustomProcessorSchema(),
-												"add_metric_tags":       observability_pipeline.AddMetricTagsProcessorSchema(),
-												"aggregate":             observability_pipeline.AggregateProcessorSchema(),
-												"rename_metric_tags":    observability_pipeline.RenameMetricTagsProcessorSchema(),
-												"tag_cardinality_limit": observability_pipeline.TagCardinalityLimitProcessorSchema(),
-												"metric_tags": schema.ListNestedBlock{
-													Description: "The `metric_tags` processor filters metrics based on their tags using Datadog tag key patterns.",
-													Validators: []validator.List{
-														listvalidator.SizeAtMost(1),
-													},
-													NestedObject: schema.NestedBlockObject{
-														Attributes: map[string]schema.Attribute{},
-														Blocks: map[string]schema.Block{
-															"rule": schema.ListNestedBlock{
-																Description: "A list of rules for filtering metric tags.",
-																Validators: []validator.List{
-																	listvalidator.IsRequired(),
-																	listvalidator.SizeAtMost(100),
-																},
-																NestedObject: schema.NestedBlockObject{
-																	Attributes: map[string]schema.Attribute{
-																		"include": schema.StringAttribute{
-																			Required:    true,
-																			Description: "A Datadog search query used to determine which metrics this rule targets.",
-																		},
-																		"mode": schema.StringAttribute{
-																			Required:    true,
-																			Description: "The processing mode for tag filtering.",
-																			Validators: []validator.String{
-																				stringvalidator.OneOf("filter"),
-																			},
-																		},
-																		"action": schema.StringAttribute{
-																			Required:    true,
-																			Description: "The action to take on tags with matching keys.",
-																			Validators: []validator.String{
-																				stringvalidator.OneOf("include", "exclude"),
-																			},
-																		},
-																		"keys": schema.ListAttribute{
-																			ElementType: types.StringType,
-																			Required:    true,
-																			Description: "A list of tag keys to include or exclude.",
-																			Validators: []validator.List{
-																				listvalidator.SizeAtLeast(1),
-																			},
-																		},
-																	},
-																},
-															},
-														},
-													},
-												},
-												"generate_metrics": schema.ListNestedBlock{
-													Description: "The `generate_metrics` processor creates custom metrics from logs. The generated metrics must be routed to a metrics destination using the input `<processor-id>.metrics`.",
-													Validators: []validator.List{
-														listvalidator.SizeAtMost(1),
-													},
-													NestedObject: schema.NestedBlockObject{
-														Attributes: map[string]schema.Attribute{},
-														Blocks: map[string]schema.Block{
-															"metric": schema.ListNestedBlock{
-																Description: "Configuration for generating individual metrics.",
-																NestedObject: schema.NestedBlockObject{
-																	Attributes: map[string]schema.Attribute{
-																		"name": schema.StringAttribute{
-																			Required:    true,
-																			Description: "Name of the custom metric to be created.",
-																		},
-																		"include": schema.StringAttribute{
-																			Required:    true,
-																			Description: "Datadog filter query to match logs for metric generation.",
-																		},
-																		"metric_type": schema.StringAttribute{
-																			Required:    true,
-																			Description: "Type of metric to create.",
-																		},
-																		"group_by": schema.ListAttribute{
-																			Optional:    true,
-																			ElementType: types.StringType,
-																			Description: "Optional fields used to group the metric series.",
-																		},
-																	},
-																	Blocks: map[string]schema.Block{
-																		"value": schema.ListNestedBlock{
-																			Description: "Specifies how the value of the generated metric is computed.",
-																			NestedObject: schema.NestedBlockObject{
-																				Attributes: map[string]schema.Attribute{
-																					"strategy": schema.StringAttribute{
-																						Required:    true,
-																						Description: "Metric value strategy: `increment_by_one` or `increment_by_field`.",
-																					},
-																					"field": schema.StringAttribute{
-																						Optional:    true,
-																						Description: "Name of the log field containing the numeric value to increment the metric by (used only for `increment_by_field`).",
-																					},
-																				},
-																			},
-																			Validators: []validator.List{
-																				listvalidator.IsRequired(),
-																				listvalidator.SizeAtMost(1),
-																			},
-																		},
-																	},
-																},
-															},
-														},
-													},
-												},
-											},
-										},
-									},
-								},
-							},
-						},
-						"destination": schema.ListNestedBlock{
-							Description: "List of destinations.",
-							NestedObject: schema.NestedBlockObject{
-								Attributes: map[string]schema.Attribute{
-									"id": schema.StringAttribute{
-										Required:    true,
-										Description: "The unique identifier for this destination.",
-									},
-									"inputs": schema.ListAttribute{
-										Required:    true,
-										Description: "A list of component IDs whose output is used as the `input` for this component.",
-										ElementType: types.StringType,
-									},
-								},
-								Blocks: map[string]schema.Block{
-									"datadog_logs": schema.ListNestedBlock{
-										Description: "The `datadog_logs` destination forwards logs to Datadog Log Management.",
-										NestedObject: schema.NestedBlockObject{
-											Blocks: map[string]schema.Block{
-												"buffer": observability_pipeline.BufferOptionsSchema(),
-												"routes": schema.ListNestedBlock{
-													Description: "A list of routing rules that forward matching logs to Datadog using dedicated API keys.",
-													NestedObject: schema.NestedBlockObject{
-														Attributes: map[string]schema.Attribute{
-															"route_id": schema.StringAttribute{
-																Required:    true,
-																Description: "Unique identifier for this route within the destination.",
-															},
-															"include": schema.StringAttribute{
-																Required:    true,
-																Description: "A Datadog search query that determines which logs are forwarded using this route.",
-															},
-															"site": schema.StringAttribute{
-																Required:    true,
-																Description: "Datadog site where matching logs are sent (for example, `us1`).",
-															},
-															"api_key_key": schema.StringAttribute{
-																Required:    true,
-																Description: "Name of the environment variable or secret that stores the Datadog API key used by this route.",
-															},
-														},
-														Blocks: map[string]schema.Block{
-															"buffer": observability_pipeline.BufferOptionsSchema(),
-														},
-													},
-													Validators: []validator.List{
-														listvalidator.SizeAtMost(100),
-													},
-												},
-											},
-										},
-									},
-									"datadog_metrics": schema.ListNestedBlock{
-										Description:  "The `datadog_metrics` destination forwards metrics to Datadog.",
-										NestedObject: schema.NestedBlockObject{},
-									},
-									"http_client": schema.ListNestedBlock{
-										Description: "The `http_client` destination sends data to an HTTP endpoint.",
-										NestedObject: schema.NestedBlockObject{
-											Attributes: map[string]schema.Attribute{
-												"encoding": schema.StringAttribute{
-													Required:    true,
-													Description: "Encoding format for events.",
-													Validators: []validator.String{
-														stringvalidator.OneOf("json"),
-													},
-												},
-												"token_key": schema.StringAttribute{
-													Optional:    true,
-													Description: "Name of the environment variable or secret that holds the authentication token.",
-												},
-												"password_key": schema.StringAttribute{
-													Optional:    true,
-													Description: "Name of the environment variable or secret that holds the password.",
-												},
-												"uri_key": schema.StringAttribute{
-													Optional:    true,
-													Description: "Name of the environment variable or secret that holds the request URI.",
-												},
-												"username_key": schema.StringAttribute{
-													Optional:    true,
-													Description: "Name of the environment variable or secret that holds the username.",
-												},
-												"auth_strategy": schema.StringAttribute{
-													Optional:    true,
-													Description: "HTTP authentication strategy.",
-													Validators: []validator.String{
-														stringvalidator.OneOf("none", "basic", "bearer"),
-													},
-												},
-											},
-											Blocks: map[string]schema.Block{
-												"compression": schema.ListNestedBlock{
-													Description: "Compression configuration for HTTP requests.",
-													NestedObject: schema.NestedBlockObject{
-														Attributes: map[string]schema.Attribute{
-															"algorithm": schema.StringAttribute{
-																Required:    true,
-																Description: "Compression algorithm.",
-																Validators: []validator.String{
-																	stringvalidator.OneOf("gzip"),
-																},
-															},
-														},
-													},
-													Validators: []validator.List{
-														listvalidator.SizeAtMost(1),
-													},
-												},
-												"tls":    observability_pipeline.ClientTlsSchema(),
-												"buffer": observability_pipeline.BufferOptionsSchema(),
-											},
-										},
-									},
-									"google_cloud_storage": schema.ListNestedBlock{
-										Description: "The `google_cloud_storage` destination stores logs in a Google Cloud Storage (GCS) bucket.",
-										NestedObject: schema.NestedBlockObject{
-											Attributes: map[string]schema.Attribute{
-												"bucket": schema.StringAttribute{
-													Required:    true,
-													Description: "Name of the GCS bucket.",
-												},
-												"key_prefix": schema.StringAttribute{
-													Optional:    true,
-													Description: "Optional prefix for object keys within the GCS bucket.",
-												},
-												"storage_class": schema.StringAttribute{
-													Required:    true,
-													Description: "Storage class used for objects stored in GCS.",
-												},
-												"acl": schema.StringAttribute{
-													Optional:    true,
-													Description: "Access control list setting for objects written to the bucket.",
-												},
-											},
-											Blocks: map[string]schema.Block{
-												"auth": gcpAuthSchema(),
-												"metadata": schema.ListNestedBlock{
-													Description: "Custom metadata key-value pairs added to each object.",
-													NestedObject: schema.NestedBlockObject{
-														Attributes: map[string]schema.Attribute{
-															"name": schema.StringAttribute{
-																Required:    true,
-																Description: "The metadata key.",
-															},
-															"value": schema.StringAttribute{
-																Required:    true,
-																Description: "The metadata value.",
+																Description: "The metadata value.",
 															},
 														},
 													},
@@ -2634,223 +1705,1181 @@ func (r *observabilityPipelineResource) Schema(_ context.Context, _ resource.Sch
 													Validators: []validator.List{
 														listvalidator.SizeAtMost(1),
 													},
-												},
-												"data_stream": schema.ListNestedBlock{
-													Description: "Configuration options for writing to OpenSearch Data Streams instead of a fixed index.",
-													NestedObject: schema.NestedBlockObject{
-														Attributes: map[string]schema.Attribute{
-															"dtype": schema.StringAttribute{
-																Optional:    true,
-																Description: "The data stream type for your logs. This determines how logs are categorized within the data stream.",
-															},
-															"dataset": schema.StringAttribute{
-																Optional:    true,
-																Description: "The data stream dataset for your logs. This groups logs by their source or application.",
-															},
-															"namespace": schema.StringAttribute{
-																Optional:    true,
-																Description: "The data stream namespace for your logs. This separates logs into different environments or domains.",
-															},
-														},
+												},
+												"data_stream": schema.ListNestedBlock{
+													Description: "Configuration options for writing to OpenSearch Data Streams instead of a fixed index.",
+													NestedObject: schema.NestedBlockObject{
+														Attributes: map[string]schema.Attribute{
+															"dtype": schema.StringAttribute{
+																Optional:    true,
+																Description: "The data stream type for your logs. This determines how logs are categorized within the data stream.",
+															},
+															"dataset": schema.StringAttribute{
+																Optional:    true,
+																Description: "The data stream dataset for your logs. This groups logs by their source or application.",
+															},
+															"namespace": schema.StringAttribute{
+																Optional:    true,
+																Description: "The data stream namespace for your logs. This separates logs into different environments or domains.",
+															},
+														},
+													},
+													Validators: []validator.List{
+														listvalidator.SizeAtMost(1),
+														listvalidator.ConflictsWith(frameworkPath.MatchRelative().AtParent().AtName("bulk_index")),
+													},
+												},
+												"buffer": observability_pipeline.BufferOptionsSchema(),
+											},
+										},
+									},
+									"amazon_opensearch": schema.ListNestedBlock{
+										Description: "The `amazon_opensearch` destination writes logs to Amazon OpenSearch.",
+										NestedObject: schema.NestedBlockObject{
+											Attributes: map[string]schema.Attribute{
+												"bulk_index": schema.StringAttribute{
+													Optional:    true,
+													Description: "The index or datastream to write logs to.",
+												},
+											},
+											Blocks: map[string]schema.Block{
+												"auth": schema.ListNestedBlock{
+													NestedObject: schema.NestedBlockObject{
+														Attributes: map[string]schema.Attribute{
+															"strategy": schema.StringAttribute{
+																Required:    true,
+																Description: "The authentication strategy to use (e.g. aws or basic).",
+															},
+															"aws_region": schema.StringAttribute{
+																Optional:    true,
+																Description: "AWS region override (if applicable).",
+															},
+															"assume_role": schema.StringAttribute{
+																Optional:    true,
+																Description: "ARN of the role to assume.",
+															},
+															"external_id": schema.StringAttribute{
+																Optional:    true,
+																Description: "External ID for assumed role.",
+															},
+															"session_name": schema.StringAttribute{
+																Optional:    true,
+																Description: "Session name for assumed role.",
+															},
+														},
+													},
+													Validators: []validator.List{
+														listvalidator.IsRequired(),
+														listvalidator.SizeAtMost(1),
+													},
+												},
+												"buffer": observability_pipeline.BufferOptionsSchema(),
+											},
+										},
+									},
+									"azure_storage": schema.ListNestedBlock{
+										Description: "The `azure_storage` destination forwards logs to an Azure Blob Storage container.",
+										NestedObject: schema.NestedBlockObject{
+											Attributes: map[string]schema.Attribute{
+												"container_name": schema.StringAttribute{
+													Required:    true,
+													Description: "The name of the Azure Blob Storage container to store logs in.",
+												},
+												"blob_prefix": schema.StringAttribute{
+													Optional:    true,
+													Description: "Optional prefix for blobs written to the container.",
+												},
+												"connection_string_key": schema.StringAttribute{
+													Optional:    true,
+													Description: "Name of the environment variable or secret that holds the Azure Storage connection string.",
+												},
+											},
+											Blocks: map[string]schema.Block{
+												"buffer": observability_pipeline.BufferOptionsSchema(),
+											},
+										},
+									},
+									"microsoft_sentinel": schema.ListNestedBlock{
+										Description: "The `microsoft_sentinel` destination forwards logs to Microsoft Sentinel.",
+										NestedObject: schema.NestedBlockObject{
+											Attributes: map[string]schema.Attribute{
+												"client_id": schema.StringAttribute{
+													Required:    true,
+													Description: "Azure AD client ID used for authentication.",
+												},
+												"tenant_id": schema.StringAttribute{
+													Required:    true,
+													Description: "Azure AD tenant ID.",
+												},
+												"dcr_immutable_id": schema.StringAttribute{
+													Required:    true,
+													Description: "The immutable ID of the Data Collection Rule (DCR).",
+												},
+												"client_secret_key": schema.StringAttribute{
+													Optional:    true,
+													Description: "Name of the environment variable or secret that holds the Azure AD client secret.",
+												},
+												"dce_uri_key": schema.StringAttribute{
+													Optional:    true,
+													Description: "Name of the environment variable or secret that holds the Data Collection Endpoint (DCE) URI.",
+												},
+												"table": schema.StringAttribute{
+													Required:    true,
+													Description: "The name of the Log Analytics table where logs will be sent.",
+												},
+											},
+											Blocks: map[string]schema.Block{
+												"buffer": observability_pipeline.BufferOptionsSchema(),
+											},
+										},
+									},
+									"google_secops": schema.ListNestedBlock{
+										Description: "The `google_chronicle` destination sends logs to Google SecOps.",
+										NestedObject: schema.NestedBlockObject{
+											Attributes: map[string]schema.Attribute{
+												"customer_id": schema.StringAttribute{
+													Required:    true,
+													Description: "The Google SecOps customer ID.",
+												},
+												"encoding": schema.StringAttribute{
+													Required:    true,
+													Description: "The encoding format for the logs sent to Google SecOps.",
+													Validators: []validator.String{
+														stringvalidator.OneOf("json", "raw_message"),
+													},
+												},
+												"endpoint_url_key": schema.StringAttribute{
+													Optional:    true,
+													Description: "Name of the environment variable or secret that holds the Google Chronicle endpoint URL.",
+												},
+												"log_type": schema.StringAttribute{
+													Required:    true,
+													Description: "The log type metadata associated with the Google SecOps destination.",
+												},
+											},
+											Blocks: map[string]schema.Block{
+												"auth":   gcpAuthSchema(),
+												"buffer": observability_pipeline.BufferOptionsSchema(),
+											},
+										},
+									},
+									"new_relic": schema.ListNestedBlock{
+										Description: "The `new_relic` destination sends logs to the New Relic platform.",
+										NestedObject: schema.NestedBlockObject{
+											Attributes: map[string]schema.Attribute{
+												"account_id_key": schema.StringAttribute{
+													Optional:    true,
+													Description: "Name of the environment variable or secret that holds the New Relic account ID.",
+												},
+												"license_key_key": schema.StringAttribute{
+													Optional:    true,
+													Description: "Name of the environment variable or secret that holds the New Relic license key.",
+												},
+												"region": schema.StringAttribute{
+													Required:    true,
+													Description: "The New Relic region.",
+												},
+											},
+											Blocks: map[string]schema.Block{
+												"buffer": observability_pipeline.BufferOptionsSchema(),
+											},
+										},
+									},
+									"sentinel_one": schema.ListNestedBlock{
+										Description: "The `sentinel_one` destination sends logs to SentinelOne.",
+										NestedObject: schema.NestedBlockObject{
+											Attributes: map[string]schema.Attribute{
+												"token_key": schema.StringAttribute{
+													Optional:    true,
+													Description: "Name of the environment variable or secret that holds the SentinelOne API token.",
+												},
+												"region": schema.StringAttribute{
+													Required:    true,
+													Description: "The SentinelOne region to send logs to.",
+												},
+											},
+											Blocks: map[string]schema.Block{
+												"buffer": observability_pipeline.BufferOptionsSchema(),
+											},
+										},
+									},
+									"socket":                    observability_pipeline.SocketDestinationSchema(),
+									"amazon_s3":                 observability_pipeline.AmazonS3DestinationSchema(),
+									"amazon_s3_generic":         observability_pipeline.AmazonS3GenericDestinationSchema(),
+									"amazon_security_lake":      observability_pipeline.AmazonSecurityLakeDestinationSchema(),
+									"crowdstrike_next_gen_siem": observability_pipeline.CrowdStrikeNextGenSiemDestinationSchema(),
+									"databricks_zerobus":        observability_pipeline.DatabricksZerobusDestinationSchema(),
+									"splunk_hec_metrics":        observability_pipeline.SplunkHECMetricsDestinationSchema(),
+									"clickhouse":                observability_pipeline.ClickhouseDestinationSchema(),
+									"cloud_prem":                observability_pipeline.CloudPremDestinationSchema(),
+									"kafka":                     observability_pipeline.KafkaDestinationSchema(),
+								},
+							},
+						},
+					},
+				},
+				Validators: []validator.List{
+					listvalidator.IsRequired(),
+					listvalidator.SizeAtMost(1),
+				},
+			},
+		},
+	}
+}
+
+// processorBlock is the schema of the processors of a processor group, shared
+// with the processor group templates.
+func processorBlock() schema.ListNestedBlock {
+	return schema.ListNestedBlock{
+		Description: "The processor contained in this group.",
+		NestedObject: schema.NestedBlockObject{
+			Validators: []validator.Object{
+				observability_pipeline.ExactlyOneProcessorValidator{},
+			},
+			Attributes: map[string]schema.Attribute{
+				"id": schema.StringAttribute{
+					Required:    true,
+					Description: "The unique identifier for this processor.",
+				},
+				"enabled": schema.BoolAttribute{
+					Required:    true,
+					Description: "Whether this processor is enabled.",
+				},
+				"include": schema.StringAttribute{
+					Required:    true,
+					Description: "A Datadog search query used to determine which logs this processor targets.",
+				},
+				"display_name": schema.StringAttribute{
+					Optional:    true,
+					Description: "A human-friendly name for this processor.",
+				},
+			},
+			Blocks: map[string]schema.Block{
+				"filter": schema.ListNestedBlock{
+					Description: "The `filter` processor allows conditional processing of logs based on a Datadog search query. Logs that match the `include` query are passed through; others are discarded.",
+					Validators: []validator.List{
+						listvalidator.SizeAtMost(1),
+					},
+					NestedObject: schema.NestedBlockObject{
+						Attributes: map[string]schema.Attribute{},
+					},
+				},
+				"parse_json": schema.ListNestedBlock{
+					Description: "The `parse_json` processor extracts JSON from a specified field and flattens it into the event. This is useful when logs contain embedded JSON as a string.",
+					Validators: []validator.List{
+						listvalidator.SizeAtMost(1),
+					},
+					NestedObject: schema.NestedBlockObject{
+						Attributes: map[string]schema.Attribute{
+							"field": schema.StringAttribute{
+								Required:    true,
+								Description: "The field to parse.",
+							},
+						},
+					},
+				},
+				"parse_xml": schema.ListNestedBlock{
+					Description: "The `parse_xml` processor parses XML from a specified field and extracts it into the event.",
+					Validators: []validator.List{
+						listvalidator.SizeAtMost(1),
+					},
+					NestedObject: schema.NestedBlockObject{
+						Attributes: map[string]schema.Attribute{
+							"field": schema.StringAttribute{
+								Required:    true,
+								Description: "The path to the log field on which you want to parse XML.",
+							},
+							"include_attr": schema.BoolAttribute{
+								Optional:    true,
+								Description: "Whether to include XML attributes in the parsed output.",
+							},
+							"always_use_text_key": schema.BoolAttribute{
+								Optional:    true,
+								Description: "Whether to always store text inside an object using the text key even when no attributes exist.",
+							},
+							"parse_number": schema.BoolAttribute{
+								Optional:    true,
+								Description: "Whether to parse numeric values from strings.",
+							},
+							"parse_bool": schema.BoolAttribute{
+								Optional:    true,
+								Description: "Whether to parse boolean values from strings.",
+							},
+							"parse_null": schema.BoolAttribute{
+								Optional:    true,
+								Description: "Whether to parse null values.",
+							},
+							"attr_prefix": schema.StringAttribute{
+								Optional:    true,
+								Description: "The prefix to use for XML attributes in the parsed output. If the field is left empty, the original attribute key is used.",
+							},
+							"text_key": schema.StringAttribute{
+								Optional:    true,
+								Description: "The key name to use for the text node when XML attributes are appended.",
+							},
+						},
+					},
+				},
+				"add_fields": schema.ListNestedBlock{
+					Description: "The `add_fields` processor adds static key-value fields to logs.",
+					Validators: []validator.List{
+						listvalidator.SizeAtMost(1),
+					},
+					NestedObject: schema.NestedBlockObject{
+						Attributes: map[string]schema.Attribute{},
+						Blocks: map[string]schema.Block{
+							"field": schema.ListNestedBlock{
+								Validators: []validator.List{
+									listvalidator.IsRequired(),
+								},
+								Description: "A list of static fields (key-value pairs) that is added to each log event processed by this component.",
+								NestedObject: schema.NestedBlockObject{
+									Attributes: map[string]schema.Attribute{
+										"name": schema.StringAttribute{
+											Required:    true,
+											Description: "The field name to add.",
+										},
+										"value": schema.StringAttribute{
+											Required:    true,
+											Description: "The value to assign to the field.",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				"add_hostname": schema.ListNestedBlock{
+					Description: "The `add_hostname` processor adds the hostname to log events.",
+					Validators: []validator.List{
+						listvalidator.SizeAtMost(1),
+					},
+					NestedObject: schema.NestedBlockObject{
+						Attributes: map[string]schema.Attribute{},
+					},
+				},
+				"rename_fields": schema.ListNestedBlock{
+					Description: "The `rename_fields` processor changes field names.",
+					Validators: []validator.List{
+						listvalidator.SizeAtMost(1),
+					},
+					NestedObject: schema.NestedBlockObject{
+						Attributes: map[string]schema.Attribute{},
+						Blocks: map[string]schema.Block{
+							"field": schema.ListNestedBlock{
+								Validators: []validator.List{
+									// this is the only way to make the list of fields required in Terraform
+									listvalidator.IsRequired(),
+								},
+								Description: "List of fields to rename.",
+								NestedObject: schema.NestedBlockObject{
+									Attributes: map[string]schema.Attribute{
+										"source": schema.StringAttribute{
+											Required:    true,
+											Description: "Source field to rename.",
+										},
+										"destination": schema.StringAttribute{
+											Required:    true,
+											Description: "Destination field name.",
+										},
+										"preserve_source": schema.BoolAttribute{
+											Required:    true,
+											Description: "Whether to keep the original field.",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				"remove_fields": schema.ListNestedBlock{
+					Description: "The `remove_fields` processor deletes specified fields from logs.",
+					Validators: []validator.List{
+						listvalidator.SizeAtMost(1),
+					},
+					NestedObject: schema.NestedBlockObject{
+						Attributes: map[string]schema.Attribute{
+							"fields": schema.ListAttribute{
+								Required:    true,
+								Description: "List of fields to remove from the events.",
+								ElementType: types.StringType,
+								Validators: []validator.List{
+									listvalidator.SizeAtLeast(1),
+								},
+							},
+						},
+					},
+				},
+				"quota": schema.ListNestedBlock{
+					Description: "The `quota` processor measures logging traffic for logs that match a specified filter. When the configured daily quota is met, the processor can drop or alert.",
+					Validators: []validator.List{
+						listvalidator.SizeAtMost(1),
+					},
+					NestedObject: schema.NestedBlockObject{
+						Attributes: map[string]schema.Attribute{
+							"name": schema.StringAttribute{
+								Required:    true,
+								Description: "The name of the quota.",
+							},
+							"drop_events": schema.BoolAttribute{
+								Optional:    true,
+								Description: "Whether to drop events exceeding the limit.",
+							},
+							"ignore_when_missing_partitions": schema.BoolAttribute{
+								Optional:    true,
+								Description: "Whether to ignore when partition fields are missing.",
+							},
+							"partition_fields": schema.ListAttribute{
+								Optional:    true,
+								ElementType: types.StringType,
+								Description: "List of partition fields.",
+							},
+							"overflow_action": schema.StringAttribute{
+								Optional:    true,
+								Description: "The action to take when the quota is exceeded: `drop`, `no_action`, or `overflow_routing`.",
+							},
+							"too_many_buckets_action": schema.StringAttribute{
+								Optional:    true,
+								Description: "The action to take when the max number of buckets is exceeded: `drop`, `no_action`, or `overflow_routing`.",
+							},
+						},
+						Blocks: map[string]schema.Block{
+							"limit": schema.ListNestedBlock{
+								NestedObject: schema.NestedBlockObject{
+									Attributes: map[string]schema.Attribute{
+										"enforce": schema.StringAttribute{
+											Required:    true,
+											Description: "Whether to enforce by 'bytes' or 'events'.",
+											Validators: []validator.String{
+												stringvalidator.OneOf("bytes", "events"),
+											},
+										},
+										"limit": schema.Int64Attribute{
+											Required:    true,
+											Description: "The daily quota limit.",
+										},
+									},
+								},
+								Validators: []validator.List{
+									listvalidator.IsRequired(),
+									listvalidator.SizeAtMost(1),
+								},
+							},
+							"override": schema.ListNestedBlock{
+								Description: "The overrides for field-specific quotas.",
+								NestedObject: schema.NestedBlockObject{
+									Blocks: map[string]schema.Block{
+										"limit": schema.ListNestedBlock{
+											NestedObject: schema.NestedBlockObject{
+												Attributes: map[string]schema.Attribute{
+													"enforce": schema.StringAttribute{
+														Required:    true,
+														Description: "Whether to enforce by 'bytes' or 'events'.",
+														Validators: []validator.String{
+															stringvalidator.OneOf("bytes", "events"),
+														},
+													},
+													"limit": schema.Int64Attribute{
+														Required:    true,
+														Description: "The daily quota limit.",
+													},
+												},
+											},
+											Validators: []validator.List{
+												listvalidator.IsRequired(),
+												listvalidator.SizeAtMost(1),
+											},
+										},
+										"field": schema.ListNestedBlock{
+											Description: "Fields that trigger this override.",
+											NestedObject: schema.NestedBlockObject{
+												Attributes: map[string]schema.Attribute{
+													"name": schema.StringAttribute{
+														Description: "The field name.",
+														Required:    true,
+													},
+													"value": schema.StringAttribute{
+														Description: "The field value.",
+														Required:    true,
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				"sensitive_data_scanner": schema.ListNestedBlock{
+					Description: "The `sensitive_data_scanner` processor detects and optionally redacts sensitive data in log events.",
+					Validators: []validator.List{
+						listvalidator.SizeAtMost(1),
+					},
+					NestedObject: schema.NestedBlockObject{
+						Attributes: map[string]schema.Attribute{},
+						Blocks: map[string]schema.Block{
+							"rule": schema.ListNestedBlock{
+								Description: "A list of rules for identifying and acting on sensitive data patterns.",
+								NestedObject: schema.NestedBlockObject{
+									Attributes: map[string]schema.Attribute{
+										"name": schema.StringAttribute{
+											Required:    true,
+											Description: "A name identifying the rule.",
+										},
+										"tags": schema.ListAttribute{
+											Optional:    true,
+											ElementType: types.StringType,
+											Description: "Tags assigned to this rule for filtering and classification.",
+										},
+									},
+									Blocks: map[string]schema.Block{
+										"keyword_options": schema.ListNestedBlock{
+											Description: "Keyword-based proximity matching for sensitive data.",
+											NestedObject: schema.NestedBlockObject{
+												Attributes: map[string]schema.Attribute{
+													"keywords": schema.ListAttribute{
+														Optional:    true,
+														ElementType: types.StringType,
+														Description: "A list of keywords to match near the sensitive pattern.",
+													},
+													"proximity": schema.Int64Attribute{
+														Optional:    true,
+														Description: "Maximum number of tokens between a keyword and a sensitive value match.",
+													},
+												},
+											},
+											Validators: []validator.List{
+												listvalidator.SizeAtMost(1),
+											},
+										},
+										"pattern": schema.ListNestedBlock{
+											Description: "Pattern detection configuration for identifying sensitive data using either a custom regex or a library reference.",
+											NestedObject: schema.NestedBlockObject{
+												Blocks: map[string]schema.Block{
+													"custom": schema.ListNestedBlock{
+														Description: "Pattern detection using a custom regular expression.",
+														NestedObject: schema.NestedBlockObject{
+															Attributes: map[string]schema.Attribute{
+																"rule": schema.StringAttribute{
+																	Optional:    true,
+																	Description: "A regular expression used to detect sensitive values. Must be a valid RE2 regular expression: lookaround assertions and backreferences are not supported.",
+																	Validators: []validator.String{
+																		validators.SDSPatternValidator(),
+																	},
+																},
+																"description": schema.StringAttribute{
+																	Optional:    true,
+																	Description: "Human-readable description providing context about a sensitive data scanner rule.",
+																},
+															},
+														},
+														Validators: []validator.List{
+															listvalidator.SizeAtMost(1),
+														},
+													},
+													"library": schema.ListNestedBlock{
+														Description:         "Pattern detection using a predefined pattern from the sensitive data scanner pattern library.",
+														MarkdownDescription: "Pattern detection using a predefined pattern from the Sensitive Data Scanner library. For Terraform setup (standard pattern data source and library rules), see the [Sensitive Data Scanner processor documentation](https://docs.datadoghq.com/observability_pipelines/processors/sensitive_data_scanner/?tab=libraryrules#set-up-the-processor-using-terraform).",
+														NestedObject: schema.NestedBlockObject{
+															Attributes: map[string]schema.Attribute{
+																"id": schema.StringAttribute{
+																	Optional:    true,
+																	Description: "Identifier for a predefined pattern from the sensitive data scanner pattern library.",
+																},
+																"use_recommended_keywords": schema.BoolAttribute{
+																	Optional:    true,
+																	Description: "Whether to augment the pattern with recommended keywords (optional).",
+																},
+																"description": schema.StringAttribute{
+																	Optional:    true,
+																	Description: "Human-readable description providing context about a sensitive data scanner rule.",
+																},
+															},
+														},
+														Validators: []validator.List{
+															listvalidator.SizeAtMost(1),
+														},
+													},
+												},
+											},
+											Validators: []validator.List{
+												listvalidator.SizeAtMost(1),
+											},
+										},
+										"scope": schema.ListNestedBlock{
+											Description: "Field-level targeting options that determine where the scanner should operate.",
+											NestedObject: schema.NestedBlockObject{
+												Blocks: map[string]schema.Block{
+													"include": schema.ListNestedBlock{
+														Description: "Explicitly include these fields for scanning.",
+														NestedObject: schema.NestedBlockObject{
+															Attributes: map[string]schema.Attribute{
+																"fields": schema.ListAttribute{
+																	Optional:    true,
+																	ElementType: types.StringType,
+																	Description: "The fields to include in scanning.",
+																},
+															},
+														},
+														Validators: []validator.List{
+															listvalidator.SizeAtMost(1),
+														},
+													},
+													"exclude": schema.ListNestedBlock{
+														Description: "Explicitly exclude these fields from scanning.",
+														NestedObject: schema.NestedBlockObject{
+															Attributes: map[string]schema.Attribute{
+																"fields": schema.ListAttribute{
+																	Optional:    true,
+																	ElementType: types.StringType,
+																	Description: "The fields to exclude from scanning.",
+																},
+															},
+														},
+														Validators: []validator.List{
+															listvalidator.SizeAtMost(1),
+														},
+													},
+												},
+												Attributes: map[string]schema.Attribute{
+													"all": schema.BoolAttribute{
+														Optional:    true,
+														Description: "Scan all fields.",
+													},
+												},
+											},
+											Validators: []validator.List{
+												listvalidator.SizeAtMost(1),
+											},
+										},
+										"on_match": schema.ListNestedBlock{
+											Description: "The action to take when a sensitive value is found.",
+											NestedObject: schema.NestedBlockObject{
+												Blocks: map[string]schema.Block{
+													"redact": schema.ListNestedBlock{
+														Description: "Redacts the matched value.",
+														NestedObject: schema.NestedBlockObject{
+															Attributes: map[string]schema.Attribute{
+																"replace": schema.StringAttribute{
+																	Optional:    true,
+																	Description: "Replacement string for redacted values (e.g., `***`).",
+																},
+															},
+														},
+														Validators: []validator.List{
+															listvalidator.SizeAtMost(1),
+														},
+													},
+													"hash": schema.ListNestedBlock{
+														Description: "Hashes the matched value.",
+														NestedObject: schema.NestedBlockObject{
+															Attributes: map[string]schema.Attribute{}, // empty options
+														},
+														Validators: []validator.List{
+															listvalidator.SizeAtMost(1),
+														},
+													},
+													"partial_redact": schema.ListNestedBlock{
+														Description: "Redacts part of the matched value (e.g., keep last 4 characters).",
+														NestedObject: schema.NestedBlockObject{
+															Attributes: map[string]schema.Attribute{
+																"characters": schema.Int64Attribute{
+																	Optional:    true,
+																	Description: "Number of characters to keep.",
+																},
+																"direction": schema.StringAttribute{
+																	Optional:    true,
+																	Description: "Direction from which to keep characters: `first` or `last`.",
+																},
+															},
+														},
+														Validators: []validator.List{
+															listvalidator.SizeAtMost(1),
+														},
+													},
+												},
+											},
+											Validators: []validator.List{
+												listvalidator.SizeAtMost(1),
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				"generate_datadog_metrics": schema.ListNestedBlock{
+					Description: "The `generate_datadog_metrics` processor creates custom metrics from logs. Metrics can be counters, gauges, or distributions and optionally grouped by log fields.",
+					Validators: []validator.List{
+						listvalidator.SizeAtMost(1),
+					},
+					NestedObject: schema.NestedBlockObject{
+						Attributes: map[string]schema.Attribute{},
+						Blocks: map[string]schema.Block{
+							"metric": schema.ListNestedBlock{
+								Description: "Configuration for generating individual metrics.",
+								NestedObject: schema.NestedBlockObject{
+									Attributes: map[string]schema.Attribute{
+										"name": schema.StringAttribute{
+											Required:    true,
+											Description: "Name of the custom metric to be created.",
+										},
+										"include": schema.StringAttribute{
+											Required:    true,
+											Description: "Datadog filter query to match logs for metric generation.",
+										},
+										"metric_type": schema.StringAttribute{
+											Required:    true,
+											Description: "Type of metric to create.",
+										},
+										"group_by": schema.ListAttribute{
+											Optional:    true,
+											ElementType: types.StringType,
+											Description: "Optional fields used to group the metric series.",
+										},
+									},
+									Blocks: map[string]schema.Block{
+										"value": schema.ListNestedBlock{
+											Description: "Specifies how the value of the generated metric is computed.",
+											NestedObject: schema.NestedBlockObject{
+												Attributes: map[string]schema.Attribute{
+													"strategy": schema.StringAttribute{
+														Required:    true,
+														Description: "Metric value strategy: `increment_by_one` or `increment_by_field`.",
+													},
+													"field": schema.StringAttribute{
+														Optional:    true,
+														Description: "Name of the log field containing the numeric value to increment the metric by (used only for `increment_by_field`).",
+													},
+												},
+											},
+											Validators: []validator.List{
+												listvalidator.IsRequired(),
+												listvalidator.SizeAtMost(1),
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				"parse_grok": observability_pipeline.ParseGrokProcessorSchema(),
+				"sample": schema.ListNestedBlock{
+					Description: "The `sample` processor allows probabilistic sampling of logs at a fixed rate.",
+					Validators: []validator.List{
+						listvalidator.SizeAtMost(1),
+					},
+					NestedObject: schema.NestedBlockObject{
+						Attributes: map[string]schema.Attribute{
+							"percentage": schema.Float64Attribute{
+								Required:    true,
+								Description: "The percentage of logs to sample.",
+							},
+							"group_by": schema.ListAttribute{
+								Optional:    true,
+								ElementType: types.StringType,
+								Description: "Optional list of fields to group events by. Each group is sampled independently.",
+							},
+						},
+					},
+				},
+				"dedupe": schema.ListNestedBlock{
+					Description: "The `dedupe` processor removes duplicate fields in log events.",
+					Validators: []validator.List{
+						listvalidator.SizeAtMost(1),
+					},
+					NestedObject: schema.NestedBlockObject{
+						Attributes: map[string]schema.Attribute{
+							"fields": schema.ListAttribute{
+								Required:    true,
+								ElementType: types.StringType,
+								Description: "A list of log field paths to check for duplicates.",
+								Validators: []validator.List{
+									listvalidator.SizeAtLeast(1),
+								},
+							},
+							"mode": schema.StringAttribute{
+								Required:    true,
+								Description: "The deduplication mode to apply to the fields.",
+							},
+						},
+					},
+				},
+				"reduce": schema.ListNestedBlock{
+					Description: "The `reduce` processor aggregates and merges logs based on matching keys and merge strategies.",
+					Validators: []validator.List{
+						listvalidator.SizeAtMost(1),
+					},
+					NestedObject: schema.NestedBlockObject{
+						Attributes: map[string]schema.Attribute{
+							"group_by": schema.ListAttribute{
+								Required:    true,
+								ElementType: types.StringType,
+								Description: "A list of fields used to group log events for merging.",
+							},
+						},
+						Blocks: map[string]schema.Block{
+							"merge_strategy": schema.ListNestedBlock{
+								Description: "List of merge strategies defining how values from grouped events should be combined.",
+								Validators: []validator.List{
+									listvalidator.IsRequired(),
+									listvalidator.SizeAtLeast(1),
+								},
+								NestedObject: schema.NestedBlockObject{
+									Attributes: map[string]schema.Attribute{
+										"path": schema.StringAttribute{
+											Required:    true,
+											Description: "The field path in the log event.",
+										},
+										"strategy": schema.StringAttribute{
+											Required:    true,
+											Description: "The merge strategy to apply.",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				"split_array": schema.ListNestedBlock{
+					Description: "The `split_array` processor splits array fields into separate events based on configured rules.",
+					Validators: []validator.List{
+						listvalidator.SizeAtMost(1),
+					},
+					NestedObject: schema.NestedBlockObject{
+						Attributes: map[string]schema.Attribute{},
+						Blocks: map[string]schema.Block{
+							"array": schema.ListNestedBlock{
+								Description: "A list of array split configurations.",
+								Validators: []validator.List{
+									listvalidator.IsRequired(),
+									listvalidator.SizeAtMost(15),
+								},
+								NestedObject: schema.NestedBlockObject{
+									Attributes: map[string]schema.Attribute{
+										"include": schema.StringAttribute{
+											Required:    true,
+											Description: "A Datadog search query used to determine which logs this array split operation targets.",
+										},
+										"field": schema.StringAttribute{
+											Required:    true,
+											Description: "The path to the array field to split.",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				"throttle": schema.ListNestedBlock{
+					Description: "The `throttle` processor limits the number of events that pass through over a given time window.",
+					Validators: []validator.List{
+						listvalidator.SizeAtMost(1),
+					},
+					NestedObject: schema.NestedBlockObject{
+						Attributes: map[string]schema.Attribute{
+							"threshold": schema.Int64Attribute{
+								Required:    true,
+								Description: "The number of events to allow before throttling is applied.",
+							},
+							"window": schema.Float64Attribute{
+								Required:    true,
+								Description: "The time window in seconds over which the threshold applies.",
+							},
+							"group_by": schema.ListAttribute{
+								Optional:    true,
+								ElementType: types.StringType,
+								Description: "Optional list of fields used to group events before applying throttling.",
+							},
+						},
+					},
+				},
+				"add_env_vars": schema.ListNestedBlock{
+					Description: "The `add_env_vars` processor adds environment variable values to log events.",
+					Validators: []validator.List{
+						listvalidator.SizeAtMost(1),
+					},
+					NestedObject: schema.NestedBlockObject{
+						Attributes: map[string]schema.Attribute{},
+						Blocks: map[string]schema.Block{
+							"variable": schema.ListNestedBlock{
+								Description: "A list of environment variable mappings to apply to log fields.",
+								NestedObject: schema.NestedBlockObject{
+									Attributes: map[string]schema.Attribute{
+										"field": schema.StringAttribute{
+											Required:    true,
+											Description: "The target field in the log event.",
+										},
+										"name": schema.StringAttribute{
+											Required:    true,
+											Description: "The name of the environment variable to read.",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				"enrichment_table": schema.ListNestedBlock{
+					Description: "The `enrichment_table` processor enriches logs using a static CSV file or GeoIP database.",
+					Validators: []validator.List{
+						listvalidator.SizeAtMost(1),
+					},
+					NestedObject: schema.NestedBlockObject{
+						Attributes: map[string]schema.Attribute{
+							"target": schema.StringAttribute{
+								Required:    true,
+								Description: "Path where enrichment results should be stored in the log.",
+							},
+						},
+						Blocks: map[string]schema.Block{
+							"file": schema.ListNestedBlock{
+								Description: "Defines a static enrichment table loaded from a CSV file.",
+								NestedObject: schema.NestedBlockObject{
+									Attributes: map[string]schema.Attribute{
+										"path": schema.StringAttribute{
+											Optional:    true,
+											Description: "Path to the CSV file.",
+										},
+									},
+									Blocks: map[string]schema.Block{
+										"encoding": schema.ListNestedBlock{
+											NestedObject: schema.NestedBlockObject{
+												Attributes: map[string]schema.Attribute{
+													"type": schema.StringAttribute{
+														Required:    true,
+														Description: "File encoding format.",
+													},
+													"delimiter": schema.StringAttribute{
+														Required:    true,
+														Description: "The `encoding` `delimiter`.",
 													},
-													Validators: []validator.List{
-														listvalidator.SizeAtMost(1),
-														listvalidator.ConflictsWith(frameworkPath.MatchRelative().AtParent().AtName("bulk_index")),
+													"includes_headers": schema.BoolAttribute{
+														Optional:    true,
+														Description: "The `encoding` `includes_headers`.",
 													},
 												},
-												"buffer": observability_pipeline.BufferOptionsSchema(),
+											},
+											Validators: []validator.List{
+												listvalidator.IsRequired(),
+												listvalidator.SizeAtMost(1),
 											},
 										},
-									},
-									"amazon_opensearch": schema.ListNestedBlock{
-										Description: "The `amazon_opensearch` destination writes logs to Amazon OpenSearch.",
-										NestedObject: schema.NestedBlockObject{
-											Attributes: map[string]schema.Attribute{
-												"bulk_index": schema.StringAttribute{
-													Optional:    true,
-													Description: "The index or datastream to write logs to.",
+										"key": schema.ListNestedBlock{
+											Description: "Key fields used to look up enrichment values.",
+											NestedObject: schema.NestedBlockObject{
+												Attributes: map[string]schema.Attribute{
+													"column": schema.StringAttribute{
+														Optional:    true,
+														Description: "The `items` `column`.",
+													},
+													"comparison": schema.StringAttribute{
+														Optional:    true,
+														Description: "The comparison method (e.g. equals).",
+													},
 												},
-											},
-											Blocks: map[string]schema.Block{
-												"auth": schema.ListNestedBlock{
-													NestedObject: schema.NestedBlockObject{
-														Attributes: map[string]schema.Attribute{
-															"strategy": schema.StringAttribute{
-																Required:    true,
-																Description: "The authentication strategy to use (e.g. aws or basic).",
-															},
-															"aws_region": schema.StringAttribute{
-																Optional:    true,
-																Description: "AWS region override (if applicable).",
-															},
-															"assume_role": schema.StringAttribute{
-																Optional:    true,
-																Description: "ARN of the role to assume.",
-															},
-															"external_id": schema.StringAttribute{
-																Optional:    true,
-																Description: "External ID for assumed role.",
-															},
-															"session_name": schema.StringAttribute{
-																Optional:    true,
-																Description: "Session name for assumed role.",
+												Blocks: map[string]schema.Block{
+													"field": schema.ListNestedBlock{
+														Description: "Specifies the source of the key value for enrichment table lookups. Set exactly one of `string_path`, `event`, `vrl`, or `secret`.",
+														NestedObject: schema.NestedBlockObject{
+															Attributes: map[string]schema.Attribute{
+																"string_path": schema.StringAttribute{
+																	Optional:    true,
+																	Description: "A plain field path in the log event (for example, `log.user.id`).",
+																},
+																"event": schema.StringAttribute{
+																	Optional:    true,
+																	Description: "The path to the field in the log event to use as the lookup key.",
+																},
+																"vrl": schema.StringAttribute{
+																	Optional:    true,
+																	Description: "A VRL expression that returns the value to use as the lookup key.",
+																},
+																"secret": schema.StringAttribute{
+																	Optional:    true,
+																	Description: "The name of the secret containing the lookup key value.",
+																},
 															},
 														},
-													},
-													Validators: []validator.List{
-														listvalidator.IsRequired(),
-														listvalidator.SizeAtMost(1),
+														Validators: []validator.List{
+															listvalidator.SizeAtMost(1),
+														},
 													},
 												},
-												"buffer": observability_pipeline.BufferOptionsSchema(),
 											},
 										},
 									},
-									"azure_storage": schema.ListNestedBlock{
-										Description: "The `azure_storage` destination forwards logs to an Azure Blob Storage container.",
-										NestedObject: schema.NestedBlockObject{
-											Attributes: map[string]schema.Attribute{
-												"container_name": schema.StringAttribute{
-													Required:    true,
-													Description: "The name of the Azure Blob Storage container to store logs in.",
-												},
-												"blob_prefix": schema.StringAttribute{
-													Optional:    true,
-													Description: "Optional prefix for blobs written to the container.",
-												},
-												"connection_string_key": schema.StringAttribute{
-													Optional:    true,
-													Description: "Name of the environment variable or secret that holds the Azure Storage connection string.",
-												},
-											},
-											Blocks: map[string]schema.Block{
-												"buffer": observability_pipeline.BufferOptionsSchema(),
-											},
+								},
+								Validators: []validator.List{
+									listvalidator.SizeAtMost(1),
+								},
+							},
+							"geoip": schema.ListNestedBlock{
+								Description: "Uses a GeoIP database to enrich logs based on an IP field.",
+								NestedObject: schema.NestedBlockObject{
+									Attributes: map[string]schema.Attribute{
+										"key_field": schema.StringAttribute{
+											Optional:    true,
+											Description: "Path to the IP field in the log.",
+										},
+										"locale": schema.StringAttribute{
+											Optional:    true,
+											Description: "Locale used to resolve geographical names.",
+										},
+										"path": schema.StringAttribute{
+											Optional:    true,
+											Description: "Path to the GeoIP database file.",
 										},
 									},
-									"microsoft_sentinel": schema.ListNestedBlock{
-										Description: "The `microsoft_sentinel` destination forwards logs to Microsoft Sentinel.",
-										NestedObject: schema.NestedBlockObject{
-											Attributes: map[string]schema.Attribute{
-												"client_id": schema.StringAttribute{
-													Required:    true,
-													Description: "Azure AD client ID used for authentication.",
-												},
-												"tenant_id": schema.StringAttribute{
-													Required:    true,
-													Description: "Azure AD tenant ID.",
-												},
-												"dcr_immutable_id": schema.StringAttribute{
-													Required:    true,
-													Description: "The immutable ID of the Data Collection Rule (DCR).",
-												},
-												"client_secret_key": schema.StringAttribute{
-													Optional:    true,
-													Description: "Name of the environment variable or secret that holds the Azure AD client secret.",
-												},
-												"dce_uri_key": schema.StringAttribute{
-													Optional:    true,
-													Description: "Name of the environment variable or secret that holds the Data Collection Endpoint (DCE) URI.",
-												},
-												"table": schema.StringAttribute{
-													Required:    true,
-													Description: "The name of the Log Analytics table where logs will be sent.",
-												},
-											},
-											Blocks: map[string]schema.Block{
-												"buffer": observability_pipeline.BufferOptionsSchema(),
-											},
+								},
+								Validators: []validator.List{
+									listvalidator.SizeAtMost(1),
+								},
+							},
+							"reference_table": schema.ListNestedBlock{
+								Description: "Uses a Datadog reference table to enrich logs.",
+								NestedObject: schema.NestedBlockObject{
+									Attributes: map[string]schema.Attribute{
+										"app_key_key": schema.StringAttribute{
+											Optional:    true,
+											Description: "Name of the environment variable or secret that holds the Datadog application key for the reference table.",
+										},
+										"key_field": schema.StringAttribute{
+											Required:    true,
+											Description: "Path to the field in the log event to match against the reference table.",
+										},
+										"table_id": schema.StringAttribute{
+											Required:    true,
+											Description: "The unique identifier of the reference table.",
+										},
+										"columns": schema.ListAttribute{
+											Optional:    true,
+											ElementType: types.StringType,
+											Description: "List of column names to include from the reference table. If not provided, all columns are included.",
 										},
 									},
-									"google_secops": schema.ListNestedBlock{
-										Description: "The `google_chronicle` destination sends logs to Google SecOps.",
-										NestedObject: schema.NestedBlockObject{
-											Attributes: map[string]schema.Attribute{
-												"customer_id": schema.StringAttribute{
-													Required:    true,
-													Description: "The Google SecOps customer ID.",
-												},
-												"encoding": schema.StringAttribute{
-													Required:    true,
-													Description: "The encoding format for the logs sent to Google SecOps.",
-													Validators: []validator.String{
-														stringvalidator.OneOf("json", "raw_message"),
-													},
-												},
-												"endpoint_url_key": schema.StringAttribute{
-													Optional:    true,
-													Description: "Name of the environment variable or secret that holds the Google Chronicle endpoint URL.",
-												},
-												"log_type": schema.StringAttribute{
-													Required:    true,
-													Description: "The log type metadata associated with the Google SecOps destination.",
-												},
-											},
-											Blocks: map[string]schema.Block{
-												"auth":   gcpAuthSchema(),
-												"buffer": observability_pipeline.BufferOptionsSchema(),
+								},
+								Validators: []validator.List{
+									listvalidator.SizeAtMost(1),
+								},
+							},
+						},
+					},
+				},
+				"ocsf_mapper":           observability_pipeline.OcsfMapperProcessorSchema(),
+				"datadog_tags":          observability_pipeline.DatadogTagsProcessorSchema(),
+				"custom_processor":      observability_pipeline.CustomProcessorSchema(),
+				"add_metric_tags":       observability_pipeline.AddMetricTagsProcessorSchema(),
+				"aggregate":             observability_pipeline.AggregateProcessorSchema(),
+				"rename_metric_tags":    observability_pipeline.RenameMetricTagsProcessorSchema(),
+				"tag_cardinality_limit": observability_pipeline.TagCardinalityLimitProcessorSchema(),
+				"metric_tags": schema.ListNestedBlock{
+					Description: "The `metric_tags` processor filters metrics based on their tags using Datadog tag key patterns.",
+					Validators: []validator.List{
+						listvalidator.SizeAtMost(1),
+					},
+					NestedObject: schema.NestedBlockObject{
+						Attributes: map[string]schema.Attribute{},
+						Blocks: map[string]schema.Block{
+							"rule": schema.ListNestedBlock{
+								Description: "A list of rules for filtering metric tags.",
+								Validators: []validator.List{
+									listvalidator.IsRequired(),
+									listvalidator.SizeAtMost(100),
+								},
+								NestedObject: schema.NestedBlockObject{
+									Attributes: map[string]schema.Attribute{
+										"include": schema.StringAttribute{
+											Required:    true,
+											Description: "A Datadog search query used to determine which metrics this rule targets.",
+										},
+										"mode": schema.StringAttribute{
+											Required:    true,
+											Description: "The processing mode for tag filtering.",
+											Validators: []validator.String{
+												stringvalidator.OneOf("filter"),
 											},
 										},
-									},
-									"new_relic": schema.ListNestedBlock{
-										Description: "The `new_relic` destination sends logs to the New Relic platform.",
-										NestedObject: schema.NestedBlockObject{
-											Attributes: map[string]schema.Attribute{
-												"account_id_key": schema.StringAttribute{
-													Optional:    true,
-													Description: "Name of the environment variable or secret that holds the New Relic account ID.",
-												},
-												"license_key_key": schema.StringAttribute{
-													Optional:    true,
-													Description: "Name of the environment variable or secret that holds the New Relic license key.",
-												},
-												"region": schema.StringAttribute{
-													Required:    true,
-													Description: "The New Relic region.",
-												},
+										"action": schema.StringAttribute{
+											Required:    true,
+											Description: "The action to take on tags with matching keys.",
+											Validators: []validator.String{
+												stringvalidator.OneOf("include", "exclude"),
 											},
-											Blocks: map[string]schema.Block{
-												"buffer": observability_pipeline.BufferOptionsSchema(),
+										},
+										"keys": schema.ListAttribute{
+											ElementType: types.StringType,
+											Required:    true,
+											Description: "A list of tag keys to include or exclude.",
+											Validators: []validator.List{
+												listvalidator.SizeAtLeast(1),
 											},
 										},
 									},
-									"sentinel_one": schema.ListNestedBlock{
-										Description: "The `sentinel_one` destination sends logs to SentinelOne.",
-										NestedObject: schema.NestedBlockObject{
-											Attributes: map[string]schema.Attribute{
-												"token_key": schema.StringAttribute{
-													Optional:    true,
-													Description: "Name of the environment variable or secret that holds the SentinelOne API token.",
-												},
-												"region": schema.StringAttribute{
-													Required:    true,
-													Description: "The SentinelOne region to send logs to.",
+								},
+							},
+						},
+					},
+				},
+				"generate_metrics": schema.ListNestedBlock{
+					Description: "The `generate_metrics` processor creates custom metrics from logs. The generated metrics must be routed to a metrics destination using the input `<processor-id>.metrics`.",
+					Validators: []validator.List{
+						listvalidator.SizeAtMost(1),
+					},
+					NestedObject: schema.NestedBlockObject{
+						Attributes: map[string]schema.Attribute{},
+						Blocks: map[string]schema.Block{
+							"metric": schema.ListNestedBlock{
+								Description: "Configuration for generating individual metrics.",
+								NestedObject: schema.NestedBlockObject{
+									Attributes: map[string]schema.Attribute{
+										"name": schema.StringAttribute{
+											Required:    true,
+											Description: "Name of the custom metric to be created.",
+										},
+										"include": schema.StringAttribute{
+											Required:    true,
+											Description: "Datadog filter query to match logs for metric generation.",
+										},
+										"metric_type": schema.StringAttribute{
+											Required:    true,
+											Description: "Type of metric to create.",
+										},
+										"group_by": schema.ListAttribute{
+											Optional:    true,
+											ElementType: types.StringType,
+											Description: "Optional fields used to group the metric series.",
+										},
+									},
+									Blocks: map[string]schema.Block{
+										"value": schema.ListNestedBlock{
+											Description: "Specifies how the value of the generated metric is computed.",
+											NestedObject: schema.NestedBlockObject{
+												Attributes: map[string]schema.Attribute{
+													"strategy": schema.StringAttribute{
+														Required:    true,
+														Description: "Metric value strategy: `increment_by_one` or `increment_by_field`.",
+													},
+													"field": schema.StringAttribute{
+														Optional:    true,
+														Description: "Name of the log field containing the numeric value to increment the metric by (used only for `increment_by_field`).",
+													},
 												},
 											},
-											Blocks: map[string]schema.Block{
-												"buffer": observability_pipeline.BufferOptionsSchema(),
+											Validators: []validator.List{
+												listvalidator.IsRequired(),
+												listvalidator.SizeAtMost(1),
 											},
 										},
 									},
-									"socket":                    observability_pipeline.SocketDestinationSchema(),
-									"amazon_s3":                 observability_pipeline.AmazonS3DestinationSchema(),
-									"amazon_s3_generic":         observability_pipeline.AmazonS3GenericDestinationSchema(),
-									"amazon_security_lake":      observability_pipeline.AmazonSecurityLakeDestinationSchema(),
-									"crowdstrike_next_gen_siem": observability_pipeline.CrowdStrikeNextGenSiemDestinationSchema(),
-									"databricks_zerobus":        observability_pipeline.DatabricksZerobusDestinationSchema(),
-									"splunk_hec_metrics":        observability_pipeline.SplunkHECMetricsDestinationSchema(),
-									"clickhouse":                observability_pipeline.ClickhouseDestinationSchema(),
-									"cloud_prem":                observability_pipeline.CloudPremDestinationSchema(),
-									"kafka":                     observability_pipeline.KafkaDestinationSchema(),
 								},
 							},
 						},
 					},
 				},
-				Validators: []validator.List{
-					listvalidator.IsRequired(),
-					listvalidator.SizeAtMost(1),
-				},
 			},
 		},
 	}
@@ -3110,7 +3139,11 @@ func expandPipeline(ctx context.Context, state *observabilityPipelineModel) (*da
 
 	// Processors - iterate through processor groups
 	for _, group := range state.Config[0].ProcessorGroups {
-		processorGroup := expandProcessorGroup(ctx, group)
+		processorGroup, groupDiags := expandProcessorGroup(ctx, group)
+		diags.Append(groupDiags...)
+		if groupDiags.HasError() {
+			return nil, diags
+		}
 		config.ProcessorGroups = append(config.ProcessorGroups, processorGroup)
 	}
 
@@ -3312,9 +3345,23 @@ func flattenPipeline(ctx context.Context, state *observabilityPipelineModel, res
 	}
 
 	// Process processor groups - each group may contain one or more processors
+	// Groups using the processors of a processor group template keep them in
+	// processors_json, so that changes are shown against the template.
+	priorProcessorsJSON := map[string]jsontypes.Normalized{}
+	if len(state.Config) > 0 {
+		for _, group := range state.Config[0].ProcessorGroups {
+			if group != nil && !group.ProcessorsJSON.IsNull() {
+				priorProcessorsJSON[group.Id.ValueString()] = group.ProcessorsJSON
+			}
+		}
+	}
 	for _, group := range cfg.GetProcessorGroups() {
 		flattenedGroup := flattenProcessorGroup(ctx, &group)
 		if flattenedGroup != nil {
+			if prior, ok := priorProcessorsJSON[group.GetId()]; ok {
+				flattenedGroup.Processors = nil
+				flattenedGroup.ProcessorsJSON = flattenProcessorsJSON(prior, group.GetProcessors())
+			}
 			outCfg.ProcessorGroups = append(outCfg.ProcessorGroups, flattenedGroup)
 		}
 	}
@@ -3607,6 +3654,33 @@ func flattenFilterProcessor(ctx context.Context, src *datadogV2.ObservabilityPip
 	return model
 }
 
+// flattenProcessorsJSON returns the processors_json of a processor group from
+// its processors, ignoring the fields set by the API which are not in the
+// template.
+func flattenProcessorsJSON(prior jsontypes.Normalized, processors []datadogV2.ObservabilityPipelineConfigProcessorItem) jsontypes.Normalized {
+	if processors == nil {
+		processors = []datadogV2.ObservabilityPipelineConfigProcessorItem{}
+	}
+	var templateProcessors, apiProcessors []any
+	apiJSON, err := json.Marshal(processors)
+	if err != nil || json.Unmarshal(apiJSON, &apiProcessors) != nil || json.Unmarshal([]byte(prior.ValueString()), &templateProcessors) != nil {
+		return prior
+	}
+	// Processors added or removed outside of Terraform are all shown.
+	if len(apiProcessors) != len(templateProcessors) {
+		return jsontypes.NewNormalizedValue(string(apiJSON))
+	}
+	filtered := filterToUserFields(any(templateProcessors), any(apiProcessors))
+	if reflect.DeepEqual(filtered, any(templateProcessors)) {
+		return prior
+	}
+	filteredJSON, err := json.Marshal(filtered)
+	if err != nil {
+		return prior
+	}
+	return jsontypes.NewNormalizedValue(string(filteredJSON))
+}
+
 // flattenProcessorGroup converts a processor group from API model to Terraform model
 func flattenProcessorGroup(ctx context.Context, group *datadogV2.ObservabilityPipelineConfigProcessorGroup) *processorGroupModel {
 	if group == nil {
@@ -3695,7 +3769,8 @@ func flattenProcessorGroup(ctx context.Context, group *datadogV2.ObservabilityPi
 }
 
 // expandProcessorGroup converts a processor group from Terraform model to API model
-func expandProcessorGroup(ctx context.Context, group *processorGroupModel) datadogV2.ObservabilityPipelineConfigProcessorGroup {
+func expandProcessorGroup(ctx context.Context, group *processorGroupModel) (datadogV2.ObservabilityPipelineConfigProcessorGroup, diag.Diagnostics) {
+	var diags diag.Diagnostics
 	apiGroup := datadogV2.NewObservabilityPipelineConfigProcessorGroupWithDefaults()
 
 	// Set group-level fields
@@ -3715,11 +3790,29 @@ func expandProcessorGroup(ctx context.Context, group *processorGroupModel) datad
 		items := expandProcessorTypes(ctx, processor)
 		processorItems = append(processorItems, items...)
 	}
+	// Processors of a processor group template are already in the API format
+	if !group.ProcessorsJSON.IsNull() && !group.ProcessorsJSON.IsUnknown() {
+		if err := json.Unmarshal([]byte(group.ProcessorsJSON.ValueString()), &processorItems); err != nil {
+			diags.AddError("Invalid processors_json", fmt.Sprintf("processors_json of processor group %q is not a list of processors: %s", group.Id.ValueString(), err))
+			return *apiGroup, diags
+		}
+	}
 	if len(processorItems) > 0 {
 		apiGroup.SetProcessors(processorItems)
 	}
 
-	return *apiGroup
+	return *apiGroup, diags
+}
+
+// expandProcessorsJSON converts processors to the processors_json of a
+// processor group template.
+func expandProcessorsJSON(ctx context.Context, processors []*processorModel) (string, error) {
+	processorItems := []datadogV2.ObservabilityPipelineConfigProcessorItem{}
+	for _, processor := range processors {
+		processorItems = append(processorItems, expandProcessorTypes(ctx, processor)...)
+	}
+	data, err := json.Marshal(processorItems)
+	return string(data), err
 }
 
 // expandProcessorTypes converts the processor types model to a list of processor items
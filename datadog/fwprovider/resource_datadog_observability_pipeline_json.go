@@ -0,0 +1,206 @@
+package fwprovider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+
+	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV2"
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/utils"
+)
+
+var (
+	_ resource.ResourceWithConfigure   = &observabilityPipelineJSONResource{}
+	_ resource.ResourceWithImportState = &observabilityPipelineJSONResource{}
+)
+
+type observabilityPipelineJSONResource struct {
+	Api  *datadogV2.ObservabilityPipelinesApi
+	Auth context.Context
+}
+
+type observabilityPipelineJSONModel struct {
+	ID         types.String         `tfsdk:"id"`
+	Name       types.String         `tfsdk:"name"`
+	ConfigJSON jsontypes.Normalized `tfsdk:"config_json"`
+}
+
+func NewObservabilityPipelineJSONResource() resource.Resource {
+	return &observabilityPipelineJSONResource{}
+}
+
+func (r *observabilityPipelineJSONResource) Configure(_ context.Context, request resource.ConfigureRequest, response *resource.ConfigureResponse) {
+	providerData := request.ProviderData.(*FrameworkProvider)
+	r.Api = providerData.DatadogApiInstances.GetObsPipelinesV2()
+	r.Auth = providerData.Auth
+}
+
+func (r *observabilityPipelineJSONResource) Metadata(_ context.Context, request resource.MetadataRequest, response *resource.MetadataResponse) {
+	response.TypeName = "observability_pipeline_json"
+}
+
+func (r *observabilityPipelineJSONResource) Schema(_ context.Context, _ resource.SchemaRequest, response *resource.SchemaResponse) {
+	response.Schema = schema.Schema{
+		Description: "Provides a Datadog Observability Pipeline JSON resource. This can be used to create and manage Observability Pipelines using the raw API configuration, including components not yet supported by `datadog_observability_pipeline`.",
+		Attributes: map[string]schema.Attribute{
+			"id": utils.ResourceIDAttribute(),
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "The pipeline name.",
+			},
+			"config_json": schema.StringAttribute{
+				Required:    true,
+				CustomType:  jsontypes.NormalizedType{},
+				Description: "The JSON configuration of the pipeline, as the `config` attribute of the Observability Pipelines API, with its `sources`, `processor_groups` and `destinations`. It is sent as is, so component types unknown to the provider can be used.",
+			},
+		},
+	}
+}
+
+func (r *observabilityPipelineJSONResource) ImportState(ctx context.Context, request resource.ImportStateRequest, response *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), request, response)
+}
+
+func (r *observabilityPipelineJSONResource) Create(ctx context.Context, request resource.CreateRequest, response *resource.CreateResponse) {
+	var plan observabilityPipelineJSONModel
+	response.Diagnostics.Append(request.Plan.Get(ctx, &plan)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	attributes, err := buildObservabilityPipelineJSONAttributes(&plan)
+	if err != nil {
+		response.Diagnostics.AddError("Failed to parse config_json", err.Error())
+		return
+	}
+	body := datadogV2.NewObservabilityPipelineSpecWithDefaults()
+	body.Data = *datadogV2.NewObservabilityPipelineSpecDataWithDefaults()
+	body.Data.Attributes = *attributes
+
+	res, httpResp, err := r.Api.CreatePipeline(r.Auth, *body)
+	if err != nil {
+		response.Diagnostics.Append(utils.FrameworkErrorDiag(utils.TranslateClientError(err, httpResp, "error creating pipeline"), ""))
+		return
+	}
+
+	plan.ID = types.StringValue(res.Data.GetId())
+	response.Diagnostics.Append(response.State.Set(ctx, &plan)...)
+}
+
+func (r *observabilityPipelineJSONResource) Read(ctx context.Context, request resource.ReadRequest, response *resource.ReadResponse) {
+	var state observabilityPipelineJSONModel
+	response.Diagnostics.Append(request.State.Get(ctx, &state)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	res, httpResp, err := r.Api.GetPipeline(r.Auth, state.ID.ValueString())
+	if err != nil {
+		if httpResp != nil && httpResp.StatusCode == http.StatusNotFound {
+			response.State.RemoveResource(ctx)
+			return
+		}
+		response.Diagnostics.Append(utils.FrameworkErrorDiag(utils.TranslateClientError(err, httpResp, "error reading pipeline"), ""))
+		return
+	}
+	// Unparsed objects are not checked: components unknown to the API client
+	// are kept as is, and marshalled back to their original JSON.
+	attributes := res.Data.GetAttributes()
+	state.Name = types.StringValue(attributes.GetName())
+
+	configJSON, err := json.Marshal(attributes.GetConfig())
+	if err != nil {
+		response.Diagnostics.AddError("Failed to marshal pipeline config", err.Error())
+		return
+	}
+	if state.ConfigJSON.IsNull() {
+		// Imported pipelines take the whole config.
+		state.ConfigJSON = jsontypes.NewNormalizedValue(string(configJSON))
+		response.Diagnostics.Append(response.State.Set(ctx, &state)...)
+		return
+	}
+
+	var userConfig, apiConfig any
+	if err := json.Unmarshal([]byte(state.ConfigJSON.ValueString()), &userConfig); err != nil {
+		response.Diagnostics.AddError("Failed to parse state config_json", err.Error())
+		return
+	}
+	if err := json.Unmarshal(configJSON, &apiConfig); err != nil {
+		response.Diagnostics.AddError("Failed to parse pipeline config", err.Error())
+		return
+	}
+
+	// Prevent spurious plan updates when Datadog adds default fields.
+	filtered := filterToUserFields(userConfig, apiConfig)
+	if !reflect.DeepEqual(filtered, userConfig) {
+		filteredJSON, err := json.Marshal(filtered)
+		if err != nil {
+			response.Diagnostics.AddError("Failed to marshal filtered pipeline config", err.Error())
+			return
+		}
+		state.ConfigJSON = jsontypes.NewNormalizedValue(string(filteredJSON))
+	}
+
+	response.Diagnostics.Append(response.State.Set(ctx, &state)...)
+}
+
+func (r *observabilityPipelineJSONResource) Update(ctx context.Context, request resource.UpdateRequest, response *resource.UpdateResponse) {
+	var plan observabilityPipelineJSONModel
+	response.Diagnostics.Append(request.Plan.Get(ctx, &plan)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	attributes, err := buildObservabilityPipelineJSONAttributes(&plan)
+	if err != nil {
+		response.Diagnostics.AddError("Failed to parse config_json", err.Error())
+		return
+	}
+	body := datadogV2.NewObservabilityPipelineWithDefaults()
+	body.Data = *datadogV2.NewObservabilityPipelineDataWithDefaults()
+	body.Data.SetId(plan.ID.ValueString())
+	body.Data.Attributes = *attributes
+
+	_, httpResp, err := r.Api.UpdatePipeline(r.Auth, plan.ID.ValueString(), *body)
+	if err != nil {
+		response.Diagnostics.Append(utils.FrameworkErrorDiag(utils.TranslateClientError(err, httpResp, "error updating pipeline"), ""))
+		return
+	}
+
+	response.Diagnostics.Append(response.State.Set(ctx, &plan)...)
+}
+
+func (r *observabilityPipelineJSONResource) Delete(ctx context.Context, request resource.DeleteRequest, response *resource.DeleteResponse) {
+	var state observabilityPipelineJSONModel
+	response.Diagnostics.Append(request.State.Get(ctx, &state)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	httpResp, err := r.Api.DeletePipeline(r.Auth, state.ID.ValueString())
+	if err != nil {
+		if httpResp != nil && httpResp.StatusCode == http.StatusNotFound {
+			return
+		}
+		response.Diagnostics.Append(utils.FrameworkErrorDiag(utils.TranslateClientError(err, httpResp, "error deleting pipeline"), ""))
+	}
+}
+
+// buildObservabilityPipelineJSONAttributes passes the user config through as
+// an unparsed object, so the API client sends it without dropping the fields
+// and component types it does not know.
+func buildObservabilityPipelineJSONAttributes(plan *observabilityPipelineJSONModel) (*datadogV2.ObservabilityPipelineDataAttributes, error) {
+	var userConfig map[string]interface{}
+	if err := json.Unmarshal([]byte(plan.ConfigJSON.ValueString()), &userConfig); err != nil {
+		return nil, err
+	}
+	config := datadogV2.ObservabilityPipelineConfig{UnparsedObject: userConfig}
+	return datadogV2.NewObservabilityPipelineDataAttributes(config, plan.Name.ValueString()), nil
+}
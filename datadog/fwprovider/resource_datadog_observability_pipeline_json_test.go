@@ -0,0 +1,52 @@
+package fwprovider
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV2"
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// A config using a processor type unknown to the API client.
+const testObservabilityPipelineConfigJSON = `{
+  "sources": [{"id": "agent", "type": "datadog_agent"}],
+  "processor_groups": [{
+    "id": "group",
+    "enabled": true,
+    "include": "*",
+    "inputs": ["agent"],
+    "processors": [{"id": "new", "enabled": true, "include": "*", "type": "not_yet_supported", "option": {"key": "value"}}]
+  }],
+  "destinations": [{"id": "logs", "type": "datadog_logs", "inputs": ["group"]}]
+}`
+
+func TestObservabilityPipelineJSONPassThrough(t *testing.T) {
+	t.Run("request", func(t *testing.T) {
+		attributes, err := buildObservabilityPipelineJSONAttributes(&observabilityPipelineJSONModel{
+			Name:       types.StringValue("main"),
+			ConfigJSON: jsontypes.NewNormalizedValue(testObservabilityPipelineConfigJSON),
+		})
+		require.NoError(t, err)
+		body := datadogV2.NewObservabilityPipelineSpecWithDefaults()
+		body.Data = *datadogV2.NewObservabilityPipelineSpecDataWithDefaults()
+		body.Data.Attributes = *attributes
+
+		data, err := json.Marshal(body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"data": {"type": "pipelines", "attributes": {"name": "main", "config": `+testObservabilityPipelineConfigJSON+`}}}`, string(data))
+	})
+
+	t.Run("response", func(t *testing.T) {
+		var pipeline datadogV2.ObservabilityPipeline
+		require.NoError(t, json.Unmarshal([]byte(`{"data": {"id": "abc", "type": "pipelines", "attributes": {"name": "main", "config": `+testObservabilityPipelineConfigJSON+`}}}`), &pipeline))
+		assert.Equal(t, "abc", pipeline.Data.GetId())
+
+		data, err := json.Marshal(pipeline.Data.Attributes.GetConfig())
+		require.NoError(t, err)
+		assert.JSONEq(t, testObservabilityPipelineConfigJSON, string(data))
+	})
+}
@@ -0,0 +1,125 @@
+package fwprovider
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/utils"
+)
+
+var (
+	_ resource.ResourceWithModifyPlan = &observabilityPipelineProcessorGroupResource{}
+)
+
+// observabilityPipelineProcessorGroupResource is a template of the processors
+// of a processor group, only stored in the Terraform state. Pipelines use it
+// through its processors_json attribute.
+type observabilityPipelineProcessorGroupResource struct{}
+
+type observabilityPipelineProcessorGroupModel struct {
+	ID             types.String         `tfsdk:"id"`
+	Processors     []*processorModel    `tfsdk:"processor"`
+	ProcessorsJSON jsontypes.Normalized `tfsdk:"processors_json"`
+}
+
+func NewObservabilityPipelineProcessorGroupResource() resource.Resource {
+	return &observabilityPipelineProcessorGroupResource{}
+}
+
+func (r *observabilityPipelineProcessorGroupResource) Metadata(_ context.Context, request resource.MetadataRequest, response *resource.MetadataResponse) {
+	response.TypeName = "observability_pipeline_processor_group"
+}
+
+func (r *observabilityPipelineProcessorGroupResource) Schema(_ context.Context, _ resource.SchemaRequest, response *resource.SchemaResponse) {
+	processors := processorBlock()
+	processors.Description = "The processors of the group."
+	processors.Validators = append(processors.Validators, listvalidator.IsRequired(), listvalidator.SizeAtLeast(1))
+
+	response.Schema = schema.Schema{
+		Description: "Provides a template of the processors of an Observability Pipeline processor group. This is a local helper, not a managed Datadog object: the Observability Pipelines API has no standalone processor groups that pipelines could reference by ID, so the template is only stored in the Terraform state, it is not read from Datadog and cannot be imported. Pipelines use its processors by setting the `processors_json` of their `processor_group` blocks to the `processors_json` of the template, so that a processor stack is defined once and shared by several pipelines. Changing the template updates every pipeline using it.",
+		Attributes: map[string]schema.Attribute{
+			"id": utils.ResourceIDAttribute(),
+			"processors_json": schema.StringAttribute{
+				Computed:    true,
+				CustomType:  jsontypes.NormalizedType{},
+				Description: "The processors of the group in the Observability Pipelines API format, to set as the `processors_json` of pipeline processor groups.",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"processor": processors,
+		},
+	}
+}
+
+func (r *observabilityPipelineProcessorGroupResource) ModifyPlan(ctx context.Context, request resource.ModifyPlanRequest, response *resource.ModifyPlanResponse) {
+	var processors types.List
+	response.Diagnostics.Append(request.Plan.GetAttribute(ctx, path.Root("processor"), &processors)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	// Compute the processors at plan time, so that pipelines using them show
+	// their changes in the same plan.
+	if !isFullyKnown(ctx, processors) {
+		response.Diagnostics.Append(response.Plan.SetAttribute(ctx, path.Root("processors_json"), jsontypes.NewNormalizedUnknown())...)
+		return
+	}
+	var plan observabilityPipelineProcessorGroupModel
+	response.Diagnostics.Append(request.Plan.Get(ctx, &plan)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	processorsJSON, err := expandProcessorsJSON(ctx, plan.Processors)
+	if err != nil {
+		response.Diagnostics.AddError("Failed to marshal processors", err.Error())
+		return
+	}
+	if !plan.ProcessorsJSON.IsUnknown() && plan.ProcessorsJSON.ValueString() == processorsJSON {
+		return
+	}
+	response.Diagnostics.Append(response.Plan.SetAttribute(ctx, path.Root("processors_json"), jsontypes.NewNormalizedValue(processorsJSON))...)
+}
+
+func (r *observabilityPipelineProcessorGroupResource) Create(ctx context.Context, request resource.CreateRequest, response *resource.CreateResponse) {
+	var plan observabilityPipelineProcessorGroupModel
+	response.Diagnostics.Append(request.Plan.Get(ctx, &plan)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	plan.ID = types.StringValue(uuid.New().String())
+	r.apply(ctx, &plan, response.Diagnostics.AddError)
+	response.Diagnostics.Append(response.State.Set(ctx, &plan)...)
+}
+
+func (r *observabilityPipelineProcessorGroupResource) Read(ctx context.Context, request resource.ReadRequest, response *resource.ReadResponse) {
+	// The template only exists in the Terraform state.
+}
+
+func (r *observabilityPipelineProcessorGroupResource) Update(ctx context.Context, request resource.UpdateRequest, response *resource.UpdateResponse) {
+	var plan observabilityPipelineProcessorGroupModel
+	response.Diagnostics.Append(request.Plan.Get(ctx, &plan)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	r.apply(ctx, &plan, response.Diagnostics.AddError)
+	response.Diagnostics.Append(response.State.Set(ctx, &plan)...)
+}
+
+func (r *observabilityPipelineProcessorGroupResource) Delete(ctx context.Context, request resource.DeleteRequest, response *resource.DeleteResponse) {
+	// Removing the template from the state is enough.
+}
+
+func (r *observabilityPipelineProcessorGroupResource) apply(ctx context.Context, plan *observabilityPipelineProcessorGroupModel, addError func(string, string)) {
+	processorsJSON, err := expandProcessorsJSON(ctx, plan.Processors)
+	if err != nil {
+		addError("Failed to marshal processors", err.Error())
+		return
+	}
+	plan.ProcessorsJSON = jsontypes.NewNormalizedValue(processorsJSON)
+}
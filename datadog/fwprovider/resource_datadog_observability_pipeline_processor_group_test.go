@@ -0,0 +1,61 @@
+package fwprovider
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV2"
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestObservabilityPipelineProcessorsJSON(t *testing.T) {
+	ctx := context.Background()
+	processorsJSON, err := expandProcessorsJSON(ctx, []*processorModel{{
+		Id:                 types.StringValue("parse"),
+		Enabled:            types.BoolValue(true),
+		Include:            types.StringValue("*"),
+		ParseJsonProcessor: []*parseJsonProcessorModel{{Field: types.StringValue("message")}},
+	}})
+	require.NoError(t, err)
+	assert.JSONEq(t, `[{"id": "parse", "enabled": true, "include": "*", "type": "parse_json", "field": "message"}]`, processorsJSON)
+
+	inputs, _ := types.ListValueFrom(ctx, types.StringType, []string{"agent"})
+	group, diags := expandProcessorGroup(ctx, &processorGroupModel{
+		Id:             types.StringValue("group"),
+		Enabled:        types.BoolValue(true),
+		Include:        types.StringValue("*"),
+		Inputs:         inputs,
+		ProcessorsJSON: jsontypes.NewNormalizedValue(processorsJSON),
+	})
+	require.False(t, diags.HasError(), diags)
+	require.Len(t, group.GetProcessors(), 1)
+	assert.Equal(t, "message", group.GetProcessors()[0].ObservabilityPipelineParseJSONProcessor.GetField())
+
+	template := jsontypes.NewNormalizedValue(processorsJSON)
+	t.Run("unchanged", func(t *testing.T) {
+		withDefaults := group.GetProcessors()
+		withDefaults[0].ObservabilityPipelineParseJSONProcessor.SetDisplayName("Parse JSON")
+		assert.Equal(t, template, flattenProcessorsJSON(template, withDefaults))
+	})
+
+	t.Run("changed", func(t *testing.T) {
+		var processors []datadogV2.ObservabilityPipelineConfigProcessorItem
+		require.NoError(t, json.Unmarshal([]byte(processorsJSON), &processors))
+		processors[0].ObservabilityPipelineParseJSONProcessor.SetField("payload")
+		assert.JSONEq(t, `[{"id": "parse", "enabled": true, "include": "*", "type": "parse_json", "field": "payload"}]`, flattenProcessorsJSON(template, processors).ValueString())
+	})
+
+	t.Run("added", func(t *testing.T) {
+		var processors []datadogV2.ObservabilityPipelineConfigProcessorItem
+		require.NoError(t, json.Unmarshal([]byte(processorsJSON), &processors))
+		processors = append(processors, processors[0])
+		assert.Len(t, processors, 2)
+		var flattened []any
+		require.NoError(t, json.Unmarshal([]byte(flattenProcessorsJSON(template, processors).ValueString()), &flattened))
+		assert.Len(t, flattened, 2)
+	})
+}
@@ -0,0 +1,374 @@
+package fwprovider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV2"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/utils"
+)
+
+var (
+	_ resource.ResourceWithConfigure   = &restrictionPolicyBindingResource{}
+	_ resource.ResourceWithImportState = &restrictionPolicyBindingResource{}
+	_ resource.ResourceWithModifyPlan  = &restrictionPolicyBindingResource{}
+)
+
+// restrictionPolicyBindingAttempts is the number of read-modify-write cycles
+// attempted before a concurrent modification of the policy is reported.
+const restrictionPolicyBindingAttempts = 3
+
+// restrictionPolicyMutexes serializes the read-modify-write cycles of the
+// bindings of a same policy, which are applied in parallel by Terraform.
+var restrictionPolicyMutexes sync.Map
+
+var restrictionPolicyPrincipalRegexp = regexp.MustCompile(`^(role|team|user|org):[^:\s]+$`)
+
+type restrictionPolicyBindingResource struct {
+	Api   *datadogV2.RestrictionPoliciesApi
+	Auth  context.Context
+	Roles *datadogV2.RolesApi
+	Teams *datadogV2.TeamsApi
+	Users *datadogV2.UsersApi
+}
+
+type restrictionPolicyBindingModel struct {
+	ID         types.String `tfsdk:"id"`
+	ResourceId types.String `tfsdk:"resource_id"`
+	Relation   types.String `tfsdk:"relation"`
+	Principal  types.String `tfsdk:"principal"`
+}
+
+func NewRestrictionPolicyBindingResource() resource.Resource {
+	return &restrictionPolicyBindingResource{}
+}
+
+func (r *restrictionPolicyBindingResource) Configure(_ context.Context, request resource.ConfigureRequest, response *resource.ConfigureResponse) {
+	providerData, _ := request.ProviderData.(*FrameworkProvider)
+	r.Api = providerData.DatadogApiInstances.GetRestrictionPoliciesApiV2()
+	r.Auth = providerData.Auth
+	r.Roles = providerData.DatadogApiInstances.GetRolesApiV2()
+	r.Teams = providerData.DatadogApiInstances.GetTeamsApiV2()
+	r.Users = providerData.DatadogApiInstances.GetUsersApiV2()
+}
+
+func (r *restrictionPolicyBindingResource) Metadata(_ context.Context, request resource.MetadataRequest, response *resource.MetadataResponse) {
+	response.TypeName = "restriction_policy_binding"
+}
+
+func (r *restrictionPolicyBindingResource) Schema(_ context.Context, _ resource.SchemaRequest, response *resource.SchemaResponse) {
+	response.Schema = schema.Schema{
+		Description: "Provides a Datadog resource to grant a relation on a resource to a single principal. The binding is merged with the other bindings of the restriction policy of the resource, which are left untouched. Conflicts may occur if used together with the `datadog_restriction_policy` resource for the same resource.",
+		Attributes: map[string]schema.Attribute{
+			"id": utils.ResourceIDAttribute(),
+			"resource_id": schema.StringAttribute{
+				Required:    true,
+				Description: "Identifier for the resource, formatted as resource_type:resource_id. See the `datadog_restriction_policy` resource for the supported resources.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"relation": schema.StringAttribute{
+				Required:    true,
+				Description: "The role/level of access. See this page for more details https://docs.datadoghq.com/api/latest/restriction-policies/#supported-relations-for-resources",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"principal": schema.StringAttribute{
+				Required:    true,
+				Description: "The principal, formatted as `type:id`. Supported types: `role`, `team`, `user`, and `org`. The principal is checked to exist when planning the creation of the binding.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(restrictionPolicyPrincipalRegexp, "must be formatted as `type:id`, with type one of `role`, `team`, `user` or `org`"),
+				},
+			},
+		},
+	}
+}
+
+func (r *restrictionPolicyBindingResource) ImportState(ctx context.Context, request resource.ImportStateRequest, response *resource.ImportStateResponse) {
+	resourceID, relation, principal, err := parseRestrictionPolicyBindingID(request.ID)
+	if err != nil {
+		response.Diagnostics.AddError("error parsing restriction policy binding ID", err.Error())
+		return
+	}
+	response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("resource_id"), resourceID)...)
+	response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("relation"), relation)...)
+	response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("principal"), principal)...)
+}
+
+func (r *restrictionPolicyBindingResource) ModifyPlan(ctx context.Context, request resource.ModifyPlanRequest, response *resource.ModifyPlanResponse) {
+	// The attributes all require a replacement, so the principal only has
+	// to be checked when the binding is created.
+	if !request.State.Raw.IsNull() {
+		return
+	}
+	var principal types.String
+	response.Diagnostics.Append(request.Plan.GetAttribute(ctx, path.Root("principal"), &principal)...)
+	if response.Diagnostics.HasError() || principal.IsUnknown() || principal.IsNull() {
+		return
+	}
+	if err := r.checkPrincipal(principal.ValueString()); err != nil {
+		response.Diagnostics.AddAttributeError(path.Root("principal"), "invalid principal", err.Error())
+	}
+}
+
+// checkPrincipal checks that the ID of a principal refers to an existing
+// role, team, user or to the current organization.
+func (r *restrictionPolicyBindingResource) checkPrincipal(principal string) error {
+	principalType, id, ok := strings.Cut(principal, ":")
+	if !ok {
+		return nil
+	}
+	var httpResp *http.Response
+	var err error
+	switch principalType {
+	case "role":
+		_, httpResp, err = r.Roles.GetRole(r.Auth, id)
+	case "team":
+		_, httpResp, err = r.Teams.GetTeam(r.Auth, id)
+	case "user":
+		_, httpResp, err = r.Users.GetUser(r.Auth, id)
+	case "org":
+		var resp datadogV2.UserResponse
+		resp, httpResp, err = r.Users.GetCurrentUser(r.Auth)
+		if err == nil {
+			data := resp.GetData()
+			relationships := data.GetRelationships()
+			org := relationships.GetOrg()
+			if orgData := org.GetData(); orgData.GetId() != id {
+				return fmt.Errorf("%s is not the current organization, whose ID is %s", principal, orgData.GetId())
+			}
+		}
+	default:
+		return nil
+	}
+	if err != nil {
+		if httpResp != nil && httpResp.StatusCode == 404 {
+			return fmt.Errorf("%s %s does not exist", principalType, id)
+		}
+		return utils.TranslateClientError(err, httpResp, fmt.Sprintf("error checking principal %s", principal))
+	}
+	return nil
+}
+
+func (r *restrictionPolicyBindingResource) Read(ctx context.Context, request resource.ReadRequest, response *resource.ReadResponse) {
+	var state restrictionPolicyBindingModel
+	response.Diagnostics.Append(request.State.Get(ctx, &state)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	bindings, err := r.getBindings(state.ResourceId.ValueString())
+	if err != nil {
+		response.Diagnostics.Append(utils.FrameworkErrorDiag(err, "error retrieving restriction policy"))
+		return
+	}
+	if !hasRestrictionPolicyBinding(bindings, state.Relation.ValueString(), state.Principal.ValueString()) {
+		response.State.RemoveResource(ctx)
+		return
+	}
+
+	state.ID = types.StringValue(restrictionPolicyBindingID(state.ResourceId.ValueString(), state.Relation.ValueString(), state.Principal.ValueString()))
+	response.Diagnostics.Append(response.State.Set(ctx, &state)...)
+}
+
+func (r *restrictionPolicyBindingResource) Create(ctx context.Context, request resource.CreateRequest, response *resource.CreateResponse) {
+	var plan restrictionPolicyBindingModel
+	response.Diagnostics.Append(request.Plan.Get(ctx, &plan)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	relation, principal := plan.Relation.ValueString(), plan.Principal.ValueString()
+	err := r.modifyBindings(plan.ResourceId.ValueString(), func(bindings []datadogV2.RestrictionPolicyBinding) ([]datadogV2.RestrictionPolicyBinding, bool) {
+		return addRestrictionPolicyBinding(bindings, relation, principal)
+	})
+	if err != nil {
+		response.Diagnostics.Append(utils.FrameworkErrorDiag(err, "error adding restriction policy binding"))
+		return
+	}
+
+	plan.ID = types.StringValue(restrictionPolicyBindingID(plan.ResourceId.ValueString(), relation, principal))
+	response.Diagnostics.Append(response.State.Set(ctx, &plan)...)
+}
+
+func (r *restrictionPolicyBindingResource) Update(ctx context.Context, request resource.UpdateRequest, response *resource.UpdateResponse) {
+	response.Diagnostics.AddError("Update not supported for this resource", "Restriction policy bindings should be updated by deleting the old binding and creating a new one.")
+}
+
+func (r *restrictionPolicyBindingResource) Delete(ctx context.Context, request resource.DeleteRequest, response *resource.DeleteResponse) {
+	var state restrictionPolicyBindingModel
+	response.Diagnostics.Append(request.State.Get(ctx, &state)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	relation, principal := state.Relation.ValueString(), state.Principal.ValueString()
+	err := r.modifyBindings(state.ResourceId.ValueString(), func(bindings []datadogV2.RestrictionPolicyBinding) ([]datadogV2.RestrictionPolicyBinding, bool) {
+		return removeRestrictionPolicyBinding(bindings, relation, principal)
+	})
+	if err != nil {
+		response.Diagnostics.Append(utils.FrameworkErrorDiag(err, "error removing restriction policy binding"))
+	}
+}
+
+// getBindings returns the bindings of the restriction policy of a resource,
+// which are empty when the resource has no policy.
+func (r *restrictionPolicyBindingResource) getBindings(resourceID string) ([]datadogV2.RestrictionPolicyBinding, error) {
+	resp, httpResp, err := r.Api.GetRestrictionPolicy(r.Auth, resourceID)
+	if err != nil {
+		if httpResp != nil && httpResp.StatusCode == 404 {
+			return nil, nil
+		}
+		return nil, utils.TranslateClientError(err, httpResp, "")
+	}
+	if err := utils.CheckForUnparsed(resp); err != nil {
+		return nil, err
+	}
+	data := resp.GetData()
+	attributes := data.GetAttributes()
+	return attributes.GetBindings(), nil
+}
+
+// modifyBindings applies a modification to the bindings of the restriction
+// policy of a resource with a read-modify-write cycle. The policy is read
+// again after being written: when the modification has been overwritten by a
+// concurrent write, the cycle is retried, and a conflict is reported after
+// restrictionPolicyBindingAttempts attempts.
+func (r *restrictionPolicyBindingResource) modifyBindings(resourceID string, modify func([]datadogV2.RestrictionPolicyBinding) ([]datadogV2.RestrictionPolicyBinding, bool)) error {
+	mutex, _ := restrictionPolicyMutexes.LoadOrStore(resourceID, &sync.Mutex{})
+	mutex.(*sync.Mutex).Lock()
+	defer mutex.(*sync.Mutex).Unlock()
+
+	for attempt := 0; attempt < restrictionPolicyBindingAttempts; attempt++ {
+		bindings, err := r.getBindings(resourceID)
+		if err != nil {
+			return err
+		}
+		bindings, changed := modify(bindings)
+		if !changed {
+			return nil
+		}
+		if err := r.putBindings(resourceID, bindings); err != nil {
+			return err
+		}
+
+		bindings, err = r.getBindings(resourceID)
+		if err != nil {
+			return err
+		}
+		if _, changed := modify(bindings); !changed {
+			return nil
+		}
+	}
+	return fmt.Errorf("the restriction policy of %s was modified concurrently %d times, try again later", resourceID, restrictionPolicyBindingAttempts)
+}
+
+// putBindings replaces the bindings of the restriction policy of a resource,
+// and deletes the policy when there are none left.
+func (r *restrictionPolicyBindingResource) putBindings(resourceID string, bindings []datadogV2.RestrictionPolicyBinding) error {
+	if len(bindings) == 0 {
+		httpResp, err := r.Api.DeleteRestrictionPolicy(r.Auth, resourceID)
+		if err != nil && (httpResp == nil || httpResp.StatusCode != 404) {
+			return utils.TranslateClientError(err, httpResp, "")
+		}
+		return nil
+	}
+
+	attributes := datadogV2.NewRestrictionPolicyAttributesWithDefaults()
+	attributes.SetBindings(bindings)
+	req := datadogV2.NewRestrictionPolicyUpdateRequestWithDefaults()
+	req.Data = *datadogV2.NewRestrictionPolicyWithDefaults()
+	req.Data.Id = resourceID
+	req.Data.SetAttributes(*attributes)
+	resp, httpResp, err := r.Api.UpdateRestrictionPolicy(r.Auth, resourceID, *req)
+	if err != nil {
+		return utils.TranslateClientError(err, httpResp, "")
+	}
+	return utils.CheckForUnparsed(resp)
+}
+
+func hasRestrictionPolicyBinding(bindings []datadogV2.RestrictionPolicyBinding, relation, principal string) bool {
+	for _, binding := range bindings {
+		if binding.GetRelation() == relation && slices.Contains(binding.GetPrincipals(), principal) {
+			return true
+		}
+	}
+	return false
+}
+
+// addRestrictionPolicyBinding returns the bindings with the principal added
+// to the relation, and whether they changed. The bindings are not modified.
+func addRestrictionPolicyBinding(bindings []datadogV2.RestrictionPolicyBinding, relation, principal string) ([]datadogV2.RestrictionPolicyBinding, bool) {
+	if hasRestrictionPolicyBinding(bindings, relation, principal) {
+		return bindings, false
+	}
+	result := make([]datadogV2.RestrictionPolicyBinding, 0, len(bindings)+1)
+	added := false
+	for _, binding := range bindings {
+		if !added && binding.GetRelation() == relation {
+			binding = *datadogV2.NewRestrictionPolicyBinding(append(slices.Clone(binding.GetPrincipals()), principal), relation)
+			added = true
+		}
+		result = append(result, binding)
+	}
+	if !added {
+		result = append(result, *datadogV2.NewRestrictionPolicyBinding([]string{principal}, relation))
+	}
+	return result, true
+}
+
+// removeRestrictionPolicyBinding returns the bindings without the principal
+// in the relation, and whether they changed. The bindings left without
+// principals are dropped. The bindings are not modified.
+func removeRestrictionPolicyBinding(bindings []datadogV2.RestrictionPolicyBinding, relation, principal string) ([]datadogV2.RestrictionPolicyBinding, bool) {
+	if !hasRestrictionPolicyBinding(bindings, relation, principal) {
+		return bindings, false
+	}
+	result := make([]datadogV2.RestrictionPolicyBinding, 0, len(bindings))
+	for _, binding := range bindings {
+		if binding.GetRelation() == relation {
+			principals := slices.DeleteFunc(slices.Clone(binding.GetPrincipals()), func(p string) bool { return p == principal })
+			if len(principals) == 0 {
+				continue
+			}
+			binding = *datadogV2.NewRestrictionPolicyBinding(principals, relation)
+		}
+		result = append(result, binding)
+	}
+	return result, true
+}
+
+func restrictionPolicyBindingID(resourceID, relation, principal string) string {
+	return resourceID + ":" + relation + ":" + principal
+}
+
+// parseRestrictionPolicyBindingID splits an ID formatted as
+// `<resource_type>:<resource_id>:<relation>:<principal_type>:<principal_id>`.
+// It is parsed from the end, as resource IDs may contain colons.
+func parseRestrictionPolicyBindingID(id string) (string, string, string, error) {
+	parts := strings.Split(id, ":")
+	if len(parts) < 5 {
+		return "", "", "", fmt.Errorf("expected an ID formatted as <resource_type>:<resource_id>:<relation>:<principal_type>:<principal_id>, got %q", id)
+	}
+	n := len(parts)
+	return strings.Join(parts[:n-3], ":"), parts[n-3], strings.Join(parts[n-2:], ":"), nil
+}
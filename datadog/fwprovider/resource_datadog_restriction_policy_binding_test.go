@@ -0,0 +1,54 @@
+package fwprovider
+
+import (
+	"testing"
+
+	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRestrictionPolicyBindings(t *testing.T) {
+	bindings := []datadogV2.RestrictionPolicyBinding{
+		*datadogV2.NewRestrictionPolicyBinding([]string{"role:abc"}, "editor"),
+		*datadogV2.NewRestrictionPolicyBinding([]string{"org:xyz"}, "viewer"),
+	}
+
+	added, changed := addRestrictionPolicyBinding(bindings, "editor", "team:def")
+	assert.True(t, changed)
+	assert.Equal(t, []string{"role:abc", "team:def"}, added[0].GetPrincipals())
+	assert.Equal(t, []string{"role:abc"}, bindings[0].GetPrincipals())
+
+	added, changed = addRestrictionPolicyBinding(added, "editor", "team:def")
+	assert.False(t, changed)
+	assert.Len(t, added, 2)
+
+	added, changed = addRestrictionPolicyBinding(added, "deleter", "user:ghi")
+	assert.True(t, changed)
+	require.Len(t, added, 3)
+	assert.Equal(t, "deleter", added[2].GetRelation())
+
+	removed, changed := removeRestrictionPolicyBinding(added, "deleter", "user:ghi")
+	assert.True(t, changed)
+	assert.Len(t, removed, 2)
+
+	_, changed = removeRestrictionPolicyBinding(removed, "viewer", "role:abc")
+	assert.False(t, changed)
+
+	removed, changed = removeRestrictionPolicyBinding(removed, "editor", "role:abc")
+	assert.True(t, changed)
+	assert.Equal(t, []string{"team:def"}, removed[0].GetPrincipals())
+	assert.Equal(t, []string{"role:abc", "team:def"}, added[0].GetPrincipals())
+}
+
+func TestParseRestrictionPolicyBindingID(t *testing.T) {
+	id := restrictionPolicyBindingID("dashboard:abc-def-ghi", "editor", "role:00000000-0000-1111-0000-000000000000")
+	resourceID, relation, principal, err := parseRestrictionPolicyBindingID(id)
+	require.NoError(t, err)
+	assert.Equal(t, "dashboard:abc-def-ghi", resourceID)
+	assert.Equal(t, "editor", relation)
+	assert.Equal(t, "role:00000000-0000-1111-0000-000000000000", principal)
+
+	_, _, _, err = parseRestrictionPolicyBindingID("dashboard:abc-def-ghi:editor")
+	assert.Error(t, err)
+}
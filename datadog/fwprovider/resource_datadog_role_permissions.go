@@ -0,0 +1,380 @@
+package fwprovider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV2"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/utils"
+)
+
+var (
+	_ resource.ResourceWithConfigure   = &rolePermissionsResource{}
+	_ resource.ResourceWithImportState = &rolePermissionsResource{}
+	_ resource.ResourceWithModifyPlan  = &rolePermissionsResource{}
+)
+
+// sensitivePermissions are the permissions granting access to the
+// organization settings, users or credentials.
+var sensitivePermissions = map[string]bool{
+	"org_management":        true,
+	"user_access_manage":    true,
+	"user_access_invite":    true,
+	"api_keys_write":        true,
+	"api_keys_delete":       true,
+	"org_app_keys_write":    true,
+	"user_app_keys":         true,
+	"service_account_write": true,
+}
+
+type rolePermissionsResource struct {
+	Api  *datadogV2.RolesApi
+	Auth context.Context
+	// permissions maps the permission names to their attributes, it is
+	// loaded once per resource instance.
+	permissions map[string]rolePermission
+}
+
+// rolePermission is a permission of the organization. Default permissions,
+// flagged as restricted by the API, are granted by Datadog to its managed
+// roles.
+type rolePermission struct {
+	ID        string
+	IsDefault bool
+}
+
+type rolePermissionsModel struct {
+	ID          types.String `tfsdk:"id"`
+	RoleID      types.String `tfsdk:"role_id"`
+	Permissions types.Set    `tfsdk:"permissions"`
+	Exclusive   types.Bool   `tfsdk:"exclusive"`
+	Restricted  types.Bool   `tfsdk:"restricted"`
+}
+
+func NewRolePermissionsResource() resource.Resource {
+	return &rolePermissionsResource{}
+}
+
+func (r *rolePermissionsResource) Configure(_ context.Context, request resource.ConfigureRequest, response *resource.ConfigureResponse) {
+	providerData, _ := request.ProviderData.(*FrameworkProvider)
+	r.Api = providerData.DatadogApiInstances.GetRolesApiV2()
+	r.Auth = providerData.Auth
+}
+
+func (r *rolePermissionsResource) Metadata(_ context.Context, request resource.MetadataRequest, response *resource.MetadataResponse) {
+	response.TypeName = "role_permissions"
+}
+
+func (r *rolePermissionsResource) Schema(_ context.Context, _ resource.SchemaRequest, response *resource.SchemaResponse) {
+	response.Schema = schema.Schema{
+		Description: "Provides a Datadog resource to manage the permissions of a role by permission name. Conflicts may occur if used together with the `permission` blocks of the `datadog_role` resource, so the `datadog_role` of the role must ignore changes to its `permission` blocks.",
+		Attributes: map[string]schema.Attribute{
+			"id": utils.ResourceIDAttribute(),
+			"role_id": schema.StringAttribute{
+				Required:    true,
+				Description: "ID of the role.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"permissions": schema.SetAttribute{
+				Required:    true,
+				ElementType: types.StringType,
+				Description: "Names of the permissions granted to the role, for example `logs_read_data`.",
+			},
+			"exclusive": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+				Description: "Whether the resource is authoritative for the permissions of the role. When `true`, the permissions which are not in `permissions` are revoked, except the default permissions Datadog grants to its managed roles. When `false`, only the permissions in `permissions` are managed.",
+			},
+			"restricted": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+				Description: "Whether to warn at plan time when sensitive permissions, granting access to the organization settings, users or credentials such as `org_management` or `api_keys_write`, are granted. Set to `false` once the grants are reviewed.",
+			},
+		},
+	}
+}
+
+func (r *rolePermissionsResource) ImportState(ctx context.Context, request resource.ImportStateRequest, response *resource.ImportStateResponse) {
+	response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("role_id"), request.ID)...)
+	response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("exclusive"), true)...)
+	response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("restricted"), true)...)
+}
+
+func (r *rolePermissionsResource) ModifyPlan(ctx context.Context, request resource.ModifyPlanRequest, response *resource.ModifyPlanResponse) {
+	var plan rolePermissionsModel
+	response.Diagnostics.Append(request.Plan.Get(ctx, &plan)...)
+	if response.Diagnostics.HasError() || plan.Permissions.IsUnknown() {
+		return
+	}
+	names, diags := rolePermissionNames(ctx, plan.Permissions)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	permissions, err := r.getPermissions()
+	if err != nil {
+		response.Diagnostics.Append(utils.FrameworkErrorDiag(err, "error listing permissions"))
+		return
+	}
+	var unknown, sensitive []string
+	for _, name := range names {
+		if _, ok := permissions[name]; !ok {
+			unknown = append(unknown, name)
+		}
+		if sensitivePermissions[name] {
+			sensitive = append(sensitive, name)
+		}
+	}
+	if len(unknown) > 0 {
+		response.Diagnostics.AddAttributeError(path.Root("permissions"), "Unknown permissions", fmt.Sprintf("permissions %s do not exist, the `datadog_permissions` data source lists the available permissions", strings.Join(unknown, ", ")))
+	}
+	if len(sensitive) > 0 && plan.Restricted.ValueBool() {
+		response.Diagnostics.AddAttributeWarning(path.Root("permissions"), "Sensitive permissions granted", fmt.Sprintf("the role is granted the sensitive permissions %s. Set `restricted` to `false` to acknowledge them.", strings.Join(sensitive, ", ")))
+	}
+}
+
+func (r *rolePermissionsResource) Read(ctx context.Context, request resource.ReadRequest, response *resource.ReadResponse) {
+	var state rolePermissionsModel
+	response.Diagnostics.Append(request.State.Get(ctx, &state)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	previous, diags := rolePermissionNames(ctx, state.Permissions)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	current, httpResp, err := r.listRolePermissions(state.RoleID.ValueString())
+	if err != nil {
+		if httpResp != nil && httpResp.StatusCode == 404 {
+			response.State.RemoveResource(ctx)
+			return
+		}
+		response.Diagnostics.Append(utils.FrameworkErrorDiag(err, "error listing role permissions"))
+		return
+	}
+
+	state.ID = state.RoleID
+	names := readRolePermissions(current, previous, state.Exclusive.ValueBool())
+	response.Diagnostics.Append(setRolePermissionNames(ctx, &state, names)...)
+	response.Diagnostics.Append(response.State.Set(ctx, &state)...)
+}
+
+func (r *rolePermissionsResource) Create(ctx context.Context, request resource.CreateRequest, response *resource.CreateResponse) {
+	var plan rolePermissionsModel
+	response.Diagnostics.Append(request.Plan.Get(ctx, &plan)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	response.Diagnostics.Append(r.apply(ctx, &plan, nil)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	response.Diagnostics.Append(response.State.Set(ctx, &plan)...)
+}
+
+func (r *rolePermissionsResource) Update(ctx context.Context, request resource.UpdateRequest, response *resource.UpdateResponse) {
+	var plan, state rolePermissionsModel
+	response.Diagnostics.Append(request.Plan.Get(ctx, &plan)...)
+	response.Diagnostics.Append(request.State.Get(ctx, &state)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	previous, diags := rolePermissionNames(ctx, state.Permissions)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	response.Diagnostics.Append(r.apply(ctx, &plan, previous)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	response.Diagnostics.Append(response.State.Set(ctx, &plan)...)
+}
+
+func (r *rolePermissionsResource) Delete(ctx context.Context, request resource.DeleteRequest, response *resource.DeleteResponse) {
+	var state rolePermissionsModel
+	response.Diagnostics.Append(request.State.Get(ctx, &state)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	names, diags := rolePermissionNames(ctx, state.Permissions)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	roleID := state.RoleID.ValueString()
+	current, httpResp, err := r.listRolePermissions(roleID)
+	if err != nil {
+		if httpResp != nil && httpResp.StatusCode == 404 {
+			return
+		}
+		response.Diagnostics.Append(utils.FrameworkErrorDiag(err, "error listing role permissions"))
+		return
+	}
+	for _, name := range names {
+		if permission, ok := current[name]; ok {
+			response.Diagnostics.Append(r.revoke(roleID, name, permission.ID)...)
+		}
+	}
+}
+
+// apply grants the planned permissions to the role and revokes the other
+// permissions it manages. previous are the permissions of the prior state.
+func (r *rolePermissionsResource) apply(ctx context.Context, plan *rolePermissionsModel, previous []string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	desired, d := rolePermissionNames(ctx, plan.Permissions)
+	diags.Append(d...)
+	if diags.HasError() {
+		return diags
+	}
+	permissions, err := r.getPermissions()
+	if err != nil {
+		diags.Append(utils.FrameworkErrorDiag(err, "error listing permissions"))
+		return diags
+	}
+	roleID := plan.RoleID.ValueString()
+	current, _, err := r.listRolePermissions(roleID)
+	if err != nil {
+		diags.Append(utils.FrameworkErrorDiag(err, "error listing role permissions"))
+		return diags
+	}
+
+	grant, revoke := diffRolePermissions(desired, previous, current, plan.Exclusive.ValueBool())
+	for _, name := range grant {
+		permission, ok := permissions[name]
+		if !ok {
+			diags.AddError("Unknown permission", fmt.Sprintf("permission %s does not exist", name))
+			continue
+		}
+		if _, httpResp, err := r.Api.AddPermissionToRole(r.Auth, roleID, buildRelationshipToPermission(permission.ID)); err != nil {
+			diags.Append(utils.FrameworkErrorDiag(utils.TranslateClientError(err, httpResp, ""), "error granting permission "+name))
+		}
+	}
+	for _, name := range revoke {
+		diags.Append(r.revoke(roleID, name, current[name].ID)...)
+	}
+
+	plan.ID = plan.RoleID
+	return diags
+}
+
+func (r *rolePermissionsResource) revoke(roleID, name, permissionID string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if _, httpResp, err := r.Api.RemovePermissionFromRole(r.Auth, roleID, buildRelationshipToPermission(permissionID)); err != nil {
+		diags.Append(utils.FrameworkErrorDiag(utils.TranslateClientError(err, httpResp, ""), "error revoking permission "+name))
+	}
+	return diags
+}
+
+func buildRelationshipToPermission(permissionID string) datadogV2.RelationshipToPermission {
+	data := datadogV2.NewRelationshipToPermissionDataWithDefaults()
+	data.SetId(permissionID)
+	relationship := datadogV2.NewRelationshipToPermissionWithDefaults()
+	relationship.SetData(*data)
+	return *relationship
+}
+
+// getPermissions returns the permissions of the organization by name.
+func (r *rolePermissionsResource) getPermissions() (map[string]rolePermission, error) {
+	if r.permissions != nil {
+		return r.permissions, nil
+	}
+	res, httpResp, err := r.Api.ListPermissions(r.Auth)
+	if err != nil {
+		return nil, utils.TranslateClientError(err, httpResp, "")
+	}
+	r.permissions = rolePermissionsByName(res.GetData())
+	return r.permissions, nil
+}
+
+// listRolePermissions returns the permissions granted to a role by name.
+func (r *rolePermissionsResource) listRolePermissions(roleID string) (map[string]rolePermission, *http.Response, error) {
+	res, httpResp, err := r.Api.ListRolePermissions(r.Auth, roleID)
+	if err != nil {
+		return nil, httpResp, err
+	}
+	return rolePermissionsByName(res.GetData()), httpResp, nil
+}
+
+func rolePermissionsByName(data []datadogV2.Permission) map[string]rolePermission {
+	permissions := make(map[string]rolePermission, len(data))
+	for _, permission := range data {
+		attributes := permission.GetAttributes()
+		permissions[attributes.GetName()] = rolePermission{ID: permission.GetId(), IsDefault: attributes.GetRestricted()}
+	}
+	return permissions
+}
+
+// readRolePermissions returns the permissions of the role tracked in the
+// state. Exclusive resources track all the permissions of the role except the
+// default ones they don't manage, others only the permissions they manage.
+func readRolePermissions(current map[string]rolePermission, previous []string, exclusive bool) []string {
+	managed := make(map[string]bool, len(previous))
+	for _, name := range previous {
+		managed[name] = true
+	}
+	var names []string
+	for name, permission := range current {
+		if managed[name] || (exclusive && !permission.IsDefault) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// diffRolePermissions returns the permissions to grant and to revoke to move
+// the role from its current permissions to the desired ones.
+func diffRolePermissions(desired, previous []string, current map[string]rolePermission, exclusive bool) ([]string, []string) {
+	wanted := make(map[string]bool, len(desired))
+	var grant []string
+	for _, name := range desired {
+		wanted[name] = true
+		if _, ok := current[name]; !ok {
+			grant = append(grant, name)
+		}
+	}
+	var revoke []string
+	for _, name := range readRolePermissions(current, previous, exclusive) {
+		if !wanted[name] {
+			revoke = append(revoke, name)
+		}
+	}
+	sort.Strings(grant)
+	return grant, revoke
+}
+
+func rolePermissionNames(ctx context.Context, set types.Set) ([]string, diag.Diagnostics) {
+	var names []string
+	if set.IsNull() || set.IsUnknown() {
+		return names, nil
+	}
+	diags := set.ElementsAs(ctx, &names, false)
+	return names, diags
+}
+
+func setRolePermissionNames(ctx context.Context, state *rolePermissionsModel, names []string) diag.Diagnostics {
+	permissions, diags := types.SetValueFrom(ctx, types.StringType, names)
+	state.Permissions = permissions
+	return diags
+}
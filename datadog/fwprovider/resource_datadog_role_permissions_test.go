@@ -0,0 +1,37 @@
+package fwprovider
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRolePermissionsDiff(t *testing.T) {
+	current := map[string]rolePermission{
+		"logs_read_data":  {ID: "1"},
+		"dashboards_read": {ID: "2", IsDefault: true},
+		"monitors_write":  {ID: "3"},
+		"org_management":  {ID: "4"},
+	}
+
+	t.Run("exclusive", func(t *testing.T) {
+		assert.Equal(t, []string{"logs_read_data", "monitors_write", "org_management"}, readRolePermissions(current, nil, true))
+		assert.Equal(t, []string{"dashboards_read", "logs_read_data", "monitors_write", "org_management"}, readRolePermissions(current, []string{"dashboards_read"}, true))
+
+		grant, revoke := diffRolePermissions([]string{"logs_read_data", "apm_read"}, nil, current, true)
+		assert.Equal(t, []string{"apm_read"}, grant)
+		assert.Equal(t, []string{"monitors_write", "org_management"}, revoke)
+
+		// Default permissions are only revoked once managed by the resource.
+		_, revoke = diffRolePermissions([]string{"logs_read_data"}, []string{"dashboards_read"}, current, true)
+		assert.Equal(t, []string{"dashboards_read", "monitors_write", "org_management"}, revoke)
+	})
+
+	t.Run("additive", func(t *testing.T) {
+		assert.Equal(t, []string{"monitors_write"}, readRolePermissions(current, []string{"monitors_write", "apm_read"}, false))
+
+		grant, revoke := diffRolePermissions([]string{"logs_read_data", "apm_read"}, []string{"logs_read_data", "monitors_write"}, current, false)
+		assert.Equal(t, []string{"apm_read"}, grant)
+		assert.Equal(t, []string{"monitors_write"}, revoke)
+	})
+}
@@ -0,0 +1,233 @@
+package fwprovider
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV2"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/utils"
+)
+
+var (
+	_ resource.ResourceWithConfigure   = &roleUsersResource{}
+	_ resource.ResourceWithImportState = &roleUsersResource{}
+	_ resource.ResourceWithModifyPlan  = &roleUsersResource{}
+)
+
+type roleUsersResource struct {
+	Api   *datadogV2.RolesApi
+	Auth  context.Context
+	Users *datadogV2.UsersApi
+}
+
+type roleUsersModel struct {
+	ID        types.String `tfsdk:"id"`
+	RoleID    types.String `tfsdk:"role_id"`
+	Users     types.Set    `tfsdk:"users"`
+	Exclusive types.Bool   `tfsdk:"exclusive"`
+}
+
+func NewRoleUsersResource() resource.Resource {
+	return &roleUsersResource{}
+}
+
+func (r *roleUsersResource) Configure(_ context.Context, request resource.ConfigureRequest, response *resource.ConfigureResponse) {
+	providerData, _ := request.ProviderData.(*FrameworkProvider)
+	r.Api = providerData.DatadogApiInstances.GetRolesApiV2()
+	r.Auth = providerData.Auth
+	r.Users = providerData.DatadogApiInstances.GetUsersApiV2()
+}
+
+func (r *roleUsersResource) Metadata(_ context.Context, request resource.MetadataRequest, response *resource.MetadataResponse) {
+	response.TypeName = "role_users"
+}
+
+func (r *roleUsersResource) Schema(_ context.Context, _ resource.SchemaRequest, response *resource.SchemaResponse) {
+	response.Schema = schema.Schema{
+		Description: "Provides a Datadog resource to manage the users of a role by email. Conflicts may occur if used together with the `datadog_user_role` resource for the same role, or with the `roles` attribute of the `datadog_user` and `datadog_service_account` resources. The roles API has no batch endpoint, so applying the resource makes one request per added or removed user, one at a time. Each request is retried as configured by the `http_client_retry_*` provider settings, and the apply stops after 5 failed requests.",
+		Attributes: map[string]schema.Attribute{
+			"id": utils.ResourceIDAttribute(),
+			"role_id": schema.StringAttribute{
+				Required:    true,
+				Description: "ID of the role.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"users": userSetAttribute("Emails of the users of the role."),
+			"exclusive": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+				Description: "Whether the resource is authoritative for the users of the role. When `true`, the users which are not in `users` are removed from the role. When `false`, only the users in `users` are managed.",
+			},
+		},
+	}
+}
+
+func (r *roleUsersResource) ImportState(ctx context.Context, request resource.ImportStateRequest, response *resource.ImportStateResponse) {
+	response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("role_id"), request.ID)...)
+	response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("exclusive"), true)...)
+}
+
+func (r *roleUsersResource) ModifyPlan(ctx context.Context, request resource.ModifyPlanRequest, response *resource.ModifyPlanResponse) {
+	response.Diagnostics.Append(checkUserSetPlan(ctx, r.Auth, r.Users, request, path.Root("users"))...)
+}
+
+func (r *roleUsersResource) Read(ctx context.Context, request resource.ReadRequest, response *resource.ReadResponse) {
+	var state roleUsersModel
+	response.Diagnostics.Append(request.State.Get(ctx, &state)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	previous, diags := userSetEmails(ctx, state.Users)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	current, httpResp, err := listRoleUsers(r.Auth, r.Api, state.RoleID.ValueString())
+	if err != nil {
+		if httpResp != nil && httpResp.StatusCode == 404 {
+			response.State.RemoveResource(ctx)
+			return
+		}
+		response.Diagnostics.Append(utils.FrameworkErrorDiag(err, "error listing role users"))
+		return
+	}
+
+	state.ID = state.RoleID
+	state.Users, diags = types.SetValueFrom(ctx, types.StringType, readUserSet(current, previous, state.Exclusive.ValueBool()))
+	response.Diagnostics.Append(diags...)
+	response.Diagnostics.Append(response.State.Set(ctx, &state)...)
+}
+
+func (r *roleUsersResource) Create(ctx context.Context, request resource.CreateRequest, response *resource.CreateResponse) {
+	var plan roleUsersModel
+	response.Diagnostics.Append(request.Plan.Get(ctx, &plan)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	response.Diagnostics.Append(r.apply(ctx, &plan, types.SetNull(types.StringType))...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	response.Diagnostics.Append(response.State.Set(ctx, &plan)...)
+}
+
+func (r *roleUsersResource) Update(ctx context.Context, request resource.UpdateRequest, response *resource.UpdateResponse) {
+	var plan, state roleUsersModel
+	response.Diagnostics.Append(request.Plan.Get(ctx, &plan)...)
+	response.Diagnostics.Append(request.State.Get(ctx, &state)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	response.Diagnostics.Append(r.apply(ctx, &plan, state.Users)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	response.Diagnostics.Append(response.State.Set(ctx, &plan)...)
+}
+
+func (r *roleUsersResource) Delete(ctx context.Context, request resource.DeleteRequest, response *resource.DeleteResponse) {
+	var state roleUsersModel
+	response.Diagnostics.Append(request.State.Get(ctx, &state)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	emails, diags := userSetEmails(ctx, state.Users)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	roleID := state.RoleID.ValueString()
+	current, httpResp, err := listRoleUsers(r.Auth, r.Api, roleID)
+	if err != nil {
+		if httpResp != nil && httpResp.StatusCode == 404 {
+			return
+		}
+		response.Diagnostics.Append(utils.FrameworkErrorDiag(err, "error listing role users"))
+		return
+	}
+	response.Diagnostics.Append(applyUserSet(r.roleUsers(roleID), r.Auth, r.Users, current, nil, emails, false)...)
+}
+
+func (r *roleUsersResource) apply(ctx context.Context, plan *roleUsersModel, previousUsers types.Set) diag.Diagnostics {
+	desired, diags := userSetEmails(ctx, plan.Users)
+	previous, d := userSetEmails(ctx, previousUsers)
+	diags.Append(d...)
+	if diags.HasError() {
+		return diags
+	}
+	roleID := plan.RoleID.ValueString()
+	current, _, err := listRoleUsers(r.Auth, r.Api, roleID)
+	if err != nil {
+		diags.Append(utils.FrameworkErrorDiag(err, "error listing role users"))
+		return diags
+	}
+	diags.Append(applyUserSet(r.roleUsers(roleID), r.Auth, r.Users, current, desired, previous, plan.Exclusive.ValueBool())...)
+	plan.ID = plan.RoleID
+	return diags
+}
+
+func (r *roleUsersResource) roleUsers(roleID string) userSetOps {
+	return userSetOps{
+		add:    func(userID string) error { return addRoleUser(r.Auth, r.Api, roleID, userID) },
+		remove: func(userID string) error { return removeRoleUser(r.Auth, r.Api, roleID, userID) },
+	}
+}
+
+// listRoleUsers returns the users of a role by lowercase email.
+func listRoleUsers(auth context.Context, api *datadogV2.RolesApi, roleID string) (map[string]userSetMember, *http.Response, error) {
+	pageSize := int64(100)
+	users := map[string]userSetMember{}
+	for pageNumber := int64(0); ; pageNumber++ {
+		resp, httpResp, err := api.ListRoleUsers(auth, roleID, *datadogV2.NewListRoleUsersOptionalParameters().
+			WithPageSize(pageSize).
+			WithPageNumber(pageNumber))
+		if err != nil {
+			return nil, httpResp, err
+		}
+		for _, user := range resp.GetData() {
+			attributes := user.GetAttributes()
+			email := attributes.GetEmail()
+			users[strings.ToLower(email)] = userSetMember{ID: user.GetId(), Email: email}
+		}
+		if int64(len(resp.GetData())) < pageSize {
+			return users, httpResp, nil
+		}
+	}
+}
+
+func addRoleUser(auth context.Context, api *datadogV2.RolesApi, roleID, userID string) error {
+	body := datadogV2.RelationshipToUser{
+		Data: *datadogV2.NewRelationshipToUserDataWithDefaults(),
+	}
+	body.Data.Id = userID
+	if _, httpResp, err := api.AddUserToRole(auth, roleID, body); err != nil {
+		return utils.TranslateClientError(err, httpResp, "")
+	}
+	return nil
+}
+
+func removeRoleUser(auth context.Context, api *datadogV2.RolesApi, roleID, userID string) error {
+	body := datadogV2.RelationshipToUser{
+		Data: *datadogV2.NewRelationshipToUserDataWithDefaults(),
+	}
+	body.Data.Id = userID
+	_, httpResp, err := api.RemoveUserFromRole(auth, roleID, body)
+	if err != nil && (httpResp == nil || httpResp.StatusCode != 404) {
+		return utils.TranslateClientError(err, httpResp, "")
+	}
+	return nil
+}
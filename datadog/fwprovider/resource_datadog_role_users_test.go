@@ -0,0 +1,116 @@
+package fwprovider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/DataDog/datadog-api-client-go/v2/api/datadog"
+	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV2"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoleUsersImportState(t *testing.T) {
+	ctx := context.Background()
+	r := NewRoleUsersResource().(*roleUsersResource)
+	schemaResp := resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	response := resource.ImportStateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)},
+	}
+	r.ImportState(ctx, resource.ImportStateRequest{ID: "00000000-0000-1111-0000-000000000000"}, &response)
+	require.False(t, response.Diagnostics.HasError(), response.Diagnostics)
+
+	var roleID types.String
+	var exclusive types.Bool
+	response.Diagnostics.Append(response.State.GetAttribute(ctx, path.Root("role_id"), &roleID)...)
+	response.Diagnostics.Append(response.State.GetAttribute(ctx, path.Root("exclusive"), &exclusive)...)
+	require.False(t, response.Diagnostics.HasError(), response.Diagnostics)
+	assert.Equal(t, "00000000-0000-1111-0000-000000000000", roleID.ValueString())
+	assert.True(t, exclusive.ValueBool())
+}
+
+func TestRoleUsersApply(t *testing.T) {
+	var calls []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		call := r.Method + " " + r.URL.Path
+		if r.Method == http.MethodPost || r.Method == http.MethodDelete {
+			var body datadogV2.RelationshipToUser
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			call += " " + body.Data.Id
+		}
+		calls = append(calls, call)
+		w.Header().Set("Content-Type", "application/json")
+		switch call {
+		case "GET /api/v2/roles/role-1/users":
+			fmt.Fprint(w, `{"data": [
+				{"id": "u-alice", "type": "users", "attributes": {"email": "Alice@example.com"}},
+				{"id": "u-bob", "type": "users", "attributes": {"email": "bob@example.com"}},
+				{"id": "u-carol", "type": "users", "attributes": {"email": "carol@example.com"}}
+			]}`)
+		case "GET /api/v2/users":
+			fmt.Fprintf(w, `{"data": [{"id": "u-dave", "type": "users", "attributes": {"email": %q}}]}`, r.URL.Query().Get("filter"))
+		case "POST /api/v2/roles/role-1/users u-dave", "DELETE /api/v2/roles/role-1/users u-bob", "DELETE /api/v2/roles/role-1/users u-carol":
+			fmt.Fprint(w, `{"data": []}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	config := datadog.NewConfiguration()
+	config.Servers = datadog.ServerConfigurations{{URL: server.URL}}
+	client := datadog.NewAPIClient(config)
+	r := &roleUsersResource{Api: datadogV2.NewRolesApi(client), Auth: context.Background(), Users: datadogV2.NewUsersApi(client)}
+	ctx := context.Background()
+
+	newModel := func(exclusive bool, emails ...string) *roleUsersModel {
+		users, _ := types.SetValueFrom(ctx, types.StringType, emails)
+		return &roleUsersModel{RoleID: types.StringValue("role-1"), Users: users, Exclusive: types.BoolValue(exclusive)}
+	}
+
+	t.Run("exclusive", func(t *testing.T) {
+		calls = nil
+		plan := newModel(true, "alice@example.com", "dave@example.com")
+		diags := r.apply(ctx, plan, types.SetNull(types.StringType))
+		assert.False(t, diags.HasError(), diags)
+		assert.Equal(t, "role-1", plan.ID.ValueString())
+		assert.ElementsMatch(t, []string{
+			"GET /api/v2/roles/role-1/users",
+			"GET /api/v2/users",
+			"POST /api/v2/roles/role-1/users u-dave",
+			"DELETE /api/v2/roles/role-1/users u-bob",
+			"DELETE /api/v2/roles/role-1/users u-carol",
+		}, calls)
+	})
+
+	t.Run("additive", func(t *testing.T) {
+		calls = nil
+		previous := newModel(false, "bob@example.com", "dave@example.com")
+		diags := r.apply(ctx, newModel(false, "alice@example.com", "dave@example.com"), previous.Users)
+		assert.False(t, diags.HasError(), diags)
+		assert.ElementsMatch(t, []string{
+			"GET /api/v2/roles/role-1/users",
+			"GET /api/v2/users",
+			"POST /api/v2/roles/role-1/users u-dave",
+			"DELETE /api/v2/roles/role-1/users u-bob",
+		}, calls)
+	})
+
+	t.Run("unknown role", func(t *testing.T) {
+		plan := newModel(true, "alice@example.com")
+		plan.RoleID = types.StringValue("role-2")
+		diags := r.apply(ctx, plan, types.SetNull(types.StringType))
+		assert.True(t, diags.HasError())
+	})
+}
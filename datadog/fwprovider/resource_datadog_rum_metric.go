@@ -11,9 +11,11 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/utils"
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/validators"
 )
 
 var (
@@ -118,6 +120,7 @@ func (r *rumMetricResource) Schema(_ context.Context, _ resource.SchemaRequest,
 					"query": schema.StringAttribute{
 						Description: "The search query. Follows RUM search syntax.",
 						Optional:    true,
+						Validators:  []validator.String{validators.SearchQueryValidator()},
 					},
 				},
 				PlanModifiers: []planmodifier.Object{
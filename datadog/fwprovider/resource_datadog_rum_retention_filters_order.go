@@ -8,16 +8,19 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/utils"
 )
 
 var (
-	_ resource.ResourceWithConfigure   = &rumRetentionFiltersOrderResource{}
-	_ resource.ResourceWithImportState = &rumRetentionFiltersOrderResource{}
+	_ resource.ResourceWithConfigure      = &rumRetentionFiltersOrderResource{}
+	_ resource.ResourceWithImportState    = &rumRetentionFiltersOrderResource{}
+	_ resource.ResourceWithValidateConfig = &rumRetentionFiltersOrderResource{}
 )
 
 type rumRetentionFiltersOrderResource struct {
@@ -29,6 +32,8 @@ type rumRetentionFiltersOrderResourceModel struct {
 	ID                types.String   `tfsdk:"id"`
 	ApplicationID     types.String   `tfsdk:"application_id"`
 	RetentionFilterID []types.String `tfsdk:"retention_filter_ids"`
+	Partial           types.Bool     `tfsdk:"partial"`
+	After             types.String   `tfsdk:"after"`
 }
 
 func NewRumRetentionFiltersOrderResource() resource.Resource {
@@ -63,10 +68,24 @@ func (r *rumRetentionFiltersOrderResource) Schema(_ context.Context, _ resource.
 				ElementType: types.StringType,
 				Required:    true,
 			},
+			"partial": schema.BoolAttribute{
+				Description: utils.PartialOrderDescription("retention_filter_ids", "retention filters"),
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"after": schema.StringAttribute{
+				Description: utils.PartialOrderAfterDescription("ID of the retention filter", "retention filters"),
+				Optional:    true,
+			},
 		},
 	}
 }
 
+func (r *rumRetentionFiltersOrderResource) ValidateConfig(ctx context.Context, request resource.ValidateConfigRequest, response *resource.ValidateConfigResponse) {
+	validatePartialOrderConfig(ctx, request.Config, &response.Diagnostics)
+}
+
 func (r *rumRetentionFiltersOrderResource) ImportState(ctx context.Context, request resource.ImportStateRequest, response *resource.ImportStateResponse) {
 	response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("application_id"), request.ID)...)
 }
@@ -92,9 +111,9 @@ func (r *rumRetentionFiltersOrderResource) Read(ctx context.Context, request res
 		return
 	}
 
-	retentionFilterIds := make([]types.String, len(resp.GetData()))
+	retentionFilterIds := make([]string, len(resp.GetData()))
 	for i, retentionFilter := range resp.GetData() {
-		retentionFilterIds[i] = types.StringValue(*retentionFilter.Id)
+		retentionFilterIds[i] = retentionFilter.GetId()
 	}
 
 	r.updateState(&state, retentionFilterIds)
@@ -148,26 +167,47 @@ func (r *rumRetentionFiltersOrderResource) orderRetentionFiltersAndUpdateState(s
 		return
 	}
 
-	retentionFilterIds := make([]types.String, len(resp.GetData()))
+	retentionFilterIds := make([]string, len(resp.GetData()))
 	for i, retentionFilter := range resp.GetData() {
-		retentionFilterIds[i] = types.StringValue(retentionFilter.Id)
+		retentionFilterIds[i] = retentionFilter.Id
 	}
 
 	r.updateState(state, retentionFilterIds)
 }
 
-func (r *rumRetentionFiltersOrderResource) updateState(state *rumRetentionFiltersOrderResourceModel, retentionFilterIds []types.String) {
-	state.RetentionFilterID = retentionFilterIds
+func (r *rumRetentionFiltersOrderResource) updateState(state *rumRetentionFiltersOrderResourceModel, retentionFilterIds []string) {
+	state.RetentionFilterID, state.After = partialOrderState(state.Partial, state.After, state.RetentionFilterID, retentionFilterIds)
+	if state.Partial.IsNull() {
+		state.Partial = types.BoolValue(false)
+	}
 }
 
 func (r *rumRetentionFiltersOrderResource) buildRetentionFiltersOrderRequestBody(state *rumRetentionFiltersOrderResourceModel) (*datadogV2.RumRetentionFiltersOrderRequest, diag.Diagnostics) {
 	diags := diag.Diagnostics{}
 	orderReq := datadogV2.NewRumRetentionFiltersOrderRequestWithDefaults()
 
-	dataList := make([]datadogV2.RumRetentionFiltersOrderData, len(state.RetentionFilterID))
-	for i, id := range state.RetentionFilterID {
+	ids := stringValues(state.RetentionFilterID)
+	if state.Partial.ValueBool() {
+		resp, _, err := r.Api.ListRetentionFilters(r.Auth, state.ApplicationID.ValueString())
+		if err != nil {
+			diags.Append(utils.FrameworkErrorDiag(err, "error listing RumRetentionFilters"))
+			return nil, diags
+		}
+		current := make([]string, len(resp.GetData()))
+		for i, retentionFilter := range resp.GetData() {
+			current[i] = retentionFilter.GetId()
+		}
+		ids, err = utils.MergePartialOrder(current, ids, state.After.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("retention_filter_ids"), "error merging retention filters order", err.Error())
+			return nil, diags
+		}
+	}
+
+	dataList := make([]datadogV2.RumRetentionFiltersOrderData, len(ids))
+	for i, id := range ids {
 		dataList[i] = datadogV2.RumRetentionFiltersOrderData{
-			Id:   id.ValueString(),
+			Id:   id,
 			Type: "retention_filters",
 		}
 	}
@@ -175,3 +215,43 @@ func (r *rumRetentionFiltersOrderResource) buildRetentionFiltersOrderRequestBody
 	orderReq.SetData(dataList)
 	return orderReq, diags
 }
+
+// validatePartialOrderConfig rejects `after` outside of the partial mode of
+// the order resources.
+func validatePartialOrderConfig(ctx context.Context, config tfsdk.Config, diags *diag.Diagnostics) {
+	var partial types.Bool
+	var after types.String
+	diags.Append(config.GetAttribute(ctx, path.Root("partial"), &partial)...)
+	diags.Append(config.GetAttribute(ctx, path.Root("after"), &after)...)
+	if after.IsNull() || partial.IsUnknown() || partial.ValueBool() {
+		return
+	}
+	diags.AddAttributeError(path.Root("after"), "invalid attribute combination", "`after` can only be set when `partial` is `true`")
+}
+
+// partialOrderState returns the order and the `after` attribute to store in
+// the state of an order resource. In partial mode, only the managed IDs and
+// the IDs splitting them are kept, and `after`, when set, is updated to the ID placed before them so that
+// moves of the block are reported as drift.
+func partialOrderState(partial types.Bool, after types.String, managed []types.String, order []string) ([]types.String, types.String) {
+	if partial.ValueBool() {
+		var predecessor string
+		order, predecessor = utils.ManagedOrder(order, stringValues(managed))
+		if !after.IsNull() {
+			after = types.StringValue(predecessor)
+		}
+	}
+	ids := make([]types.String, len(order))
+	for i, id := range order {
+		ids[i] = types.StringValue(id)
+	}
+	return ids, after
+}
+
+func stringValues(values []types.String) []string {
+	result := make([]string, len(values))
+	for i, value := range values {
+		result[i] = value.ValueString()
+	}
+	return result
+}
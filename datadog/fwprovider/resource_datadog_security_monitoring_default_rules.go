@@ -0,0 +1,493 @@
+package fwprovider
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/DataDog/datadog-api-client-go/v2/api/datadog"
+	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV2"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/utils"
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/validators"
+)
+
+const securityMonitoringDefaultRulesPageSize = 100
+
+var (
+	_ resource.ResourceWithConfigure        = &securityMonitoringDefaultRulesResource{}
+	_ resource.ResourceWithConfigValidators = &securityMonitoringDefaultRulesResource{}
+	_ resource.ResourceWithModifyPlan       = &securityMonitoringDefaultRulesResource{}
+)
+
+type securityMonitoringDefaultRulesResource struct {
+	api  *datadogV2.SecurityMonitoringApi
+	auth context.Context
+	now  func() time.Time
+}
+
+type securityMonitoringDefaultRulesModel struct {
+	ID             types.String      `tfsdk:"id"`
+	Query          types.String      `tfsdk:"query"`
+	Tags           types.Set         `tfsdk:"tags"`
+	Sources        types.Set         `tfsdk:"sources"`
+	ExcludeRuleIDs types.Set         `tfsdk:"exclude_rule_ids"`
+	Enabled        types.Bool        `tfsdk:"enabled"`
+	Notifications  types.List        `tfsdk:"notifications"`
+	Filters        []ruleFilterModel `tfsdk:"filter"`
+	RuleIDs        types.Set         `tfsdk:"rule_ids"`
+}
+
+// defaultRulesPolicy selects default rules and holds the settings applied to
+// all of them. Nil settings are left untouched on the rules.
+type defaultRulesPolicy struct {
+	query          string
+	tags           []string
+	sources        []string
+	excludeRuleIDs []string
+	enabled        *bool
+	notifications  []string
+	filters        []datadogV2.SecurityMonitoringFilter
+}
+
+func NewSecurityMonitoringDefaultRulesResource() resource.Resource {
+	return &securityMonitoringDefaultRulesResource{now: time.Now}
+}
+
+func (r *securityMonitoringDefaultRulesResource) Configure(_ context.Context, request resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	providerData := request.ProviderData.(*FrameworkProvider)
+	r.api = providerData.DatadogApiInstances.GetSecurityMonitoringApiV2()
+	r.auth = providerData.Auth
+}
+
+func (r *securityMonitoringDefaultRulesResource) Metadata(_ context.Context, _ resource.MetadataRequest, response *resource.MetadataResponse) {
+	response.TypeName = "security_monitoring_default_rules"
+}
+
+func (r *securityMonitoringDefaultRulesResource) Schema(_ context.Context, _ resource.SchemaRequest, response *resource.SchemaResponse) {
+	response.Schema = schema.Schema{
+		Description: "Provides a Datadog Security Monitoring resource applying a common policy to the default rules selected by tags, sources or a search query. Default rules shipped after the policy was applied are picked up on the next plan. Destroying the resource removes the filters it added; the enabled state and notifications of the rules are left as they are. Rules should not be managed by both this resource and `datadog_security_monitoring_default_rule`, use `exclude_rule_ids` to leave them out.",
+		Attributes: map[string]schema.Attribute{
+			"id": utils.ResourceIDAttribute(),
+			"query": schema.StringAttribute{
+				Optional:    true,
+				Description: "Security monitoring rule search query selecting the default rules, for example `tactic:TA0001-initial-access`.",
+				Validators:  []validator.String{stringvalidator.LengthAtLeast(1)},
+			},
+			"tags": schema.SetAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Tags the selected default rules must all have, for example `security:attack`.",
+			},
+			"sources": schema.SetAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Log sources of the selected default rules, matched against their `source` tag, for example `cloudtrail`.",
+			},
+			"exclude_rule_ids": schema.SetAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "IDs of default rules left out of the selection.",
+			},
+			"enabled": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether the selected rules are enabled. The rules are left as they are when unset.",
+			},
+			"notifications": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Notification targets of all the cases of the selected rules, replacing their current targets. The targets are left as they are when unset.",
+			},
+			"rule_ids": schema.SetAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "IDs of the default rules the policy is applied to. On refresh, the selected rules which do not follow the policy, such as newly shipped rules, are left out so that the next apply updates them.",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"filter": schema.ListNestedBlock{
+				Description: "Filters added to the selected rules, for example to suppress the events of test accounts. Other filters of the rules are kept.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"action": schema.StringAttribute{
+							Required:    true,
+							Description: "The type of filtering action.",
+							Validators: []validator.String{
+								validators.NewEnumValidator[validator.String](datadogV2.NewSecurityMonitoringFilterActionFromValue),
+							},
+						},
+						"query": schema.StringAttribute{
+							Required:    true,
+							Description: "Query for selecting logs to apply the filtering action.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *securityMonitoringDefaultRulesResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.AtLeastOneOf(
+			path.MatchRoot("query"),
+			path.MatchRoot("tags"),
+			path.MatchRoot("sources"),
+		),
+	}
+}
+
+// ModifyPlan lists the default rules matching the selection, so that rules
+// shipped since the last apply, or no longer following the policy, show up
+// in the plan.
+func (r *securityMonitoringDefaultRulesResource) ModifyPlan(ctx context.Context, request resource.ModifyPlanRequest, response *resource.ModifyPlanResponse) {
+	if request.State.Raw.IsNull() {
+		return
+	}
+	var plan securityMonitoringDefaultRulesModel
+	response.Diagnostics.Append(request.Plan.Get(ctx, &plan)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	if plan.Query.IsUnknown() || plan.Tags.IsUnknown() || plan.Sources.IsUnknown() || plan.ExcludeRuleIDs.IsUnknown() {
+		response.Diagnostics.Append(response.Plan.SetAttribute(ctx, path.Root("rule_ids"), types.SetUnknown(types.StringType))...)
+		return
+	}
+
+	policy, diags := buildDefaultRulesPolicy(ctx, &plan)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	rules, diags := r.listMatchingRules(policy)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	ruleIDs, diags := types.SetValueFrom(ctx, types.StringType, defaultRuleIDs(rules))
+	response.Diagnostics.Append(diags...)
+	response.Diagnostics.Append(response.Plan.SetAttribute(ctx, path.Root("rule_ids"), ruleIDs)...)
+}
+
+func (r *securityMonitoringDefaultRulesResource) Create(ctx context.Context, request resource.CreateRequest, response *resource.CreateResponse) {
+	var plan securityMonitoringDefaultRulesModel
+	response.Diagnostics.Append(request.Plan.Get(ctx, &plan)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	plan.ID = types.StringValue(computeDefaultRulesID(&plan))
+	r.apply(ctx, &plan, nil, response.Diagnostics.Append)
+	response.Diagnostics.Append(response.State.Set(ctx, &plan)...)
+}
+
+func (r *securityMonitoringDefaultRulesResource) Read(ctx context.Context, request resource.ReadRequest, response *resource.ReadResponse) {
+	var state securityMonitoringDefaultRulesModel
+	response.Diagnostics.Append(request.State.Get(ctx, &state)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	policy, diags := buildDefaultRulesPolicy(ctx, &state)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	rules, diags := r.listMatchingRules(policy)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	var compliant []string
+	for _, rule := range rules {
+		if _, changed := policy.buildUpdatePayload(rule, nil); !changed {
+			compliant = append(compliant, rule.GetId())
+		}
+	}
+	state.RuleIDs, diags = types.SetValueFrom(ctx, types.StringType, compliant)
+	response.Diagnostics.Append(diags...)
+	response.Diagnostics.Append(response.State.Set(ctx, &state)...)
+}
+
+func (r *securityMonitoringDefaultRulesResource) Update(ctx context.Context, request resource.UpdateRequest, response *resource.UpdateResponse) {
+	var plan, state securityMonitoringDefaultRulesModel
+	response.Diagnostics.Append(request.Plan.Get(ctx, &plan)...)
+	response.Diagnostics.Append(request.State.Get(ctx, &state)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	r.apply(ctx, &plan, &state, response.Diagnostics.Append)
+	response.Diagnostics.Append(response.State.Set(ctx, &plan)...)
+}
+
+func (r *securityMonitoringDefaultRulesResource) Delete(ctx context.Context, request resource.DeleteRequest, response *resource.DeleteResponse) {
+	var state securityMonitoringDefaultRulesModel
+	response.Diagnostics.Append(request.State.Get(ctx, &state)...)
+	if response.Diagnostics.HasError() || len(state.Filters) == 0 {
+		return
+	}
+	var ruleIDs []string
+	response.Diagnostics.Append(state.RuleIDs.ElementsAs(ctx, &ruleIDs, false)...)
+	removed := buildPayloadFilters(state.Filters)
+	for _, ruleID := range ruleIDs {
+		response.Diagnostics.Append(r.removeFilters(ruleID, removed)...)
+	}
+}
+
+// apply applies the policy of the plan to the matching rules, and removes the
+// filters of the prior policy from the rules it no longer applies to. The
+// rule_ids of the plan are set to the rules the policy was applied to.
+func (r *securityMonitoringDefaultRulesResource) apply(ctx context.Context, plan, prior *securityMonitoringDefaultRulesModel, addDiags func(...diag.Diagnostic)) {
+	policy, diags := buildDefaultRulesPolicy(ctx, plan)
+	addDiags(diags...)
+	if diags.HasError() {
+		return
+	}
+	rules, diags := r.listMatchingRules(policy)
+	addDiags(diags...)
+	if diags.HasError() {
+		return
+	}
+
+	var priorFilters, removed []datadogV2.SecurityMonitoringFilter
+	var priorRuleIDs []string
+	if prior != nil {
+		priorFilters = buildPayloadFilters(prior.Filters)
+		removed = removedFilters(priorFilters, policy.filters)
+		addDiags(prior.RuleIDs.ElementsAs(ctx, &priorRuleIDs, false)...)
+	}
+	// Apply to the planned rules only, so that rules shipped since the plan
+	// are picked up by the next one.
+	var planned []string
+	if !plan.RuleIDs.IsUnknown() {
+		addDiags(plan.RuleIDs.ElementsAs(ctx, &planned, false)...)
+		rules = slices.DeleteFunc(rules, func(rule *datadogV2.SecurityMonitoringStandardRuleResponse) bool {
+			return !slices.Contains(planned, rule.GetId())
+		})
+	}
+
+	applied := make([]string, 0, len(rules))
+	for _, rule := range rules {
+		payload, changed := policy.buildUpdatePayload(rule, removed)
+		if changed {
+			if _, _, err := r.api.UpdateSecurityMonitoringRule(r.auth, rule.GetId(), *payload); err != nil {
+				addDiags(utils.FrameworkErrorDiag(err, "error updating security monitoring default rule "+rule.GetId()))
+				continue
+			}
+		}
+		applied = append(applied, rule.GetId())
+	}
+
+	selected := defaultRuleIDs(rules)
+	for _, ruleID := range priorRuleIDs {
+		if len(priorFilters) > 0 && !slices.Contains(selected, ruleID) {
+			addDiags(r.removeFilters(ruleID, priorFilters)...)
+		}
+	}
+
+	ruleIDs, diags := types.SetValueFrom(ctx, types.StringType, applied)
+	addDiags(diags...)
+	plan.RuleIDs = ruleIDs
+}
+
+// removeFilters removes filters from a rule the policy no longer applies to.
+func (r *securityMonitoringDefaultRulesResource) removeFilters(ruleID string, filters []datadogV2.SecurityMonitoringFilter) diag.Diagnostics {
+	var diags diag.Diagnostics
+	ruleResponse, httpResponse, err := r.api.GetSecurityMonitoringRule(r.auth, ruleID)
+	if err != nil {
+		if httpResponse != nil && httpResponse.StatusCode == http.StatusNotFound {
+			return diags
+		}
+		diags.Append(utils.FrameworkErrorDiag(err, "error reading security monitoring default rule "+ruleID))
+		return diags
+	}
+	rule := ruleResponse.SecurityMonitoringStandardRuleResponse
+	if rule == nil {
+		return diags
+	}
+	kept := slices.DeleteFunc(slices.Clone(rule.GetFilters()), func(f datadogV2.SecurityMonitoringFilter) bool {
+		return containsFilter(filters, f)
+	})
+	if len(kept) == len(rule.GetFilters()) {
+		return diags
+	}
+	payload := datadogV2.SecurityMonitoringRuleUpdatePayload{Filters: kept}
+	payload.SetIsEnabled(rule.GetIsEnabled())
+	if _, _, err := r.api.UpdateSecurityMonitoringRule(r.auth, ruleID, payload); err != nil {
+		diags.Append(utils.FrameworkErrorDiag(err, "error updating security monitoring default rule "+ruleID))
+	}
+	return diags
+}
+
+// listMatchingRules lists the default rules selected by the policy, skipping
+// the rules past their deprecation date, which cannot be updated anymore.
+func (r *securityMonitoringDefaultRulesResource) listMatchingRules(policy *defaultRulesPolicy) ([]*datadogV2.SecurityMonitoringStandardRuleResponse, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	params := datadogV2.ListSecurityMonitoringRulesOptionalParameters{PageSize: datadog.PtrInt64(securityMonitoringDefaultRulesPageSize)}
+	if policy.query != "" {
+		params.Query = &policy.query
+	}
+
+	var rules []*datadogV2.SecurityMonitoringStandardRuleResponse
+	for pageNumber := int64(0); ; pageNumber++ {
+		params.PageNumber = datadog.PtrInt64(pageNumber)
+		response, _, err := r.api.ListSecurityMonitoringRules(r.auth, params)
+		if err != nil {
+			diags.Append(utils.FrameworkErrorDiag(err, "error listing security monitoring rules"))
+			return nil, diags
+		}
+		for _, item := range response.GetData() {
+			rule := item.SecurityMonitoringStandardRuleResponse
+			if rule == nil || !policy.matches(rule) {
+				continue
+			}
+			if deprecation, ok := rule.GetDeprecationDateOk(); ok && time.UnixMilli(*deprecation).Before(r.now()) {
+				continue
+			}
+			rules = append(rules, rule)
+		}
+		meta := response.GetMeta()
+		page := meta.GetPage()
+		if len(response.GetData()) < securityMonitoringDefaultRulesPageSize || (pageNumber+1)*securityMonitoringDefaultRulesPageSize >= page.GetTotalCount() {
+			break
+		}
+	}
+	return rules, diags
+}
+
+func buildDefaultRulesPolicy(ctx context.Context, model *securityMonitoringDefaultRulesModel) (*defaultRulesPolicy, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	policy := &defaultRulesPolicy{
+		query:   model.Query.ValueString(),
+		filters: buildPayloadFilters(model.Filters),
+	}
+	diags.Append(model.Tags.ElementsAs(ctx, &policy.tags, false)...)
+	diags.Append(model.Sources.ElementsAs(ctx, &policy.sources, false)...)
+	diags.Append(model.ExcludeRuleIDs.ElementsAs(ctx, &policy.excludeRuleIDs, false)...)
+	if !model.Enabled.IsNull() && !model.Enabled.IsUnknown() {
+		policy.enabled = model.Enabled.ValueBoolPointer()
+	}
+	if !model.Notifications.IsNull() && !model.Notifications.IsUnknown() {
+		policy.notifications = []string{}
+		diags.Append(model.Notifications.ElementsAs(ctx, &policy.notifications, false)...)
+	}
+	return policy, diags
+}
+
+func (p *defaultRulesPolicy) matches(rule *datadogV2.SecurityMonitoringStandardRuleResponse) bool {
+	if !rule.GetIsDefault() || slices.Contains(p.excludeRuleIDs, rule.GetId()) {
+		return false
+	}
+	tags := rule.GetTags()
+	for _, tag := range p.tags {
+		if !slices.Contains(tags, tag) {
+			return false
+		}
+	}
+	if len(p.sources) == 0 {
+		return true
+	}
+	for _, source := range p.sources {
+		if slices.Contains(tags, "source:"+source) {
+			return true
+		}
+	}
+	return false
+}
+
+// buildUpdatePayload returns the payload applying the policy to the rule, and
+// whether the rule does not follow it yet. Removed filters are the filters of
+// a prior policy which must be taken off the rule.
+func (p *defaultRulesPolicy) buildUpdatePayload(rule *datadogV2.SecurityMonitoringStandardRuleResponse, removed []datadogV2.SecurityMonitoringFilter) (*datadogV2.SecurityMonitoringRuleUpdatePayload, bool) {
+	payload := datadogV2.SecurityMonitoringRuleUpdatePayload{}
+	changed := false
+
+	enabled := rule.GetIsEnabled()
+	if p.enabled != nil && *p.enabled != enabled {
+		enabled = *p.enabled
+		changed = true
+	}
+	payload.SetIsEnabled(enabled)
+
+	if p.notifications != nil {
+		cases := slices.Clone(rule.GetCases())
+		notifications := slices.Clone(p.notifications)
+		sort.Strings(notifications)
+		for i := range cases {
+			current := slices.Clone(cases[i].GetNotifications())
+			sort.Strings(current)
+			if !stringSliceEquals(current, notifications) {
+				cases[i].Notifications = p.notifications
+				changed = true
+			}
+		}
+		payload.Cases = cases
+	}
+
+	filters := slices.DeleteFunc(slices.Clone(rule.GetFilters()), func(f datadogV2.SecurityMonitoringFilter) bool {
+		return containsFilter(removed, f)
+	})
+	for _, f := range p.filters {
+		if !containsFilter(filters, f) {
+			filters = append(filters, f)
+		}
+	}
+	if !compareFilters(rule.GetFilters(), filters) {
+		payload.Filters = filters
+		changed = true
+	}
+	return &payload, changed
+}
+
+// removedFilters returns the filters of the prior policy which are not part
+// of the current one.
+func removedFilters(prior, current []datadogV2.SecurityMonitoringFilter) []datadogV2.SecurityMonitoringFilter {
+	var removed []datadogV2.SecurityMonitoringFilter
+	for _, f := range prior {
+		if !containsFilter(current, f) {
+			removed = append(removed, f)
+		}
+	}
+	return removed
+}
+
+func containsFilter(filters []datadogV2.SecurityMonitoringFilter, filter datadogV2.SecurityMonitoringFilter) bool {
+	return slices.ContainsFunc(filters, func(f datadogV2.SecurityMonitoringFilter) bool {
+		return f.GetAction() == filter.GetAction() && f.GetQuery() == filter.GetQuery()
+	})
+}
+
+func defaultRuleIDs(rules []*datadogV2.SecurityMonitoringStandardRuleResponse) []string {
+	ids := make([]string, len(rules))
+	for i, rule := range rules {
+		ids[i] = rule.GetId()
+	}
+	return ids
+}
+
+func computeDefaultRulesID(model *securityMonitoringDefaultRulesModel) string {
+	var b strings.Builder
+	b.WriteString(model.Query.ValueString())
+	for _, set := range []types.Set{model.Tags, model.Sources} {
+		b.WriteRune('|')
+		elements := make([]string, 0, len(set.Elements()))
+		for _, element := range set.Elements() {
+			elements = append(elements, element.String())
+		}
+		sort.Strings(elements)
+		b.WriteString(strings.Join(elements, ","))
+	}
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(b.String())))
+}
@@ -0,0 +1,84 @@
+package fwprovider
+
+import (
+	"testing"
+
+	"github.com/DataDog/datadog-api-client-go/v2/api/datadog"
+	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV2"
+	"github.com/stretchr/testify/assert"
+)
+
+func newDefaultRulesTestRule(id string, tags ...string) *datadogV2.SecurityMonitoringStandardRuleResponse {
+	rule := datadogV2.NewSecurityMonitoringStandardRuleResponse()
+	rule.SetId(id)
+	rule.SetIsDefault(true)
+	rule.SetIsEnabled(true)
+	rule.SetTags(tags)
+	rule.SetCases([]datadogV2.SecurityMonitoringRuleCase{
+		{Status: datadogV2.SECURITYMONITORINGRULESEVERITY_HIGH.Ptr(), Notifications: []string{"@old"}},
+		{Status: datadogV2.SECURITYMONITORINGRULESEVERITY_INFO.Ptr()},
+	})
+	return rule
+}
+
+func newDefaultRulesTestFilter(action datadogV2.SecurityMonitoringFilterAction, query string) datadogV2.SecurityMonitoringFilter {
+	return datadogV2.SecurityMonitoringFilter{Action: action.Ptr(), Query: datadog.PtrString(query)}
+}
+
+func TestDefaultRulesPolicyMatches(t *testing.T) {
+	policy := &defaultRulesPolicy{
+		tags:           []string{"security:attack"},
+		sources:        []string{"cloudtrail", "okta"},
+		excludeRuleIDs: []string{"excluded"},
+	}
+	assert.True(t, policy.matches(newDefaultRulesTestRule("a", "security:attack", "source:okta")))
+	assert.False(t, policy.matches(newDefaultRulesTestRule("b", "security:attack", "source:gcp")))
+	assert.False(t, policy.matches(newDefaultRulesTestRule("c", "source:cloudtrail")))
+	assert.False(t, policy.matches(newDefaultRulesTestRule("excluded", "security:attack", "source:cloudtrail")))
+
+	custom := newDefaultRulesTestRule("d", "security:attack", "source:okta")
+	custom.SetIsDefault(false)
+	assert.False(t, policy.matches(custom))
+}
+
+func TestDefaultRulesPolicyBuildUpdatePayload(t *testing.T) {
+	testAccounts := newDefaultRulesTestFilter(datadogV2.SECURITYMONITORINGFILTERACTION_SUPPRESS, "@usr.name:test-*")
+	staging := newDefaultRulesTestFilter(datadogV2.SECURITYMONITORINGFILTERACTION_SUPPRESS, "env:staging")
+	own := newDefaultRulesTestFilter(datadogV2.SECURITYMONITORINGFILTERACTION_REQUIRE, "env:prod")
+	disabled := false
+
+	t.Run("unset settings are left untouched", func(t *testing.T) {
+		payload, changed := (&defaultRulesPolicy{}).buildUpdatePayload(newDefaultRulesTestRule("a"), nil)
+		assert.False(t, changed)
+		assert.True(t, payload.GetIsEnabled())
+		assert.Nil(t, payload.Cases)
+		assert.Nil(t, payload.Filters)
+	})
+
+	t.Run("policy is applied", func(t *testing.T) {
+		rule := newDefaultRulesTestRule("a")
+		rule.SetFilters([]datadogV2.SecurityMonitoringFilter{own, staging})
+		policy := &defaultRulesPolicy{enabled: &disabled, notifications: []string{"@slack-sec"}, filters: []datadogV2.SecurityMonitoringFilter{testAccounts}}
+
+		payload, changed := policy.buildUpdatePayload(rule, []datadogV2.SecurityMonitoringFilter{staging})
+		assert.True(t, changed)
+		assert.False(t, payload.GetIsEnabled())
+		assert.Equal(t, []string{"@slack-sec"}, payload.Cases[0].Notifications)
+		assert.Equal(t, []string{"@slack-sec"}, payload.Cases[1].Notifications)
+		assert.Equal(t, []datadogV2.SecurityMonitoringFilter{own, testAccounts}, payload.Filters)
+		assert.Equal(t, []string{"@old"}, rule.GetCases()[0].Notifications, "the rule itself is not modified")
+	})
+
+	t.Run("compliant rule", func(t *testing.T) {
+		rule := newDefaultRulesTestRule("a")
+		rule.SetIsEnabled(false)
+		rule.SetFilters([]datadogV2.SecurityMonitoringFilter{testAccounts, own})
+		cases := rule.GetCases()
+		cases[0].Notifications = []string{"@b", "@a"}
+		cases[1].Notifications = []string{"@a", "@b"}
+		policy := &defaultRulesPolicy{enabled: &disabled, notifications: []string{"@a", "@b"}, filters: []datadogV2.SecurityMonitoringFilter{testAccounts}}
+
+		_, changed := policy.buildUpdatePayload(rule, nil)
+		assert.False(t, changed)
+	})
+}
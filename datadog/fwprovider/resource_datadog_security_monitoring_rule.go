@@ -27,9 +27,11 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	fwutils "github.com/terraform-providers/terraform-provider-datadog/datadog/internal/fwutils"
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/securityrule"
 	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/utils"
 	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/validators"
 )
@@ -67,6 +69,7 @@ type securityMonitoringRuleResourceModel struct {
 	CalculatedFields  []calculatedFieldModel    `tfsdk:"calculated_field"`
 	SchedulingOptions []schedulingOptionsModel  `tfsdk:"scheduling_options"`
 	Options           []ruleOptionsModel        `tfsdk:"options"`
+	TestCases         []ruleTestCaseModel       `tfsdk:"test_case"`
 }
 
 type ruleCaseModel struct {
@@ -77,6 +80,16 @@ type ruleCaseModel struct {
 	Actions       []ruleCaseActionModel `tfsdk:"action"`
 }
 
+type ruleTestCaseModel struct {
+	Name           types.String `tfsdk:"name"`
+	Message        types.String `tfsdk:"message"`
+	Source         types.String `tfsdk:"source"`
+	Service        types.String `tfsdk:"service"`
+	Hostname       types.String `tfsdk:"hostname"`
+	Tags           types.List   `tfsdk:"tags"`
+	ExpectedStatus types.String `tfsdk:"expected_status"`
+}
+
 type ruleCaseActionModel struct {
 	Type    types.String                 `tfsdk:"type"`
 	Options []ruleCaseActionOptionsModel `tfsdk:"options"`
@@ -599,6 +612,45 @@ func (r *securityMonitoringRuleResource) Schema(_ context.Context, _ resource.Sc
 					},
 				},
 			},
+			"test_case": schema.ListNestedBlock{
+				Description: "Sample logs the rule is tested against during plan, only for `log_detection` rules. The rule test API checks which queries match each sample, the cases are then evaluated in order with a count of one for each matching query, and the plan fails when the triggered case does not have the expected status. The tests only run when the `query`, `case` or `test_case` blocks change.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Required:    true,
+							Description: "Name of the test case.",
+						},
+						"message": schema.StringAttribute{
+							Required:    true,
+							Description: "Message of the sample log.",
+						},
+						"source": schema.StringAttribute{
+							Optional:    true,
+							Description: "Source (`ddsource`) of the sample log.",
+						},
+						"service": schema.StringAttribute{
+							Optional:    true,
+							Description: "Service of the sample log.",
+						},
+						"hostname": schema.StringAttribute{
+							Optional:    true,
+							Description: "Hostname of the sample log.",
+						},
+						"tags": schema.ListAttribute{
+							Optional:    true,
+							ElementType: types.StringType,
+							Description: "Tags (`ddtags`) of the sample log.",
+						},
+						"expected_status": schema.StringAttribute{
+							Required:    true,
+							Description: "Status of the case the sample log is expected to trigger, or `none` when it must not trigger any case.",
+							Validators: []validator.String{
+								stringvalidator.OneOf(append(enumStrings((*datadogV2.SecurityMonitoringRuleSeverity)(nil).GetAllowedValues()), "none")...),
+							},
+						},
+					},
+				},
+			},
 			"scheduling_options": schema.ListNestedBlock{
 				Description: "Options for scheduled rules. When this field is present, the rule runs based on the schedule. When absent, it runs in real time on ingested logs.",
 				Validators: []validator.List{
@@ -2602,6 +2654,18 @@ func (r *securityMonitoringRuleResource) ModifyPlan(ctx context.Context, request
 	}
 
 	r.resourceDatadogSecurityMonitoringRuleCustomizeDiff(ctx, &plan, &state, response)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	// The rule test API is only called when the outcome of the test cases may
+	// have changed.
+	changed, diags := testCasesChanged(ctx, request.Plan, request.State)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() || !changed {
+		return
+	}
+	r.runTestCases(ctx, &plan, response)
 }
 
 // stripDefaultTagsFromSet removes elements from tags whose `key:value` matches
@@ -2663,3 +2727,172 @@ func (r *securityMonitoringRuleResource) resourceDatadogSecurityMonitoringRuleCu
 		response.Diagnostics.Append(utils.FrameworkErrorDiag(err, "error validating security monitoring rule"))
 	}
 }
+
+// testCasesAreKnown reports whether the parts of the rule and test cases
+// used to run the test cases are known, which may only be the case at apply.
+// testCasesChanged returns whether the queries, cases or test cases of the
+// rule differ from the state.
+func testCasesChanged(ctx context.Context, plan tfsdk.Plan, state tfsdk.State) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if state.Raw.IsNull() {
+		return true, diags
+	}
+	for _, name := range []string{"query", "case", "test_case"} {
+		var planValue, stateValue types.List
+		diags.Append(plan.GetAttribute(ctx, path.Root(name), &planValue)...)
+		diags.Append(state.GetAttribute(ctx, path.Root(name), &stateValue)...)
+		if diags.HasError() {
+			return false, diags
+		}
+		if !planValue.Equal(stateValue) {
+			return true, diags
+		}
+	}
+	return false, diags
+}
+
+func testCasesAreKnown(plan *securityMonitoringRuleResourceModel) bool {
+	for _, query := range plan.Queries {
+		if query.Query.IsUnknown() || query.Name.IsUnknown() || query.Aggregation.IsUnknown() {
+			return false
+		}
+	}
+	for _, ruleCase := range plan.Cases {
+		if ruleCase.Status.IsUnknown() {
+			return false
+		}
+	}
+	for _, testCase := range plan.TestCases {
+		for _, v := range []types.String{testCase.Message, testCase.Source, testCase.Service, testCase.Hostname, testCase.ExpectedStatus} {
+			if v.IsUnknown() {
+				return false
+			}
+		}
+		if testCase.Tags.IsUnknown() {
+			return false
+		}
+	}
+	return true
+}
+
+func buildTestPayloadFromModel(ctx context.Context, model *securityMonitoringRuleResourceModel) *datadogV2.SecurityMonitoringRuleTestPayload {
+	payload := datadogV2.SecurityMonitoringStandardRuleTestPayload{}
+	buildCreateCommonPayload(ctx, model, &payload)
+	payload.SetCases(buildCreatePayloadCases(ctx, model.Cases))
+	payload.SetQueries(buildCreateStandardPayloadQueries(ctx, model.Queries))
+	payload.SetType(datadogV2.SECURITYMONITORINGRULETYPETEST_LOG_DETECTION)
+	if len(model.ReferenceTables) > 0 {
+		payload.SetReferenceTables(buildPayloadReferenceTables(model.ReferenceTables))
+	}
+	if !model.GroupSignalsBy.IsNull() && !model.GroupSignalsBy.IsUnknown() && len(model.GroupSignalsBy.Elements()) > 0 {
+		var groupSignalsBy []string
+		model.GroupSignalsBy.ElementsAs(ctx, &groupSignalsBy, false)
+		payload.SetGroupSignalsBy(groupSignalsBy)
+	}
+	if len(model.SchedulingOptions) > 0 {
+		payload.SetSchedulingOptions(*buildPayloadSchedulingOptions(model.SchedulingOptions))
+	}
+	if len(model.CalculatedFields) > 0 {
+		payload.SetCalculatedFields(buildPayloadCalculatedFields(model.CalculatedFields))
+	}
+	testPayload := datadogV2.SecurityMonitoringStandardRuleTestPayloadAsSecurityMonitoringRuleTestPayload(&payload)
+	return &testPayload
+}
+
+func buildTestCasePayloadData(ctx context.Context, testCase ruleTestCaseModel) *datadogV2.SecurityMonitoringRuleQueryPayloadData {
+	data := datadogV2.NewSecurityMonitoringRuleQueryPayloadData()
+	data.SetMessage(testCase.Message.ValueString())
+	if !testCase.Source.IsNull() {
+		data.SetDdsource(testCase.Source.ValueString())
+	}
+	if !testCase.Service.IsNull() {
+		data.SetService(testCase.Service.ValueString())
+	}
+	if !testCase.Hostname.IsNull() {
+		data.SetHostname(testCase.Hostname.ValueString())
+	}
+	if !testCase.Tags.IsNull() {
+		var tags []string
+		testCase.Tags.ElementsAs(ctx, &tags, false)
+		data.SetDdtags(strings.Join(tags, ","))
+	}
+	return data
+}
+
+// runTestCases checks which queries match the sample log of each test case
+// with the rule test API, then evaluates the cases of the rule locally.
+func (r *securityMonitoringRuleResource) runTestCases(ctx context.Context, plan *securityMonitoringRuleResourceModel, response *resource.ModifyPlanResponse) {
+	if len(plan.TestCases) == 0 {
+		return
+	}
+	if plan.Type.ValueString() != string(datadogV2.SECURITYMONITORINGRULETYPETEST_LOG_DETECTION) || isThirdPartyRule(plan) {
+		response.Diagnostics.AddAttributeError(path.Root("test_case"), "test_case is not supported for this rule", "test cases are only supported for `log_detection` rules which are not third-party rules")
+		return
+	}
+	if !testCasesAreKnown(plan) {
+		log.Printf("[DEBUG] Skipping test cases until the rule is known")
+		return
+	}
+
+	// Every sample log is tested against every query, a match being the
+	// expected result.
+	request := datadogV2.NewSecurityMonitoringRuleTestRequest()
+	request.SetRule(*buildTestPayloadFromModel(ctx, plan))
+	for _, testCase := range plan.TestCases {
+		data := buildTestCasePayloadData(ctx, testCase)
+		for queryIndex := range plan.Queries {
+			queryPayload := datadogV2.NewSecurityMonitoringRuleQueryPayload()
+			queryPayload.SetIndex(int64(queryIndex))
+			queryPayload.SetExpectedResult(true)
+			queryPayload.SetPayload(*data)
+			request.RuleQueryPayloads = append(request.RuleQueryPayloads, *queryPayload)
+		}
+	}
+	testResponse, _, err := r.api.TestSecurityMonitoringRule(r.auth, *request)
+	if err != nil {
+		response.Diagnostics.Append(utils.FrameworkErrorDiag(err, "error testing security monitoring rule"))
+		return
+	}
+	if err := utils.CheckForUnparsed(testResponse); err != nil {
+		response.Diagnostics.AddError("response contains unparsed object", err.Error())
+		return
+	}
+	results := testResponse.GetResults()
+	if len(results) != len(request.RuleQueryPayloads) {
+		response.Diagnostics.AddError("unexpected response", fmt.Sprintf("expected %d test results, got %d", len(request.RuleQueryPayloads), len(results)))
+		return
+	}
+
+	cases := make([]securityrule.Case, len(plan.Cases))
+	for i, ruleCase := range plan.Cases {
+		cases[i] = securityrule.Case{Name: ruleCase.Name.ValueString(), Condition: ruleCase.Condition.ValueString(), Status: ruleCase.Status.ValueString()}
+	}
+	for i, testCase := range plan.TestCases {
+		values := make(map[string]float64, len(plan.Queries))
+		var matched []string
+		for queryIndex, query := range plan.Queries {
+			name := securityrule.QueryName(query.Name.ValueString(), queryIndex)
+			values[name] = 0
+			if results[i*len(plan.Queries)+queryIndex] {
+				values[name] = 1
+				matched = append(matched, name)
+			}
+		}
+		status := "none"
+		triggered, err := securityrule.TriggeredCase(cases, values)
+		if err != nil {
+			response.Diagnostics.AddAttributeError(path.Root("test_case").AtListIndex(i), "Security monitoring rule test case failed", fmt.Sprintf("test case %q: %s", testCase.Name.ValueString(), err))
+			continue
+		}
+		if triggered >= 0 {
+			status = cases[triggered].Status
+		}
+		if status != testCase.ExpectedStatus.ValueString() {
+			response.Diagnostics.AddAttributeError(
+				path.Root("test_case").AtListIndex(i),
+				"Security monitoring rule test case failed",
+				fmt.Sprintf("test case %q: expected status %q, got %q; matching queries: %q", testCase.Name.ValueString(), testCase.ExpectedStatus.ValueString(), status, matched),
+			)
+		}
+	}
+}
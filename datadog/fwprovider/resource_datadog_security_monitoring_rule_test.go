@@ -0,0 +1,63 @@
+package fwprovider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSecurityMonitoringRuleTestCasesChanged(t *testing.T) {
+	ctx := context.Background()
+	r := NewSecurityMonitoringRuleResource()
+	schemaResp := resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	ruleType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	// newRule returns a rule with a single query and the given name, every
+	// other attribute being null.
+	newRule := func(name, query string) tftypes.Value {
+		nullObject := func(typ tftypes.Object, values map[string]tftypes.Value) tftypes.Value {
+			attributes := map[string]tftypes.Value{}
+			for attribute, attributeType := range typ.AttributeTypes {
+				attributes[attribute] = tftypes.NewValue(attributeType, nil)
+			}
+			for attribute, value := range values {
+				attributes[attribute] = value
+			}
+			return tftypes.NewValue(typ, attributes)
+		}
+		queryList := ruleType.AttributeTypes["query"].(tftypes.List)
+		queryType := queryList.ElementType.(tftypes.Object)
+		return nullObject(ruleType, map[string]tftypes.Value{
+			"name": tftypes.NewValue(tftypes.String, name),
+			"query": tftypes.NewValue(queryList, []tftypes.Value{
+				nullObject(queryType, map[string]tftypes.Value{"query": tftypes.NewValue(tftypes.String, query)}),
+			}),
+		})
+	}
+	plan := func(raw tftypes.Value) tfsdk.Plan { return tfsdk.Plan{Schema: schemaResp.Schema, Raw: raw} }
+	state := func(raw tftypes.Value) tfsdk.State { return tfsdk.State{Schema: schemaResp.Schema, Raw: raw} }
+
+	cases := map[string]struct {
+		plan    tfsdk.Plan
+		state   tfsdk.State
+		changed bool
+	}{
+		"create":            {plan: plan(newRule("a", "service:a")), state: state(tftypes.NewValue(ruleType, nil)), changed: true},
+		"query changed":     {plan: plan(newRule("a", "service:b")), state: state(newRule("a", "service:a")), changed: true},
+		"other changes":     {plan: plan(newRule("b", "service:a")), state: state(newRule("a", "service:a"))},
+		"nothing to change": {plan: plan(newRule("a", "service:a")), state: state(newRule("a", "service:a"))},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			changed, diags := testCasesChanged(ctx, tc.plan, tc.state)
+			require.False(t, diags.HasError(), diags)
+			assert.Equal(t, tc.changed, changed)
+		})
+	}
+}
@@ -10,9 +10,11 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/utils"
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/validators"
 )
 
 var (
@@ -84,14 +86,17 @@ func (r *securityMonitoringSuppressionResource) Schema(_ context.Context, _ reso
 			"rule_query": schema.StringAttribute{
 				Required:    true,
 				Description: "The rule query of the suppression rule, with the same syntax as the search bar for detection rules.",
+				Validators:  []validator.String{validators.SearchQueryValidator()},
 			},
 			"suppression_query": schema.StringAttribute{
 				Optional:    true,
 				Description: "The suppression query of the suppression rule. If a signal matches this query, it is suppressed and is not triggered. It uses the same syntax as the queries to search signals in the Signals Explorer.",
+				Validators:  []validator.String{validators.SearchQueryValidator()},
 			},
 			"data_exclusion_query": schema.StringAttribute{
 				Optional:    true,
 				Description: "An exclusion query on the input data of the security rules, which could be logs, Agent events, or other types of data based on the security rule. Events matching this query are ignored by any detection rules referenced in the suppression rule.",
+				Validators:  []validator.String{validators.SearchQueryValidator()},
 			},
 			"validate": schema.BoolAttribute{
 				Optional:    true,
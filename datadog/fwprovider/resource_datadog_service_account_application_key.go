@@ -22,6 +22,7 @@ import (
 var (
 	_ resource.ResourceWithConfigure   = &serviceAccountApplicationKeyResource{}
 	_ resource.ResourceWithImportState = &serviceAccountApplicationKeyResource{}
+	_ resource.ResourceWithModifyPlan  = &serviceAccountApplicationKeyResource{}
 )
 
 type serviceAccountApplicationKeyResource struct {
@@ -37,6 +38,7 @@ type serviceAccountApplicationKeyModel struct {
 	CreatedAt        types.String `tfsdk:"created_at"`
 	Last4            types.String `tfsdk:"last4"`
 	Scopes           types.Set    `tfsdk:"scopes"`
+	keyRotationModel
 }
 
 func NewServiceAccountApplicationKeyResource() resource.Resource {
@@ -95,6 +97,11 @@ func (r *serviceAccountApplicationKeyResource) Schema(_ context.Context, _ resou
 			"id": utils.ResourceIDAttribute(),
 		},
 	}
+	addKeyRotationAttributes(response.Schema.Attributes)
+}
+
+func (r *serviceAccountApplicationKeyResource) ModifyPlan(ctx context.Context, request resource.ModifyPlanRequest, response *resource.ModifyPlanResponse) {
+	modifyKeyRotationPlan(ctx, request, response, path.Root("id"), path.Root("key"), path.Root("created_at"), path.Root("last4"))
 }
 
 func (r *serviceAccountApplicationKeyResource) ImportState(ctx context.Context, request resource.ImportStateRequest, response *resource.ImportStateResponse) {
@@ -165,38 +172,67 @@ func (r *serviceAccountApplicationKeyResource) Create(ctx context.Context, reque
 		return
 	}
 	r.updateStateFullKey(ctx, &state, &resp)
+	response.Diagnostics.Append(state.keyRotated(false, "", "", r.deleteKey(serviceAccountId))...)
 
 	// Save data into Terraform state
 	response.Diagnostics.Append(response.State.Set(ctx, &state)...)
 }
 
 func (r *serviceAccountApplicationKeyResource) Update(ctx context.Context, request resource.UpdateRequest, response *resource.UpdateResponse) {
-	var state serviceAccountApplicationKeyModel
+	var state, prior serviceAccountApplicationKeyModel
 	response.Diagnostics.Append(request.Plan.Get(ctx, &state)...)
+	response.Diagnostics.Append(request.State.Get(ctx, &prior)...)
 	if response.Diagnostics.HasError() {
 		return
 	}
 
 	serviceAccountId := state.ServiceAccountId.ValueString()
 
-	id := state.ID.ValueString()
-
-	body, diags := r.buildServiceAccountApplicationKeyUpdateRequestBody(ctx, &state)
-	response.Diagnostics.Append(diags...)
+	// The key is unknown when a rotation is planned
+	rotated := state.Key.IsUnknown()
+	response.Diagnostics.Append(state.deletePreviousKey(prior.keyRotationModel, rotated, r.deleteKey(serviceAccountId))...)
 	if response.Diagnostics.HasError() {
 		return
 	}
 
-	resp, _, err := r.Api.UpdateServiceAccountApplicationKey(r.Auth, serviceAccountId, id, *body)
-	if err != nil {
-		response.Diagnostics.Append(utils.FrameworkErrorDiag(err, "error updating ServiceAccountApplicationKey"))
-		return
-	}
-	if err := utils.CheckForUnparsed(resp); err != nil {
-		response.Diagnostics.AddError("response contains unparsedObject", err.Error())
-		return
+	if rotated {
+		body, diags := r.buildServiceAccountApplicationKeyRequestBody(ctx, &state)
+		response.Diagnostics.Append(diags...)
+		if response.Diagnostics.HasError() {
+			return
+		}
+
+		resp, _, err := r.Api.CreateServiceAccountApplicationKey(r.Auth, serviceAccountId, *body)
+		if err != nil {
+			response.Diagnostics.Append(utils.FrameworkErrorDiag(err, "error rotating ServiceAccountApplicationKey"))
+			return
+		}
+		if err := utils.CheckForUnparsed(resp); err != nil {
+			response.Diagnostics.AddError("response contains unparsedObject", err.Error())
+			return
+		}
+		r.updateStateFullKey(ctx, &state, &resp)
+	} else {
+		id := state.ID.ValueString()
+
+		body, diags := r.buildServiceAccountApplicationKeyUpdateRequestBody(ctx, &state)
+		response.Diagnostics.Append(diags...)
+		if response.Diagnostics.HasError() {
+			return
+		}
+
+		resp, _, err := r.Api.UpdateServiceAccountApplicationKey(r.Auth, serviceAccountId, id, *body)
+		if err != nil {
+			response.Diagnostics.Append(utils.FrameworkErrorDiag(err, "error updating ServiceAccountApplicationKey"))
+			return
+		}
+		if err := utils.CheckForUnparsed(resp); err != nil {
+			response.Diagnostics.AddError("response contains unparsedObject", err.Error())
+			return
+		}
+		r.updateStatePartialKey(ctx, &state, &resp)
 	}
-	r.updateStatePartialKey(ctx, &state, &resp)
+	response.Diagnostics.Append(state.keyRotated(rotated, prior.ID.ValueString(), prior.Key.ValueString(), r.deleteKey(serviceAccountId))...)
 
 	// Save data into Terraform state
 	response.Diagnostics.Append(response.State.Set(ctx, &state)...)
@@ -220,6 +256,23 @@ func (r *serviceAccountApplicationKeyResource) Delete(ctx context.Context, reque
 		response.Diagnostics.Append(utils.FrameworkErrorDiag(err, "error deleting service_account_application_key"))
 		return
 	}
+	if !state.PreviousKeyId.IsNull() {
+		if err := r.deleteKey(serviceAccountId)(state.PreviousKeyId.ValueString()); err != nil {
+			response.Diagnostics.Append(utils.FrameworkErrorDiag(err, "error deleting previous service_account_application_key"))
+		}
+	}
+}
+
+// deleteKey returns a function deleting a key of the service account replaced
+// by a rotation, which may already be gone.
+func (r *serviceAccountApplicationKeyResource) deleteKey(serviceAccountId string) func(id string) error {
+	return func(id string) error {
+		httpResp, err := r.Api.DeleteServiceAccountApplicationKey(r.Auth, serviceAccountId, id)
+		if err != nil && (httpResp == nil || httpResp.StatusCode != 404) {
+			return utils.TranslateClientError(err, httpResp, "error deleting service_account_application_key")
+		}
+		return nil
+	}
 }
 
 func (r *serviceAccountApplicationKeyResource) updateStatePartialKey(ctx context.Context, state *serviceAccountApplicationKeyModel, resp *datadogV2.PartialApplicationKeyResponse) {
@@ -230,6 +283,9 @@ func (r *serviceAccountApplicationKeyResource) updateStatePartialKey(ctx context
 
 	if createdAt, ok := attributes.GetCreatedAtOk(); ok {
 		state.CreatedAt = types.StringValue(*createdAt)
+		if t, err := time.Parse(time.RFC3339, *createdAt); err == nil {
+			state.setRotatedAt(t)
+		}
 	}
 
 	if last4, ok := attributes.GetLast4Ok(); ok {
@@ -258,6 +314,7 @@ func (r *serviceAccountApplicationKeyResource) updateStateFullKey(ctx context.Co
 	if createdAt, ok := attributes.GetCreatedAtOk(); ok {
 		timeStr := createdAt.Format(time.RFC3339)
 		state.CreatedAt = types.StringValue(timeStr)
+		state.setRotatedAt(*createdAt)
 
 	}
 
@@ -18,6 +18,7 @@ import (
 
 	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/planmodifiers"
 	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/utils"
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/validators"
 )
 
 var (
@@ -136,6 +137,7 @@ func (r *spansMetricResource) Schema(_ context.Context, _ resource.SchemaRequest
 						Computed:    true,
 						Description: "The search query - following the span search syntax.",
 						Default:     stringdefault.StaticString("*"),
+						Validators:  []validator.String{validators.SearchQueryValidator()},
 					},
 				},
 				// This field is marked as required for now since the framework does not allow
@@ -41,6 +41,7 @@ type syntheticsPrivateLocationModel struct {
 	Name                        types.String                             `tfsdk:"name"`
 	Tags                        types.List                               `tfsdk:"tags"`
 	ApiKey                      types.String                             `tfsdk:"api_key"`
+	RotationTrigger             types.String                             `tfsdk:"rotation_trigger"`
 }
 
 type syntheticsPrivateLocationMetadataModel struct {
@@ -95,6 +96,15 @@ func (r *syntheticsPrivateLocationResource) Schema(_ context.Context, _ resource
 				Optional:    true,
 				Sensitive:   true,
 			},
+			"rotation_trigger": schema.StringAttribute{
+				Description: "Arbitrary value that regenerates the private location credentials when it changes, for example the ID of a `time_rotating` resource. The Datadog API only issues credentials when a private location is created, so changing this value replaces the private location and its ID changes. Setting or removing the value does not regenerate the credentials.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIf(func(_ context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+						resp.RequiresReplace = !req.StateValue.IsNull() && !req.PlanValue.IsNull()
+					}, "Changing `rotation_trigger` regenerates the private location credentials.", "Changing `rotation_trigger` regenerates the private location credentials."),
+				},
+			},
 			"id": utils.ResourceIDAttribute(),
 			"restriction_policy_resource_id": schema.StringAttribute{
 				Description: "Resource ID to use when setting restrictions with a `datadog_restriction_policy` resource.",
@@ -0,0 +1,52 @@
+package fwprovider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSyntheticsPrivateLocationRotationTrigger(t *testing.T) {
+	ctx := context.Background()
+	schemaResp := resource.SchemaResponse{}
+	NewSyntheticsPrivateLocationResource().Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	attribute := schemaResp.Schema.Attributes["rotation_trigger"].(schema.StringAttribute)
+	// RequiresReplaceIf skips resource creation and destruction, which are
+	// detected from null raw values.
+	state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+	diags := state.Set(ctx, &syntheticsPrivateLocationModel{Name: types.StringValue("pl"), Tags: types.ListNull(types.StringType)})
+	assert.False(t, diags.HasError(), diags)
+
+	cases := map[string]struct {
+		state, plan types.String
+		expected    bool
+	}{
+		"rotated":   {types.StringValue("2024-01-01"), types.StringValue("2024-04-01"), true},
+		"unknown":   {types.StringValue("2024-01-01"), types.StringUnknown(), true},
+		"unchanged": {types.StringValue("2024-01-01"), types.StringValue("2024-01-01"), false},
+		"added":     {types.StringNull(), types.StringValue("2024-01-01"), false},
+		"removed":   {types.StringValue("2024-01-01"), types.StringNull(), false},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			req := planmodifier.StringRequest{
+				StateValue: c.state,
+				PlanValue:  c.plan,
+				State:      state,
+				Plan:       tfsdk.Plan{Schema: state.Schema, Raw: state.Raw},
+			}
+			resp := planmodifier.StringResponse{PlanValue: c.plan}
+			for _, modifier := range attribute.PlanModifiers {
+				modifier.PlanModifyString(ctx, req, &resp)
+			}
+			assert.Equal(t, c.expected, resp.RequiresReplace)
+		})
+	}
+}
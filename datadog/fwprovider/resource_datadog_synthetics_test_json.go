@@ -0,0 +1,396 @@
+package fwprovider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/DataDog/datadog-api-client-go/v2/api/datadog"
+	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV1"
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/utils"
+)
+
+const syntheticsTestsPath = "/api/v1/synthetics/tests"
+
+// syntheticsTestComputedFields are set by Datadog and are stripped from both
+// the payload sent to the API and the JSON stored in state, so that a test
+// exported from the UI can be committed as-is.
+var syntheticsTestComputedFields = []string{
+	"public_id",
+	"monitor_id",
+	"created_at",
+	"created_by",
+	"creator",
+	"deleted_at",
+	"modified_at",
+	"modified_by",
+	"overall_state",
+	"overall_state_modified",
+	"stepCount",
+	"version",
+	"version_uuid",
+}
+
+// syntheticsTestJSONTypes lists the test types that can be managed through
+// the v1 type-specific endpoints.
+var syntheticsTestJSONTypes = []string{"api", "browser", "mobile"}
+
+var (
+	_ resource.ResourceWithConfigure      = &syntheticsTestJSONResource{}
+	_ resource.ResourceWithImportState    = &syntheticsTestJSONResource{}
+	_ resource.ResourceWithValidateConfig = &syntheticsTestJSONResource{}
+)
+
+type syntheticsTestJSONResource struct {
+	Api  *datadogV1.SyntheticsApi
+	Http *datadog.APIClient
+	Auth context.Context
+}
+
+type syntheticsTestJSONModel struct {
+	ID        types.String         `tfsdk:"id"`
+	JSON      jsontypes.Normalized `tfsdk:"json"`
+	MonitorID types.Int64          `tfsdk:"monitor_id"`
+}
+
+func NewSyntheticsTestJSONResource() resource.Resource {
+	return &syntheticsTestJSONResource{}
+}
+
+func (r *syntheticsTestJSONResource) Configure(_ context.Context, request resource.ConfigureRequest, response *resource.ConfigureResponse) {
+	providerData := request.ProviderData.(*FrameworkProvider)
+	r.Api = providerData.DatadogApiInstances.GetSyntheticsApiV1()
+	r.Http = providerData.DatadogApiInstances.HttpClient
+	r.Auth = providerData.Auth
+}
+
+func (r *syntheticsTestJSONResource) Metadata(_ context.Context, request resource.MetadataRequest, response *resource.MetadataResponse) {
+	response.TypeName = "synthetics_test_json"
+}
+
+func (r *syntheticsTestJSONResource) Schema(_ context.Context, _ resource.SchemaRequest, response *resource.SchemaResponse) {
+	const replaceDesc = "Changing the test `type` forces the creation of a new test."
+	response.Schema = schema.Schema{
+		Description: "Provides a Datadog Synthetics test JSON resource. This can be used to create and manage API (including multistep), browser and mobile Synthetics tests using the JSON definition exported from the Datadog UI or returned by the API. Network tests, which use the v2 Synthetics API, are not supported: use `datadog_synthetics_test` to manage them.",
+		Attributes: map[string]schema.Attribute{
+			"json": schema.StringAttribute{
+				Required:      true,
+				CustomType:    jsontypes.NormalizedType{},
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplaceIf(syntheticsTestJSONTypeChanged, replaceDesc, replaceDesc)},
+				Description:   "The JSON formatted definition of the Synthetics test. Computed fields such as `public_id`, `monitor_id` or `created_at` are ignored. The `example` and `pattern` of secure config variables are not returned by the API and are kept from the configuration.",
+			},
+			"monitor_id": schema.Int64Attribute{
+				Computed:      true,
+				PlanModifiers: []planmodifier.Int64{int64planmodifier.UseStateForUnknown()},
+				Description:   "ID of the monitor associated with the Datadog Synthetics test.",
+			},
+			"id": utils.ResourceIDAttribute(),
+		},
+	}
+}
+
+func (r *syntheticsTestJSONResource) ImportState(ctx context.Context, request resource.ImportStateRequest, response *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), request, response)
+}
+
+func (r *syntheticsTestJSONResource) ValidateConfig(ctx context.Context, request resource.ValidateConfigRequest, response *resource.ValidateConfigResponse) {
+	var config syntheticsTestJSONModel
+	response.Diagnostics.Append(request.Config.Get(ctx, &config)...)
+	if response.Diagnostics.HasError() || config.JSON.IsNull() || config.JSON.IsUnknown() {
+		return
+	}
+
+	var test map[string]any
+	if err := json.Unmarshal([]byte(config.JSON.ValueString()), &test); err != nil {
+		response.Diagnostics.AddAttributeError(path.Root("json"), "invalid JSON", err.Error())
+		return
+	}
+	testType, _ := test["type"].(string)
+	if !isSupportedSyntheticsTestJSONType(testType) {
+		response.Diagnostics.AddAttributeError(
+			path.Root("json"),
+			"unsupported test type",
+			unsupportedSyntheticsTestJSONType(testType),
+		)
+	}
+}
+
+func (r *syntheticsTestJSONResource) Create(ctx context.Context, request resource.CreateRequest, response *resource.CreateResponse) {
+	var plan syntheticsTestJSONModel
+	response.Diagnostics.Append(request.Plan.Get(ctx, &plan)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	test, testType, err := buildSyntheticsTestJSONPayload(plan.JSON.ValueString())
+	if err != nil {
+		response.Diagnostics.AddError("error building synthetics test payload", err.Error())
+		return
+	}
+
+	respByte, httpResp, err := utils.SendRequest(r.Auth, r.Http, "POST", syntheticsTestsPath+"/"+testType, &test)
+	if err != nil {
+		response.Diagnostics.Append(utils.FrameworkErrorDiag(utils.TranslateClientError(err, httpResp, "error creating synthetics test"), ""))
+		return
+	}
+	respMap, err := utils.ConvertResponseByteToMap(respByte)
+	if err != nil {
+		response.Diagnostics.AddError("error parsing synthetics test response", err.Error())
+		return
+	}
+
+	publicID, ok := respMap["public_id"].(string)
+	if !ok {
+		response.Diagnostics.AddError("error retrieving public_id from response", "")
+		return
+	}
+
+	plan.ID = types.StringValue(publicID)
+	plan.MonitorID = syntheticsTestJSONMonitorID(respMap)
+	response.Diagnostics.Append(response.State.Set(ctx, &plan)...)
+}
+
+func (r *syntheticsTestJSONResource) Read(ctx context.Context, request resource.ReadRequest, response *resource.ReadResponse) {
+	var state syntheticsTestJSONModel
+	response.Diagnostics.Append(request.State.Get(ctx, &state)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	var prior map[string]any
+	if !state.JSON.IsNull() && state.JSON.ValueString() != "" {
+		if err := json.Unmarshal([]byte(state.JSON.ValueString()), &prior); err != nil {
+			response.Diagnostics.AddError("error parsing state JSON", err.Error())
+			return
+		}
+	}
+
+	// The type is unknown on import, look it up from the generic endpoint.
+	testType, _ := prior["type"].(string)
+	if testType == "" {
+		test, httpResp, err := r.Api.GetTest(r.Auth, state.ID.ValueString())
+		if err != nil {
+			if httpResp != nil && httpResp.StatusCode == http.StatusNotFound {
+				response.State.RemoveResource(ctx)
+				return
+			}
+			response.Diagnostics.Append(utils.FrameworkErrorDiag(utils.TranslateClientError(err, httpResp, "error getting synthetics test"), ""))
+			return
+		}
+		testType = string(test.GetType())
+	}
+	if !isSupportedSyntheticsTestJSONType(testType) {
+		response.Diagnostics.AddError("unsupported test type", fmt.Sprintf("synthetics test %s has type %q which cannot be managed by this resource", state.ID.ValueString(), testType))
+		return
+	}
+
+	respByte, httpResp, err := utils.SendRequest(r.Auth, r.Http, "GET", syntheticsTestsPath+"/"+testType+"/"+state.ID.ValueString(), nil)
+	if err != nil {
+		if httpResp != nil && httpResp.StatusCode == http.StatusNotFound {
+			response.State.RemoveResource(ctx)
+			return
+		}
+		response.Diagnostics.Append(utils.FrameworkErrorDiag(utils.TranslateClientError(err, httpResp, "error getting synthetics test"), ""))
+		return
+	}
+	respMap, err := utils.ConvertResponseByteToMap(respByte)
+	if err != nil {
+		response.Diagnostics.AddError("error parsing synthetics test response", err.Error())
+		return
+	}
+
+	state.MonitorID = syntheticsTestJSONMonitorID(respMap)
+	// Keep the JSON as written by the user, including any computed fields it
+	// carries, unless the test changed outside of Terraform.
+	test := flattenSyntheticsTestJSON(respMap, prior)
+	if prior == nil || !reflect.DeepEqual(test, prior) {
+		testJSON, err := json.Marshal(test)
+		if err != nil {
+			response.Diagnostics.AddError("error marshalling synthetics test", err.Error())
+			return
+		}
+		state.JSON = jsontypes.NewNormalizedValue(string(testJSON))
+	}
+
+	response.Diagnostics.Append(response.State.Set(ctx, &state)...)
+}
+
+func (r *syntheticsTestJSONResource) Update(ctx context.Context, request resource.UpdateRequest, response *resource.UpdateResponse) {
+	var plan, state syntheticsTestJSONModel
+	response.Diagnostics.Append(request.Plan.Get(ctx, &plan)...)
+	response.Diagnostics.Append(request.State.Get(ctx, &state)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	test, testType, err := buildSyntheticsTestJSONPayload(plan.JSON.ValueString())
+	if err != nil {
+		response.Diagnostics.AddError("error building synthetics test payload", err.Error())
+		return
+	}
+
+	id := state.ID.ValueString()
+	respByte, httpResp, err := utils.SendRequest(r.Auth, r.Http, "PUT", syntheticsTestsPath+"/"+testType+"/"+id, &test)
+	if err != nil {
+		response.Diagnostics.Append(utils.FrameworkErrorDiag(utils.TranslateClientError(err, httpResp, "error updating synthetics test"), ""))
+		return
+	}
+	respMap, err := utils.ConvertResponseByteToMap(respByte)
+	if err != nil {
+		response.Diagnostics.AddError("error parsing synthetics test response", err.Error())
+		return
+	}
+
+	plan.ID = types.StringValue(id)
+	plan.MonitorID = syntheticsTestJSONMonitorID(respMap)
+	response.Diagnostics.Append(response.State.Set(ctx, &plan)...)
+}
+
+func (r *syntheticsTestJSONResource) Delete(ctx context.Context, request resource.DeleteRequest, response *resource.DeleteResponse) {
+	var state syntheticsTestJSONModel
+	response.Diagnostics.Append(request.State.Get(ctx, &state)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	payload := datadogV1.SyntheticsDeleteTestsPayload{PublicIds: []string{state.ID.ValueString()}}
+	if _, httpResp, err := r.Api.DeleteTests(r.Auth, payload); err != nil {
+		if httpResp != nil && httpResp.StatusCode == http.StatusNotFound {
+			return
+		}
+		response.Diagnostics.Append(utils.FrameworkErrorDiag(utils.TranslateClientError(err, httpResp, "error deleting synthetics test"), ""))
+	}
+}
+
+func syntheticsTestJSONTypeChanged(ctx context.Context, request planmodifier.StringRequest, response *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+	if request.StateValue.IsNull() || request.PlanValue.IsUnknown() {
+		return
+	}
+	var oldTest, newTest map[string]any
+	if json.Unmarshal([]byte(request.StateValue.ValueString()), &oldTest) != nil {
+		return
+	}
+	if json.Unmarshal([]byte(request.PlanValue.ValueString()), &newTest) != nil {
+		return
+	}
+	response.RequiresReplace = oldTest["type"] != newTest["type"]
+}
+
+func isSupportedSyntheticsTestJSONType(testType string) bool {
+	for _, t := range syntheticsTestJSONTypes {
+		if t == testType {
+			return true
+		}
+	}
+	return false
+}
+
+// unsupportedSyntheticsTestJSONType explains why a test type cannot be managed
+// by the resource.
+func unsupportedSyntheticsTestJSONType(testType string) string {
+	if testType == string(datadogV1.SYNTHETICSTESTDETAILSTYPE_NETWORK) {
+		return "network tests are managed through the v2 Synthetics API, which has a different payload. Use `datadog_synthetics_test` to manage network tests."
+	}
+	return fmt.Sprintf("`type` must be one of %s, got %q. Multistep API tests have the `api` type. Use `datadog_synthetics_test` to manage other test types.", strings.Join(syntheticsTestJSONTypes, ", "), testType)
+}
+
+// buildSyntheticsTestJSONPayload returns the user JSON without its computed
+// fields, along with the test type used to route the request.
+func buildSyntheticsTestJSONPayload(testJSON string) (string, string, error) {
+	var test map[string]any
+	if err := json.Unmarshal([]byte(testJSON), &test); err != nil {
+		return "", "", err
+	}
+	testType, _ := test["type"].(string)
+	if !isSupportedSyntheticsTestJSONType(testType) {
+		return "", "", errors.New(unsupportedSyntheticsTestJSONType(testType))
+	}
+	for _, f := range syntheticsTestComputedFields {
+		utils.DeleteKeyInMap(test, strings.Split(f, "."))
+	}
+	payload, err := json.Marshal(test)
+	if err != nil {
+		return "", "", err
+	}
+	return string(payload), testType, nil
+}
+
+func syntheticsTestJSONMonitorID(test map[string]any) types.Int64 {
+	if id, ok := test["monitor_id"].(float64); ok {
+		return types.Int64Value(int64(id))
+	}
+	return types.Int64Null()
+}
+
+// flattenSyntheticsTestJSON converts an API response into the JSON stored in
+// state. Computed fields are removed, secure variable values that the API
+// does not return are restored from the prior JSON, and fields the user did
+// not set are dropped so that new API defaults do not cause drift. On import
+// there is no prior JSON and the whole test is kept. Computed fields are also
+// removed from prior so the result can be compared with it.
+func flattenSyntheticsTestJSON(test map[string]any, prior map[string]any) any {
+	for _, f := range syntheticsTestComputedFields {
+		utils.DeleteKeyInMap(test, strings.Split(f, "."))
+		if prior != nil {
+			utils.DeleteKeyInMap(prior, strings.Split(f, "."))
+		}
+	}
+	if prior == nil {
+		return test
+	}
+	restoreSyntheticsSecureVariables(test, prior)
+	return filterToUserFields(prior, test)
+}
+
+// restoreSyntheticsSecureVariables copies the `example` and `pattern` of
+// secure config variables from the prior JSON, matching variables by name.
+func restoreSyntheticsSecureVariables(test map[string]any, prior map[string]any) {
+	config, _ := test["config"].(map[string]any)
+	priorConfig, _ := prior["config"].(map[string]any)
+	if config == nil || priorConfig == nil {
+		return
+	}
+	variables, _ := config["variables"].([]any)
+	priorVariables, _ := priorConfig["variables"].([]any)
+
+	priorByName := make(map[string]map[string]any, len(priorVariables))
+	for _, v := range priorVariables {
+		if variable, ok := v.(map[string]any); ok {
+			if name, ok := variable["name"].(string); ok {
+				priorByName[name] = variable
+			}
+		}
+	}
+	for _, v := range variables {
+		variable, ok := v.(map[string]any)
+		if !ok {
+			continue
+		}
+		if secure, _ := variable["secure"].(bool); !secure {
+			continue
+		}
+		name, _ := variable["name"].(string)
+		priorVariable, ok := priorByName[name]
+		if !ok {
+			continue
+		}
+		for _, key := range []string{"example", "pattern"} {
+			if value, ok := priorVariable[key]; ok {
+				variable[key] = value
+			}
+		}
+	}
+}
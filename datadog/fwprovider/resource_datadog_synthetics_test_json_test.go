@@ -0,0 +1,86 @@
+package fwprovider
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func unmarshalTestJSON(t *testing.T, s string) map[string]any {
+	t.Helper()
+	var m map[string]any
+	require.NoError(t, json.Unmarshal([]byte(s), &m))
+	return m
+}
+
+func TestBuildSyntheticsTestJSONPayloadStripsComputedFields(t *testing.T) {
+	payload, testType, err := buildSyntheticsTestJSONPayload(`{
+		"public_id": "abc-def-ghi",
+		"monitor_id": 12345,
+		"created_at": "2024-01-01T00:00:00Z",
+		"name": "UI exported test",
+		"type": "browser"
+	}`)
+	require.NoError(t, err)
+	assert.Equal(t, "browser", testType)
+	assert.JSONEq(t, `{"name": "UI exported test", "type": "browser"}`, payload)
+}
+
+func TestBuildSyntheticsTestJSONPayloadRejectsUnsupportedType(t *testing.T) {
+	_, _, err := buildSyntheticsTestJSONPayload(`{"name": "network", "type": "network"}`)
+	assert.ErrorContains(t, err, "Use `datadog_synthetics_test` to manage network tests.")
+	_, _, err = buildSyntheticsTestJSONPayload(`{"name": "untyped"}`)
+	assert.ErrorContains(t, err, "`type` must be one of api, browser, mobile, got \"\"")
+}
+
+func TestFlattenSyntheticsTestJSON(t *testing.T) {
+	api := `{
+		"public_id": "abc-def-ghi",
+		"monitor_id": 12345,
+		"name": "checkout",
+		"type": "api",
+		"status": "live",
+		"config": {
+			"variables": [
+				{"name": "PASSWORD", "type": "text", "secure": true},
+				{"name": "USER", "type": "text", "example": "jdoe", "pattern": "jdoe"}
+			]
+		},
+		"options": {"tick_every": 60, "min_failure_duration": 0}
+	}`
+
+	t.Run("import keeps the whole test", func(t *testing.T) {
+		flattened := flattenSyntheticsTestJSON(unmarshalTestJSON(t, api), nil)
+		result, ok := flattened.(map[string]any)
+		require.True(t, ok)
+		assert.NotContains(t, result, "public_id")
+		assert.NotContains(t, result, "monitor_id")
+		assert.Equal(t, "live", result["status"])
+	})
+
+	t.Run("secure variables and user fields are preserved", func(t *testing.T) {
+		prior := unmarshalTestJSON(t, `{
+			"public_id": "abc-def-ghi",
+			"name": "checkout",
+			"type": "api",
+			"config": {
+				"variables": [
+					{"name": "PASSWORD", "type": "text", "secure": true, "example": "{{ SECRET }}", "pattern": "{{ SECRET }}"},
+					{"name": "USER", "type": "text", "example": "jdoe", "pattern": "jdoe"}
+				]
+			},
+			"options": {"tick_every": 60}
+		}`)
+		flattened := flattenSyntheticsTestJSON(unmarshalTestJSON(t, api), prior)
+		assert.Equal(t, prior, flattened)
+		assert.NotContains(t, prior, "public_id")
+	})
+
+	t.Run("drift is reported", func(t *testing.T) {
+		prior := unmarshalTestJSON(t, `{"name": "old name", "type": "api"}`)
+		flattened := flattenSyntheticsTestJSON(unmarshalTestJSON(t, api), prior)
+		assert.Equal(t, map[string]any{"name": "checkout", "type": "api"}, flattened)
+	})
+}
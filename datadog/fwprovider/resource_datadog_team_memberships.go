@@ -0,0 +1,477 @@
+package fwprovider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV2"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/utils"
+)
+
+var (
+	_ resource.ResourceWithConfigure   = &teamMembershipsResource{}
+	_ resource.ResourceWithImportState = &teamMembershipsResource{}
+	_ resource.ResourceWithModifyPlan  = &teamMembershipsResource{}
+)
+
+type teamMembershipsResource struct {
+	Api   *datadogV2.TeamsApi
+	Auth  context.Context
+	Users *datadogV2.UsersApi
+}
+
+type teamMembershipsModel struct {
+	ID        types.String `tfsdk:"id"`
+	TeamID    types.String `tfsdk:"team_id"`
+	Members   types.Set    `tfsdk:"members"`
+	Exclusive types.Bool   `tfsdk:"exclusive"`
+}
+
+func NewTeamMembershipsResource() resource.Resource {
+	return &teamMembershipsResource{}
+}
+
+func (r *teamMembershipsResource) Configure(_ context.Context, request resource.ConfigureRequest, response *resource.ConfigureResponse) {
+	providerData, _ := request.ProviderData.(*FrameworkProvider)
+	r.Api = providerData.DatadogApiInstances.GetTeamsApiV2()
+	r.Auth = providerData.Auth
+	r.Users = providerData.DatadogApiInstances.GetUsersApiV2()
+}
+
+func (r *teamMembershipsResource) Metadata(_ context.Context, request resource.MetadataRequest, response *resource.MetadataResponse) {
+	response.TypeName = "team_memberships"
+}
+
+func (r *teamMembershipsResource) Schema(_ context.Context, _ resource.SchemaRequest, response *resource.SchemaResponse) {
+	response.Schema = schema.Schema{
+		Description: "Provides a Datadog resource to manage the members of a team by email. Conflicts may occur if used together with the `datadog_team_membership` resource for the same team. The teams API has no batch endpoint, so applying the resource makes one request per added or removed member, one at a time. Each request is retried as configured by the `http_client_retry_*` provider settings, and the apply stops after 5 failed requests.",
+		Attributes: map[string]schema.Attribute{
+			"id": utils.ResourceIDAttribute(),
+			"team_id": schema.StringAttribute{
+				Required:    true,
+				Description: "ID of the team.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"members": userSetAttribute("Emails of the members of the team."),
+			"exclusive": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+				Description: "Whether the resource is authoritative for the members of the team. When `true`, the members which are not in `members` are removed from the team. When `false`, only the members in `members` are managed.",
+			},
+		},
+	}
+}
+
+func (r *teamMembershipsResource) ImportState(ctx context.Context, request resource.ImportStateRequest, response *resource.ImportStateResponse) {
+	response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("team_id"), request.ID)...)
+	response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("exclusive"), true)...)
+}
+
+func (r *teamMembershipsResource) ModifyPlan(ctx context.Context, request resource.ModifyPlanRequest, response *resource.ModifyPlanResponse) {
+	response.Diagnostics.Append(checkUserSetPlan(ctx, r.Auth, r.Users, request, path.Root("members"))...)
+}
+
+func (r *teamMembershipsResource) Read(ctx context.Context, request resource.ReadRequest, response *resource.ReadResponse) {
+	var state teamMembershipsModel
+	response.Diagnostics.Append(request.State.Get(ctx, &state)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	previous, diags := userSetEmails(ctx, state.Members)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	current, httpResp, err := listTeamMembers(r.Auth, r.Api, r.Users, state.TeamID.ValueString())
+	if err != nil {
+		if httpResp != nil && httpResp.StatusCode == 404 {
+			response.State.RemoveResource(ctx)
+			return
+		}
+		response.Diagnostics.Append(utils.FrameworkErrorDiag(err, "error listing team memberships"))
+		return
+	}
+
+	state.ID = state.TeamID
+	state.Members, diags = types.SetValueFrom(ctx, types.StringType, readUserSet(current, previous, state.Exclusive.ValueBool()))
+	response.Diagnostics.Append(diags...)
+	response.Diagnostics.Append(response.State.Set(ctx, &state)...)
+}
+
+func (r *teamMembershipsResource) Create(ctx context.Context, request resource.CreateRequest, response *resource.CreateResponse) {
+	var plan teamMembershipsModel
+	response.Diagnostics.Append(request.Plan.Get(ctx, &plan)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	response.Diagnostics.Append(r.apply(ctx, &plan, types.SetNull(types.StringType))...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	response.Diagnostics.Append(response.State.Set(ctx, &plan)...)
+}
+
+func (r *teamMembershipsResource) Update(ctx context.Context, request resource.UpdateRequest, response *resource.UpdateResponse) {
+	var plan, state teamMembershipsModel
+	response.Diagnostics.Append(request.Plan.Get(ctx, &plan)...)
+	response.Diagnostics.Append(request.State.Get(ctx, &state)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	response.Diagnostics.Append(r.apply(ctx, &plan, state.Members)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	response.Diagnostics.Append(response.State.Set(ctx, &plan)...)
+}
+
+func (r *teamMembershipsResource) Delete(ctx context.Context, request resource.DeleteRequest, response *resource.DeleteResponse) {
+	var state teamMembershipsModel
+	response.Diagnostics.Append(request.State.Get(ctx, &state)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	emails, diags := userSetEmails(ctx, state.Members)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	teamID := state.TeamID.ValueString()
+	current, httpResp, err := listTeamMembers(r.Auth, r.Api, r.Users, teamID)
+	if err != nil {
+		if httpResp != nil && httpResp.StatusCode == 404 {
+			return
+		}
+		response.Diagnostics.Append(utils.FrameworkErrorDiag(err, "error listing team memberships"))
+		return
+	}
+	response.Diagnostics.Append(applyUserSet(r.teamMembers(teamID), r.Auth, r.Users, current, nil, emails, false)...)
+}
+
+func (r *teamMembershipsResource) apply(ctx context.Context, plan *teamMembershipsModel, previousMembers types.Set) diag.Diagnostics {
+	desired, diags := userSetEmails(ctx, plan.Members)
+	previous, d := userSetEmails(ctx, previousMembers)
+	diags.Append(d...)
+	if diags.HasError() {
+		return diags
+	}
+	teamID := plan.TeamID.ValueString()
+	current, _, err := listTeamMembers(r.Auth, r.Api, r.Users, teamID)
+	if err != nil {
+		diags.Append(utils.FrameworkErrorDiag(err, "error listing team memberships"))
+		return diags
+	}
+	diags.Append(applyUserSet(r.teamMembers(teamID), r.Auth, r.Users, current, desired, previous, plan.Exclusive.ValueBool())...)
+	plan.ID = plan.TeamID
+	return diags
+}
+
+func (r *teamMembershipsResource) teamMembers(teamID string) userSetOps {
+	return userSetOps{
+		add:    func(userID string) error { return addTeamMember(r.Auth, r.Api, teamID, userID) },
+		remove: func(userID string) error { return removeTeamMember(r.Auth, r.Api, teamID, userID) },
+	}
+}
+
+// listTeamMembers returns the members of a team by lowercase email.
+func listTeamMembers(auth context.Context, api *datadogV2.TeamsApi, users *datadogV2.UsersApi, teamID string) (map[string]userSetMember, *http.Response, error) {
+	pageSize := int64(100)
+	members := map[string]userSetMember{}
+	var missing []string
+	for pageNumber := int64(0); ; pageNumber++ {
+		resp, httpResp, err := api.GetTeamMemberships(auth, teamID, *datadogV2.NewGetTeamMembershipsOptionalParameters().
+			WithPageSize(pageSize).
+			WithPageNumber(pageNumber))
+		if err != nil {
+			return nil, httpResp, err
+		}
+		emails := map[string]string{}
+		for _, included := range resp.GetIncluded() {
+			if included.User != nil {
+				attributes := included.User.GetAttributes()
+				emails[included.User.GetId()] = attributes.GetEmail()
+			}
+		}
+		for _, membership := range resp.GetData() {
+			relationships := membership.GetRelationships()
+			user := relationships.GetUser()
+			userID := user.Data.GetId()
+			if email, ok := emails[userID]; ok {
+				members[strings.ToLower(email)] = userSetMember{ID: userID, Email: email}
+			} else {
+				missing = append(missing, userID)
+			}
+		}
+		if int64(len(resp.GetData())) < pageSize {
+			break
+		}
+	}
+	// Users are included in the responses, only fetch the ones which are not.
+	for _, userID := range missing {
+		resp, httpResp, err := users.GetUser(auth, userID)
+		if err != nil {
+			return nil, httpResp, err
+		}
+		user := resp.GetData()
+		attributes := user.GetAttributes()
+		email := attributes.GetEmail()
+		members[strings.ToLower(email)] = userSetMember{ID: userID, Email: email}
+	}
+	return members, nil, nil
+}
+
+func addTeamMember(auth context.Context, api *datadogV2.TeamsApi, teamID, userID string) error {
+	relationships := datadogV2.NewUserTeamRelationshipsWithDefaults()
+	relationships.User = &datadogV2.RelationshipToUserTeamUser{
+		Data: *datadogV2.NewRelationshipToUserTeamUserDataWithDefaults(),
+	}
+	relationships.User.Data.Id = userID
+
+	body := datadogV2.NewUserTeamRequestWithDefaults()
+	body.Data = *datadogV2.NewUserTeamCreateWithDefaults()
+	body.Data.SetRelationships(*relationships)
+	if _, httpResp, err := api.CreateTeamMembership(auth, teamID, *body); err != nil {
+		return utils.TranslateClientError(err, httpResp, "")
+	}
+	return nil
+}
+
+func removeTeamMember(auth context.Context, api *datadogV2.TeamsApi, teamID, userID string) error {
+	httpResp, err := api.DeleteTeamMembership(auth, teamID, userID)
+	if err != nil && (httpResp == nil || httpResp.StatusCode != 404) {
+		return utils.TranslateClientError(err, httpResp, "")
+	}
+	return nil
+}
+
+type userSetMember struct {
+	ID    string
+	Email string
+}
+
+func userSetAttribute(description string) schema.SetAttribute {
+	return schema.SetAttribute{
+		Required:    true,
+		ElementType: types.StringType,
+		Description: description + " Emails are matched case-insensitively, and must belong to existing users.",
+	}
+}
+
+// checkUserSetPlan warns when the emails added to a user set do not belong to
+// existing users. It is not an error, as the users may be created by the same
+// apply.
+func checkUserSetPlan(ctx context.Context, auth context.Context, users *datadogV2.UsersApi, request resource.ModifyPlanRequest, attributePath path.Path) diag.Diagnostics {
+	var planned, prior types.Set
+	diags := request.Plan.GetAttribute(ctx, attributePath, &planned)
+	if !request.State.Raw.IsNull() {
+		diags.Append(request.State.GetAttribute(ctx, attributePath, &prior)...)
+	}
+	if diags.HasError() || planned.IsUnknown() {
+		return diags
+	}
+	desired, d := userSetEmails(ctx, planned)
+	diags.Append(d...)
+	previous, d := userSetEmails(ctx, prior)
+	diags.Append(d...)
+	if diags.HasError() {
+		return diags
+	}
+
+	known := map[string]bool{}
+	for _, email := range previous {
+		known[strings.ToLower(email)] = true
+	}
+	var added []string
+	for _, email := range desired {
+		if !known[strings.ToLower(email)] {
+			added = append(added, email)
+		}
+	}
+	if len(added) == 0 {
+		return diags
+	}
+	if _, err := resolveUserEmails(auth, users, added); err != nil {
+		diags.AddAttributeWarning(attributePath, "Unknown users", err.Error()+". The apply fails unless these users are created first, for example by `datadog_user` resources of the same configuration.")
+	}
+	return diags
+}
+
+// userSetOps adds and removes the users of a team or a role.
+type userSetOps struct {
+	add, remove func(userID string) error
+}
+
+// userSetMaxErrors bounds the failed requests of an apply. The API client
+// already retries each request on rate limits and server errors, as configured
+// by the `http_client_retry_*` provider settings.
+const userSetMaxErrors = 5
+
+// applyUserSet adds the desired users to a team or a role, and removes the
+// other users it manages. current are the users of the team or the role, and
+// previous the emails of the prior state.
+func applyUserSet(ops userSetOps, auth context.Context, users *datadogV2.UsersApi, current map[string]userSetMember, desired, previous []string, exclusive bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+	add, remove := diffUserSet(desired, previous, current, exclusive)
+
+	// Resolve all the added emails at once.
+	userIDs, err := resolveUserEmails(auth, users, add)
+	if err != nil {
+		diags.AddError("Unknown users", err.Error())
+		return diags
+	}
+	// There is no batch endpoint, so users are added and removed one request
+	// at a time.
+	type change struct {
+		apply func() error
+		msg   string
+	}
+	var changes []change
+	for _, email := range add {
+		userID := userIDs[strings.ToLower(email)]
+		changes = append(changes, change{func() error { return ops.add(userID) }, "error adding user " + email})
+	}
+	for _, email := range remove {
+		member := current[email]
+		changes = append(changes, change{func() error { return ops.remove(member.ID) }, "error removing user " + member.Email})
+	}
+	failed := 0
+	for i, c := range changes {
+		if failed == userSetMaxErrors {
+			diags.AddError("Too many errors", fmt.Sprintf("stopped after %d failed requests, %d changes were not applied", failed, len(changes)-i))
+			break
+		}
+		if err := c.apply(); err != nil {
+			diags.Append(utils.FrameworkErrorDiag(err, c.msg))
+			failed++
+		}
+	}
+	return diags
+}
+
+// readUserSet returns the emails of the users tracked in the state. Exclusive
+// resources track all the users of the parent, others only the users they
+// manage. The spelling of the emails in the prior state is kept.
+func readUserSet(current map[string]userSetMember, previous []string, exclusive bool) []string {
+	managed := make(map[string]string, len(previous))
+	for _, email := range previous {
+		managed[strings.ToLower(email)] = email
+	}
+	var emails []string
+	for key, member := range current {
+		if email, ok := managed[key]; ok {
+			emails = append(emails, email)
+		} else if exclusive {
+			emails = append(emails, member.Email)
+		}
+	}
+	sort.Strings(emails)
+	return emails
+}
+
+// diffUserSet returns the emails to add, and the lowercase emails to remove
+// to move the parent from its current users to the desired ones.
+func diffUserSet(desired, previous []string, current map[string]userSetMember, exclusive bool) ([]string, []string) {
+	wanted := make(map[string]bool, len(desired))
+	var add []string
+	for _, email := range desired {
+		key := strings.ToLower(email)
+		wanted[key] = true
+		if _, ok := current[key]; !ok {
+			add = append(add, email)
+		}
+	}
+	var remove []string
+	for _, email := range readUserSet(current, previous, exclusive) {
+		if key := strings.ToLower(email); !wanted[key] {
+			remove = append(remove, key)
+		}
+	}
+	sort.Strings(add)
+	return add, remove
+}
+
+// resolveUserEmailsFilterLimit is the number of emails above which users are
+// resolved by listing all the users of the organization once, instead of one
+// search per email.
+const resolveUserEmailsFilterLimit = 10
+
+// resolveUserEmails returns the IDs of the users with the given emails, by
+// lowercase email.
+func resolveUserEmails(auth context.Context, users *datadogV2.UsersApi, emails []string) (map[string]string, error) {
+	userIDs := make(map[string]string, len(emails))
+	if len(emails) == 0 {
+		return userIDs, nil
+	}
+	wanted := make(map[string]bool, len(emails))
+	for _, email := range emails {
+		wanted[strings.ToLower(email)] = true
+	}
+
+	pageSize := int64(100)
+	listUsers := func(filter *string) error {
+		for pageNumber := int64(0); ; pageNumber++ {
+			resp, httpResp, err := users.ListUsers(auth, datadogV2.ListUsersOptionalParameters{Filter: filter, PageSize: &pageSize, PageNumber: &pageNumber})
+			if err != nil {
+				return utils.TranslateClientError(err, httpResp, "error listing users")
+			}
+			for _, user := range resp.GetData() {
+				attributes := user.GetAttributes()
+				if key := strings.ToLower(attributes.GetEmail()); wanted[key] && !attributes.GetDisabled() {
+					userIDs[key] = user.GetId()
+				}
+			}
+			if int64(len(resp.GetData())) < pageSize || len(userIDs) == len(wanted) {
+				return nil
+			}
+		}
+	}
+	if len(wanted) > resolveUserEmailsFilterLimit {
+		if err := listUsers(nil); err != nil {
+			return nil, err
+		}
+	} else {
+		for email := range wanted {
+			filter := email
+			if err := listUsers(&filter); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	var missing []string
+	for email := range wanted {
+		if _, ok := userIDs[email]; !ok {
+			missing = append(missing, email)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return nil, fmt.Errorf("no active user found with the emails %s", strings.Join(missing, ", "))
+	}
+	return userIDs, nil
+}
+
+func userSetEmails(ctx context.Context, set types.Set) ([]string, diag.Diagnostics) {
+	var emails []string
+	if set.IsNull() || set.IsUnknown() {
+		return emails, nil
+	}
+	diags := set.ElementsAs(ctx, &emails, false)
+	return emails, diags
+}
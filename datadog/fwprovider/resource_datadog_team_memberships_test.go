@@ -0,0 +1,58 @@
+package fwprovider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserSetDiff(t *testing.T) {
+	current := map[string]userSetMember{
+		"alice@example.com": {ID: "1", Email: "Alice@example.com"},
+		"bob@example.com":   {ID: "2", Email: "bob@example.com"},
+		"carol@example.com": {ID: "3", Email: "carol@example.com"},
+	}
+
+	t.Run("exclusive", func(t *testing.T) {
+		assert.Equal(t, []string{"Alice@example.com", "bob@example.com", "carol@example.com"}, readUserSet(current, nil, true))
+		// The spelling of the configuration is kept.
+		assert.Equal(t, []string{"alice@example.com", "bob@example.com", "carol@example.com"}, readUserSet(current, []string{"alice@example.com", "dave@example.com"}, true))
+
+		add, remove := diffUserSet([]string{"ALICE@example.com", "dave@example.com"}, nil, current, true)
+		assert.Equal(t, []string{"dave@example.com"}, add)
+		assert.Equal(t, []string{"bob@example.com", "carol@example.com"}, remove)
+	})
+
+	t.Run("additive", func(t *testing.T) {
+		assert.Equal(t, []string{"bob@example.com"}, readUserSet(current, []string{"bob@example.com", "dave@example.com"}, false))
+
+		add, remove := diffUserSet([]string{"alice@example.com", "dave@example.com"}, []string{"alice@example.com", "bob@example.com"}, current, false)
+		assert.Equal(t, []string{"dave@example.com"}, add)
+		assert.Equal(t, []string{"bob@example.com"}, remove)
+	})
+}
+
+func TestApplyUserSetStopsOnErrors(t *testing.T) {
+	current := map[string]userSetMember{}
+	for i := 0; i < 8; i++ {
+		email := fmt.Sprintf("user%d@example.com", i)
+		current[email] = userSetMember{ID: fmt.Sprint(i), Email: email}
+	}
+	requests := 0
+	ops := userSetOps{
+		add: func(string) error { return errors.New("unexpected") },
+		remove: func(string) error {
+			requests++
+			return errors.New("rate limited")
+		},
+	}
+
+	diags := applyUserSet(ops, context.Background(), nil, current, nil, nil, true)
+	assert.Equal(t, userSetMaxErrors, requests)
+	require.Len(t, diags, userSetMaxErrors+1)
+	assert.Equal(t, "stopped after 5 failed requests, 3 changes were not applied", diags[userSetMaxErrors].Detail())
+}
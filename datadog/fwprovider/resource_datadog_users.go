@@ -0,0 +1,580 @@
+package fwprovider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV2"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/utils"
+)
+
+var (
+	_ resource.ResourceWithConfigure      = &usersResource{}
+	_ resource.ResourceWithValidateConfig = &usersResource{}
+)
+
+type usersResource struct {
+	Api   *datadogV2.UsersApi
+	Auth  context.Context
+	Roles *datadogV2.RolesApi
+	Teams *datadogV2.TeamsApi
+}
+
+type usersModel struct {
+	ID                 types.String     `tfsdk:"id"`
+	SendUserInvitation types.Bool       `tfsdk:"send_user_invitation"`
+	Users              []*bulkUserModel `tfsdk:"user"`
+	UserIDs            types.Map        `tfsdk:"user_ids"`
+	Failures           types.Map        `tfsdk:"failures"`
+}
+
+type bulkUserModel struct {
+	Email    types.String `tfsdk:"email"`
+	Name     types.String `tfsdk:"name"`
+	Roles    types.Set    `tfsdk:"roles"`
+	Teams    types.Set    `tfsdk:"teams"`
+	Disabled types.Bool   `tfsdk:"disabled"`
+}
+
+func NewUsersResource() resource.Resource {
+	return &usersResource{}
+}
+
+func (r *usersResource) Configure(_ context.Context, request resource.ConfigureRequest, response *resource.ConfigureResponse) {
+	providerData, _ := request.ProviderData.(*FrameworkProvider)
+	r.Api = providerData.DatadogApiInstances.GetUsersApiV2()
+	r.Auth = providerData.Auth
+	r.Roles = providerData.DatadogApiInstances.GetRolesApiV2()
+	r.Teams = providerData.DatadogApiInstances.GetTeamsApiV2()
+}
+
+func (r *usersResource) Metadata(_ context.Context, request resource.MetadataRequest, response *resource.MetadataResponse) {
+	response.TypeName = "users"
+}
+
+func (r *usersResource) Schema(_ context.Context, _ resource.SchemaRequest, response *resource.SchemaResponse) {
+	response.Schema = schema.Schema{
+		Description: "Provides a Datadog resource to provision a set of users, for example from an identity source file. Users are created, updated, disabled and re-enabled to match the configuration, and users removed from the configuration are disabled. A failure on a user is reported as a warning and in `failures`, without failing the other users. Conflicts may occur if used together with the `datadog_user` resource for the same users.",
+		Attributes: map[string]schema.Attribute{
+			"id": utils.ResourceIDAttribute(),
+			"send_user_invitation": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+				Description: "Whether an invitation email should be sent to the users when they are created.",
+			},
+			"user_ids": schema.MapAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "The IDs of the users by email.",
+			},
+			"failures": schema.MapAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "The errors of the last apply by email, for the users which could not be reconciled. They are retried on the next apply.",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"user": schema.SetNestedBlock{
+				Description: "A user to provision.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"email": schema.StringAttribute{
+							Required:    true,
+							Description: "Email address of the user. Emails are matched case-insensitively.",
+						},
+						"name": schema.StringAttribute{
+							Optional:    true,
+							Description: "Name of the user. Should be set only for password authentication, as it is overridden by Google or SAML authentication.",
+						},
+						"roles": schema.SetAttribute{
+							Optional:    true,
+							ElementType: types.StringType,
+							Description: "IDs of the roles of the user. The roles are not managed when unset.",
+						},
+						"teams": schema.SetAttribute{
+							Optional:    true,
+							ElementType: types.StringType,
+							Description: "IDs of the teams of the user. The user is removed from the teams of the other users of the resource which are not listed. The teams are not managed when unset.",
+						},
+						"disabled": schema.BoolAttribute{
+							Optional:    true,
+							Description: "Whether the user is disabled.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *usersResource) ValidateConfig(ctx context.Context, request resource.ValidateConfigRequest, response *resource.ValidateConfigResponse) {
+	var config usersModel
+	response.Diagnostics.Append(request.Config.Get(ctx, &config)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	seen := map[string]bool{}
+	for _, user := range config.Users {
+		if user.Email.IsUnknown() || user.Email.IsNull() {
+			continue
+		}
+		key := strings.ToLower(user.Email.ValueString())
+		if seen[key] {
+			response.Diagnostics.AddAttributeError(path.Root("user"), "Duplicate user", fmt.Sprintf("user %s is defined more than once", user.Email.ValueString()))
+		}
+		seen[key] = true
+	}
+}
+
+func (r *usersResource) Read(ctx context.Context, request resource.ReadRequest, response *resource.ReadResponse) {
+	var state usersModel
+	response.Diagnostics.Append(request.State.Get(ctx, &state)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	existing, err := r.listUsersByEmail()
+	if err != nil {
+		response.Diagnostics.Append(utils.FrameworkErrorDiag(err, "error listing users"))
+		return
+	}
+	teams := newBulkUserTeams(r, state.Users)
+
+	userIDs := map[string]string{}
+	var users []*bulkUserModel
+	for _, user := range state.Users {
+		email := user.Email.ValueString()
+		current, ok := existing[strings.ToLower(email)]
+		if !ok {
+			// The user was never created, or was removed outside Terraform.
+			continue
+		}
+		userIDs[email] = current.GetId()
+		attributes := current.GetAttributes()
+		if !user.Name.IsNull() {
+			user.Name = types.StringValue(attributes.GetName())
+		}
+		if !user.Disabled.IsNull() || attributes.GetDisabled() {
+			user.Disabled = types.BoolValue(attributes.GetDisabled())
+		}
+		if !user.Roles.IsNull() {
+			var diags diag.Diagnostics
+			user.Roles, diags = types.SetValueFrom(ctx, types.StringType, bulkUserRoles(current))
+			response.Diagnostics.Append(diags...)
+		}
+		if !user.Teams.IsNull() {
+			memberOf, err := teams.memberOf(email)
+			if err != nil {
+				response.Diagnostics.Append(utils.FrameworkErrorDiag(err, "error listing team memberships"))
+				return
+			}
+			var diags diag.Diagnostics
+			user.Teams, diags = types.SetValueFrom(ctx, types.StringType, memberOf)
+			response.Diagnostics.Append(diags...)
+		}
+		users = append(users, user)
+	}
+	state.Users = users
+
+	var diags diag.Diagnostics
+	state.UserIDs, diags = types.MapValueFrom(ctx, types.StringType, userIDs)
+	response.Diagnostics.Append(diags...)
+	response.Diagnostics.Append(response.State.Set(ctx, &state)...)
+}
+
+func (r *usersResource) Create(ctx context.Context, request resource.CreateRequest, response *resource.CreateResponse) {
+	var plan usersModel
+	response.Diagnostics.Append(request.Plan.Get(ctx, &plan)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	plan.ID = types.StringValue(uuid.New().String())
+	response.Diagnostics.Append(r.apply(ctx, &plan, nil)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	response.Diagnostics.Append(response.State.Set(ctx, &plan)...)
+}
+
+func (r *usersResource) Update(ctx context.Context, request resource.UpdateRequest, response *resource.UpdateResponse) {
+	var plan, state usersModel
+	response.Diagnostics.Append(request.Plan.Get(ctx, &plan)...)
+	response.Diagnostics.Append(request.State.Get(ctx, &state)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	response.Diagnostics.Append(r.apply(ctx, &plan, state.Users)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	response.Diagnostics.Append(response.State.Set(ctx, &plan)...)
+}
+
+func (r *usersResource) Delete(ctx context.Context, request resource.DeleteRequest, response *resource.DeleteResponse) {
+	var state usersModel
+	response.Diagnostics.Append(request.State.Get(ctx, &state)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	userIDs := map[string]string{}
+	response.Diagnostics.Append(state.UserIDs.ElementsAs(ctx, &userIDs, false)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	// Datadog does not delete users, disable them instead.
+	for email, userID := range userIDs {
+		if httpResp, err := r.Api.DisableUser(r.Auth, userID); err != nil && (httpResp == nil || httpResp.StatusCode != 404) {
+			response.Diagnostics.Append(utils.FrameworkErrorDiag(utils.TranslateClientError(err, httpResp, ""), "error disabling user "+email))
+		}
+	}
+}
+
+// apply reconciles the planned users, and disables the users of the prior
+// state which are not planned anymore. Errors on a user are recorded in the
+// failures of the plan, and only reported as warnings.
+func (r *usersResource) apply(ctx context.Context, plan *usersModel, prior []*bulkUserModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	existing, err := r.listUsersByEmail()
+	if err != nil {
+		diags.Append(utils.FrameworkErrorDiag(err, "error listing users"))
+		return diags
+	}
+	teams := newBulkUserTeams(r, append(append([]*bulkUserModel{}, plan.Users...), prior...))
+
+	userIDs := map[string]string{}
+	failures := map[string]string{}
+	var invitations []string
+	planned := map[string]bool{}
+	for _, user := range plan.Users {
+		email := user.Email.ValueString()
+		planned[strings.ToLower(email)] = true
+		current, ok := existing[strings.ToLower(email)]
+		var err error
+		if !ok {
+			var created bool
+			current, created, err = r.createUser(ctx, user)
+			if created {
+				invitations = append(invitations, email)
+			} else {
+				// The user already existed, reconcile it as a listed one.
+				ok = err == nil
+			}
+		}
+		if current != nil {
+			userIDs[email] = current.GetId()
+			if ok {
+				err = r.updateUser(ctx, user, current)
+			}
+			if err == nil {
+				err = teams.reconcile(ctx, user, current.GetId())
+			}
+		}
+		if err != nil {
+			failures[email] = err.Error()
+		}
+	}
+
+	for _, user := range prior {
+		email := user.Email.ValueString()
+		if planned[strings.ToLower(email)] {
+			continue
+		}
+		current, ok := existing[strings.ToLower(email)]
+		if !ok || current.Attributes.GetDisabled() {
+			continue
+		}
+		if httpResp, err := r.Api.DisableUser(r.Auth, current.GetId()); err != nil && (httpResp == nil || httpResp.StatusCode != 404) {
+			failures[email] = utils.TranslateClientError(err, httpResp, "error disabling user").Error()
+		}
+	}
+
+	if plan.SendUserInvitation.ValueBool() && len(invitations) > 0 {
+		if err := r.sendInvitations(invitations, userIDs); err != nil {
+			for _, email := range invitations {
+				failures[email] = err.Error()
+			}
+		}
+	}
+
+	if len(failures) > 0 {
+		emails := make([]string, 0, len(failures))
+		for email := range failures {
+			emails = append(emails, email)
+		}
+		sort.Strings(emails)
+		for _, email := range emails {
+			diags.AddWarning("Failed to reconcile user "+email, failures[email])
+		}
+	}
+	var d diag.Diagnostics
+	plan.UserIDs, d = types.MapValueFrom(ctx, types.StringType, userIDs)
+	diags.Append(d...)
+	plan.Failures, d = types.MapValueFrom(ctx, types.StringType, failures)
+	diags.Append(d...)
+	return diags
+}
+
+// listUsersByEmail lists all the users of the organization, including the
+// disabled ones, by lowercase email.
+func (r *usersResource) listUsersByEmail() (map[string]*datadogV2.User, error) {
+	users := map[string]*datadogV2.User{}
+	resp, _ := r.Api.ListUsersWithPagination(r.Auth, *datadogV2.NewListUsersOptionalParameters().WithPageSize(500))
+	for paginationResult := range resp {
+		if paginationResult.Error != nil {
+			return nil, paginationResult.Error
+		}
+		user := paginationResult.Item
+		users[strings.ToLower(user.Attributes.GetEmail())] = &user
+	}
+	return users, nil
+}
+
+// createUser creates a user, and returns whether it was created. Datadog does
+// not actually delete users, so the creation may conflict with a user which is
+// not listed: the existing user is returned instead, as with `datadog_user`.
+func (r *usersResource) createUser(ctx context.Context, user *bulkUserModel) (*datadogV2.User, bool, error) {
+	attributes := datadogV2.NewUserCreateAttributesWithDefaults()
+	attributes.SetEmail(user.Email.ValueString())
+	if !user.Name.IsNull() {
+		attributes.SetName(user.Name.ValueString())
+	}
+	data := datadogV2.NewUserCreateDataWithDefaults()
+	data.SetAttributes(*attributes)
+
+	var roles []string
+	user.Roles.ElementsAs(ctx, &roles, false)
+	rolesData := make([]datadogV2.RelationshipToRoleData, len(roles))
+	for i, role := range roles {
+		rolesData[i] = *datadogV2.NewRelationshipToRoleData()
+		rolesData[i].SetId(role)
+	}
+	relationships := datadogV2.NewUserRelationships()
+	relationships.SetRoles(datadogV2.RelationshipToRoles{Data: rolesData})
+	data.SetRelationships(*relationships)
+
+	body := datadogV2.NewUserCreateRequestWithDefaults()
+	body.SetData(*data)
+	resp, httpResp, err := r.Api.CreateUser(r.Auth, *body)
+	if err != nil {
+		if httpResp != nil && httpResp.StatusCode == 409 {
+			existing, err := r.findUser(user.Email.ValueString())
+			return existing, false, err
+		}
+		return nil, false, utils.TranslateClientError(err, httpResp, "error creating user")
+	}
+	created := resp.GetData()
+	if user.Disabled.ValueBool() {
+		if httpResp, err := r.Api.DisableUser(r.Auth, created.GetId()); err != nil {
+			return &created, true, utils.TranslateClientError(err, httpResp, "error disabling user")
+		}
+	}
+	return &created, true, nil
+}
+
+// findUser searches the user with the given email.
+func (r *usersResource) findUser(email string) (*datadogV2.User, error) {
+	resp, httpResp, err := r.Api.ListUsers(r.Auth, *datadogV2.NewListUsersOptionalParameters().WithFilter(email))
+	if err != nil {
+		return nil, utils.TranslateClientError(err, httpResp, "error searching user")
+	}
+	for _, user := range resp.GetData() {
+		if strings.EqualFold(user.Attributes.GetEmail(), email) {
+			return &user, nil
+		}
+	}
+	// Another user has the email as handle.
+	return nil, fmt.Errorf("a user already exists with handle %s", email)
+}
+
+// updateUser updates an existing user, re-enabling it if it is disabled and
+// not planned as such.
+func (r *usersResource) updateUser(ctx context.Context, user *bulkUserModel, current *datadogV2.User) error {
+	attributes := current.GetAttributes()
+	userID := current.GetId()
+	if (!user.Name.IsNull() && user.Name.ValueString() != attributes.GetName()) || user.Disabled.ValueBool() != attributes.GetDisabled() {
+		updateAttributes := datadogV2.NewUserUpdateAttributesWithDefaults()
+		if !user.Name.IsNull() {
+			updateAttributes.SetName(user.Name.ValueString())
+		}
+		updateAttributes.SetDisabled(user.Disabled.ValueBool())
+		data := datadogV2.NewUserUpdateDataWithDefaults()
+		data.SetAttributes(*updateAttributes)
+		data.SetId(userID)
+		body := datadogV2.NewUserUpdateRequestWithDefaults()
+		body.SetData(*data)
+		if _, httpResp, err := r.Api.UpdateUser(r.Auth, userID, *body); err != nil {
+			return utils.TranslateClientError(err, httpResp, "error updating user")
+		}
+	}
+
+	if user.Roles.IsNull() {
+		return nil
+	}
+	var roles []string
+	user.Roles.ElementsAs(ctx, &roles, false)
+	wanted := map[string]bool{}
+	for _, role := range roles {
+		wanted[role] = true
+	}
+	has := map[string]bool{}
+	for _, role := range bulkUserRoles(current) {
+		has[role] = true
+		if !wanted[role] {
+			if err := removeRoleUser(r.Auth, r.Roles, role, userID); err != nil {
+				return fmt.Errorf("error removing role %s: %w", role, err)
+			}
+		}
+	}
+	for _, role := range roles {
+		if !has[role] {
+			if err := addRoleUser(r.Auth, r.Roles, role, userID); err != nil {
+				return fmt.Errorf("error adding role %s: %w", role, err)
+			}
+		}
+	}
+	return nil
+}
+
+// sendInvitations sends the invitations of the created users in a single
+// request.
+func (r *usersResource) sendInvitations(emails []string, userIDs map[string]string) error {
+	invitations := make([]datadogV2.UserInvitationData, 0, len(emails))
+	for _, email := range emails {
+		userData := datadogV2.NewRelationshipToUserDataWithDefaults()
+		userData.SetId(userIDs[email])
+		user := datadogV2.NewRelationshipToUserWithDefaults()
+		user.SetData(*userData)
+		relationships := datadogV2.NewUserInvitationRelationshipsWithDefaults()
+		relationships.SetUser(*user)
+		invitation := datadogV2.NewUserInvitationDataWithDefaults()
+		invitation.SetRelationships(*relationships)
+		invitations = append(invitations, *invitation)
+	}
+	body := datadogV2.NewUserInvitationsRequestWithDefaults()
+	body.SetData(invitations)
+	if _, httpResp, err := r.Api.SendInvitations(r.Auth, *body); err != nil {
+		return utils.TranslateClientError(err, httpResp, "error sending user invitations")
+	}
+	return nil
+}
+
+func bulkUserRoles(user *datadogV2.User) []string {
+	relationships := user.GetRelationships()
+	toRoles := relationships.GetRoles()
+	roles := make([]string, 0, len(toRoles.GetData()))
+	for _, role := range toRoles.GetData() {
+		roles = append(roles, role.GetId())
+	}
+	sort.Strings(roles)
+	return roles
+}
+
+// bulkUserTeams tracks the members of the teams referenced by the users of
+// the resource, each team being listed once.
+type bulkUserTeams struct {
+	api     *datadogV2.TeamsApi
+	auth    context.Context
+	users   *datadogV2.UsersApi
+	teamIDs []string
+	members map[string]map[string]userSetMember
+}
+
+func newBulkUserTeams(r *usersResource, users []*bulkUserModel) *bulkUserTeams {
+	referenced := map[string]bool{}
+	for _, user := range users {
+		for _, team := range user.Teams.Elements() {
+			if team, ok := team.(types.String); ok {
+				referenced[team.ValueString()] = true
+			}
+		}
+	}
+	teamIDs := make([]string, 0, len(referenced))
+	for teamID := range referenced {
+		teamIDs = append(teamIDs, teamID)
+	}
+	sort.Strings(teamIDs)
+	return &bulkUserTeams{
+		api:     r.Teams,
+		auth:    r.Auth,
+		users:   r.Api,
+		teamIDs: teamIDs,
+		members: map[string]map[string]userSetMember{},
+	}
+}
+
+func (t *bulkUserTeams) listMembers(teamID string) (map[string]userSetMember, error) {
+	if members, ok := t.members[teamID]; ok {
+		return members, nil
+	}
+	members, httpResp, err := listTeamMembers(t.auth, t.api, t.users, teamID)
+	if err != nil {
+		if httpResp != nil && httpResp.StatusCode == 404 {
+			members = map[string]userSetMember{}
+		} else {
+			return nil, utils.TranslateClientError(err, httpResp, "")
+		}
+	}
+	t.members[teamID] = members
+	return members, nil
+}
+
+// memberOf returns the referenced teams the user is a member of.
+func (t *bulkUserTeams) memberOf(email string) ([]string, error) {
+	teamIDs := []string{}
+	for _, teamID := range t.teamIDs {
+		members, err := t.listMembers(teamID)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := members[strings.ToLower(email)]; ok {
+			teamIDs = append(teamIDs, teamID)
+		}
+	}
+	return teamIDs, nil
+}
+
+// reconcile adds the user to its teams, and removes it from the other
+// referenced teams.
+func (t *bulkUserTeams) reconcile(ctx context.Context, user *bulkUserModel, userID string) error {
+	if user.Teams.IsNull() {
+		return nil
+	}
+	var teams []string
+	user.Teams.ElementsAs(ctx, &teams, false)
+	wanted := map[string]bool{}
+	for _, teamID := range teams {
+		wanted[teamID] = true
+	}
+	memberOf, err := t.memberOf(user.Email.ValueString())
+	if err != nil {
+		return err
+	}
+	has := map[string]bool{}
+	for _, teamID := range memberOf {
+		has[teamID] = true
+		if !wanted[teamID] {
+			if err := removeTeamMember(t.auth, t.api, teamID, userID); err != nil {
+				return fmt.Errorf("error removing team %s: %w", teamID, err)
+			}
+		}
+	}
+	for _, teamID := range teams {
+		if !has[teamID] {
+			if err := addTeamMember(t.auth, t.api, teamID, userID); err != nil {
+				return fmt.Errorf("error adding team %s: %w", teamID, err)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package fwprovider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/DataDog/datadog-api-client-go/v2/api/datadog"
+	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV2"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUsersApply(t *testing.T) {
+	var calls []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method + " " + r.URL.Path {
+		case "GET /api/v2/users":
+			if filter := r.URL.Query().Get("filter"); filter != "" {
+				// Users which are not listed, but conflict on creation.
+				if filter == "hidden@example.com" {
+					fmt.Fprint(w, `{"data": [{"id": "u-hidden", "type": "users", "attributes": {"email": "Hidden@example.com", "disabled": true}}]}`)
+				} else {
+					fmt.Fprint(w, `{"data": []}`)
+				}
+				return
+			}
+			fmt.Fprint(w, `{"data": [
+				{"id": "u-old", "type": "users", "attributes": {"email": "old@example.com", "disabled": false}},
+				{"id": "u-back", "type": "users", "attributes": {"email": "Back@example.com", "disabled": true}}
+			]}`)
+		case "POST /api/v2/users":
+			var body datadogV2.UserCreateRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			if email := body.Data.Attributes.Email; email == "taken@example.com" || email == "hidden@example.com" {
+				w.WriteHeader(http.StatusConflict)
+				fmt.Fprint(w, `{"errors": ["conflict"]}`)
+				return
+			}
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprintf(w, `{"data": {"id": "u-new", "type": "users", "attributes": {"email": %q}}}`, body.Data.Attributes.Email)
+		case "PATCH /api/v2/users/u-back", "PATCH /api/v2/users/u-hidden":
+			fmt.Fprint(w, `{"data": {"id": "u-back", "type": "users", "attributes": {"email": "back@example.com", "disabled": false}}}`)
+		case "DELETE /api/v2/users/u-old":
+			w.WriteHeader(http.StatusNoContent)
+		case "POST /api/v2/user_invitations":
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, `{"data": [{"id": "i-new", "type": "user_invitations"}]}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	config := datadog.NewConfiguration()
+	config.Servers = datadog.ServerConfigurations{{URL: server.URL}}
+	client := datadog.NewAPIClient(config)
+	r := &usersResource{Api: datadogV2.NewUsersApi(client), Auth: context.Background(), Roles: datadogV2.NewRolesApi(client), Teams: datadogV2.NewTeamsApi(client)}
+
+	newUser := func(email string) *bulkUserModel {
+		return &bulkUserModel{Email: types.StringValue(email), Roles: types.SetNull(types.StringType), Teams: types.SetNull(types.StringType)}
+	}
+	plan := &usersModel{
+		SendUserInvitation: types.BoolValue(true),
+		Users:              []*bulkUserModel{newUser("new@example.com"), newUser("back@example.com"), newUser("taken@example.com"), newUser("hidden@example.com")},
+	}
+	diags := r.apply(context.Background(), plan, []*bulkUserModel{newUser("old@example.com"), newUser("back@example.com")})
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, 1, diags.WarningsCount())
+
+	userIDs := map[string]string{}
+	plan.UserIDs.ElementsAs(context.Background(), &userIDs, false)
+	assert.Equal(t, map[string]string{"new@example.com": "u-new", "back@example.com": "u-back", "hidden@example.com": "u-hidden"}, userIDs)
+	failures := map[string]string{}
+	plan.Failures.ElementsAs(context.Background(), &failures, false)
+	assert.Equal(t, map[string]string{"taken@example.com": "a user already exists with handle taken@example.com"}, failures)
+
+	assert.ElementsMatch(t, []string{
+		"GET /api/v2/users",
+		"POST /api/v2/users",
+		"POST /api/v2/users",
+		"GET /api/v2/users",
+		"POST /api/v2/users",
+		"GET /api/v2/users",
+		"PATCH /api/v2/users/u-back",
+		"PATCH /api/v2/users/u-hidden",
+		"DELETE /api/v2/users/u-old",
+		"POST /api/v2/user_invitations",
+	}, calls)
+}
@@ -2,11 +2,14 @@ package fwutils
 
 import (
 	"context"
+	"fmt"
+	"math/big"
 	"sort"
 	"strconv"
 
 	"github.com/DataDog/datadog-api-client-go/v2/api/datadog"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 )
 
 func ToTerraformStr(v any, ok bool) types.String {
@@ -103,3 +106,61 @@ func SetOptStringList(typeCollection any, set func([]string), ctx context.Contex
 		}
 	}
 }
+
+// TerraformValueToGo converts a Terraform value into the types used by
+// encoding/json: objects and maps become map[string]any, lists, sets and
+// tuples become []any, and numbers become float64.
+func TerraformValueToGo(v tftypes.Value) (any, error) {
+	if !v.IsKnown() {
+		return nil, fmt.Errorf("value is unknown")
+	}
+	if v.IsNull() {
+		return nil, nil
+	}
+	switch typ := v.Type(); {
+	case typ.Is(tftypes.String):
+		var s string
+		err := v.As(&s)
+		return s, err
+	case typ.Is(tftypes.Bool):
+		var b bool
+		err := v.As(&b)
+		return b, err
+	case typ.Is(tftypes.Number):
+		n := new(big.Float)
+		if err := v.As(&n); err != nil {
+			return nil, err
+		}
+		f, _ := n.Float64()
+		return f, nil
+	case typ.Is(tftypes.Object{}), typ.Is(tftypes.Map{}):
+		var attributes map[string]tftypes.Value
+		if err := v.As(&attributes); err != nil {
+			return nil, err
+		}
+		result := make(map[string]any, len(attributes))
+		for key, attribute := range attributes {
+			value, err := TerraformValueToGo(attribute)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", key, err)
+			}
+			result[key] = value
+		}
+		return result, nil
+	case typ.Is(tftypes.List{}), typ.Is(tftypes.Set{}), typ.Is(tftypes.Tuple{}):
+		var elements []tftypes.Value
+		if err := v.As(&elements); err != nil {
+			return nil, err
+		}
+		result := make([]any, 0, len(elements))
+		for i, element := range elements {
+			value, err := TerraformValueToGo(element)
+			if err != nil {
+				return nil, fmt.Errorf("%d: %w", i, err)
+			}
+			result = append(result, value)
+		}
+		return result, nil
+	}
+	return nil, fmt.Errorf("unsupported type %s", v.Type())
+}
@@ -0,0 +1,278 @@
+package grok
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// call is a matcher or filter invocation such as `date("yyyy-MM-dd")`. args
+// is nil when the call has no parentheses.
+type call struct {
+	name string
+	args []string
+}
+
+func parseCall(s string) (*call, error) {
+	s = strings.TrimSpace(s)
+	open := strings.IndexByte(s, '(')
+	if open < 0 {
+		if !ruleNameRegexp.MatchString(s) {
+			return nil, fmt.Errorf("invalid name %q", s)
+		}
+		return &call{name: s}, nil
+	}
+	name := strings.TrimSpace(s[:open])
+	if !ruleNameRegexp.MatchString(name) {
+		return nil, fmt.Errorf("invalid name %q", name)
+	}
+	if !strings.HasSuffix(s, ")") {
+		return nil, fmt.Errorf("missing closing parenthesis in %q", s)
+	}
+	inner := strings.TrimSpace(s[open+1 : len(s)-1])
+	args := []string{}
+	if inner != "" {
+		for _, arg := range splitTopLevel(inner, ',') {
+			args = append(args, strings.TrimSpace(arg))
+		}
+	}
+	return &call{name: name, args: args}, nil
+}
+
+// splitTopLevel splits s on sep, ignoring separators in quotes and
+// parentheses.
+func splitTopLevel(s string, sep byte) []string {
+	var parts []string
+	var quote byte
+	depth, start := 0, 0
+	for i := 0; i < len(s); i++ {
+		switch ch := s[i]; {
+		case quote != 0 && ch == '\\':
+			i++
+		case quote != 0:
+			if ch == quote {
+				quote = 0
+			}
+		case ch == '"' || ch == '\'':
+			quote = ch
+		case ch == '(':
+			depth++
+		case ch == ')':
+			depth--
+		case ch == sep && depth == 0:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, s[start:])
+}
+
+// unquote returns the value of a quoted argument. Only quotes and
+// backslashes are unescaped, other escape sequences are kept as is so that
+// regular expressions can be passed verbatim.
+func unquote(s string) (string, error) {
+	s = strings.TrimSpace(s)
+	if len(s) == 0 || (s[0] != '"' && s[0] != '\'') {
+		return s, nil
+	}
+	quote := s[0]
+	if len(s) < 2 || s[len(s)-1] != quote {
+		return "", fmt.Errorf("unterminated string %s", s)
+	}
+	var out strings.Builder
+	inner := s[1 : len(s)-1]
+	for i := 0; i < len(inner); i++ {
+		ch := inner[i]
+		if ch == '\\' && i+1 < len(inner) {
+			switch next := inner[i+1]; next {
+			case '"', '\'', '\\':
+				out.WriteByte(next)
+				i++
+				continue
+			}
+		} else if ch == quote {
+			return "", fmt.Errorf("unescaped quote in string %s", s)
+		}
+		out.WriteByte(ch)
+	}
+	return out.String(), nil
+}
+
+type filterFunc func(args []string, value any) (any, bool)
+
+type filterDefinition struct {
+	minArgs, maxArgs int
+	apply            filterFunc
+}
+
+// filters lists the built-in filters of the Datadog grok dialect. Filters
+// without a local implementation keep the raw value.
+var filters = map[string]filterDefinition{
+	"number":             {0, 0, numberFilter},
+	"integer":            {0, 0, integerFilter},
+	"boolean":            {0, 0, booleanFilter},
+	"nullIf":             {1, 1, nullIfFilter},
+	"json":               {0, 0, jsonFilter},
+	"rubyhash":           {0, 0, nil},
+	"useragent":          {0, 1, nil},
+	"querystring":        {0, 0, querystringFilter},
+	"decodeuricomponent": {0, 0, decodeURIComponentFilter},
+	"lowercase":          {0, 0, stringFilter(strings.ToLower)},
+	"uppercase":          {0, 0, stringFilter(strings.ToUpper)},
+	"keyvalue":           {0, 4, nil},
+	"xml":                {0, 0, nil},
+	"csv":                {1, 4, nil},
+	"scale":              {1, 1, scaleFilter},
+	"array":              {0, 4, nil},
+	"url":                {0, 0, nil},
+}
+
+func validateFilter(f *call) error {
+	definition, ok := filters[f.name]
+	if !ok {
+		return fmt.Errorf("undefined filter %q", f.name)
+	}
+	if n := len(f.args); n < definition.minArgs || n > definition.maxArgs {
+		if definition.minArgs == definition.maxArgs {
+			return fmt.Errorf("filter %q takes %d argument(s), got %d", f.name, definition.minArgs, n)
+		}
+		return fmt.Errorf("filter %q takes between %d and %d arguments, got %d", f.name, definition.minArgs, definition.maxArgs, n)
+	}
+	for _, arg := range f.args {
+		if _, err := unquote(arg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyFilter applies a filter to a value. It returns false when the value
+// must not be extracted.
+func applyFilter(f *call, value any) (any, bool) {
+	definition := filters[f.name]
+	if definition.apply == nil {
+		return value, true
+	}
+	args := make([]string, 0, len(f.args))
+	for _, arg := range f.args {
+		unquoted, _ := unquote(arg)
+		args = append(args, unquoted)
+	}
+	return definition.apply(args, value)
+}
+
+func stringFilter(fn func(string) string) filterFunc {
+	return func(_ []string, value any) (any, bool) {
+		if s, ok := value.(string); ok {
+			return fn(s), true
+		}
+		return value, true
+	}
+}
+
+func numberFilter(_ []string, value any) (any, bool) {
+	s, ok := value.(string)
+	if !ok {
+		return value, true
+	}
+	n, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return nil, false
+	}
+	return n, true
+}
+
+func integerFilter(_ []string, value any) (any, bool) {
+	s, ok := value.(string)
+	if !ok {
+		return value, true
+	}
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return nil, false
+	}
+	return n, true
+}
+
+func booleanFilter(_ []string, value any) (any, bool) {
+	s, ok := value.(string)
+	if !ok {
+		return value, true
+	}
+	return strings.EqualFold(s, "true"), true
+}
+
+func nullIfFilter(args []string, value any) (any, bool) {
+	if s, ok := value.(string); ok && s == args[0] {
+		return nil, false
+	}
+	return value, true
+}
+
+func jsonFilter(_ []string, value any) (any, bool) {
+	s, ok := value.(string)
+	if !ok {
+		return value, true
+	}
+	var decoded any
+	if err := json.Unmarshal([]byte(s), &decoded); err != nil {
+		return nil, false
+	}
+	return decoded, true
+}
+
+func querystringFilter(_ []string, value any) (any, bool) {
+	s, ok := value.(string)
+	if !ok {
+		return value, true
+	}
+	values, err := url.ParseQuery(strings.TrimPrefix(s, "?"))
+	if err != nil {
+		return nil, false
+	}
+	out := make(map[string]any, len(values))
+	for key, v := range values {
+		out[key] = v[0]
+	}
+	return out, true
+}
+
+func decodeURIComponentFilter(_ []string, value any) (any, bool) {
+	s, ok := value.(string)
+	if !ok {
+		return value, true
+	}
+	decoded, err := url.PathUnescape(s)
+	if err != nil {
+		return value, true
+	}
+	return decoded, true
+}
+
+func scaleFilter(args []string, value any) (any, bool) {
+	factor, err := strconv.ParseFloat(args[0], 64)
+	if err != nil {
+		return value, true
+	}
+	var n float64
+	switch v := value.(type) {
+	case float64:
+		n = v
+	case int64:
+		n = float64(v)
+	case string:
+		if n, err = strconv.ParseFloat(v, 64); err != nil {
+			return nil, false
+		}
+	default:
+		return value, true
+	}
+	scaled := n * factor
+	if scaled == math.Trunc(scaled) && math.Abs(scaled) < 1<<53 {
+		return int64(scaled), true
+	}
+	return scaled, true
+}
@@ -0,0 +1,337 @@
+// Package grok implements the grok dialect of Datadog log pipelines, so that
+// parsing rules can be checked against their samples without calling the API.
+//
+// Rules are written one per line as `ruleName pattern`. Outside of
+// `%{matcher:extract:filter}` tokens, patterns are regular expressions, and a
+// rule must match the whole log.
+package grok
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"regexp/syntax"
+	"strings"
+)
+
+// Parser holds the compiled match rules of a grok parser.
+type Parser struct {
+	rules []*compiledRule
+	// Unchecked lists the match rules that use regular expression features,
+	// such as lookarounds, which cannot be evaluated locally.
+	Unchecked []string
+}
+
+type compiledRule struct {
+	name     string
+	re       *regexp.Regexp
+	captures map[string]*capture
+}
+
+// capture describes a value extracted by a `%{matcher:extract:filter}` token.
+type capture struct {
+	path   string
+	date   *dateFormat
+	filter *call
+}
+
+type ruleDefinition struct {
+	name    string
+	pattern string
+}
+
+// Compile compiles match rules, which may reference support rules and the
+// match rules defined before them. The returned error joins every problem
+// found in the rules.
+func Compile(matchRules, supportRules string) (*Parser, error) {
+	var errs []error
+	support, supportErrs := parseRuleDefinitions("support rule", supportRules)
+	errs = append(errs, supportErrs...)
+	matches, matchErrs := parseRuleDefinitions("match rule", matchRules)
+	errs = append(errs, matchErrs...)
+	if len(matches) == 0 && len(matchErrs) == 0 {
+		errs = append(errs, errors.New("at least one match rule is required"))
+	}
+
+	supportByName := make(map[string]ruleDefinition, len(support))
+	for _, rule := range support {
+		supportByName[rule.name] = rule
+	}
+	for _, rule := range matches {
+		if _, ok := supportByName[rule.name]; ok {
+			errs = append(errs, fmt.Errorf("rule %q is defined both as a match rule and as a support rule", rule.name))
+		}
+	}
+
+	// Support rules are compiled on their own to report their errors once,
+	// even when they are not referenced.
+	for _, rule := range support {
+		c := compiler{support: supportByName}
+		expr, err := c.expand(rule.pattern, []string{rule.name})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("support rule %q: %w", rule.name, err))
+			continue
+		}
+		if _, err := regexp.Compile(expr); err != nil && !isUnsupportedSyntax(err) {
+			errs = append(errs, fmt.Errorf("support rule %q: invalid regular expression: %w", rule.name, err))
+		}
+	}
+
+	// Match rules may reference the match rules defined before them, such as
+	// `access.combined %{access.common} ...`.
+	referenceable := make(map[string]ruleDefinition, len(support)+len(matches))
+	for name, rule := range supportByName {
+		referenceable[name] = rule
+	}
+	parser := &Parser{}
+	for _, rule := range matches {
+		c := compiler{support: referenceable, captures: map[string]*capture{}}
+		expr, err := c.expand(rule.pattern, []string{rule.name})
+		if _, ok := referenceable[rule.name]; !ok {
+			referenceable[rule.name] = rule
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("match rule %q: %w", rule.name, err))
+			continue
+		}
+		re, err := regexp.Compile(`^(?:` + expr + `)$`)
+		if err != nil {
+			if isUnsupportedSyntax(err) {
+				parser.Unchecked = append(parser.Unchecked, rule.name)
+				continue
+			}
+			errs = append(errs, fmt.Errorf("match rule %q: invalid regular expression: %w", rule.name, err))
+			continue
+		}
+		parser.rules = append(parser.rules, &compiledRule{name: rule.name, re: re, captures: c.captures})
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return parser, nil
+}
+
+// Parse runs a log through the match rules in order. It returns the name of
+// the first matching rule and the extracted attributes.
+func (p *Parser) Parse(log string) (string, map[string]any, bool) {
+	for _, rule := range p.rules {
+		match := rule.re.FindStringSubmatchIndex(log)
+		if match == nil {
+			continue
+		}
+		attributes := map[string]any{}
+		for i, name := range rule.re.SubexpNames() {
+			c, ok := rule.captures[name]
+			if !ok || match[2*i] < 0 {
+				continue
+			}
+			c.extract(attributes, log[match[2*i]:match[2*i+1]])
+		}
+		return rule.name, attributes, true
+	}
+	return "", nil, false
+}
+
+// Complete reports whether every match rule can be evaluated locally, in
+// which case a log not matched by Parse is not matched by Datadog either.
+func (p *Parser) Complete() bool {
+	return len(p.Unchecked) == 0
+}
+
+func (c *capture) extract(attributes map[string]any, raw string) {
+	var value any = raw
+	if c.date != nil {
+		if millis, ok := c.date.parse(raw); ok {
+			value = millis
+		}
+	}
+	if c.filter != nil {
+		filtered, ok := applyFilter(c.filter, value)
+		if !ok {
+			return
+		}
+		value = filtered
+	}
+
+	if c.path == "" {
+		// `%{data::json}` and similar extract to the root of the log.
+		if m, ok := value.(map[string]any); ok {
+			for k, v := range m {
+				attributes[k] = v
+			}
+		}
+		return
+	}
+	setPath(attributes, c.path, value)
+}
+
+func setPath(attributes map[string]any, path string, value any) {
+	keys := strings.Split(path, ".")
+	current := attributes
+	for _, key := range keys[:len(keys)-1] {
+		next, ok := current[key].(map[string]any)
+		if !ok {
+			next = map[string]any{}
+			current[key] = next
+		}
+		current = next
+	}
+	current[keys[len(keys)-1]] = value
+}
+
+var ruleNameRegexp = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+func parseRuleDefinitions(kind, text string) ([]ruleDefinition, []error) {
+	var rules []ruleDefinition
+	var errs []error
+	seen := map[string]bool{}
+	for i, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		name, pattern := line, ""
+		if sep := strings.IndexAny(line, " \t"); sep >= 0 {
+			name, pattern = line[:sep], strings.TrimSpace(line[sep+1:])
+		}
+		switch {
+		case !ruleNameRegexp.MatchString(name):
+			errs = append(errs, fmt.Errorf("%s on line %d: invalid rule name %q", kind, i+1, name))
+			continue
+		case pattern == "":
+			errs = append(errs, fmt.Errorf("%s %q on line %d has no pattern", kind, name, i+1))
+			continue
+		case seen[name]:
+			errs = append(errs, fmt.Errorf("%s %q is defined more than once", kind, name))
+			continue
+		}
+		seen[name] = true
+		rules = append(rules, ruleDefinition{name: name, pattern: pattern})
+	}
+	return rules, errs
+}
+
+// compiler expands the grok tokens of a rule into a regular expression.
+type compiler struct {
+	// support holds the rules which can be referenced by name.
+	support  map[string]ruleDefinition
+	captures map[string]*capture
+	groups   int
+}
+
+// expand converts a pattern into a regular expression. stack holds the
+// rules being expanded, to detect cycles.
+func (c *compiler) expand(pattern string, stack []string) (string, error) {
+	var out strings.Builder
+	for i := 0; i < len(pattern); {
+		start := strings.Index(pattern[i:], "%{")
+		if start < 0 {
+			out.WriteString(pattern[i:])
+			break
+		}
+		out.WriteString(pattern[i : i+start])
+		i += start
+
+		end, err := tokenEnd(pattern, i+2)
+		if err != nil {
+			return "", err
+		}
+		expr, err := c.expandToken(pattern[i+2:end], stack)
+		if err != nil {
+			return "", err
+		}
+		out.WriteString(expr)
+		i = end + 1
+	}
+	return out.String(), nil
+}
+
+// tokenEnd returns the index of the brace closing the token starting at
+// start, skipping braces in quoted arguments.
+func tokenEnd(pattern string, start int) (int, error) {
+	var quote byte
+	for i := start; i < len(pattern); i++ {
+		switch ch := pattern[i]; {
+		case quote != 0 && ch == '\\':
+			i++
+		case quote != 0:
+			if ch == quote {
+				quote = 0
+			}
+		case ch == '"' || ch == '\'':
+			quote = ch
+		case ch == '}':
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("unterminated token %q", pattern[start-2:])
+}
+
+func (c *compiler) expandToken(token string, stack []string) (string, error) {
+	parts := splitTopLevel(token, ':')
+	if len(parts) > 3 {
+		return "", fmt.Errorf("invalid token %%{%s}: expected %%{matcher:extract:filter}", token)
+	}
+	matcher, err := parseCall(parts[0])
+	if err != nil {
+		return "", fmt.Errorf("invalid token %%{%s}: %w", token, err)
+	}
+
+	captured := &capture{}
+	if len(parts) > 1 {
+		captured.path = strings.TrimSpace(parts[1])
+	}
+	if len(parts) > 2 {
+		filter, err := parseCall(parts[2])
+		if err != nil {
+			return "", fmt.Errorf("invalid filter in %%{%s}: %w", token, err)
+		}
+		if err := validateFilter(filter); err != nil {
+			return "", fmt.Errorf("invalid filter in %%{%s}: %w", token, err)
+		}
+		captured.filter = filter
+	}
+
+	var expr string
+	if rule, ok := c.support[matcher.name]; ok && matcher.args == nil {
+		for _, name := range stack {
+			if name == rule.name {
+				return "", fmt.Errorf("rule %q references itself through %s", rule.name, strings.Join(append(stack, rule.name), " -> "))
+			}
+		}
+		expr, err = c.expand(rule.pattern, append(stack, rule.name))
+		if err != nil {
+			return "", err
+		}
+	} else if m, ok := matchers[matcher.name]; ok {
+		expr, captured.date, err = m(matcher.args)
+		if err != nil {
+			return "", fmt.Errorf("invalid matcher %%{%s}: %w", token, err)
+		}
+	} else {
+		return "", fmt.Errorf("undefined support rule or matcher %q", matcher.name)
+	}
+
+	if c.captures == nil || (captured.path == "" && captured.filter == nil) {
+		return "(?:" + expr + ")", nil
+	}
+	name := fmt.Sprintf("grok%d", c.groups)
+	c.groups++
+	c.captures[name] = captured
+	return fmt.Sprintf("(?P<%s>%s)", name, expr), nil
+}
+
+// isUnsupportedSyntax reports whether a regular expression is rejected by Go
+// because it uses features of the Java engine used by Datadog.
+func isUnsupportedSyntax(err error) bool {
+	var syntaxErr *syntax.Error
+	if !errors.As(err, &syntaxErr) {
+		return false
+	}
+	switch syntaxErr.Code {
+	case syntax.ErrInvalidPerlOp, syntax.ErrInvalidEscape, syntax.ErrInvalidCharRange, syntax.ErrInvalidNamedCapture, syntax.ErrInvalidRepeatSize:
+		return true
+	}
+	return false
+}
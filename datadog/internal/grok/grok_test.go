@@ -0,0 +1,190 @@
+package grok
+
+import (
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	cases := map[string]struct {
+		matchRules   string
+		supportRules string
+		log          string
+		rule         string
+		attributes   map[string]any
+		matched      bool
+	}{
+		"word and integer": {
+			matchRules: `rule %{word:user.name} connected on %{integer:port}`,
+			log:        "john connected on 8080",
+			rule:       "rule",
+			attributes: map[string]any{"user": map[string]any{"name": "john"}, "port": "8080"},
+			matched:    true,
+		},
+		"support rule": {
+			matchRules:   `access %{_client} %{notSpace:status}`,
+			supportRules: `_client %{ipv4:network.client.ip}`,
+			log:          "10.0.0.1 200",
+			rule:         "access",
+			attributes:   map[string]any{"network": map[string]any{"client": map[string]any{"ip": "10.0.0.1"}}, "status": "200"},
+			matched:      true,
+		},
+		"first rule wins": {
+			matchRules: "first %{data:a}\nsecond %{word:b}",
+			log:        "hello",
+			rule:       "first",
+			attributes: map[string]any{"a": "hello"},
+			matched:    true,
+		},
+		"filters": {
+			matchRules: `rule %{notSpace:duration:number} %{data::json}`,
+			log:        `1.5 {"level":"info"}`,
+			rule:       "rule",
+			attributes: map[string]any{"duration": 1.5, "level": "info"},
+			matched:    true,
+		},
+		"date": {
+			matchRules: `rule %{date("yyyy-MM-dd HH:mm:ss"):date}`,
+			log:        "2024-01-02 03:04:05",
+			rule:       "rule",
+			attributes: map[string]any{"date": int64(1704164645000)},
+			matched:    true,
+		},
+		"match rule reference": {
+			matchRules:   "common %{_client} %{integer:status}\ncombined %{common} \"%{data:referer}\"",
+			supportRules: `_client %{ipv4:network.client.ip}`,
+			log:          `10.0.0.1 200 "https://example.com"`,
+			rule:         "combined",
+			attributes:   map[string]any{"network": map[string]any{"client": map[string]any{"ip": "10.0.0.1"}}, "status": "200", "referer": "https://example.com"},
+			matched:      true,
+		},
+		"localized offset": {
+			matchRules: `rule %{date("yyyy-MM-dd HH:mm:ss O"):date}`,
+			log:        "2024-01-02 03:04:05 GMT+8",
+			rule:       "rule",
+			attributes: map[string]any{"date": "2024-01-02 03:04:05 GMT+8"},
+			matched:    true,
+		},
+		"whole log": {
+			matchRules: `rule %{word:a}`,
+			log:        "hello world",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			parser, err := Compile(tc.matchRules, tc.supportRules)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			rule, attributes, matched := parser.Parse(tc.log)
+			if matched != tc.matched || rule != tc.rule {
+				t.Fatalf("expected rule %q (%v), got %q (%v)", tc.rule, tc.matched, rule, matched)
+			}
+			if tc.matched && !reflect.DeepEqual(attributes, tc.attributes) {
+				t.Errorf("expected %#v, got %#v", tc.attributes, attributes)
+			}
+		})
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	cases := map[string]struct {
+		matchRules   string
+		supportRules string
+		errors       []string
+	}{
+		"undefined support rule": {
+			matchRules: `rule %{_missing}`,
+			errors:     []string{`undefined support rule or matcher "_missing"`},
+		},
+		"unterminated token": {
+			matchRules: `rule %{word`,
+			errors:     []string{"unterminated token"},
+		},
+		"invalid regular expression": {
+			matchRules: `rule (%{word}`,
+			errors:     []string{`match rule "rule": invalid regular expression`},
+		},
+		"later match rule reference": {
+			matchRules: "first %{second}\nsecond %{word}",
+			errors:     []string{`match rule "first": undefined support rule or matcher "second"`},
+		},
+		"support rule cycle": {
+			matchRules:   `rule %{_a}`,
+			supportRules: "_a %{_b}\n_b %{_a}",
+			errors:       []string{"references itself"},
+		},
+		"duplicate and missing pattern": {
+			matchRules: "rule %{word}\nrule %{word}\nempty",
+			errors:     []string{`match rule "rule" is defined more than once`, `match rule "empty" on line 3 has no pattern`},
+		},
+		"undefined filter": {
+			matchRules: `rule %{word:a:unknown}`,
+			errors:     []string{`undefined filter "unknown"`},
+		},
+		"invalid matcher arguments": {
+			matchRules: `rule %{word("a"):a}`,
+			errors:     []string{"the matcher takes no argument"},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := Compile(tc.matchRules, tc.supportRules)
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			for _, expected := range tc.errors {
+				if !strings.Contains(err.Error(), expected) {
+					t.Errorf("expected %q in %q", expected, err.Error())
+				}
+			}
+		})
+	}
+}
+
+func TestCompileUnchecked(t *testing.T) {
+	parser, err := Compile("lookahead (?=a)%{word:a}\nplain %{word:b}", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if parser.Complete() {
+		t.Error("expected the parser to be incomplete")
+	}
+	if !reflect.DeepEqual(parser.Unchecked, []string{"lookahead"}) {
+		t.Errorf("unexpected unchecked rules %v", parser.Unchecked)
+	}
+	if rule, _, ok := parser.Parse("abc"); !ok || rule != "plain" {
+		t.Errorf("expected the plain rule to match, got %q", rule)
+	}
+}
+
+func TestCompileNginx(t *testing.T) {
+	matchRules, err := os.ReadFile("testdata/nginx_match_rules.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	supportRules, err := os.ReadFile("testdata/nginx_support_rules.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	parser, err := Compile(string(matchRules), string(supportRules))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// The access rules use atomic groups, which Go does not support.
+	if !reflect.DeepEqual(parser.Unchecked, []string{"access.common", "access.combined"}) {
+		t.Errorf("unexpected unchecked rules %v", parser.Unchecked)
+	}
+	rule, attributes, ok := parser.Parse(`2017/09/26 14:36:50 [error] 8409#8409: *317058 "/usr/share/nginx/html/sql/sql-admin/index.php" is not found (2: No such file or directory), client: 217.92.148.44, server: localhost`)
+	if !ok || rule != "error.format" {
+		t.Fatalf("expected the error.format rule to match, got %q", rule)
+	}
+	if level := attributes["level"]; level != "error" {
+		t.Errorf("unexpected level %v", level)
+	}
+	if message := attributes["error"].(map[string]any)["message"]; message != `8409#8409: *317058 "/usr/share/nginx/html/sql/sql-admin/index.php" is not found (2: No such file or directory)` {
+		t.Errorf("unexpected error message %v", message)
+	}
+}
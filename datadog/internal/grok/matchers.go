@@ -0,0 +1,293 @@
+package grok
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+type matcherFunc func(args []string) (string, *dateFormat, error)
+
+const (
+	ipv4Expr     = `(?:(?:25[0-5]|2[0-4][0-9]|1[0-9]{2}|[1-9]?[0-9])\.){3}(?:25[0-5]|2[0-4][0-9]|1[0-9]{2}|[1-9]?[0-9])`
+	ipv6Expr     = `(?:[0-9A-Fa-f]{0,4}:){2,7}(?:[0-9A-Fa-f]{1,4}|` + ipv4Expr + `)?`
+	hostnameExpr = `[A-Za-z0-9](?:[A-Za-z0-9-]{0,62})(?:\.[A-Za-z0-9](?:[A-Za-z0-9-]{0,62}))*\.?`
+	numberExpr   = `[+-]?(?:[0-9]+(?:\.[0-9]*)?|\.[0-9]+)`
+	exponentExpr = `(?:[eE][+-]?[0-9]+)?`
+)
+
+// matchers lists the built-in matchers of the Datadog grok dialect, see
+// https://docs.datadoghq.com/logs/log_configuration/parsing/#matcher-and-filter
+var matchers = map[string]matcherFunc{
+	"date":               dateMatcher,
+	"regex":              regexMatcher,
+	"notSpace":           staticMatcher(`\S+`),
+	"boolean":            booleanMatcher,
+	"numberStr":          staticMatcher(numberExpr),
+	"number":             staticMatcher(numberExpr),
+	"numberExtStr":       staticMatcher(numberExpr + exponentExpr),
+	"numberExt":          staticMatcher(numberExpr + exponentExpr),
+	"integerStr":         staticMatcher(`[+-]?[0-9]+`),
+	"integer":            staticMatcher(`[+-]?[0-9]+`),
+	"integerExtStr":      staticMatcher(`[+-]?[0-9]+` + exponentExpr),
+	"integerExt":         staticMatcher(`[+-]?[0-9]+` + exponentExpr),
+	"word":               staticMatcher(`\b\w+\b`),
+	"doubleQuotedString": staticMatcher(`"(?:[^"\\]|\\.)*"`),
+	"singleQuotedString": staticMatcher(`'(?:[^'\\]|\\.)*'`),
+	"quotedString":       staticMatcher(`"(?:[^"\\]|\\.)*"|'(?:[^'\\]|\\.)*'`),
+	"uuid":               staticMatcher(`[0-9A-Fa-f]{8}-[0-9A-Fa-f]{4}-[0-9A-Fa-f]{4}-[0-9A-Fa-f]{4}-[0-9A-Fa-f]{12}`),
+	"mac":                staticMatcher(`(?:[0-9A-Fa-f]{2}[:-]){5}[0-9A-Fa-f]{2}`),
+	"ipv4":               staticMatcher(ipv4Expr),
+	"ipv6":               staticMatcher(ipv6Expr),
+	"ip":                 staticMatcher(ipv4Expr + `|` + ipv6Expr),
+	"hostname":           staticMatcher(hostnameExpr),
+	"ipOrHost":           staticMatcher(ipv4Expr + `|` + ipv6Expr + `|` + hostnameExpr),
+	"port":               staticMatcher(`[0-9]{1,5}`),
+	"data":               staticMatcher(`(?s:.*?)`),
+}
+
+func staticMatcher(expr string) matcherFunc {
+	return func(args []string) (string, *dateFormat, error) {
+		if args != nil {
+			return "", nil, fmt.Errorf("the matcher takes no argument")
+		}
+		return expr, nil, nil
+	}
+}
+
+func regexMatcher(args []string) (string, *dateFormat, error) {
+	if len(args) != 1 {
+		return "", nil, fmt.Errorf("regex takes exactly one argument")
+	}
+	pattern, err := unquote(args[0])
+	if err != nil {
+		return "", nil, err
+	}
+	return pattern, nil, nil
+}
+
+func booleanMatcher(args []string) (string, *dateFormat, error) {
+	switch len(args) {
+	case 0:
+		return `(?i:true|false)`, nil, nil
+	case 2:
+		trueValue, err := unquote(args[0])
+		if err != nil {
+			return "", nil, err
+		}
+		falseValue, err := unquote(args[1])
+		if err != nil {
+			return "", nil, err
+		}
+		return `(?i:` + regexp.QuoteMeta(trueValue) + `|` + regexp.QuoteMeta(falseValue) + `)`, nil, nil
+	}
+	return "", nil, fmt.Errorf("boolean takes either no argument or the true and false values")
+}
+
+// dateFormat converts dates matched with a Java date pattern into
+// milliseconds since the epoch.
+type dateFormat struct {
+	// layout is the Go equivalent of the Java pattern, empty when the
+	// pattern has no equivalent.
+	layout   string
+	location *time.Location
+}
+
+func (f *dateFormat) parse(value string) (int64, bool) {
+	if f.layout == "" {
+		return 0, false
+	}
+	t, err := time.ParseInLocation(f.layout, value, f.location)
+	if err != nil {
+		return 0, false
+	}
+	return t.UnixMilli(), true
+}
+
+func dateMatcher(args []string) (string, *dateFormat, error) {
+	if len(args) < 1 || len(args) > 3 {
+		return "", nil, fmt.Errorf("date takes a pattern, and optionally a timezone and a locale")
+	}
+	pattern, err := unquote(args[0])
+	if err != nil {
+		return "", nil, err
+	}
+	expr, layout, err := convertJavaDatePattern(pattern)
+	if err != nil {
+		return "", nil, err
+	}
+
+	format := &dateFormat{layout: layout, location: time.UTC}
+	if len(args) > 1 {
+		timezone, err := unquote(args[1])
+		if err != nil {
+			return "", nil, err
+		}
+		// Unknown timezones are not reported since the timezone database may
+		// be missing locally.
+		if location, err := time.LoadLocation(timezone); err == nil {
+			format.location = location
+		} else if offset, err := time.Parse("-07:00", timezone); err == nil {
+			format.location = offset.Location()
+		}
+	}
+	return expr, format, nil
+}
+
+// convertJavaDatePattern converts a java.time date pattern into a regular
+// expression and, when possible, a Go time layout.
+func convertJavaDatePattern(pattern string) (string, string, error) {
+	var expr, layout strings.Builder
+	layoutSupported := true
+	for i := 0; i < len(pattern); {
+		ch := pattern[i]
+		if ch == '\'' {
+			end := strings.IndexByte(pattern[i+1:], '\'')
+			if end < 0 {
+				return "", "", fmt.Errorf("unterminated quote in date pattern %q", pattern)
+			}
+			literal := pattern[i+1 : i+1+end]
+			if literal == "" {
+				literal = "'"
+			}
+			expr.WriteString(regexp.QuoteMeta(literal))
+			layout.WriteString(literal)
+			i += end + 2
+			continue
+		}
+		if !isASCIILetter(ch) {
+			expr.WriteString(regexp.QuoteMeta(string(ch)))
+			layout.WriteByte(ch)
+			i++
+			continue
+		}
+
+		count := 1
+		for i+count < len(pattern) && pattern[i+count] == ch {
+			count++
+		}
+		i += count
+
+		fieldExpr, fieldLayout := javaDateField(ch, count, layout.String())
+		if fieldExpr == "" {
+			return "", "", fmt.Errorf("unsupported letter %q in date pattern %q", ch, pattern)
+		}
+		if fieldLayout == "" {
+			layoutSupported = false
+		}
+		expr.WriteString(fieldExpr)
+		layout.WriteString(fieldLayout)
+	}
+	if !layoutSupported {
+		return expr.String(), "", nil
+	}
+	return expr.String(), layout.String(), nil
+}
+
+// javaDateField returns the regular expression and Go layout of a run of
+// count letters of a Java date pattern.
+func javaDateField(letter byte, count int, previousLayout string) (string, string) {
+	digits := func(n int) string {
+		if n == 1 {
+			return `[0-9]{1,2}`
+		}
+		return fmt.Sprintf(`[0-9]{%d}`, n)
+	}
+	switch letter {
+	case 'y', 'Y', 'u':
+		if count == 2 {
+			return `[0-9]{2}`, "06"
+		}
+		return `[0-9]{4}`, "2006"
+	case 'M', 'L':
+		switch count {
+		case 1:
+			return digits(1), "1"
+		case 2:
+			return digits(2), "01"
+		case 3:
+			return `[A-Za-z]{3}`, "Jan"
+		}
+		return `[A-Za-z]+`, "January"
+	case 'd':
+		if count == 1 {
+			return digits(1), "2"
+		}
+		return digits(2), "02"
+	case 'H':
+		if count == 1 {
+			return digits(1), "15"
+		}
+		return digits(2), "15"
+	case 'h':
+		if count == 1 {
+			return digits(1), "3"
+		}
+		return digits(2), "03"
+	case 'm':
+		if count == 1 {
+			return digits(1), "4"
+		}
+		return digits(2), "04"
+	case 's':
+		if count == 1 {
+			return digits(1), "5"
+		}
+		return digits(2), "05"
+	case 'S':
+		// Go only parses fractional seconds following a separator.
+		if strings.HasSuffix(previousLayout, ".") || strings.HasSuffix(previousLayout, ",") {
+			return fmt.Sprintf(`[0-9]{%d}`, count), strings.Repeat("0", count)
+		}
+		return fmt.Sprintf(`[0-9]{%d}`, count), ""
+	case 'a':
+		return `(?:AM|PM|am|pm)`, "PM"
+	case 'E':
+		if count <= 3 {
+			return `[A-Za-z]{3}`, "Mon"
+		}
+		return `[A-Za-z]+`, "Monday"
+	case 'Z':
+		// Datadog follows Joda-Time: `Z` is an offset without a colon, `ZZ`
+		// an offset with a colon and `ZZZ` a zone ID.
+		switch count {
+		case 1:
+			return `(?:[+-][0-9]{2}:?[0-9]{2}|Z)`, "-0700"
+		case 2:
+			return `(?:[+-][0-9]{2}:?[0-9]{2}|Z)`, "Z07:00"
+		}
+		return `[A-Za-z][A-Za-z0-9_/+-]*`, ""
+	case 'X', 'x':
+		switch count {
+		case 1:
+			return `(?:[+-][0-9]{2}(?:[0-9]{2})?|Z)`, ""
+		case 2:
+			return `(?:[+-][0-9]{4}|Z)`, "Z0700"
+		}
+		return `(?:[+-][0-9]{2}:[0-9]{2}|Z)`, "Z07:00"
+	case 'O':
+		// Localized offsets: `O` is `GMT+8` and `OOOO` is `GMT+08:00`.
+		if count == 1 {
+			return `GMT(?:[+-][0-9]{1,2}(?::[0-9]{2}(?::[0-9]{2})?)?)?`, ""
+		}
+		return `GMT(?:[+-][0-9]{2}:[0-9]{2}(?::[0-9]{2})?)?`, ""
+	case 'z', 'V':
+		return `[A-Za-z][A-Za-z0-9_/+-]*`, ""
+	case 'D':
+		return `[0-9]{1,3}`, ""
+	case 'k', 'K':
+		return digits(count), ""
+	case 'G':
+		return `[A-Za-z]+`, ""
+	case 'w', 'W', 'e', 'c', 'F', 'Q', 'q':
+		return `[0-9]{1,2}`, ""
+	case 'n', 'N', 'A':
+		return `[0-9]+`, ""
+	}
+	return "", ""
+}
+
+func isASCIILetter(ch byte) bool {
+	return (ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z')
+}
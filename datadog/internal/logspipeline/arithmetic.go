@@ -0,0 +1,185 @@
+package logspipeline
+
+import (
+	"fmt"
+	"strconv"
+	"unicode"
+)
+
+// arithmeticExpression is an expression of the arithmetic processor, see
+// https://docs.datadoghq.com/logs/log_configuration/processors/#arithmetic-processor
+type arithmeticExpression interface {
+	eval(attributes map[string]any, replaceMissing bool) (float64, bool)
+}
+
+type arithmeticNumber float64
+
+func (n arithmeticNumber) eval(map[string]any, bool) (float64, bool) { return float64(n), true }
+
+type arithmeticAttribute string
+
+func (a arithmeticAttribute) eval(attributes map[string]any, replaceMissing bool) (float64, bool) {
+	value, ok := getPath(attributes, string(a))
+	if !ok {
+		return 0, replaceMissing
+	}
+	return numberValue(value)
+}
+
+type arithmeticNegate struct{ operand arithmeticExpression }
+
+func (n arithmeticNegate) eval(attributes map[string]any, replaceMissing bool) (float64, bool) {
+	value, ok := n.operand.eval(attributes, replaceMissing)
+	return -value, ok
+}
+
+type arithmeticOperation struct {
+	operator    byte
+	left, right arithmeticExpression
+}
+
+func (o arithmeticOperation) eval(attributes map[string]any, replaceMissing bool) (float64, bool) {
+	left, ok := o.left.eval(attributes, replaceMissing)
+	if !ok {
+		return 0, false
+	}
+	right, ok := o.right.eval(attributes, replaceMissing)
+	if !ok {
+		return 0, false
+	}
+	switch o.operator {
+	case '+':
+		return left + right, true
+	case '-':
+		return left - right, true
+	case '*':
+		return left * right, true
+	}
+	if right == 0 {
+		return 0, false
+	}
+	return left / right, true
+}
+
+func parseArithmetic(s string) (arithmeticExpression, error) {
+	p := &arithmeticParser{input: s}
+	expression, err := p.parseSum()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpaces()
+	if p.pos < len(p.input) {
+		return nil, fmt.Errorf("unexpected %q at offset %d", p.input[p.pos:], p.pos)
+	}
+	return expression, nil
+}
+
+type arithmeticParser struct {
+	input string
+	pos   int
+}
+
+func (p *arithmeticParser) skipSpaces() {
+	for p.pos < len(p.input) && unicode.IsSpace(rune(p.input[p.pos])) {
+		p.pos++
+	}
+}
+
+func (p *arithmeticParser) parseSum() (arithmeticExpression, error) {
+	left, err := p.parseProduct()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		p.skipSpaces()
+		if p.pos >= len(p.input) || (p.input[p.pos] != '+' && p.input[p.pos] != '-') {
+			return left, nil
+		}
+		operator := p.input[p.pos]
+		p.pos++
+		right, err := p.parseProduct()
+		if err != nil {
+			return nil, err
+		}
+		left = arithmeticOperation{operator: operator, left: left, right: right}
+	}
+}
+
+func (p *arithmeticParser) parseProduct() (arithmeticExpression, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		p.skipSpaces()
+		if p.pos >= len(p.input) || (p.input[p.pos] != '*' && p.input[p.pos] != '/') {
+			return left, nil
+		}
+		operator := p.input[p.pos]
+		p.pos++
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = arithmeticOperation{operator: operator, left: left, right: right}
+	}
+}
+
+func (p *arithmeticParser) parseUnary() (arithmeticExpression, error) {
+	p.skipSpaces()
+	if p.pos < len(p.input) && p.input[p.pos] == '-' {
+		p.pos++
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return arithmeticNegate{operand}, nil
+	}
+	return p.parseOperand()
+}
+
+func (p *arithmeticParser) parseOperand() (arithmeticExpression, error) {
+	if p.pos >= len(p.input) {
+		return nil, fmt.Errorf("unexpected end of expression")
+	}
+	start := p.pos
+	ch := p.input[p.pos]
+	switch {
+	case ch == '(':
+		p.pos++
+		expression, err := p.parseSum()
+		if err != nil {
+			return nil, err
+		}
+		p.skipSpaces()
+		if p.pos >= len(p.input) || p.input[p.pos] != ')' {
+			return nil, fmt.Errorf("unbalanced parenthesis at offset %d", start)
+		}
+		p.pos++
+		return expression, nil
+	case ch >= '0' && ch <= '9' || ch == '.':
+		for p.pos < len(p.input) && (p.input[p.pos] >= '0' && p.input[p.pos] <= '9' || p.input[p.pos] == '.') {
+			p.pos++
+		}
+		n, err := strconv.ParseFloat(p.input[start:p.pos], 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q", p.input[start:p.pos])
+		}
+		return arithmeticNumber(n), nil
+	case isAttributeChar(ch):
+		// Attribute names may contain `-`, so subtractions of attributes
+		// must be surrounded by spaces.
+		for p.pos < len(p.input) && (isAttributeChar(p.input[p.pos]) || p.input[p.pos] == '-') {
+			p.pos++
+		}
+		if p.pos < len(p.input) && p.input[p.pos] == '(' {
+			return nil, fmt.Errorf("function %q is not supported", p.input[start:p.pos])
+		}
+		return arithmeticAttribute(p.input[start:p.pos]), nil
+	}
+	return nil, fmt.Errorf("unexpected %q at offset %d", p.input[start:], start)
+}
+
+func isAttributeChar(ch byte) bool {
+	return ch == '_' || ch == '.' || ch == '@' || (ch >= '0' && ch <= '9') || (ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z')
+}
@@ -0,0 +1,160 @@
+package logspipeline
+
+import (
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// firstStringSource returns the value of the first source holding a string.
+func firstStringSource(e *event, sources []string) (string, bool) {
+	for _, source := range sources {
+		if value, ok := getPath(e.attributes, source); ok {
+			if s, ok := value.(string); ok {
+				return s, true
+			}
+		}
+	}
+	return "", false
+}
+
+func compileURLParser(c *compiler, definition map[string]any, path string) processor {
+	sources := stringList(definition, "sources")
+	target := stringValue(definition, "target")
+	normalizeEndingSlashes := boolValue(definition, "normalize_ending_slashes")
+	return func(e *event) {
+		raw, ok := firstStringSource(e, sources)
+		if !ok {
+			return
+		}
+		parsed, err := url.Parse(raw)
+		if err != nil || parsed.Host == "" {
+			return
+		}
+		details := map[string]any{
+			"scheme": parsed.Scheme,
+			"host":   parsed.Hostname(),
+		}
+		if port, err := strconv.Atoi(parsed.Port()); err == nil {
+			details["port"] = float64(port)
+		}
+		urlPath := parsed.EscapedPath()
+		if normalizeEndingSlashes && len(urlPath) > 1 {
+			urlPath = strings.TrimRight(urlPath, "/")
+		}
+		details["path"] = urlPath
+		if parsed.RawQuery != "" {
+			queryString := map[string]any{}
+			for key, values := range parsed.Query() {
+				queryString[key] = values[0]
+			}
+			details["queryString"] = queryString
+		}
+		if parsed.User != nil {
+			details["user"] = parsed.User.Username()
+		}
+		setPath(e.attributes, target, details)
+	}
+}
+
+func compileUserAgentParser(c *compiler, definition map[string]any, path string) processor {
+	sources := stringList(definition, "sources")
+	target := stringValue(definition, "target")
+	isEncoded := boolValue(definition, "is_encoded")
+	return func(e *event) {
+		raw, ok := firstStringSource(e, sources)
+		if !ok {
+			return
+		}
+		if isEncoded {
+			decoded, err := url.QueryUnescape(raw)
+			if err != nil {
+				return
+			}
+			raw = decoded
+		}
+		setPath(e.attributes, target, parseUserAgent(raw))
+	}
+}
+
+type userAgentRule struct {
+	family string
+	re     *regexp.Regexp
+}
+
+// The rules cover common clients only, unlike the full set of rules used by
+// Datadog, and are evaluated in order.
+var (
+	browserRules = []userAgentRule{
+		{"Googlebot", regexp.MustCompile(`Googlebot/(\d+)(?:\.(\d+))?`)},
+		{"Edge", regexp.MustCompile(`Edg(?:e|A|iOS)?/(\d+)(?:\.(\d+))?(?:\.(\d+))?`)},
+		{"Opera", regexp.MustCompile(`OPR/(\d+)(?:\.(\d+))?(?:\.(\d+))?`)},
+		{"Chrome Mobile iOS", regexp.MustCompile(`CriOS/(\d+)(?:\.(\d+))?(?:\.(\d+))?`)},
+		{"Firefox iOS", regexp.MustCompile(`FxiOS/(\d+)(?:\.(\d+))?(?:\.(\d+))?`)},
+		{"Chrome Mobile", regexp.MustCompile(`Chrome/(\d+)(?:\.(\d+))?(?:\.(\d+))?.* Mobile`)},
+		{"Chrome", regexp.MustCompile(`Chrome/(\d+)(?:\.(\d+))?(?:\.(\d+))?`)},
+		{"Firefox Mobile", regexp.MustCompile(`Mobile.*Firefox/(\d+)(?:\.(\d+))?`)},
+		{"Firefox", regexp.MustCompile(`Firefox/(\d+)(?:\.(\d+))?`)},
+		{"Mobile Safari", regexp.MustCompile(`Version/(\d+)(?:\.(\d+))?(?:\.(\d+))?.* Mobile/\S+ Safari`)},
+		{"Safari", regexp.MustCompile(`Version/(\d+)(?:\.(\d+))?(?:\.(\d+))?.* Safari`)},
+		{"IE", regexp.MustCompile(`(?:MSIE |Trident/.*rv:)(\d+)(?:\.(\d+))?`)},
+		{"curl", regexp.MustCompile(`^curl/(\d+)(?:\.(\d+))?(?:\.(\d+))?`)},
+		{"Wget", regexp.MustCompile(`^Wget/(\d+)(?:\.(\d+))?(?:\.(\d+))?`)},
+		{"Python Requests", regexp.MustCompile(`^python-requests/(\d+)(?:\.(\d+))?(?:\.(\d+))?`)},
+		{"Go-http-client", regexp.MustCompile(`^Go-http-client/(\d+)(?:\.(\d+))?`)},
+	}
+	osRules = []userAgentRule{
+		{"Windows", regexp.MustCompile(`Windows NT (\d+)(?:\.(\d+))?`)},
+		{"iOS", regexp.MustCompile(`(?:iPhone|CPU) OS (\d+)(?:_(\d+))?(?:_(\d+))?`)},
+		{"Mac OS X", regexp.MustCompile(`Mac OS X (\d+)(?:[_.](\d+))?(?:[_.](\d+))?`)},
+		{"Android", regexp.MustCompile(`Android (\d+)(?:\.(\d+))?(?:\.(\d+))?`)},
+		{"Chrome OS", regexp.MustCompile(`CrOS \S+ (\d+)(?:\.(\d+))?(?:\.(\d+))?`)},
+		{"Ubuntu", regexp.MustCompile(`Ubuntu()`)},
+		{"Linux", regexp.MustCompile(`Linux()`)},
+	}
+	botRegexp = regexp.MustCompile(`(?i)bot|crawler|spider`)
+)
+
+// parseUserAgent extracts the browser, operating system and device of a user
+// agent, with the layout of the attributes set by Datadog.
+func parseUserAgent(userAgent string) map[string]any {
+	browser := matchUserAgent(browserRules, userAgent, []string{"major", "minor", "patch"})
+	os := matchUserAgent(osRules, userAgent, []string{"major", "minor", "patch_minor"})
+
+	device := map[string]any{"family": "Other", "category": "Desktop"}
+	switch {
+	case botRegexp.MatchString(userAgent):
+		device = map[string]any{"family": "Spider", "category": "Bot"}
+	case strings.Contains(userAgent, "iPhone"):
+		device = map[string]any{"family": "iPhone", "category": "Mobile"}
+	case strings.Contains(userAgent, "iPad"):
+		device = map[string]any{"family": "iPad", "category": "Tablet"}
+	case strings.Contains(userAgent, "Mobile") || strings.Contains(userAgent, "iPod"):
+		device["category"] = "Mobile"
+	case strings.Contains(userAgent, "Android") || strings.Contains(userAgent, "Tablet"):
+		// Android tablets are the Android devices without `Mobile`.
+		device["category"] = "Tablet"
+	case browser["family"] == "curl" || browser["family"] == "Wget" || browser["family"] == "Python Requests" || browser["family"] == "Go-http-client":
+		device["category"] = "Other"
+	}
+
+	return map[string]any{"browser": browser, "os": os, "device": device}
+}
+
+func matchUserAgent(rules []userAgentRule, userAgent string, versionKeys []string) map[string]any {
+	for _, rule := range rules {
+		match := rule.re.FindStringSubmatch(userAgent)
+		if match == nil {
+			continue
+		}
+		result := map[string]any{"family": rule.family}
+		for i, key := range versionKeys {
+			if i+1 < len(match) && match[i+1] != "" {
+				result[key] = match[i+1]
+			}
+		}
+		return result
+	}
+	return map[string]any{"family": "Other"}
+}
@@ -0,0 +1,340 @@
+// Package logspipeline simulates Datadog log pipelines locally, so that their
+// processors can be tested against sample logs without calling the API.
+//
+// Pipelines are described with the layout of the `datadog_logs_custom_pipeline`
+// resource, where blocks are lists of objects. Logs are JSON objects; their
+// tags are read from and written to the `ddtags` attribute, as in the logs
+// intake API.
+package logspipeline
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Pipeline is a compiled log pipeline.
+type Pipeline struct {
+	enabled    bool
+	filter     query
+	processors []processor
+}
+
+type processor func(e *event)
+
+type event struct {
+	attributes map[string]any
+	tags       []string
+	// remapped lists the reserved attributes already set by a remapper, since
+	// only the first remapper of a reserved attribute applies.
+	remapped map[string]bool
+}
+
+type processorCompiler func(c *compiler, definition map[string]any, path string) processor
+
+var processorCompilers = map[string]processorCompiler{
+	"arithmetic_processor":        compileArithmeticProcessor,
+	"attribute_remapper":          compileAttributeRemapper,
+	"category_processor":          compileCategoryProcessor,
+	"date_remapper":               reservedRemapper("date", normalizeDate),
+	"decoder_processor":           compileDecoderProcessor,
+	"exclude_attribute_processor": compileExcludeAttributeProcessor,
+	"grok_parser":                 compileGrokParser,
+	"lookup_processor":            compileLookupProcessor,
+	"message_remapper":            reservedRemapper("message", nil),
+	"service_remapper":            reservedRemapper("service", nil),
+	"span_id_remapper":            reservedRemapper("span_id", nil),
+	"status_remapper":             reservedRemapper("status", normalizeStatus),
+	"string_builder_processor":    compileStringBuilderProcessor,
+	"trace_id_remapper":           reservedRemapper("trace_id", nil),
+	"url_parser":                  compileURLParser,
+	"user_agent_parser":           compileUserAgentParser,
+}
+
+// unsupportedProcessors depend on data, such as GeoIP databases or reference
+// tables, which is not available locally.
+var unsupportedProcessors = map[string]bool{
+	"array_map_processor":              true,
+	"array_processor":                  true,
+	"geo_ip_parser":                    true,
+	"reference_table_lookup_processor": true,
+	"schema_processor":                 true,
+}
+
+type compiler struct {
+	warnings []string
+	errs     []error
+}
+
+func (c *compiler) warnf(path, format string, args ...any) {
+	c.warnings = append(c.warnings, path+": "+fmt.Sprintf(format, args...))
+}
+
+func (c *compiler) errorf(path, format string, args ...any) {
+	c.errs = append(c.errs, fmt.Errorf("%s: %s", path, fmt.Sprintf(format, args...)))
+}
+
+// Compile compiles a pipeline definition. Processors which cannot be
+// simulated are skipped and reported as warnings.
+func Compile(definition map[string]any) (*Pipeline, []string, error) {
+	c := &compiler{}
+	pipeline := c.compilePipeline(definition, "")
+	if err := errors.Join(c.errs...); err != nil {
+		return nil, c.warnings, err
+	}
+	return pipeline, c.warnings, nil
+}
+
+func (c *compiler) compilePipeline(definition map[string]any, prefix string) *Pipeline {
+	pipeline := &Pipeline{enabled: boolValue(definition, "is_enabled"), filter: matchAll{}}
+	if filters := blocks(definition, "filter"); len(filters) > 0 {
+		q, err := parseQuery(stringValue(filters[0], "query"))
+		if err != nil {
+			c.errorf(prefix+"filter.0.query", "%s", err)
+		} else {
+			pipeline.filter = q
+		}
+	}
+
+	for i, processorDefinition := range blocks(definition, "processor") {
+		processorPath := fmt.Sprintf("%sprocessor.%d", prefix, i)
+		// Processor objects coming from the state hold an empty list for
+		// every processor type but one.
+		types := make([]string, 0, 1)
+		for processorType := range processorDefinition {
+			if len(blocks(processorDefinition, processorType)) > 0 {
+				types = append(types, processorType)
+			}
+		}
+		sort.Strings(types)
+		for _, processorType := range types {
+			inner := blocks(processorDefinition, processorType)[0]
+			innerPath := processorPath + "." + processorType + ".0"
+			if !boolValue(inner, "is_enabled") {
+				continue
+			}
+			if unsupportedProcessors[processorType] {
+				c.warnf(innerPath, "the %s processor is not simulated", processorType)
+				continue
+			}
+			compile, ok := processorCompilers[processorType]
+			if processorType == "pipeline" {
+				compile, ok = compileNestedPipeline, true
+			}
+			if !ok {
+				c.errorf(processorPath, "unknown processor type %q", processorType)
+				continue
+			}
+			if p := compile(c, inner, innerPath); p != nil {
+				pipeline.processors = append(pipeline.processors, p)
+			}
+		}
+	}
+	return pipeline
+}
+
+func compileNestedPipeline(c *compiler, definition map[string]any, path string) processor {
+	nested := c.compilePipeline(definition, path+".")
+	return func(e *event) {
+		nested.run(e)
+	}
+}
+
+// Process runs a log through the pipeline. It returns the processed log and
+// whether the log matched the pipeline filter.
+func (p *Pipeline) Process(log map[string]any) (map[string]any, bool) {
+	e := newEvent(log)
+	matched := p.run(e)
+	return e.log(), matched
+}
+
+func (p *Pipeline) run(e *event) bool {
+	if !p.enabled || !p.filter.match(e) {
+		return false
+	}
+	for _, process := range p.processors {
+		process(e)
+	}
+	return true
+}
+
+func newEvent(log map[string]any) *event {
+	e := &event{attributes: deepCopy(log).(map[string]any), remapped: map[string]bool{}}
+	switch tags := e.attributes["ddtags"].(type) {
+	case string:
+		for _, tag := range strings.Split(tags, ",") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				e.tags = append(e.tags, tag)
+			}
+		}
+	case []any:
+		for _, tag := range tags {
+			if s, ok := tag.(string); ok {
+				e.tags = append(e.tags, s)
+			}
+		}
+	}
+	delete(e.attributes, "ddtags")
+	return e
+}
+
+func (e *event) log() map[string]any {
+	if len(e.tags) > 0 {
+		e.attributes["ddtags"] = strings.Join(e.tags, ",")
+	}
+	return e.attributes
+}
+
+// blocks returns the objects of a nested block, which are either a list of
+// objects or a single object.
+func blocks(definition map[string]any, key string) []map[string]any {
+	switch value := definition[key].(type) {
+	case map[string]any:
+		return []map[string]any{value}
+	case []any:
+		result := make([]map[string]any, 0, len(value))
+		for _, item := range value {
+			if m, ok := item.(map[string]any); ok {
+				result = append(result, m)
+			}
+		}
+		return result
+	}
+	return nil
+}
+
+func stringValue(definition map[string]any, key string) string {
+	s, _ := definition[key].(string)
+	return s
+}
+
+func boolValue(definition map[string]any, key string) bool {
+	b, _ := definition[key].(bool)
+	return b
+}
+
+func stringList(definition map[string]any, key string) []string {
+	list, _ := definition[key].([]any)
+	result := make([]string, 0, len(list))
+	for _, item := range list {
+		if s, ok := item.(string); ok {
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+func getPath(attributes map[string]any, path string) (any, bool) {
+	if value, ok := attributes[path]; ok {
+		return value, true
+	}
+	current := attributes
+	keys := strings.Split(path, ".")
+	for i, key := range keys {
+		value, ok := current[key]
+		if !ok {
+			return nil, false
+		}
+		if i == len(keys)-1 {
+			return value, true
+		}
+		if current, ok = value.(map[string]any); !ok {
+			return nil, false
+		}
+	}
+	return nil, false
+}
+
+func setPath(attributes map[string]any, path string, value any) {
+	keys := strings.Split(path, ".")
+	current := attributes
+	for _, key := range keys[:len(keys)-1] {
+		next, ok := current[key].(map[string]any)
+		if !ok {
+			next = map[string]any{}
+			current[key] = next
+		}
+		current = next
+	}
+	current[keys[len(keys)-1]] = value
+}
+
+func deletePath(attributes map[string]any, path string) {
+	if _, ok := attributes[path]; ok {
+		delete(attributes, path)
+		return
+	}
+	keys := strings.Split(path, ".")
+	current := attributes
+	for _, key := range keys[:len(keys)-1] {
+		next, ok := current[key].(map[string]any)
+		if !ok {
+			return
+		}
+		current = next
+	}
+	delete(current, keys[len(keys)-1])
+}
+
+// formatScalar formats strings, numbers and booleans.
+func formatScalar(value any) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		return v, true
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	case int64:
+		return strconv.FormatInt(v, 10), true
+	case bool:
+		return strconv.FormatBool(v), true
+	case json.Number:
+		return v.String(), true
+	}
+	return "", false
+}
+
+// formatValue formats any value, encoding objects and arrays as JSON.
+func formatValue(value any) string {
+	if s, ok := formatScalar(value); ok {
+		return s
+	}
+	encoded, _ := json.Marshal(value)
+	return string(encoded)
+}
+
+func numberValue(value any) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case int64:
+		return float64(v), true
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+func deepCopy(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		result := make(map[string]any, len(v))
+		for key, item := range v {
+			result[key] = deepCopy(item)
+		}
+		return result
+	case []any:
+		result := make([]any, len(v))
+		for i, item := range v {
+			result[i] = deepCopy(item)
+		}
+		return result
+	}
+	return value
+}
@@ -0,0 +1,211 @@
+package logspipeline
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func decode(t *testing.T, s string) map[string]any {
+	t.Helper()
+	var m map[string]any
+	if err := json.Unmarshal([]byte(s), &m); err != nil {
+		t.Fatalf("invalid JSON %s: %v", s, err)
+	}
+	return m
+}
+
+func TestProcess(t *testing.T) {
+	cases := map[string]struct {
+		pipeline string
+		log      string
+		expected string
+		matched  bool
+	}{
+		"filter not matched": {
+			pipeline: `{"is_enabled": true, "filter": [{"query": "source:nginx"}], "processor": [
+				{"attribute_remapper": [{"is_enabled": true, "sources": ["a"], "source_type": "attribute", "target": "b", "target_type": "attribute"}]}
+			]}`,
+			log:      `{"ddsource": "redis", "a": 1}`,
+			expected: `{"ddsource": "redis", "a": 1}`,
+		},
+		"disabled processor": {
+			pipeline: `{"is_enabled": true, "processor": [
+				{"attribute_remapper": [{"is_enabled": false, "sources": ["a"], "source_type": "attribute", "target": "b", "target_type": "attribute"}]}
+			]}`,
+			log:      `{"a": 1}`,
+			expected: `{"a": 1}`,
+			matched:  true,
+		},
+		"attribute remapper": {
+			pipeline: `{"is_enabled": true, "filter": [{"query": "source:nginx"}], "processor": [
+				{"attribute_remapper": [{"is_enabled": true, "sources": ["missing", "http.code"], "source_type": "attribute", "target": "http.status_code", "target_type": "attribute", "target_format": "integer"}]},
+				{"attribute_remapper": [{"is_enabled": true, "sources": ["env"], "source_type": "tag", "target": "environment", "target_type": "attribute", "preserve_source": true}]},
+				{"attribute_remapper": [{"is_enabled": true, "sources": ["team"], "source_type": "attribute", "target": "team", "target_type": "tag"}]}
+			]}`,
+			log:      `{"ddsource": "nginx", "ddtags": "env:prod", "http": {"code": "200"}, "team": "web"}`,
+			expected: `{"ddsource": "nginx", "ddtags": "env:prod,team:web", "http": {"status_code": 200}, "environment": "prod"}`,
+			matched:  true,
+		},
+		"reserved remappers": {
+			pipeline: `{"is_enabled": true, "processor": [
+				{"status_remapper": [{"is_enabled": true, "sources": ["level"]}]},
+				{"status_remapper": [{"is_enabled": true, "sources": ["severity"]}]},
+				{"date_remapper": [{"is_enabled": true, "sources": ["timestamp"]}]},
+				{"service_remapper": [{"is_enabled": true, "sources": ["app"]}]},
+				{"message_remapper": [{"is_enabled": true, "sources": ["msg"]}]}
+			]}`,
+			log:      `{"level": "WARN", "severity": 3, "timestamp": 1704164645123, "app": "web", "msg": "hello"}`,
+			expected: `{"level": "WARN", "severity": 3, "timestamp": 1704164645123, "app": "web", "msg": "hello", "status": "warning", "date": "2024-01-02T03:04:05.123Z", "service": "web", "message": "hello"}`,
+			matched:  true,
+		},
+		"category, arithmetic and string builder": {
+			pipeline: `{"is_enabled": true, "processor": [
+				{"category_processor": [{"is_enabled": true, "target": "http.status_category", "category": [
+					{"name": "OK", "filter": [{"query": "@http.status_code:[200 TO 299]"}]},
+					{"name": "Error", "filter": [{"query": "@http.status_code:>=500"}]}
+				]}]},
+				{"arithmetic_processor": [{"is_enabled": true, "expression": "(end - start) * 1000", "target": "duration"}]},
+				{"string_builder_processor": [{"is_enabled": true, "template": "%{http.method} %{http.path}", "target": "resource"}]},
+				{"string_builder_processor": [{"is_enabled": true, "template": "%{missing}", "target": "skipped"}]}
+			]}`,
+			log:      `{"http": {"status_code": 503, "method": "GET", "path": "/api"}, "start": 1.5, "end": 2}`,
+			expected: `{"http": {"status_code": 503, "method": "GET", "path": "/api", "status_category": "Error"}, "start": 1.5, "end": 2, "duration": 500, "resource": "GET /api"}`,
+			matched:  true,
+		},
+		"parsers": {
+			pipeline: `{"is_enabled": true, "processor": [
+				{"grok_parser": [{"is_enabled": true, "source": "message", "grok": [{"support_rules": "", "match_rules": "access %{word:http.method} %{notSpace:http.url} %{notSpace:http.useragent}"}]}]},
+				{"url_parser": [{"is_enabled": true, "sources": ["http.url"], "target": "http.url_details"}]},
+				{"user_agent_parser": [{"is_enabled": true, "sources": ["http.useragent"], "target": "http.useragent_details"}]}
+			]}`,
+			log: `{"message": "GET https://example.com:8443/a/b?x=1 curl/8.4.0"}`,
+			expected: `{"message": "GET https://example.com:8443/a/b?x=1 curl/8.4.0", "http": {
+				"method": "GET", "url": "https://example.com:8443/a/b?x=1", "useragent": "curl/8.4.0",
+				"url_details": {"scheme": "https", "host": "example.com", "port": 8443, "path": "/a/b", "queryString": {"x": "1"}},
+				"useragent_details": {"browser": {"family": "curl", "major": "8", "minor": "4", "patch": "0"}, "os": {"family": "Other"}, "device": {"family": "Other", "category": "Other"}}
+			}}`,
+			matched: true,
+		},
+		"lookup, decoder and exclusion": {
+			pipeline: `{"is_enabled": true, "processor": [
+				{"lookup_processor": [{"is_enabled": true, "source": "code", "target": "name", "lookup_table": ["1,one", "2,two"]}]},
+				{"lookup_processor": [{"is_enabled": true, "source": "other", "target": "other_name", "lookup_table": ["1,one"], "default_lookup": "unknown"}]},
+				{"decoder_processor": [{"is_enabled": true, "source": "encoded", "target": "decoded", "binary_to_text_encoding": "base64", "input_representation": "utf_8"}]},
+				{"exclude_attribute_processor": [{"is_enabled": true, "attribute_to_exclude": "secret.token"}]}
+			]}`,
+			log:      `{"code": 2, "other": "9", "encoded": "aGVsbG8=", "secret": {"token": "x", "kept": true}}`,
+			expected: `{"code": 2, "other": "9", "encoded": "aGVsbG8=", "secret": {"kept": true}, "name": "two", "other_name": "unknown", "decoded": "hello"}`,
+			matched:  true,
+		},
+		"nested pipeline": {
+			pipeline: `{"is_enabled": true, "processor": [
+				{"pipeline": [{"is_enabled": true, "filter": [{"query": "env:prod"}], "processor": [
+					{"attribute_remapper": [{"is_enabled": true, "sources": ["a"], "source_type": "attribute", "target": "b", "target_type": "attribute"}]}
+				]}]},
+				{"pipeline": [{"is_enabled": true, "filter": [{"query": "env:staging"}], "processor": [
+					{"attribute_remapper": [{"is_enabled": true, "sources": ["a"], "source_type": "attribute", "target": "c", "target_type": "attribute"}]}
+				]}]}
+			]}`,
+			log:      `{"ddtags": ["env:prod"], "a": 1}`,
+			expected: `{"ddtags": "env:prod", "b": 1}`,
+			matched:  true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			pipeline, _, err := Compile(decode(t, tc.pipeline))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			log, matched := pipeline.Process(decode(t, tc.log))
+			if matched != tc.matched {
+				t.Errorf("expected matched to be %v", tc.matched)
+			}
+			if expected := decode(t, tc.expected); !reflect.DeepEqual(normalize(t, log), expected) {
+				actual, _ := json.Marshal(log)
+				t.Errorf("unexpected log %s", actual)
+			}
+		})
+	}
+}
+
+// normalize round-trips a log through JSON so that numbers are float64.
+func normalize(t *testing.T, log map[string]any) map[string]any {
+	encoded, err := json.Marshal(log)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return decode(t, string(encoded))
+}
+
+func TestCompileWarningsAndErrors(t *testing.T) {
+	_, warnings, err := Compile(decode(t, `{"is_enabled": true, "processor": [
+		{"geo_ip_parser": [{"is_enabled": true, "sources": ["ip"], "target": "geo"}]},
+		{"grok_parser": [{"is_enabled": true, "source": "message", "grok": [{"support_rules": "", "match_rules": "rule (?=a)%{word}"}]}]}
+	]}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []string{
+		"processor.0.geo_ip_parser.0: the geo_ip_parser processor is not simulated",
+		"processor.1.grok_parser.0: the match rules rule use regular expression features which are not simulated",
+	}
+	if !reflect.DeepEqual(warnings, expected) {
+		t.Errorf("unexpected warnings %q", warnings)
+	}
+
+	_, _, err = Compile(decode(t, `{"is_enabled": true, "filter": [{"query": "(a"}], "processor": [
+		{"grok_parser": [{"is_enabled": true, "source": "message", "grok": [{"support_rules": "", "match_rules": "rule %{_missing}"}]}]}
+	]}`))
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	for _, expected := range []string{"filter.0.query: unbalanced parenthesis", "processor.0.grok_parser.0.grok.0: match rule \"rule\""} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("expected %q in %q", expected, err.Error())
+		}
+	}
+}
+
+func TestQuery(t *testing.T) {
+	e := newEvent(decode(t, `{"message": "Connection refused by upstream", "service": "web", "status": "error", "ddtags": "env:prod,team:core", "http": {"status_code": 502, "method": "GET"}, "users": ["a", "b"]}`))
+	cases := map[string]bool{
+		"":                               true,
+		"*":                              true,
+		"refused":                        true,
+		"Refus*":                         true,
+		"refuse":                         false,
+		`"refused by"`:                   true,
+		"service:web status:error":       true,
+		"service:web AND status:warn":    false,
+		"service:api OR env:prod":        true,
+		"-env:prod":                      false,
+		"NOT team:other":                 true,
+		"env:(staging OR prod)":          true,
+		"@http.status_code:[500 TO 599]": true,
+		"@http.status_code:{200 TO 502}": false,
+		"@http.status_code:>=502":        true,
+		"@http.method:get":               false,
+		"@http.method:G*":                true,
+		"@users:b":                       true,
+		"(service:web OR service:api) -@http.method:POST": true,
+	}
+	for q, expected := range cases {
+		parsed, err := parseQuery(q)
+		if err != nil {
+			t.Errorf("unexpected error for %q: %v", q, err)
+			continue
+		}
+		if parsed.match(e) != expected {
+			t.Errorf("expected %q to match: %v", q, expected)
+		}
+	}
+
+	for _, q := range []string{"(a", "a)", "@a:", "a AND", "OR a", `"a`, "@a:[1 TO", "@a:>b", "bad facet!:a"} {
+		if _, err := parseQuery(q); err == nil {
+			t.Errorf("expected an error for %q", q)
+		}
+	}
+}
@@ -0,0 +1,411 @@
+package logspipeline
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/grok"
+)
+
+func compileAttributeRemapper(c *compiler, definition map[string]any, path string) processor {
+	sources := stringList(definition, "sources")
+	sourceType := stringValue(definition, "source_type")
+	target := stringValue(definition, "target")
+	targetType := stringValue(definition, "target_type")
+	targetFormat := stringValue(definition, "target_format")
+	preserveSource := boolValue(definition, "preserve_source")
+	overrideOnConflict := boolValue(definition, "override_on_conflict")
+
+	return func(e *event) {
+		var values []any
+		var source string
+		for _, candidate := range sources {
+			if sourceType == "tag" {
+				for _, value := range e.tagValues(candidate) {
+					values = append(values, value)
+				}
+			} else if value, ok := getPath(e.attributes, candidate); ok {
+				values = []any{value}
+			}
+			if len(values) > 0 {
+				source = candidate
+				break
+			}
+		}
+		if len(values) == 0 {
+			return
+		}
+
+		if targetType == "tag" {
+			if len(e.tagValues(target)) > 0 {
+				if !overrideOnConflict {
+					return
+				}
+				e.removeTags(target)
+			}
+			for _, value := range values {
+				e.tags = append(e.tags, target+":"+formatValue(value))
+			}
+		} else {
+			if _, exists := getPath(e.attributes, target); exists && !overrideOnConflict {
+				return
+			}
+			value := values[0]
+			if len(values) > 1 {
+				value = values
+			}
+			converted, ok := convertFormat(value, targetFormat)
+			if !ok {
+				return
+			}
+			setPath(e.attributes, target, converted)
+		}
+
+		if !preserveSource && !(source == target && sourceType == targetType) {
+			if sourceType == "tag" {
+				e.removeTags(source)
+			} else {
+				deletePath(e.attributes, source)
+			}
+		}
+	}
+}
+
+func convertFormat(value any, format string) (any, bool) {
+	switch format {
+	case "string":
+		return formatValue(value), true
+	case "integer":
+		n, ok := numberValue(value)
+		if !ok {
+			return nil, false
+		}
+		return int64(n), true
+	case "double":
+		return numberValue(value)
+	}
+	return value, true
+}
+
+func (e *event) tagValues(key string) []string {
+	var values []string
+	for _, tag := range e.tags {
+		if k, value, _ := strings.Cut(tag, ":"); k == key {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+func (e *event) removeTags(key string) {
+	tags := e.tags[:0]
+	for _, tag := range e.tags {
+		if k, _, _ := strings.Cut(tag, ":"); k != key {
+			tags = append(tags, tag)
+		}
+	}
+	e.tags = tags
+}
+
+// reservedRemapper remaps the first existing source to a reserved attribute.
+// normalize converts the value, and returns false when it is invalid.
+func reservedRemapper(attribute string, normalize func(any) (any, bool)) processorCompiler {
+	return func(c *compiler, definition map[string]any, path string) processor {
+		sources := stringList(definition, "sources")
+		return func(e *event) {
+			if e.remapped[attribute] {
+				return
+			}
+			for _, source := range sources {
+				value, ok := getPath(e.attributes, source)
+				if !ok {
+					continue
+				}
+				if normalize != nil {
+					if value, ok = normalize(value); !ok {
+						continue
+					}
+				}
+				e.attributes[attribute] = value
+				e.remapped[attribute] = true
+				return
+			}
+		}
+	}
+}
+
+// normalizeStatus maps severities to the statuses of Datadog, see
+// https://docs.datadoghq.com/logs/log_configuration/processors/#log-status-remapper
+func normalizeStatus(value any) (any, bool) {
+	if n, ok := value.(float64); ok {
+		severities := []string{"emergency", "alert", "critical", "error", "warning", "notice", "info", "debug"}
+		if n >= 0 && n < float64(len(severities)) && n == math.Trunc(n) {
+			return severities[int(n)], true
+		}
+		return nil, false
+	}
+	s, ok := value.(string)
+	if !ok || s == "" {
+		return nil, false
+	}
+	if n, err := strconv.Atoi(s); err == nil {
+		return normalizeStatus(float64(n))
+	}
+	lower := strings.ToLower(s)
+	switch {
+	case strings.HasPrefix(lower, "emerg"), strings.HasPrefix(lower, "f"):
+		return "emergency", true
+	case strings.HasPrefix(lower, "a"):
+		return "alert", true
+	case strings.HasPrefix(lower, "c"):
+		return "critical", true
+	case strings.HasPrefix(lower, "e"):
+		return "error", true
+	case strings.HasPrefix(lower, "w"):
+		return "warning", true
+	case strings.HasPrefix(lower, "n"):
+		return "notice", true
+	case strings.HasPrefix(lower, "i"):
+		return "info", true
+	case strings.HasPrefix(lower, "d"), strings.HasPrefix(lower, "trace"), strings.HasPrefix(lower, "verbose"):
+		return "debug", true
+	case strings.HasPrefix(lower, "o"), strings.HasPrefix(lower, "s"):
+		return "ok", true
+	}
+	return "info", true
+}
+
+var dateLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999Z0700",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02 15:04:05,999999999",
+	time.RFC1123Z,
+	time.RFC1123,
+	time.RFC850,
+	time.ANSIC,
+	time.UnixDate,
+	"02/Jan/2006:15:04:05 -0700",
+}
+
+// normalizeDate converts dates to ISO 8601 in UTC. Numbers are epochs in
+// milliseconds, or in seconds when too small to be milliseconds.
+func normalizeDate(value any) (any, bool) {
+	var t time.Time
+	switch v := value.(type) {
+	case float64:
+		if v < 1e11 {
+			v *= 1000
+		}
+		t = time.UnixMilli(int64(v))
+	case string:
+		if n, err := strconv.ParseFloat(v, 64); err == nil {
+			return normalizeDate(n)
+		}
+		parsed := false
+		for _, layout := range dateLayouts {
+			if candidate, err := time.Parse(layout, v); err == nil {
+				t, parsed = candidate, true
+				break
+			}
+		}
+		if !parsed {
+			return nil, false
+		}
+	default:
+		return nil, false
+	}
+	return t.UTC().Format("2006-01-02T15:04:05.000Z"), true
+}
+
+func compileCategoryProcessor(c *compiler, definition map[string]any, path string) processor {
+	type category struct {
+		name   string
+		filter query
+	}
+	target := stringValue(definition, "target")
+	var categories []category
+	for i, categoryDefinition := range blocks(definition, "category") {
+		var filter query = matchAll{}
+		if filters := blocks(categoryDefinition, "filter"); len(filters) > 0 {
+			q, err := parseQuery(stringValue(filters[0], "query"))
+			if err != nil {
+				c.errorf(path+".category."+strconv.Itoa(i)+".filter.0.query", "%s", err)
+				continue
+			}
+			filter = q
+		}
+		categories = append(categories, category{name: stringValue(categoryDefinition, "name"), filter: filter})
+	}
+	return func(e *event) {
+		for _, category := range categories {
+			if category.filter.match(e) {
+				setPath(e.attributes, target, category.name)
+				return
+			}
+		}
+	}
+}
+
+func compileArithmeticProcessor(c *compiler, definition map[string]any, path string) processor {
+	expression, err := parseArithmetic(stringValue(definition, "expression"))
+	if err != nil {
+		c.warnf(path, "the expression is not simulated: %s", err)
+		return nil
+	}
+	target := stringValue(definition, "target")
+	replaceMissing := boolValue(definition, "is_replace_missing")
+	return func(e *event) {
+		result, ok := expression.eval(e.attributes, replaceMissing)
+		if !ok || math.IsNaN(result) || math.IsInf(result, 0) {
+			return
+		}
+		setPath(e.attributes, target, result)
+	}
+}
+
+var templateRegexp = regexp.MustCompile(`%\{([^}]+)\}`)
+
+func compileStringBuilderProcessor(c *compiler, definition map[string]any, path string) processor {
+	template := stringValue(definition, "template")
+	target := stringValue(definition, "target")
+	replaceMissing := boolValue(definition, "is_replace_missing")
+	return func(e *event) {
+		missing := false
+		result := templateRegexp.ReplaceAllStringFunc(template, func(token string) string {
+			value, ok := getPath(e.attributes, strings.TrimSpace(token[2:len(token)-1]))
+			if !ok {
+				missing = true
+				return ""
+			}
+			return formatValue(value)
+		})
+		if missing && !replaceMissing {
+			return
+		}
+		setPath(e.attributes, target, result)
+	}
+}
+
+func compileLookupProcessor(c *compiler, definition map[string]any, path string) processor {
+	source := stringValue(definition, "source")
+	target := stringValue(definition, "target")
+	defaultLookup, hasDefault := definition["default_lookup"].(string)
+	hasDefault = hasDefault && defaultLookup != ""
+	table := map[string]string{}
+	for i, entry := range stringList(definition, "lookup_table") {
+		key, value, ok := strings.Cut(entry, ",")
+		if !ok {
+			c.errorf(path+".lookup_table."+strconv.Itoa(i), "invalid entry %q, expected `key,value`", entry)
+			continue
+		}
+		table[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+	return func(e *event) {
+		raw, ok := getPath(e.attributes, source)
+		if !ok {
+			return
+		}
+		key, ok := formatScalar(raw)
+		if !ok {
+			return
+		}
+		if value, found := table[key]; found {
+			setPath(e.attributes, target, value)
+		} else if hasDefault {
+			setPath(e.attributes, target, defaultLookup)
+		}
+	}
+}
+
+func compileDecoderProcessor(c *compiler, definition map[string]any, path string) processor {
+	source := stringValue(definition, "source")
+	target := stringValue(definition, "target")
+	encoding := stringValue(definition, "binary_to_text_encoding")
+	if representation := stringValue(definition, "input_representation"); representation != "utf_8" {
+		c.warnf(path, "the %s input representation is not simulated", representation)
+		return nil
+	}
+	return func(e *event) {
+		raw, ok := getPath(e.attributes, source)
+		if !ok {
+			return
+		}
+		encoded, ok := raw.(string)
+		if !ok {
+			return
+		}
+		var decoded []byte
+		var err error
+		switch encoding {
+		case "base64":
+			decoded, err = base64.StdEncoding.DecodeString(encoded)
+		case "base16":
+			decoded, err = hex.DecodeString(encoded)
+		default:
+			return
+		}
+		if err != nil || !utf8.Valid(decoded) {
+			return
+		}
+		setPath(e.attributes, target, string(decoded))
+	}
+}
+
+func compileExcludeAttributeProcessor(c *compiler, definition map[string]any, path string) processor {
+	attribute := stringValue(definition, "attribute_to_exclude")
+	return func(e *event) {
+		deletePath(e.attributes, attribute)
+	}
+}
+
+func compileGrokParser(c *compiler, definition map[string]any, path string) processor {
+	source := stringValue(definition, "source")
+	rules := blocks(definition, "grok")
+	if len(rules) == 0 {
+		return nil
+	}
+	parser, err := grok.Compile(stringValue(rules[0], "match_rules"), stringValue(rules[0], "support_rules"))
+	if err != nil {
+		c.errorf(path+".grok.0", "%s", err)
+		return nil
+	}
+	if !parser.Complete() {
+		c.warnf(path, "the match rules %s use regular expression features which are not simulated", strings.Join(parser.Unchecked, ", "))
+	}
+	return func(e *event) {
+		raw, ok := getPath(e.attributes, source)
+		if !ok {
+			return
+		}
+		value, ok := raw.(string)
+		if !ok {
+			return
+		}
+		_, attributes, matched := parser.Parse(value)
+		if !matched {
+			return
+		}
+		mergeAttributes(e.attributes, attributes)
+	}
+}
+
+func mergeAttributes(target, source map[string]any) {
+	for key, value := range source {
+		if nested, ok := value.(map[string]any); ok {
+			if existing, ok := target[key].(map[string]any); ok {
+				mergeAttributes(existing, nested)
+				continue
+			}
+		}
+		target[key] = value
+	}
+}
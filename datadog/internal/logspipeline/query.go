@@ -0,0 +1,256 @@
+package logspipeline
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/validators"
+)
+
+// query is a compiled log search query, see
+// https://docs.datadoghq.com/logs/explorer/search_syntax/
+type query interface {
+	match(e *event) bool
+}
+
+type matchAll struct{}
+
+func (matchAll) match(*event) bool { return true }
+
+type andQuery struct{ left, right query }
+
+func (q andQuery) match(e *event) bool { return q.left.match(e) && q.right.match(e) }
+
+type orQuery struct{ left, right query }
+
+func (q orQuery) match(e *event) bool { return q.left.match(e) || q.right.match(e) }
+
+type notQuery struct{ operand query }
+
+func (q notQuery) match(e *event) bool { return !q.operand.match(e) }
+
+// termQuery matches a value, either against a facet or, without facet,
+// against the words of the message.
+type termQuery struct {
+	facet   string
+	value   string
+	pattern *regexp.Regexp
+	phrase  bool
+}
+
+type rangeQuery struct {
+	facet                   string
+	low, high               string
+	includeLow, includeHigh bool
+}
+
+type compareQuery struct {
+	facet    string
+	operator string
+	value    float64
+}
+
+// reservedFacets are the facets without `@` that refer to attributes rather
+// than to tags.
+var reservedFacets = map[string]string{
+	"host":     "host",
+	"service":  "service",
+	"status":   "status",
+	"source":   "ddsource",
+	"trace_id": "trace_id",
+	"message":  "message",
+}
+
+// parseQuery parses a search query with the shared parser and compiles it
+// into matchers.
+func parseQuery(s string) (query, error) {
+	parsed, err := validators.ParseSearchQuery(s)
+	if err != nil {
+		return nil, err
+	}
+	return compileQuery(parsed)
+}
+
+func compileQuery(parsed validators.SearchQuery) (query, error) {
+	switch q := parsed.(type) {
+	case validators.SearchQueryAll:
+		return matchAll{}, nil
+	case validators.SearchQueryAnd:
+		left, err := compileQuery(q.Left)
+		if err != nil {
+			return nil, err
+		}
+		right, err := compileQuery(q.Right)
+		if err != nil {
+			return nil, err
+		}
+		return andQuery{left, right}, nil
+	case validators.SearchQueryOr:
+		left, err := compileQuery(q.Left)
+		if err != nil {
+			return nil, err
+		}
+		right, err := compileQuery(q.Right)
+		if err != nil {
+			return nil, err
+		}
+		return orQuery{left, right}, nil
+	case validators.SearchQueryNot:
+		operand, err := compileQuery(q.Operand)
+		if err != nil {
+			return nil, err
+		}
+		return notQuery{operand}, nil
+	case validators.SearchQueryTerm:
+		term := termQuery{facet: q.Facet, value: q.Value, phrase: q.Phrase}
+		if q.Pattern != "" {
+			flags := ""
+			if q.Facet == "" || !strings.HasPrefix(q.Facet, "@") {
+				flags = "(?i)"
+			}
+			term.pattern = regexp.MustCompile(flags + `^(?s:` + q.Pattern + `)$`)
+		}
+		return term, nil
+	case validators.SearchQueryRange:
+		return rangeQuery{facet: q.Facet, low: q.Low, high: q.High, includeLow: q.IncludeLow, includeHigh: q.IncludeHigh}, nil
+	case validators.SearchQueryComparison:
+		number, err := strconv.ParseFloat(q.Value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("comparison with %q is not simulated, only numbers are supported", q.Value)
+		}
+		return compareQuery{facet: q.Facet, operator: q.Operator, value: number}, nil
+	}
+	return nil, fmt.Errorf("unsupported query %T", parsed)
+}
+
+func (q termQuery) match(e *event) bool {
+	if q.facet == "" {
+		return q.matchMessage(e)
+	}
+	for _, value := range facetValues(e, q.facet) {
+		if q.matchValue(value, !strings.HasPrefix(q.facet, "@")) {
+			return true
+		}
+	}
+	return false
+}
+
+func (q termQuery) matchValue(value string, caseInsensitive bool) bool {
+	if q.pattern != nil {
+		return q.pattern.MatchString(value)
+	}
+	if caseInsensitive {
+		return strings.EqualFold(value, q.value)
+	}
+	return value == q.value
+}
+
+// matchMessage implements full-text search on the message: phrases and
+// words with punctuation match substrings, other words match whole words.
+func (q termQuery) matchMessage(e *event) bool {
+	message, _ := e.attributes["message"].(string)
+	if q.pattern == nil && (q.phrase || strings.IndexFunc(q.value, isSeparator) >= 0) {
+		return strings.Contains(strings.ToLower(message), strings.ToLower(q.value))
+	}
+	for _, word := range strings.FieldsFunc(message, isSeparator) {
+		if q.matchValue(word, true) {
+			return true
+		}
+	}
+	return false
+}
+
+func isSeparator(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_'
+}
+
+func (q rangeQuery) match(e *event) bool {
+	low, lowErr := strconv.ParseFloat(q.low, 64)
+	high, highErr := strconv.ParseFloat(q.high, 64)
+	numeric := (lowErr == nil || q.low == "*") && (highErr == nil || q.high == "*")
+	for _, value := range facetValues(e, q.facet) {
+		if numeric {
+			n, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			if (q.low == "*" || n > low || (q.includeLow && n == low)) &&
+				(q.high == "*" || n < high || (q.includeHigh && n == high)) {
+				return true
+			}
+			continue
+		}
+		if (q.low == "*" || value > q.low || (q.includeLow && value == q.low)) &&
+			(q.high == "*" || value < q.high || (q.includeHigh && value == q.high)) {
+			return true
+		}
+	}
+	return false
+}
+
+func (q compareQuery) match(e *event) bool {
+	for _, value := range facetValues(e, q.facet) {
+		n, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			continue
+		}
+		switch q.operator {
+		case ">":
+			if n > q.value {
+				return true
+			}
+		case ">=":
+			if n >= q.value {
+				return true
+			}
+		case "<":
+			if n < q.value {
+				return true
+			}
+		case "<=":
+			if n <= q.value {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// facetValues returns the values of a facet as strings: attributes for
+// `@` facets and reserved attributes, tag values otherwise.
+func facetValues(e *event, facet string) []string {
+	path, isAttribute := strings.CutPrefix(facet, "@")
+	if !isAttribute {
+		path, isAttribute = reservedFacets[facet]
+	}
+	if !isAttribute {
+		var values []string
+		for _, tag := range e.tags {
+			if key, value, _ := strings.Cut(tag, ":"); key == facet {
+				values = append(values, value)
+			}
+		}
+		return values
+	}
+
+	value, ok := getPath(e.attributes, path)
+	if !ok {
+		return nil
+	}
+	if list, ok := value.([]any); ok {
+		values := make([]string, 0, len(list))
+		for _, item := range list {
+			if s, ok := formatScalar(item); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	}
+	if s, ok := formatScalar(value); ok {
+		return []string{s}
+	}
+	return nil
+}
@@ -0,0 +1,174 @@
+// Package sds evaluates Sensitive Data Scanner rules locally, to check their
+// patterns and test them against samples before they are sent to Datadog.
+package sds
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"regexp/syntax"
+	"strings"
+	"unicode/utf8"
+)
+
+// ErrMatchesEmpty is returned by Check for patterns matching the empty
+// string, which match every scanned value.
+var ErrMatchesEmpty = errors.New("the pattern matches the empty string, so it matches every scanned value")
+
+// CompilePattern compiles a Sensitive Data Scanner pattern. Patterns use the
+// RE2 syntax, which runs in linear time: backreferences and lookaround
+// assertions are not supported.
+func CompilePattern(pattern string) (*regexp.Regexp, error) {
+	re, err := regexp.Compile(pattern)
+	if err == nil {
+		return re, nil
+	}
+	var syntaxErr *syntax.Error
+	if errors.As(err, &syntaxErr) {
+		switch {
+		case lookaround(syntaxErr.Expr) != "":
+			return nil, fmt.Errorf("lookaround assertion `%s` is not supported by the RE2 syntax", lookaround(syntaxErr.Expr))
+		case syntaxErr.Code == syntax.ErrInvalidEscape && isBackreference(syntaxErr.Expr):
+			return nil, fmt.Errorf("backreference `%s` is not supported by the RE2 syntax", syntaxErr.Expr)
+		case syntaxErr.Code == syntax.ErrInvalidRepeatSize:
+			return nil, fmt.Errorf("invalid repeat count `%s`: repeat counts are limited to 1000 by the RE2 syntax", syntaxErr.Expr)
+		}
+	}
+	return nil, err
+}
+
+// lookaround returns the lookaround assertion an invalid expression starts
+// with, if any.
+func lookaround(expr string) string {
+	for _, prefix := range []string{"(?=", "(?!", "(?<=", "(?<!"} {
+		if strings.HasPrefix(expr, prefix) {
+			return prefix
+		}
+	}
+	return ""
+}
+
+func isBackreference(expr string) bool {
+	return len(expr) == 2 && expr[0] == '\\' && expr[1] >= '1' && expr[1] <= '9'
+}
+
+// Check compiles a pattern, and returns ErrMatchesEmpty for patterns which
+// are valid but match the empty string.
+func Check(pattern string) error {
+	re, err := CompilePattern(pattern)
+	if err != nil {
+		return err
+	}
+	if re.MatchString("") {
+		return ErrMatchesEmpty
+	}
+	return nil
+}
+
+// Rule is a Sensitive Data Scanner rule.
+type Rule struct {
+	Pattern string
+	// Keywords, when set, must be found in the CharacterCount characters
+	// preceding a match for it to be kept.
+	Keywords       []string
+	CharacterCount int
+	Suppressions   Suppressions
+}
+
+// Suppressions discard the matches starting with, ending with or equal to
+// one of their values.
+type Suppressions struct {
+	StartsWith []string
+	EndsWith   []string
+	ExactMatch []string
+}
+
+// Matcher evaluates a rule on samples.
+type Matcher struct {
+	rule *Rule
+	re   *regexp.Regexp
+}
+
+// Compile returns a matcher for the rule.
+func (r *Rule) Compile() (*Matcher, error) {
+	re, err := CompilePattern(r.Pattern)
+	if err != nil {
+		return nil, err
+	}
+	return &Matcher{rule: r, re: re}, nil
+}
+
+// Match returns the first match of the rule in the sample which is not
+// discarded by the keyword proximity check or the suppressions, and whether
+// there is one.
+func (m *Matcher) Match(sample string) (string, bool) {
+	for _, loc := range m.re.FindAllStringIndex(sample, -1) {
+		match := sample[loc[0]:loc[1]]
+		if match == "" || m.suppressed(match) || !m.hasKeyword(sample[:loc[0]]) {
+			continue
+		}
+		return match, true
+	}
+	return "", false
+}
+
+func (m *Matcher) suppressed(match string) bool {
+	s := m.rule.Suppressions
+	for _, prefix := range s.StartsWith {
+		if strings.HasPrefix(match, prefix) {
+			return true
+		}
+	}
+	for _, suffix := range s.EndsWith {
+		if strings.HasSuffix(match, suffix) {
+			return true
+		}
+	}
+	for _, value := range s.ExactMatch {
+		if match == value {
+			return true
+		}
+	}
+	return false
+}
+
+// hasKeyword checks the keywords, case-insensitively, in the characters
+// preceding a match.
+func (m *Matcher) hasKeyword(before string) bool {
+	if len(m.rule.Keywords) == 0 {
+		return true
+	}
+	window := before
+	if count := utf8.RuneCountInString(before); count > m.rule.CharacterCount {
+		runes := []rune(before)
+		window = string(runes[count-m.rule.CharacterCount:])
+	}
+	window = strings.ToLower(window)
+	for _, keyword := range m.rule.Keywords {
+		if strings.Contains(window, strings.ToLower(keyword)) {
+			return true
+		}
+	}
+	return false
+}
+
+// TestSamples evaluates a rule on samples it must match and samples it must
+// not match, and returns a description of each failed sample.
+func (r *Rule) TestSamples(match, noMatch []string) ([]string, error) {
+	m, err := r.Compile()
+	if err != nil {
+		return nil, err
+	}
+	var failures []string
+	for _, sample := range match {
+		if _, ok := m.Match(sample); !ok {
+			failures = append(failures, fmt.Sprintf("%q is not matched", sample))
+		}
+	}
+	for _, sample := range noMatch {
+		if found, ok := m.Match(sample); ok {
+			failures = append(failures, fmt.Sprintf("%q is matched by %q", sample, found))
+		}
+	}
+	return failures, nil
+}
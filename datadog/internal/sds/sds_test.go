@@ -0,0 +1,77 @@
+package sds
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestCheck(t *testing.T) {
+	for _, pattern := range []string{`\d{3}-\d{2}-\d{4}`, `(?i)api[_-]?key=[a-z0-9]{32}`, `\bAKIA[0-9A-Z]{16}\b`} {
+		if err := Check(pattern); err != nil {
+			t.Errorf("unexpected error for %s: %v", pattern, err)
+		}
+	}
+
+	invalid := map[string]string{
+		`password(?=:)`:     "lookaround assertion `(?=` is not supported",
+		`(?<!x)\d{4}`:       "lookaround assertion `(?<!` is not supported",
+		`(['"]).*\1`:        "backreference `\\1` is not supported",
+		`\d{1001}`:          "repeat counts are limited to 1000",
+		`[a-z`:              "missing closing ]",
+		`\d*`:               ErrMatchesEmpty.Error(),
+		`(secret)?[0-9]{0}`: ErrMatchesEmpty.Error(),
+	}
+	for pattern, message := range invalid {
+		err := Check(pattern)
+		if err == nil {
+			t.Errorf("expected an error for %s", pattern)
+			continue
+		}
+		if !strings.Contains(err.Error(), message) {
+			t.Errorf("expected error for %s to contain %q, got %q", pattern, message, err)
+		}
+	}
+	if !errors.Is(Check(`.*`), ErrMatchesEmpty) {
+		t.Error("expected ErrMatchesEmpty")
+	}
+}
+
+func TestTestSamples(t *testing.T) {
+	rule := Rule{
+		Pattern:        `\b\d{4}-\d{4}-\d{4}-\d{4}\b`,
+		Keywords:       []string{"card", "CC"},
+		CharacterCount: 10,
+		Suppressions: Suppressions{
+			StartsWith: []string{"0000"},
+			ExactMatch: []string{"4111-1111-1111-1111"},
+		},
+	}
+	failures, err := rule.TestSamples(
+		[]string{
+			"Card: 1234-5678-9012-3456",
+			"cc=0000-1111-2222-3333 cc=1234-5678-9012-3456",
+			"no keyword: 1234-5678-9012-3456",
+		},
+		[]string{
+			"card number 1234-5678-9012-3456",
+			"card: 0000-1111-2222-3333",
+			"card: 4111-1111-1111-1111",
+			"card: 1234-5678-9012-3456",
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{
+		`"no keyword: 1234-5678-9012-3456" is not matched`,
+		`"card: 1234-5678-9012-3456" is matched by "1234-5678-9012-3456"`,
+	}
+	if strings.Join(failures, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("expected failures %q, got %q", expected, failures)
+	}
+
+	if _, err := (&Rule{Pattern: `(a)\1`}).TestSamples(nil, nil); err == nil {
+		t.Error("expected an error")
+	}
+}
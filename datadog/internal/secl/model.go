@@ -0,0 +1,349 @@
+package secl
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Type is the type of a SECL field or value.
+type Type int
+
+const (
+	// TypeAny is the type of variables, which is only known by the Agent.
+	TypeAny Type = iota
+	TypeString
+	TypeInt
+	TypeBool
+	TypeIP
+)
+
+func (t Type) String() string {
+	switch t {
+	case TypeString:
+		return "string"
+	case TypeInt:
+		return "integer"
+	case TypeBool:
+		return "boolean"
+	case TypeIP:
+		return "IP"
+	}
+	return "any"
+}
+
+// commonNamespaces are the field namespaces available in rules of every event
+// type. The first segment of the other fields is the event type.
+var commonNamespaces = map[string]bool{
+	"cgroup":    true,
+	"container": true,
+	"event":     true,
+	"network":   true,
+	"process":   true,
+}
+
+var fileFields = map[string]Type{
+	"change_time":            TypeInt,
+	"device_path":            TypeString,
+	"extension":              TypeString,
+	"filesystem":             TypeString,
+	"gid":                    TypeInt,
+	"group":                  TypeString,
+	"hashes":                 TypeString,
+	"in_upper_layer":         TypeBool,
+	"inode":                  TypeInt,
+	"mode":                   TypeInt,
+	"modification_time":      TypeInt,
+	"mount_detached":         TypeBool,
+	"mount_id":               TypeInt,
+	"mount_path":             TypeString,
+	"mount_source":           TypeString,
+	"mount_visible":          TypeBool,
+	"name":                   TypeString,
+	"package.name":           TypeString,
+	"package.source_version": TypeString,
+	"package.version":        TypeString,
+	"path":                   TypeString,
+	"rights":                 TypeInt,
+	"uid":                    TypeInt,
+	"user":                   TypeString,
+}
+
+var processFields = map[string]Type{
+	"args":                      TypeString,
+	"args_flags":                TypeString,
+	"args_options":              TypeString,
+	"args_truncated":            TypeBool,
+	"argv":                      TypeString,
+	"argv0":                     TypeString,
+	"auid":                      TypeInt,
+	"cap_effective":             TypeInt,
+	"cap_permitted":             TypeInt,
+	"cgroup.id":                 TypeString,
+	"cgroup.manager":            TypeString,
+	"cgroup.version":            TypeInt,
+	"cmdline":                   TypeString,
+	"comm":                      TypeString,
+	"container.id":              TypeString,
+	"created_at":                TypeInt,
+	"egid":                      TypeInt,
+	"egroup":                    TypeString,
+	"envp":                      TypeString,
+	"envs":                      TypeString,
+	"envs_truncated":            TypeBool,
+	"euid":                      TypeInt,
+	"euser":                     TypeString,
+	"exec_time":                 TypeInt,
+	"exit_time":                 TypeInt,
+	"fsgid":                     TypeInt,
+	"fsgroup":                   TypeString,
+	"fsuid":                     TypeInt,
+	"fsuser":                    TypeString,
+	"gid":                       TypeInt,
+	"group":                     TypeString,
+	"is_exec":                   TypeBool,
+	"is_kworker":                TypeBool,
+	"is_thread":                 TypeBool,
+	"pid":                       TypeInt,
+	"ppid":                      TypeInt,
+	"tid":                       TypeInt,
+	"tty_name":                  TypeString,
+	"uid":                       TypeInt,
+	"user":                      TypeString,
+	"user_session.k8s_groups":   TypeString,
+	"user_session.k8s_uid":      TypeString,
+	"user_session.k8s_username": TypeString,
+	"user_sid":                  TypeString,
+}
+
+var registryFields = map[string]Type{
+	"key_name":   TypeString,
+	"key_path":   TypeString,
+	"value_name": TypeString,
+}
+
+var addrFields = map[string]Type{
+	"addr.family":    TypeInt,
+	"addr.hostname":  TypeString,
+	"addr.ip":        TypeIP,
+	"addr.is_public": TypeBool,
+	"addr.port":      TypeInt,
+	"protocol":       TypeInt,
+	"retval":         TypeInt,
+}
+
+var networkFields = map[string]Type{
+	"destination.ip":        TypeIP,
+	"destination.is_public": TypeBool,
+	"destination.port":      TypeInt,
+	"device.ifname":         TypeString,
+	"l3_protocol":           TypeInt,
+	"l4_protocol":           TypeInt,
+	"size":                  TypeInt,
+	"source.ip":             TypeIP,
+	"source.is_public":      TypeBool,
+	"source.port":           TypeInt,
+}
+
+// fields maps the fields of the Agent's event model, as documented for Linux
+// and Windows rules, to their type.
+var fields = map[string]Type{}
+
+func addFields(prefix string, set map[string]Type) {
+	for name, t := range set {
+		fields[prefix+"."+name] = t
+	}
+}
+
+func addProcess(prefix string) {
+	addFields(prefix, processFields)
+	addFields(prefix+".file", fileFields)
+	addFields(prefix+".interpreter.file", fileFields)
+}
+
+// addProcessLineage adds a process with its parent and ancestors.
+func addProcessLineage(prefix string) {
+	addProcess(prefix)
+	addProcess(prefix + ".parent")
+	addProcess(prefix + ".ancestors")
+	fields[prefix+".ancestors.length"] = TypeInt
+}
+
+// addFileEvent adds an event on a file with the given extra fields.
+func addFileEvent(event string, extra map[string]Type) {
+	addFields(event+".file", fileFields)
+	addFields(event, map[string]Type{"retval": TypeInt, "syscall.path": TypeString})
+	addFields(event, extra)
+}
+
+func init() {
+	addProcessLineage("process")
+	addFields("container", map[string]Type{"created_at": TypeInt, "id": TypeString, "runtime": TypeString, "tags": TypeString})
+	addFields("cgroup", map[string]Type{"id": TypeString, "manager": TypeString, "version": TypeInt})
+	addFields("event", map[string]Type{"async": TypeBool, "hostname": TypeString, "origin": TypeString, "os": TypeString, "service": TypeString, "timestamp": TypeInt})
+	addFields("network", networkFields)
+
+	addProcess("exec")
+	fields["exec.syscall.path"] = TypeString
+	addProcess("exit")
+	addFields("exit", map[string]Type{"cause": TypeInt, "code": TypeInt})
+	addProcessLineage("ptrace.tracee")
+	addFields("ptrace", map[string]Type{"request": TypeInt, "retval": TypeInt})
+	addProcessLineage("signal.target")
+	addFields("signal", map[string]Type{"pid": TypeInt, "type": TypeInt, "retval": TypeInt})
+	addProcessLineage("setrlimit.target")
+	addFields("setrlimit", map[string]Type{"resource": TypeInt, "rlim_cur": TypeInt, "rlim_max": TypeInt, "retval": TypeInt})
+
+	addFileEvent("open", map[string]Type{"flags": TypeInt, "file.destination.mode": TypeInt, "syscall.flags": TypeInt, "syscall.mode": TypeInt})
+	addFileEvent("chmod", map[string]Type{"file.destination.mode": TypeInt, "file.destination.rights": TypeInt, "syscall.mode": TypeInt})
+	addFileEvent("chown", map[string]Type{"file.destination.gid": TypeInt, "file.destination.group": TypeString, "file.destination.uid": TypeInt, "file.destination.user": TypeString, "syscall.gid": TypeInt, "syscall.uid": TypeInt})
+	addFileEvent("mkdir", map[string]Type{"file.destination.mode": TypeInt, "file.destination.rights": TypeInt, "syscall.mode": TypeInt})
+	addFileEvent("rmdir", nil)
+	addFileEvent("unlink", map[string]Type{"flags": TypeInt, "syscall.dirfd": TypeInt, "syscall.flags": TypeInt})
+	addFileEvent("utimes", nil)
+	addFileEvent("chdir", nil)
+	addFileEvent("rename", map[string]Type{"syscall.destination.path": TypeString})
+	addFields("rename.file.destination", fileFields)
+	addFileEvent("link", map[string]Type{"syscall.destination.path": TypeString})
+	addFields("link.file.destination", fileFields)
+	addFileEvent("setxattr", map[string]Type{"file.destination.name": TypeString, "file.destination.namespace": TypeString})
+	addFileEvent("removexattr", map[string]Type{"file.destination.name": TypeString, "file.destination.namespace": TypeString})
+	addFileEvent("mmap", map[string]Type{"flags": TypeInt, "protection": TypeInt})
+	addFileEvent("splice", map[string]Type{"pipe_entry_flag": TypeInt, "pipe_exit_flag": TypeInt})
+	addFileEvent("load_module", map[string]Type{"args": TypeString, "args_truncated": TypeBool, "argv": TypeString, "loaded_from_memory": TypeBool, "name": TypeString})
+	addFileEvent("cgroup_write", map[string]Type{"pid": TypeInt})
+
+	addFields("mount", map[string]Type{
+		"detached":                TypeBool,
+		"fs_type":                 TypeString,
+		"mountpoint.path":         TypeString,
+		"retval":                  TypeInt,
+		"root.path":               TypeString,
+		"source.path":             TypeString,
+		"syscall.fs_type":         TypeString,
+		"syscall.mountpoint.path": TypeString,
+		"syscall.source.path":     TypeString,
+		"visible":                 TypeBool,
+	})
+	addFields("unload_module", map[string]Type{"name": TypeString, "retval": TypeInt})
+	addFields("kill", map[string]Type{"signal": TypeInt, "retval": TypeInt})
+	addFields("mprotect", map[string]Type{"req_protection": TypeInt, "vm_protection": TypeInt, "retval": TypeInt})
+	addFields("capset", map[string]Type{"cap_effective": TypeInt, "cap_permitted": TypeInt})
+	addFields("setuid", map[string]Type{"uid": TypeInt, "euid": TypeInt, "fsuid": TypeInt, "user": TypeString, "euser": TypeString, "fsuser": TypeString})
+	addFields("setgid", map[string]Type{"gid": TypeInt, "egid": TypeInt, "fsgid": TypeInt, "group": TypeString, "egroup": TypeString, "fsgroup": TypeString})
+	addFields("selinux", map[string]Type{"bool.name": TypeString, "bool.state": TypeString, "bool_commit.state": TypeBool, "enforce.status": TypeString})
+	addFields("bpf", map[string]Type{
+		"cmd":              TypeInt,
+		"map.name":         TypeString,
+		"map.type":         TypeInt,
+		"prog.attach_type": TypeInt,
+		"prog.helpers":     TypeInt,
+		"prog.name":        TypeString,
+		"prog.tag":         TypeString,
+		"prog.type":        TypeInt,
+		"retval":           TypeInt,
+	})
+	addFields("sysctl", map[string]Type{
+		"action":              TypeInt,
+		"file_position":       TypeInt,
+		"name":                TypeString,
+		"name_truncated":      TypeBool,
+		"old_value":           TypeString,
+		"old_value_truncated": TypeBool,
+		"value":               TypeString,
+		"value_truncated":     TypeBool,
+	})
+	addFields("prctl", map[string]Type{"is_name_truncated": TypeBool, "new_name": TypeString, "option": TypeInt, "retval": TypeInt})
+	addFields("setsockopt", map[string]Type{
+		"filter_hash":         TypeString,
+		"filter_instructions": TypeString,
+		"filter_length":       TypeInt,
+		"is_filter_truncated": TypeBool,
+		"level":               TypeInt,
+		"optname":             TypeInt,
+		"retval":              TypeInt,
+		"socket_family":       TypeInt,
+		"socket_protocol":     TypeInt,
+		"socket_type":         TypeInt,
+	})
+	for _, event := range []string{"bind", "connect", "accept"} {
+		addFields(event, addrFields)
+	}
+	addFields("dns", map[string]Type{
+		"id":             TypeInt,
+		"is_query":       TypeBool,
+		"question.class": TypeInt,
+		"question.count": TypeInt,
+		"question.name":  TypeString,
+		"question.type":  TypeInt,
+		"response.code":  TypeInt,
+	})
+	addFields("imds", map[string]Type{
+		"aws.is_imds_v2":                TypeBool,
+		"aws.security_credentials.type": TypeString,
+		"cloud_provider":                TypeString,
+		"host":                          TypeString,
+		"server":                        TypeString,
+		"type":                          TypeString,
+		"url":                           TypeString,
+		"user_agent":                    TypeString,
+	})
+	addFields("packet", networkFields)
+	addFields("network_flow_monitor", map[string]Type{
+		"device.ifname":               TypeString,
+		"flows.destination.ip":        TypeIP,
+		"flows.destination.is_public": TypeBool,
+		"flows.destination.port":      TypeInt,
+		"flows.egress.data_size":      TypeInt,
+		"flows.egress.packet_count":   TypeInt,
+		"flows.ingress.data_size":     TypeInt,
+		"flows.ingress.packet_count":  TypeInt,
+		"flows.l3_protocol":           TypeInt,
+		"flows.l4_protocol":           TypeInt,
+		"flows.length":                TypeInt,
+		"flows.source.ip":             TypeIP,
+		"flows.source.is_public":      TypeBool,
+		"flows.source.port":           TypeInt,
+	})
+	fields["packet.filter"] = TypeString
+	fields["ondemand.name"] = TypeString
+	for i := 1; i <= 4; i++ {
+		arg := "ondemand.arg" + strconv.Itoa(i)
+		fields[arg+".str"] = TypeString
+		fields[arg+".uint"] = TypeInt
+	}
+
+	// Windows events
+	for _, event := range []string{"create", "delete", "write", "rename"} {
+		addFields(event+".file", fileFields)
+	}
+	addFields("rename.file.destination", fileFields)
+	for _, event := range []string{"create", "open", "delete", "set"} {
+		addFields(event+".registry", registryFields)
+	}
+	addFields("set", map[string]Type{"value_name": TypeString, "registry.value": TypeString})
+	addFields("change_permission", map[string]Type{"new_sd": TypeString, "old_sd": TypeString, "path": TypeString, "type": TypeString, "user_domain": TypeString, "user_name": TypeString})
+}
+
+// fieldType returns the type of the field, and whether it exists. The length
+// of string fields is available with the `.length` suffix.
+func fieldType(name string) (Type, bool) {
+	if t, ok := fields[name]; ok {
+		return t, true
+	}
+	if base, ok := strings.CutSuffix(name, ".length"); ok {
+		if t, ok := fields[base]; ok && t == TypeString {
+			return TypeInt, true
+		}
+	}
+	return TypeAny, false
+}
+
+// eventType returns the event type of the field, empty for the fields which
+// are available with every event type.
+func eventType(name string) string {
+	namespace, _, _ := strings.Cut(name, ".")
+	if commonNamespaces[namespace] {
+		return ""
+	}
+	return namespace
+}
@@ -0,0 +1,555 @@
+// Package secl checks Security Agent rule expressions written in SECL, the
+// language used by CSM Threats, against the Agent's event model.
+package secl
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenWord
+	tokenString
+	tokenPattern
+	tokenRegexp
+	tokenVariable
+	tokenOperator
+)
+
+type token struct {
+	kind   tokenKind
+	value  string
+	offset int
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokenEOF:
+		return "end of expression"
+	case tokenString:
+		return strconv.Quote(t.value)
+	case tokenPattern:
+		return "~" + strconv.Quote(t.value)
+	case tokenRegexp:
+		return "r" + strconv.Quote(t.value)
+	case tokenVariable:
+		return "${" + t.value + "}"
+	}
+	return fmt.Sprintf("%q", t.value)
+}
+
+// operators are sorted so that the longest ones are matched first.
+var operators = []string{"==", "!=", "=~", "!~", "<=", ">=", "&&", "||", "<", ">", "!", "&", "|", "^", "-", "(", ")", "[", "]", ","}
+
+var (
+	variableRegexp = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_.]*$`)
+	fieldRegexp    = regexp.MustCompile(`^[a-z][a-z0-9_]*(\.[a-z0-9_]+)*$`)
+	constantRegexp = regexp.MustCompile(`^[A-Z][A-Z0-9_]*$`)
+	durationRegexp = regexp.MustCompile(`^[0-9]+(ns|us|ms|s|m|h)$`)
+)
+
+func isWordChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_' || c == '.' || c == ':' || c == '/'
+}
+
+func tokenize(s string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '"' || (c == '~' || c == 'r') && i+1 < len(s) && s[i+1] == '"':
+			kind := tokenString
+			start := i
+			if c == '~' {
+				kind = tokenPattern
+				i++
+			} else if c == 'r' {
+				kind = tokenRegexp
+				i++
+			}
+			value, end, err := readString(s, i)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, token{kind: kind, value: value, offset: start})
+			i = end
+		case c == '$':
+			end := strings.IndexByte(s[i:], '}')
+			if !strings.HasPrefix(s[i:], "${") || end < 0 {
+				return nil, fmt.Errorf("unterminated variable at offset %d", i)
+			}
+			name := s[i+2 : i+end]
+			if !variableRegexp.MatchString(name) {
+				return nil, fmt.Errorf("invalid variable name %q at offset %d", name, i)
+			}
+			tokens = append(tokens, token{kind: tokenVariable, value: name, offset: i})
+			i += end + 1
+		case isWordChar(c):
+			start := i
+			for i < len(s) && isWordChar(s[i]) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenWord, value: s[start:i], offset: start})
+		default:
+			matched := false
+			for _, op := range operators {
+				if strings.HasPrefix(s[i:], op) {
+					tokens = append(tokens, token{kind: tokenOperator, value: op, offset: i})
+					i += len(op)
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				return nil, fmt.Errorf("unexpected character %q at offset %d", c, i)
+			}
+		}
+	}
+	return append(tokens, token{kind: tokenEOF, offset: len(s)}), nil
+}
+
+// readString reads the double quoted string starting at offset start, and
+// returns its unescaped value and the offset right after it.
+func readString(s string, start int) (string, int, error) {
+	var b strings.Builder
+	for i := start + 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			if i+1 >= len(s) {
+				return "", 0, fmt.Errorf("unterminated string at offset %d", start)
+			}
+			i++
+			if s[i] != '"' && s[i] != '\\' {
+				b.WriteByte('\\')
+			}
+			b.WriteByte(s[i])
+		case '"':
+			return b.String(), i + 1, nil
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return "", 0, fmt.Errorf("unterminated string at offset %d", start)
+}
+
+type valueKind int
+
+const (
+	valueLiteral valueKind = iota
+	valueField
+	valueVariable
+	valuePattern
+	valueRegexp
+	valueExpression
+)
+
+// value is the result of a sub-expression: its type, and what it is made of
+// for the checks which depend on it.
+type value struct {
+	kind valueKind
+	typ  Type
+	text string
+}
+
+func (v value) String() string {
+	switch v.kind {
+	case valueField:
+		return fmt.Sprintf("%s field %s", v.typ, v.text)
+	case valueVariable:
+		return "variable " + v.text
+	case valuePattern:
+		return "pattern " + v.text
+	case valueRegexp:
+		return "regular expression " + v.text
+	}
+	return fmt.Sprintf("%s %s", v.typ, v.text)
+}
+
+type checker struct {
+	tokens []token
+	pos    int
+	// event is the event type of the rule, set by the first field which is
+	// specific to an event type.
+	event      string
+	eventField string
+	warnings   []string
+}
+
+// Check parses a SECL rule expression and checks its fields, operators,
+// values, patterns and regular expressions against the event model. It
+// returns the event type of the rule, empty when the expression only uses
+// fields common to every event type.
+//
+// Only syntax errors are returned as an error. The model only documents the
+// fields known when it was written, and the Agent adds event types and fields
+// with every release: unknown fields and the type mismatches are returned as
+// warnings instead.
+func Check(expression string) (string, []string, error) {
+	tokens, err := tokenize(expression)
+	if err != nil {
+		return "", nil, err
+	}
+	c := &checker{tokens: tokens}
+	if c.peek().kind == tokenEOF {
+		return "", nil, fmt.Errorf("empty expression")
+	}
+	result, err := c.parseOr()
+	if err != nil {
+		return "", nil, err
+	}
+	if t := c.peek(); t.kind != tokenEOF {
+		if t.value == ")" {
+			return "", nil, fmt.Errorf("unbalanced parenthesis at offset %d", t.offset)
+		}
+		return "", nil, fmt.Errorf("unexpected %s at offset %d", t, t.offset)
+	}
+	if result.typ != TypeBool && result.typ != TypeAny {
+		c.warnf("the expression must be a boolean, %s is not", result)
+	}
+	return c.event, c.warnings, nil
+}
+
+func (c *checker) warnf(format string, args ...any) {
+	c.warnings = append(c.warnings, fmt.Sprintf(format, args...))
+}
+
+func (c *checker) peek() token {
+	return c.tokens[c.pos]
+}
+
+func (c *checker) next() token {
+	t := c.tokens[c.pos]
+	if t.kind != tokenEOF {
+		c.pos++
+	}
+	return t
+}
+
+// accept consumes the next token when it is one of the given operators or
+// keywords.
+func (c *checker) accept(values ...string) (token, bool) {
+	t := c.peek()
+	if t.kind != tokenOperator && t.kind != tokenWord {
+		return t, false
+	}
+	for _, v := range values {
+		if t.value == v {
+			return c.next(), true
+		}
+	}
+	return t, false
+}
+
+func (c *checker) requireBool(op token, values ...value) {
+	for _, v := range values {
+		if v.typ != TypeBool && v.typ != TypeAny {
+			c.warnf("%s at offset %d requires boolean operands, %s is not", op.value, op.offset, v)
+		}
+	}
+}
+
+func (c *checker) parseOr() (value, error) {
+	left, err := c.parseAnd()
+	if err != nil {
+		return left, err
+	}
+	for {
+		op, ok := c.accept("||", "or")
+		if !ok {
+			return left, nil
+		}
+		right, err := c.parseAnd()
+		if err != nil {
+			return right, err
+		}
+		c.requireBool(op, left, right)
+		left = value{kind: valueExpression, typ: TypeBool, text: "expression"}
+	}
+}
+
+func (c *checker) parseAnd() (value, error) {
+	left, err := c.parseNot()
+	if err != nil {
+		return left, err
+	}
+	for {
+		op, ok := c.accept("&&", "and")
+		if !ok {
+			return left, nil
+		}
+		right, err := c.parseNot()
+		if err != nil {
+			return right, err
+		}
+		c.requireBool(op, left, right)
+		left = value{kind: valueExpression, typ: TypeBool, text: "expression"}
+	}
+}
+
+func (c *checker) parseNot() (value, error) {
+	if op, ok := c.accept("!", "not"); ok {
+		operand, err := c.parseNot()
+		if err != nil {
+			return operand, err
+		}
+		c.requireBool(op, operand)
+		return value{kind: valueExpression, typ: TypeBool, text: "expression"}, nil
+	}
+	return c.parseComparison()
+}
+
+func (c *checker) parseComparison() (value, error) {
+	left, err := c.parseBitOperation()
+	if err != nil {
+		return left, err
+	}
+	if op, ok := c.accept("==", "!=", "=~", "!~", "<", "<=", ">", ">="); ok {
+		right, err := c.parseBitOperation()
+		if err != nil {
+			return right, err
+		}
+		c.checkComparison(left, op, right)
+		return value{kind: valueExpression, typ: TypeBool, text: "comparison"}, nil
+	}
+	op, ok := c.accept("in", "allin", "not")
+	if !ok {
+		return left, nil
+	}
+	if op.value == "not" {
+		if _, ok := c.accept("in"); !ok {
+			return left, fmt.Errorf("expected in after not at offset %d", op.offset)
+		}
+		op.value = "not in"
+	}
+	elements, err := c.parseArray()
+	if err != nil {
+		return left, err
+	}
+	for _, element := range elements {
+		c.checkComparison(left, op, element)
+	}
+	return value{kind: valueExpression, typ: TypeBool, text: "comparison"}, nil
+}
+
+func (c *checker) parseArray() ([]value, error) {
+	if _, ok := c.accept("["); !ok {
+		// A variable, a field or a CIDR can be used instead of a list.
+		v, err := c.parsePrimary()
+		return []value{v}, err
+	}
+	var elements []value
+	for {
+		element, err := c.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		elements = append(elements, element)
+		if _, ok := c.accept("]"); ok {
+			return elements, nil
+		}
+		if t, ok := c.accept(","); !ok {
+			return nil, fmt.Errorf("expected , or ] at offset %d, got %s", t.offset, t)
+		}
+	}
+}
+
+func (c *checker) parseBitOperation() (value, error) {
+	left, err := c.parseUnary()
+	if err != nil {
+		return left, err
+	}
+	for {
+		op, ok := c.accept("&", "|", "^")
+		if !ok {
+			return left, nil
+		}
+		right, err := c.parseUnary()
+		if err != nil {
+			return right, err
+		}
+		for _, v := range []value{left, right} {
+			if v.typ != TypeInt && v.typ != TypeAny {
+				c.warnf("%s at offset %d requires integer operands, %s is not", op.value, op.offset, v)
+			}
+		}
+		left = value{kind: valueExpression, typ: TypeInt, text: "expression"}
+	}
+}
+
+func (c *checker) parseUnary() (value, error) {
+	if op, ok := c.accept("-"); ok {
+		operand, err := c.parseUnary()
+		if err != nil {
+			return operand, err
+		}
+		if operand.typ != TypeInt && operand.typ != TypeAny {
+			c.warnf("- at offset %d requires an integer operand, %s is not", op.offset, operand)
+		}
+		return value{kind: valueExpression, typ: TypeInt, text: "expression"}, nil
+	}
+	return c.parsePrimary()
+}
+
+func (c *checker) parsePrimary() (value, error) {
+	t := c.next()
+	switch t.kind {
+	case tokenString:
+		return value{kind: valueLiteral, typ: TypeString, text: t.String()}, nil
+	case tokenPattern:
+		if t.value == "" {
+			return value{}, fmt.Errorf("empty pattern at offset %d", t.offset)
+		}
+		return value{kind: valuePattern, typ: TypeString, text: t.String()}, nil
+	case tokenRegexp:
+		if _, err := regexp.Compile(t.value); err != nil {
+			return value{}, fmt.Errorf("invalid regular expression at offset %d: %s", t.offset, err)
+		}
+		return value{kind: valueRegexp, typ: TypeString, text: t.String()}, nil
+	case tokenVariable:
+		return value{kind: valueVariable, typ: TypeAny, text: t.String()}, nil
+	case tokenWord:
+		return c.parseWord(t)
+	case tokenOperator:
+		if t.value == "(" {
+			inner, err := c.parseOr()
+			if err != nil {
+				return inner, err
+			}
+			if closing, ok := c.accept(")"); !ok {
+				if closing.kind == tokenEOF {
+					return inner, fmt.Errorf("unbalanced parenthesis at offset %d", t.offset)
+				}
+				return inner, fmt.Errorf("expected ) at offset %d, got %s", closing.offset, closing)
+			}
+			return inner, nil
+		}
+	}
+	if t.kind == tokenEOF {
+		return value{}, fmt.Errorf("unexpected end of expression")
+	}
+	return value{}, fmt.Errorf("unexpected %s at offset %d", t, t.offset)
+}
+
+func (c *checker) parseWord(t token) (value, error) {
+	word := t.value
+	switch {
+	case word == "true" || word == "false":
+		return value{kind: valueLiteral, typ: TypeBool, text: word}, nil
+	case word == "in" || word == "allin" || word == "not" || word == "and" || word == "or":
+		return value{}, fmt.Errorf("unexpected %s at offset %d", word, t.offset)
+	case durationRegexp.MatchString(word):
+		return value{kind: valueLiteral, typ: TypeInt, text: word}, nil
+	case constantRegexp.MatchString(word):
+		// Constants such as O_CREAT, SIGKILL or CAP_SYS_ADMIN are integers.
+		return value{kind: valueLiteral, typ: TypeInt, text: word}, nil
+	}
+	if _, err := strconv.ParseInt(word, 0, 64); err == nil {
+		return value{kind: valueLiteral, typ: TypeInt, text: word}, nil
+	}
+	if net.ParseIP(word) != nil {
+		return value{kind: valueLiteral, typ: TypeIP, text: word}, nil
+	}
+	if _, _, err := net.ParseCIDR(word); err == nil {
+		return value{kind: valueLiteral, typ: TypeIP, text: word}, nil
+	}
+	if !fieldRegexp.MatchString(word) {
+		return value{}, fmt.Errorf("invalid value %q at offset %d", word, t.offset)
+	}
+	word, err := c.parseIterator(t)
+	if err != nil {
+		return value{}, err
+	}
+	if event := eventType(word); event != "" {
+		if c.event == "" {
+			c.event, c.eventField = event, word
+		} else if c.event != event {
+			c.warnf("field %q at offset %d belongs to the %s event type, but %q makes the rule match %s events; a rule matches a single event type", word, t.offset, event, c.eventField, c.event)
+		}
+	}
+	typ, ok := fieldType(word)
+	if !ok {
+		namespace, _, _ := strings.Cut(word, ".")
+		if !hasNamespace(namespace) {
+			c.warnf("unknown field %q at offset %d, %q is not a known event type", word, t.offset, namespace)
+		} else {
+			c.warnf("unknown field %q at offset %d", word, t.offset)
+		}
+	}
+	return value{kind: valueField, typ: typ, text: word}, nil
+}
+
+// parseIterator parses the iterator which can follow a field, as in
+// process.ancestors[A].file.name, and returns the name of the field without
+// the iterator.
+func (c *checker) parseIterator(t token) (string, error) {
+	open := c.peek()
+	if open.kind != tokenOperator || open.value != "[" || open.offset != t.offset+len(t.value) {
+		return t.value, nil
+	}
+	c.next()
+	if name := c.next(); name.kind != tokenWord || !constantRegexp.MatchString(name.value) {
+		return "", fmt.Errorf("invalid iterator %s at offset %d", name, name.offset)
+	}
+	closing, ok := c.accept("]")
+	if !ok {
+		return "", fmt.Errorf("expected ] at offset %d, got %s", closing.offset, closing)
+	}
+	rest := c.peek()
+	if rest.kind != tokenWord || !strings.HasPrefix(rest.value, ".") || rest.offset != closing.offset+1 {
+		return t.value, nil
+	}
+	c.next()
+	field := t.value + rest.value
+	if !fieldRegexp.MatchString(field) {
+		return "", fmt.Errorf("invalid value %q at offset %d", rest.value, rest.offset)
+	}
+	return field, nil
+}
+
+// hasNamespace reports whether the namespace is an event type or a common
+// namespace of the model.
+func hasNamespace(namespace string) bool {
+	for field := range fields {
+		if strings.HasPrefix(field, namespace+".") {
+			return true
+		}
+	}
+	return false
+}
+
+var operatorTypes = map[string][]Type{
+	"==":     {TypeString, TypeInt, TypeBool, TypeIP},
+	"!=":     {TypeString, TypeInt, TypeBool, TypeIP},
+	"=~":     {TypeString},
+	"!~":     {TypeString},
+	"<":      {TypeInt},
+	"<=":     {TypeInt},
+	">":      {TypeInt},
+	">=":     {TypeInt},
+	"in":     {TypeString, TypeInt, TypeIP},
+	"not in": {TypeString, TypeInt, TypeIP},
+	"allin":  {TypeString, TypeInt, TypeIP},
+}
+
+func (c *checker) checkComparison(left value, op token, right value) {
+	if left.typ != TypeAny && right.typ != TypeAny && left.typ != right.typ {
+		c.warnf("%s at offset %d compares %s with %s", op.value, op.offset, left, right)
+		return
+	}
+	typ := left.typ
+	if typ == TypeAny {
+		typ = right.typ
+	}
+	if typ != TypeAny && !slices.Contains(operatorTypes[op.value], typ) {
+		c.warnf("%s at offset %d is not supported for %s values", op.value, op.offset, typ)
+	}
+}
@@ -0,0 +1,93 @@
+package secl
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCheck(t *testing.T) {
+	valid := map[string]string{
+		`exec.file.path == "/usr/bin/curl"`:                                                    "exec",
+		`exec.file.name in ["nc", ~"ncat*", r"^socat$"] && process.parent.file.name == "bash"`: "exec",
+		`open.file.path =~ "/etc/*" && open.flags & (O_CREAT | O_TRUNC) > 0`:                   "open",
+		`open.file.path.length > 100 and not process.is_thread`:                                "open",
+		`chmod.file.destination.mode & S_IWOTH != 0 && chmod.retval == 0`:                      "chmod",
+		`connect.addr.ip in [10.0.0.0/8, 192.168.1.1, ::1] && connect.addr.port != 443`:        "connect",
+		`process.created_at < 5s && process.ancestors.file.name not in ["sshd"]`:               "",
+		`exec.args_flags allin ["l", "p"] && exec.uid == ${process.uid}`:                       "exec",
+		`signal.target.parent.file.path == "/usr/bin/dockerd" && signal.type == SIGKILL`:       "signal",
+		`dns.question.name == "example.com" && network.destination.port == 53`:                 "dns",
+		`create.registry.key_path == ~"HKLM\\SOFTWARE\\*"`:                                     "create",
+		`${my_flag}`:            "",
+		`setsockopt.level == 1`: "setsockopt",
+		`prctl.option == 15`:    "prctl",
+		`network_flow_monitor.device.ifname == "eth0"`:                              "network_flow_monitor",
+		`exec.syscall.path == "/usr/bin/java"`:                                      "exec",
+		`process.ancestors[A].file.name == "java" && process.ancestors[A].uid == 0`: "",
+		`exec.file.name == "java" && process.ancestors[A].comm in ["sh"]`:           "exec",
+	}
+	for expression, event := range valid {
+		got, warnings, err := Check(expression)
+		if err != nil {
+			t.Errorf("unexpected error for %s: %v", expression, err)
+			continue
+		}
+		if len(warnings) > 0 {
+			t.Errorf("unexpected warnings for %s: %v", expression, warnings)
+		}
+		if got != event {
+			t.Errorf("expected event type %q for %s, got %q", event, expression, got)
+		}
+	}
+
+	warned := map[string]string{
+		`exec.file.pth == "/bin/sh"`:                       `unknown field "exec.file.pth"`,
+		`exex.file.path == "/bin/sh"`:                      `"exex" is not a known event type`,
+		`exec.syscall.path`:                                `the expression must be a boolean, string field exec.syscall.path is not`,
+		`exec.file.path == 1`:                              `== at offset 15 compares string field exec.file.path with integer 1`,
+		`open.flags == "O_CREAT"`:                          `compares integer field open.flags with string "O_CREAT"`,
+		`exec.file.path > "/bin"`:                          `> at offset 15 is not supported for string values`,
+		`process.is_thread in [true]`:                      `in at offset 18 is not supported for boolean values`,
+		`exec.file.name in ["a", 1]`:                       `compares string field exec.file.name with integer 1`,
+		`exec.file.name == "a" && open.file.name == "b"`:   `belongs to the open event type, but "exec.file.name" makes the rule match exec events`,
+		`exec.file.path == "a" && exec.pid`:                `&& at offset 22 requires boolean operands, integer field exec.pid is not`,
+		`open.flags & "a" > 0`:                             `& at offset 11 requires integer operands`,
+		`connect.addr.ip == "10.0.0.1"`:                    `compares IP field connect.addr.ip with string "10.0.0.1"`,
+		`container.id == "a" && container.ids.length == 1`: `unknown field "container.ids.length"`,
+		`process.ancestors[A].file.nme == "java"`:          `unknown field "process.ancestors.file.nme"`,
+	}
+	for expression, expected := range warned {
+		_, warnings, err := Check(expression)
+		if err != nil {
+			t.Errorf("unexpected error for %s: %v", expression, err)
+			continue
+		}
+		if !strings.Contains(strings.Join(warnings, "\n"), expected) {
+			t.Errorf("expected %s to warn with %q, got %v", expression, expected, warnings)
+		}
+	}
+
+	invalid := map[string]string{
+		``:                                               "empty expression",
+		`exec.file.path == r"(unclosed"`:                 `invalid regular expression at offset 18`,
+		`exec.file.path =~ ~""`:                          `empty pattern at offset 18`,
+		`exec.file.path == "/bin/sh`:                     `unterminated string at offset 18`,
+		`exec.file.name in ["a" "b"]`:                    `expected , or ] at offset 23`,
+		`(exec.file.name == "a"`:                         `unbalanced parenthesis at offset 0`,
+		`exec.file.name == "a")`:                         `unbalanced parenthesis at offset 21`,
+		`exec.file.name not ["a"]`:                       `expected in after not at offset 15`,
+		`exec.file.name == "a" ||`:                       `unexpected end of expression`,
+		`exec.file.name == "a" ; exec.pid == 1`:          `unexpected character ';' at offset 22`,
+		`exec.file.name == ${}`:                          `invalid variable name ""`,
+		`process.file.path == "a" && Process.pid == 1`:   `invalid value "Process.pid"`,
+		`process.file.path == "a" exec.file.path == "b"`: `unexpected "exec.file.path" at offset 25`,
+		`process.ancestors[a].file.name == "java"`:       `invalid iterator "a" at offset 18`,
+		`process.ancestors[A == "java"`:                  `expected ] at offset 20`,
+	}
+	for expression, expected := range invalid {
+		_, _, err := Check(expression)
+		if err == nil || !strings.Contains(err.Error(), expected) {
+			t.Errorf("expected %s to fail with %q, got %v", expression, expected, err)
+		}
+	}
+}
@@ -0,0 +1,245 @@
+// Package securityrule evaluates the cases of security monitoring rules
+// locally, to test rules against sample events.
+package securityrule
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Case is a rule case, evaluated in order: the first case whose condition
+// holds generates the signal.
+type Case struct {
+	Name      string
+	Condition string
+	Status    string
+}
+
+// QueryName returns the name of the query at the given index, which defaults
+// to a letter based on its position as in the Datadog UI.
+func QueryName(name string, index int) string {
+	if name != "" {
+		return name
+	}
+	if index < 26 {
+		return string(rune('a' + index))
+	}
+	return "q" + strconv.Itoa(index)
+}
+
+// TriggeredCase returns the index of the first case triggered by the values
+// of the queries, or -1 when no case is triggered. A case without condition
+// is triggered as soon as a query has a value.
+func TriggeredCase(cases []Case, values map[string]float64) (int, error) {
+	for i, c := range cases {
+		if strings.TrimSpace(c.Condition) == "" {
+			for _, v := range values {
+				if v > 0 {
+					return i, nil
+				}
+			}
+			continue
+		}
+		triggered, err := EvaluateCondition(c.Condition, values)
+		if err != nil {
+			return -1, fmt.Errorf("case %d: %s", i, err)
+		}
+		if triggered {
+			return i, nil
+		}
+	}
+	return -1, nil
+}
+
+// EvaluateCondition evaluates a case condition such as `a > 0 && b >= 2` with
+// the given values of the queries.
+func EvaluateCondition(condition string, values map[string]float64) (bool, error) {
+	e := &evaluator{input: condition, values: values}
+	e.skipSpaces()
+	result, err := e.parseOr()
+	if err != nil {
+		return false, err
+	}
+	if e.pos < len(e.input) {
+		return false, fmt.Errorf("unexpected %q at offset %d in condition %q", e.input[e.pos:], e.pos, condition)
+	}
+	return result != 0, nil
+}
+
+type evaluator struct {
+	input  string
+	pos    int
+	values map[string]float64
+}
+
+func (e *evaluator) skipSpaces() {
+	for e.pos < len(e.input) && unicode.IsSpace(rune(e.input[e.pos])) {
+		e.pos++
+	}
+}
+
+// accept consumes the first of the operators found at the current position.
+func (e *evaluator) accept(operators ...string) (string, bool) {
+	for _, op := range operators {
+		if strings.HasPrefix(e.input[e.pos:], op) {
+			e.pos += len(op)
+			e.skipSpaces()
+			return op, true
+		}
+	}
+	return "", false
+}
+
+func boolValue(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func (e *evaluator) parseOr() (float64, error) {
+	left, err := e.parseAnd()
+	if err != nil {
+		return 0, err
+	}
+	for {
+		if _, ok := e.accept("||"); !ok {
+			return left, nil
+		}
+		right, err := e.parseAnd()
+		if err != nil {
+			return 0, err
+		}
+		left = boolValue(left != 0 || right != 0)
+	}
+}
+
+func (e *evaluator) parseAnd() (float64, error) {
+	left, err := e.parseComparison()
+	if err != nil {
+		return 0, err
+	}
+	for {
+		if _, ok := e.accept("&&"); !ok {
+			return left, nil
+		}
+		right, err := e.parseComparison()
+		if err != nil {
+			return 0, err
+		}
+		left = boolValue(left != 0 && right != 0)
+	}
+}
+
+func (e *evaluator) parseComparison() (float64, error) {
+	left, err := e.parseSum()
+	if err != nil {
+		return 0, err
+	}
+	op, ok := e.accept(">=", "<=", "==", "!=", ">", "<")
+	if !ok {
+		return left, nil
+	}
+	right, err := e.parseSum()
+	if err != nil {
+		return 0, err
+	}
+	switch op {
+	case ">=":
+		return boolValue(left >= right), nil
+	case "<=":
+		return boolValue(left <= right), nil
+	case "==":
+		return boolValue(left == right), nil
+	case "!=":
+		return boolValue(left != right), nil
+	case ">":
+		return boolValue(left > right), nil
+	}
+	return boolValue(left < right), nil
+}
+
+func (e *evaluator) parseSum() (float64, error) {
+	left, err := e.parseProduct()
+	if err != nil {
+		return 0, err
+	}
+	for {
+		op, ok := e.accept("+", "-")
+		if !ok {
+			return left, nil
+		}
+		right, err := e.parseProduct()
+		if err != nil {
+			return 0, err
+		}
+		if op == "+" {
+			left += right
+		} else {
+			left -= right
+		}
+	}
+}
+
+func (e *evaluator) parseProduct() (float64, error) {
+	left, err := e.parseOperand()
+	if err != nil {
+		return 0, err
+	}
+	for {
+		op, ok := e.accept("*", "/")
+		if !ok {
+			return left, nil
+		}
+		right, err := e.parseOperand()
+		if err != nil {
+			return 0, err
+		}
+		if op == "*" {
+			left *= right
+		} else if right != 0 {
+			left /= right
+		} else {
+			left = 0
+		}
+	}
+}
+
+func (e *evaluator) parseOperand() (float64, error) {
+	if _, ok := e.accept("!"); ok {
+		operand, err := e.parseOperand()
+		return boolValue(operand == 0), err
+	}
+	if _, ok := e.accept("("); ok {
+		inner, err := e.parseOr()
+		if err != nil {
+			return 0, err
+		}
+		if _, ok := e.accept(")"); !ok {
+			return 0, fmt.Errorf("missing ) at offset %d in condition %q", e.pos, e.input)
+		}
+		return inner, nil
+	}
+	start := e.pos
+	for e.pos < len(e.input) && (unicode.IsLetter(rune(e.input[e.pos])) || unicode.IsDigit(rune(e.input[e.pos])) || e.input[e.pos] == '_' || e.input[e.pos] == '.') {
+		e.pos++
+	}
+	word := e.input[start:e.pos]
+	e.skipSpaces()
+	if word == "" {
+		if start == len(e.input) {
+			return 0, fmt.Errorf("unexpected end of condition %q", e.input)
+		}
+		return 0, fmt.Errorf("unexpected %q at offset %d in condition %q", e.input[start:start+1], start, e.input)
+	}
+	if number, err := strconv.ParseFloat(word, 64); err == nil {
+		return number, nil
+	}
+	value, ok := e.values[word]
+	if !ok {
+		return 0, fmt.Errorf("unknown query %q in condition %q", word, e.input)
+	}
+	return value, nil
+}
@@ -0,0 +1,77 @@
+package securityrule
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEvaluateCondition(t *testing.T) {
+	values := map[string]float64{"a": 1, "b": 0, "failed_login": 3}
+	cases := map[string]bool{
+		"a > 0":                     true,
+		"a > 0 && b > 0":            false,
+		"a > 0 || b > 0":            true,
+		"failed_login >= 3":         true,
+		"a + failed_login > 3":      true,
+		"(a > 0 || b > 0) && b < 1": true,
+		"!(b > 0)":                  true,
+		"failed_login * 2 == 6":     true,
+		"a":                         true,
+	}
+	for condition, expected := range cases {
+		result, err := EvaluateCondition(condition, values)
+		if err != nil {
+			t.Errorf("unexpected error for %q: %v", condition, err)
+			continue
+		}
+		if result != expected {
+			t.Errorf("expected %q to be %v", condition, expected)
+		}
+	}
+
+	errors := map[string]string{
+		"c > 0":        `unknown query "c"`,
+		"a >":          "unexpected end of condition",
+		"(a > 0":       "missing )",
+		"a > 0 b":      `unexpected "b" at offset 6`,
+		"a > 0 & b":    `unexpected "& b" at offset 6`,
+		"a > 0 && # 1": `unexpected "#" at offset 9`,
+	}
+	for condition, expected := range errors {
+		_, err := EvaluateCondition(condition, values)
+		if err == nil || !strings.Contains(err.Error(), expected) {
+			t.Errorf("expected %q to fail with %q, got %v", condition, expected, err)
+		}
+	}
+}
+
+func TestTriggeredCase(t *testing.T) {
+	cases := []Case{
+		{Condition: "a > 0 && b > 0", Status: "high"},
+		{Condition: "a > 0", Status: "medium"},
+		{Status: "info"},
+	}
+	for _, tc := range []struct {
+		values   map[string]float64
+		expected int
+	}{
+		{map[string]float64{"a": 1, "b": 1}, 0},
+		{map[string]float64{"a": 1, "b": 0}, 1},
+		{map[string]float64{"a": 0, "b": 1}, 2},
+		{map[string]float64{"a": 0, "b": 0}, -1},
+	} {
+		triggered, err := TriggeredCase(cases, tc.values)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if triggered != tc.expected {
+			t.Errorf("expected case %d for %v, got %d", tc.expected, tc.values, triggered)
+		}
+	}
+}
+
+func TestQueryName(t *testing.T) {
+	if QueryName("", 0) != "a" || QueryName("", 2) != "c" || QueryName("logins", 1) != "logins" {
+		t.Error("unexpected query names")
+	}
+}
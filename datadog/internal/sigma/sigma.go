@@ -0,0 +1,666 @@
+// Package sigma converts Sigma detection rules (https://sigmahq.io) into the
+// queries, case, options and tags of Datadog log detection rules.
+package sigma
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV2"
+	"gopkg.in/yaml.v3"
+)
+
+// QueryName is the name of the query generated for a Sigma rule, referenced
+// by the condition of its case.
+const QueryName = "sigma"
+
+// LogSourceQueryKey is the key of a field mapping holding the Datadog query
+// selecting the logs of the log source, for example `source:cloudtrail`.
+const LogSourceQueryKey = "logsource"
+
+const (
+	defaultEvaluationWindow  = 300
+	defaultKeepAlive         = 3600
+	defaultMaxSignalDuration = 86400
+)
+
+// FieldMappings maps Sigma field names to Datadog attributes, per log source.
+// Keys are `*` for every rule, a log source `product`, `category` or
+// `service`, `product/category` or `product/service`; more specific keys
+// take precedence. Unmapped fields are searched as `@<field>`.
+type FieldMappings map[string]map[string]string
+
+// Rule is the Datadog log detection rule converted from a Sigma rule.
+type Rule struct {
+	Name              string
+	Message           string
+	Tags              []string
+	Query             Query
+	Case              Case
+	EvaluationWindow  int64
+	KeepAlive         int64
+	MaxSignalDuration int64
+}
+
+// Query is the single query of a converted rule.
+type Query struct {
+	Name           string
+	Query          string
+	Aggregation    string
+	GroupByFields  []string
+	DistinctFields []string
+}
+
+// Case is the single case of a converted rule.
+type Case struct {
+	Status    string
+	Condition string
+}
+
+// UnsupportedError lists the constructs of a Sigma rule that have no Datadog
+// equivalent. No rule is returned in that case, as a partial translation
+// would not detect the same events.
+type UnsupportedError struct {
+	Constructs []string
+}
+
+func (e *UnsupportedError) Error() string {
+	return "the Sigma rule uses constructs that cannot be translated: " + strings.Join(e.Constructs, "; ")
+}
+
+type logSource struct {
+	Product  string `yaml:"product"`
+	Category string `yaml:"category"`
+	Service  string `yaml:"service"`
+}
+
+type document struct {
+	Title          string     `yaml:"title"`
+	ID             string     `yaml:"id"`
+	Description    string     `yaml:"description"`
+	References     []string   `yaml:"references"`
+	Tags           []string   `yaml:"tags"`
+	LogSource      logSource  `yaml:"logsource"`
+	Detection      yaml.Node  `yaml:"detection"`
+	FalsePositives []string   `yaml:"falsepositives"`
+	Level          string     `yaml:"level"`
+	Correlation    *yaml.Node `yaml:"correlation"`
+}
+
+var levelStatuses = map[string]string{
+	"informational": "info",
+	"low":           "low",
+	"medium":        "medium",
+	"high":          "high",
+	"critical":      "critical",
+}
+
+var attackTactics = map[string]string{
+	"reconnaissance":       "TA0043",
+	"resource_development": "TA0042",
+	"initial_access":       "TA0001",
+	"execution":            "TA0002",
+	"persistence":          "TA0003",
+	"privilege_escalation": "TA0004",
+	"defense_evasion":      "TA0005",
+	"credential_access":    "TA0006",
+	"discovery":            "TA0007",
+	"lateral_movement":     "TA0008",
+	"collection":           "TA0009",
+	"exfiltration":         "TA0010",
+	"command_and_control":  "TA0011",
+	"impact":               "TA0040",
+}
+
+var attackTechnique = regexp.MustCompile(`^attack\.(t\d{4}(?:\.\d{3})?)$`)
+
+// Convert converts a Sigma rule written in YAML into a Datadog rule, using
+// the field mappings of its log source.
+func Convert(source string, mappings FieldMappings) (*Rule, error) {
+	var doc document
+	if err := yaml.Unmarshal([]byte(source), &doc); err != nil {
+		return nil, fmt.Errorf("invalid Sigma rule: %s", err)
+	}
+	if doc.Correlation != nil {
+		return nil, &UnsupportedError{Constructs: []string{"correlation rules"}}
+	}
+	if strings.TrimSpace(doc.Title) == "" {
+		return nil, fmt.Errorf("invalid Sigma rule: missing title")
+	}
+	if doc.Detection.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("invalid Sigma rule: missing detection")
+	}
+
+	c := &converter{fields: mappings.resolve(doc.LogSource)}
+	rule := &Rule{
+		Name:              doc.Title,
+		Message:           message(&doc),
+		Tags:              tags(&doc),
+		EvaluationWindow:  defaultEvaluationWindow,
+		KeepAlive:         defaultKeepAlive,
+		MaxSignalDuration: defaultMaxSignalDuration,
+	}
+	rule.Query.Name = QueryName
+	rule.Query.Aggregation = "count"
+	rule.Case.Status = "info"
+	if doc.Level != "" {
+		status, ok := levelStatuses[doc.Level]
+		if !ok {
+			return nil, fmt.Errorf("invalid Sigma rule: unknown level %q", doc.Level)
+		}
+		rule.Case.Status = status
+	}
+
+	var condition string
+	for i := 0; i+1 < len(doc.Detection.Content); i += 2 {
+		key, value := doc.Detection.Content[i].Value, doc.Detection.Content[i+1]
+		switch key {
+		case "condition":
+			switch {
+			case value.Kind == yaml.ScalarNode:
+				condition = value.Value
+			case value.Kind == yaml.SequenceNode && len(value.Content) == 1:
+				condition = value.Content[0].Value
+			default:
+				c.unsupported("multiple conditions")
+			}
+		case "timeframe":
+			window, err := parseTimeframe(value.Value)
+			if err != nil {
+				return nil, err
+			}
+			if !datadogV2.SecurityMonitoringRuleEvaluationWindow(window).IsValid() {
+				c.unsupported(fmt.Sprintf("timeframe %s, which is not a valid evaluation window", value.Value))
+			}
+			rule.EvaluationWindow = window
+			if window > rule.KeepAlive {
+				rule.KeepAlive = window
+			}
+		default:
+			c.names = append(c.names, key)
+			c.selections = append(c.selections, c.selection(key, value))
+		}
+	}
+	if strings.TrimSpace(condition) == "" {
+		return nil, fmt.Errorf("invalid Sigma rule: missing detection condition")
+	}
+
+	search, aggregation, _ := strings.Cut(condition, "|")
+	detection, err := c.condition(search)
+	if err != nil {
+		return nil, err
+	}
+	rule.Case.Condition = QueryName + " > 0"
+	if strings.TrimSpace(aggregation) != "" {
+		c.aggregation(aggregation, rule)
+	}
+	if len(c.unsupportedConstructs) > 0 {
+		return nil, &UnsupportedError{Constructs: c.unsupportedConstructs}
+	}
+
+	logSourceQuery, ok := c.fields[LogSourceQueryKey]
+	if !ok {
+		if name := firstNonEmpty(doc.LogSource.Service, doc.LogSource.Product); name != "" {
+			logSourceQuery = "source:" + escape(name)
+		}
+	}
+	if strings.ContainsAny(logSourceQuery, " \t") {
+		logSourceQuery = "(" + logSourceQuery + ")"
+	}
+	if logSourceQuery != "" {
+		detection = and(&expr{text: logSourceQuery}, detection)
+	}
+	rule.Query.Query = detection.render()
+	return rule, nil
+}
+
+func (m FieldMappings) resolve(source logSource) map[string]string {
+	keys := []string{"*", source.Product, source.Category, source.Service}
+	if source.Product != "" {
+		keys = append(keys, source.Product+"/"+source.Category, source.Product+"/"+source.Service)
+	}
+	fields := map[string]string{}
+	for _, key := range keys {
+		if key == "" || strings.HasSuffix(key, "/") {
+			continue
+		}
+		for field, attribute := range m[key] {
+			fields[field] = attribute
+		}
+	}
+	return fields
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+func parseTimeframe(timeframe string) (int64, error) {
+	if len(timeframe) < 2 {
+		return 0, fmt.Errorf("invalid Sigma rule: invalid timeframe %q", timeframe)
+	}
+	units := map[byte]time.Duration{'s': time.Second, 'm': time.Minute, 'h': time.Hour, 'd': 24 * time.Hour}
+	unit, ok := units[timeframe[len(timeframe)-1]]
+	count, err := strconv.ParseInt(timeframe[:len(timeframe)-1], 10, 64)
+	if !ok || err != nil {
+		return 0, fmt.Errorf("invalid Sigma rule: invalid timeframe %q", timeframe)
+	}
+	return int64(time.Duration(count) * unit / time.Second), nil
+}
+
+func message(doc *document) string {
+	sections := []string{firstNonEmpty(strings.TrimSpace(doc.Description), doc.Title)}
+	if len(doc.FalsePositives) > 0 {
+		sections = append(sections, "**False positives:**\n- "+strings.Join(doc.FalsePositives, "\n- "))
+	}
+	if len(doc.References) > 0 {
+		sections = append(sections, "**References:**\n- "+strings.Join(doc.References, "\n- "))
+	}
+	return strings.Join(sections, "\n\n")
+}
+
+func tags(doc *document) []string {
+	var result []string
+	if doc.ID != "" {
+		result = append(result, "sigma_id:"+doc.ID)
+	}
+	for _, tag := range doc.Tags {
+		tag = strings.ToLower(tag)
+		if match := attackTechnique.FindStringSubmatch(tag); match != nil {
+			result = append(result, "technique:"+strings.ToUpper(match[1]))
+		} else if id, ok := attackTactics[strings.TrimPrefix(tag, "attack.")]; ok && strings.HasPrefix(tag, "attack.") {
+			result = append(result, "tactic:"+id+"-"+strings.ReplaceAll(strings.TrimPrefix(tag, "attack."), "_", "-"))
+		} else {
+			result = append(result, "sigma:"+tag)
+		}
+	}
+	return result
+}
+
+// expr is a Datadog search query, kept as a tree to only parenthesize the
+// operands that need it.
+type expr struct {
+	op       string
+	operands []*expr
+	text     string
+}
+
+func combine(op string, operands ...*expr) *expr {
+	e := &expr{op: op}
+	for _, operand := range operands {
+		if operand.op == op {
+			e.operands = append(e.operands, operand.operands...)
+		} else {
+			e.operands = append(e.operands, operand)
+		}
+	}
+	if len(e.operands) == 1 {
+		return e.operands[0]
+	}
+	return e
+}
+
+func and(operands ...*expr) *expr { return combine("AND", operands...) }
+
+func or(operands ...*expr) *expr { return combine("OR", operands...) }
+
+func not(operand *expr) *expr { return &expr{op: "NOT", operands: []*expr{operand}} }
+
+func (e *expr) render() string {
+	switch e.op {
+	case "":
+		return e.text
+	case "NOT":
+		return "NOT " + e.operands[0].renderOperand()
+	}
+	parts := make([]string, len(e.operands))
+	for i, operand := range e.operands {
+		parts[i] = operand.renderOperand()
+	}
+	return strings.Join(parts, " "+e.op+" ")
+}
+
+func (e *expr) renderOperand() string {
+	if e.op == "AND" || e.op == "OR" {
+		return "(" + e.render() + ")"
+	}
+	return e.render()
+}
+
+type converter struct {
+	fields                map[string]string
+	names                 []string
+	selections            []*expr
+	unsupportedConstructs []string
+}
+
+func (c *converter) unsupported(construct string) {
+	c.unsupportedConstructs = append(c.unsupportedConstructs, construct)
+}
+
+func (c *converter) attribute(field string) string {
+	if attribute, ok := c.fields[field]; ok {
+		return attribute
+	}
+	return "@" + field
+}
+
+// selection converts a search identifier: a map of fields matched together,
+// a list of such maps matching any of them, or a list of keywords.
+func (c *converter) selection(name string, node *yaml.Node) *expr {
+	switch node.Kind {
+	case yaml.MappingNode:
+		var operands []*expr
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			operands = append(operands, c.field(name, node.Content[i].Value, node.Content[i+1]))
+		}
+		if len(operands) == 0 {
+			c.unsupported(fmt.Sprintf("empty search identifier %s", name))
+			return &expr{}
+		}
+		return and(operands...)
+	case yaml.SequenceNode:
+		var operands []*expr
+		for _, item := range node.Content {
+			if item.Kind == yaml.MappingNode {
+				operands = append(operands, c.selection(name, item))
+			} else {
+				operands = append(operands, c.field(name, "", item))
+			}
+		}
+		if len(operands) == 0 {
+			c.unsupported(fmt.Sprintf("empty search identifier %s", name))
+			return &expr{}
+		}
+		return or(operands...)
+	}
+	return c.field(name, "", node)
+}
+
+var comparisonModifiers = map[string]string{"gt": ">", "gte": ">=", "lt": "<", "lte": "<="}
+
+// field converts the values of a field, with its modifiers such as
+// `CommandLine|contains|all`. Keywords have no field name.
+func (c *converter) field(selection, key string, node *yaml.Node) *expr {
+	parts := strings.Split(key, "|")
+	field, modifiers := parts[0], parts[1:]
+
+	var values []*yaml.Node
+	switch node.Kind {
+	case yaml.SequenceNode:
+		values = node.Content
+	case yaml.ScalarNode:
+		values = []*yaml.Node{node}
+	default:
+		c.unsupported(fmt.Sprintf("nested value of %s in %s", firstNonEmpty(key, "keywords"), selection))
+		return &expr{}
+	}
+
+	all := false
+	wildcards := ""
+	comparison := ""
+	exists, cidr := false, false
+	for _, modifier := range modifiers {
+		switch modifier {
+		case "all":
+			all = true
+		case "contains", "startswith", "endswith":
+			wildcards = modifier
+		case "gt", "gte", "lt", "lte":
+			comparison = comparisonModifiers[modifier]
+		case "exists":
+			exists = true
+		case "cidr":
+			cidr = true
+		case "cased":
+			// Datadog attribute searches are case sensitive.
+		default:
+			c.unsupported(fmt.Sprintf("modifier %s of %s in %s", modifier, firstNonEmpty(field, "keywords"), selection))
+			return &expr{}
+		}
+	}
+	if field == "" && (comparison != "" || exists || cidr) {
+		c.unsupported(fmt.Sprintf("modifiers %s of keywords in %s", strings.Join(modifiers, "|"), selection))
+		return &expr{}
+	}
+
+	attribute := c.attribute(field)
+	var operands []*expr
+	for _, value := range values {
+		var text string
+		switch {
+		case value.Kind != yaml.ScalarNode:
+			c.unsupported(fmt.Sprintf("nested value of %s in %s", firstNonEmpty(field, "keywords"), selection))
+			return &expr{}
+		case value.Tag == "!!null":
+			if field == "" {
+				c.unsupported(fmt.Sprintf("null keyword in %s", selection))
+				return &expr{}
+			}
+			operands = append(operands, not(&expr{text: attribute + ":*"}))
+			continue
+		case exists:
+			present, err := strconv.ParseBool(value.Value)
+			if err != nil {
+				c.unsupported(fmt.Sprintf("non-boolean exists value %q of %s in %s", value.Value, field, selection))
+				return &expr{}
+			}
+			if present {
+				operands = append(operands, &expr{text: attribute + ":*"})
+			} else {
+				operands = append(operands, not(&expr{text: attribute + ":*"}))
+			}
+			continue
+		case comparison != "":
+			if _, err := strconv.ParseFloat(value.Value, 64); err != nil {
+				c.unsupported(fmt.Sprintf("non-numeric comparison with %q of %s in %s", value.Value, field, selection))
+				return &expr{}
+			}
+			text = attribute + ":" + comparison + value.Value
+		case cidr:
+			text = "CIDR(" + attribute + "," + value.Value + ")"
+		case value.Value == "" && wildcards == "":
+			text = `""`
+		default:
+			text = searchValue(value.Value, wildcards)
+		}
+		if field != "" && !cidr && comparison == "" {
+			text = attribute + ":" + text
+		}
+		operands = append(operands, &expr{text: text})
+	}
+	if len(operands) == 0 {
+		c.unsupported(fmt.Sprintf("empty list of values of %s in %s", firstNonEmpty(field, "keywords"), selection))
+		return &expr{}
+	}
+	if all {
+		return and(operands...)
+	}
+	return or(operands...)
+}
+
+// searchValue converts a Sigma value, where `*` and `?` are wildcards unless
+// escaped with a backslash, into an escaped Datadog search value.
+func searchValue(value, wildcards string) string {
+	var b strings.Builder
+	if wildcards == "contains" || wildcards == "endswith" {
+		b.WriteByte('*')
+	}
+	runes := []rune(value)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case r == '\\' && i+1 < len(runes) && strings.ContainsRune(`*?\`, runes[i+1]):
+			i++
+			b.WriteString(escape(string(runes[i])))
+		case r == '*' || r == '?':
+			b.WriteRune(r)
+		default:
+			b.WriteString(escape(string(r)))
+		}
+	}
+	if wildcards == "contains" || wildcards == "startswith" {
+		b.WriteByte('*')
+	}
+	return b.String()
+}
+
+// escape escapes the characters of the Datadog search syntax.
+func escape(value string) string {
+	var b strings.Builder
+	for _, r := range value {
+		if strings.ContainsRune(`+-=&|><!(){}[]^"~*?:\/ `, r) || r == '\t' {
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+var conditionToken = regexp.MustCompile(`\(|\)|[^\s()]+`)
+
+// condition converts the search part of a detection condition, such as
+// `selection and not 1 of filter_*`.
+func (c *converter) condition(condition string) (*expr, error) {
+	p := &conditionParser{converter: c, condition: condition, tokens: conditionToken.FindAllString(condition, -1)}
+	result, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("invalid Sigma rule: unexpected %q in condition %q", p.tokens[p.pos], condition)
+	}
+	return result, nil
+}
+
+type conditionParser struct {
+	*converter
+	condition string
+	tokens    []string
+	pos       int
+}
+
+func (p *conditionParser) peek() string {
+	if p.pos < len(p.tokens) {
+		return strings.ToLower(p.tokens[p.pos])
+	}
+	return ""
+}
+
+func (p *conditionParser) parseOr() (*expr, error) {
+	left, err := p.parseAnd()
+	for err == nil && p.peek() == "or" {
+		p.pos++
+		var right *expr
+		if right, err = p.parseAnd(); err == nil {
+			left = or(left, right)
+		}
+	}
+	return left, err
+}
+
+func (p *conditionParser) parseAnd() (*expr, error) {
+	left, err := p.parseNot()
+	for err == nil && p.peek() == "and" {
+		p.pos++
+		var right *expr
+		if right, err = p.parseNot(); err == nil {
+			left = and(left, right)
+		}
+	}
+	return left, err
+}
+
+func (p *conditionParser) parseNot() (*expr, error) {
+	if p.peek() == "not" {
+		p.pos++
+		operand, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return not(operand), nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *conditionParser) parsePrimary() (*expr, error) {
+	token := p.peek()
+	p.pos++
+	switch {
+	case token == "":
+		return nil, fmt.Errorf("invalid Sigma rule: unexpected end of condition %q", p.condition)
+	case token == "(":
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() != ")" {
+			return nil, fmt.Errorf("invalid Sigma rule: missing ) in condition %q", p.condition)
+		}
+		p.pos++
+		return inner, nil
+	case (token == "1" || token == "all" || token == "any") && p.peek() == "of":
+		p.pos++
+		if p.pos >= len(p.tokens) {
+			return nil, fmt.Errorf("invalid Sigma rule: missing search identifier after %s of in condition %q", token, p.condition)
+		}
+		pattern := p.tokens[p.pos]
+		p.pos++
+		var operands []*expr
+		for i, name := range p.names {
+			if matched, _ := path.Match(pattern, name); matched || (pattern == "them" && !strings.HasPrefix(name, "_")) {
+				operands = append(operands, p.selections[i])
+			}
+		}
+		if len(operands) == 0 {
+			return nil, fmt.Errorf("invalid Sigma rule: no search identifier matches %q in condition %q", pattern, p.condition)
+		}
+		if token == "all" {
+			return and(operands...), nil
+		}
+		return or(operands...), nil
+	}
+	name := p.tokens[p.pos-1]
+	for i, n := range p.names {
+		if n == name {
+			return p.selections[i], nil
+		}
+	}
+	return nil, fmt.Errorf("invalid Sigma rule: unknown search identifier %q in condition %q", name, p.condition)
+}
+
+var countAggregation = regexp.MustCompile(`^count\(\s*([\w.]*)\s*\)(?:\s+by\s+([\w.]+(?:\s*,\s*[\w.]+)*))?\s*(>=|<=|==|=|>|<)\s*(\d+)$`)
+
+// aggregation converts the deprecated aggregation expressions of Sigma
+// conditions, such as `count(User) by SourceIp > 10`.
+func (c *converter) aggregation(aggregation string, rule *Rule) {
+	aggregation = strings.TrimSpace(aggregation)
+	match := countAggregation.FindStringSubmatch(aggregation)
+	if match == nil {
+		c.unsupported(fmt.Sprintf("aggregation %q", aggregation))
+		return
+	}
+	if match[1] != "" {
+		rule.Query.Aggregation = "cardinality"
+		rule.Query.DistinctFields = []string{c.attribute(match[1])}
+	}
+	if match[2] != "" {
+		for _, field := range strings.Split(match[2], ",") {
+			rule.Query.GroupByFields = append(rule.Query.GroupByFields, c.attribute(strings.TrimSpace(field)))
+		}
+	}
+	operator := match[3]
+	if operator == "=" {
+		operator = "=="
+	}
+	rule.Case.Condition = QueryName + " " + operator + " " + match[4]
+}
@@ -0,0 +1,115 @@
+package sigma
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const processCreation = `
+title: Suspicious Shell Download
+id: 5d4f1b2c-0000-4000-8000-000000000001
+description: Detects shells downloading payloads.
+references:
+  - https://example.com/report
+tags:
+  - attack.execution
+  - attack.t1059.004
+  - cve.2024-0001
+logsource:
+  product: linux
+  category: process_creation
+detection:
+  selection_img:
+    Image|endswith:
+      - /bash
+      - /sh
+  selection_cmd:
+    CommandLine|contains|all:
+      - curl
+      - '| sh'
+  filter_user:
+    User: root
+    ParentImage: null
+  condition: all of selection_* and not 1 of filter_*
+falsepositives:
+  - Installers
+level: high
+`
+
+func TestConvert(t *testing.T) {
+	rule, err := Convert(processCreation, FieldMappings{
+		"linux":                    {LogSourceQueryKey: "source:auditd OR source:sysmon", "User": "@usr.name"},
+		"linux/process_creation":   {"Image": "@process.executable.path", "CommandLine": "@process.cmdline"},
+		"windows/process_creation": {"Image": "@Image"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "Suspicious Shell Download", rule.Name)
+	assert.Equal(t, "Detects shells downloading payloads.\n\n**False positives:**\n- Installers\n\n**References:**\n- https://example.com/report", rule.Message)
+	assert.Equal(t, []string{"sigma_id:5d4f1b2c-0000-4000-8000-000000000001", "tactic:TA0002-execution", "technique:T1059.004", "sigma:cve.2024-0001"}, rule.Tags)
+	assert.Equal(t, `(source:auditd OR source:sysmon) AND (@process.executable.path:*\/bash OR @process.executable.path:*\/sh) AND @process.cmdline:*curl* AND @process.cmdline:*\|\ sh* AND NOT (@usr.name:root AND NOT @ParentImage:*)`, rule.Query.Query)
+	assert.Equal(t, Query{Name: "sigma", Query: rule.Query.Query, Aggregation: "count"}, rule.Query)
+	assert.Equal(t, Case{Status: "high", Condition: "sigma > 0"}, rule.Case)
+	assert.Equal(t, []int64{300, 3600, 86400}, []int64{rule.EvaluationWindow, rule.KeepAlive, rule.MaxSignalDuration})
+}
+
+func TestConvertAggregation(t *testing.T) {
+	rule, err := Convert(`
+title: Password spraying
+logsource:
+  product: aws
+  service: cloudtrail
+detection:
+  selection:
+    eventName: ConsoleLogin
+    errorMessage: 'Failed authentication'
+  timeframe: 2h
+  condition: selection | count(userIdentity.userName) by sourceIPAddress > 10
+`, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, `source:cloudtrail AND @eventName:ConsoleLogin AND @errorMessage:Failed\ authentication`, rule.Query.Query)
+	assert.Equal(t, "cardinality", rule.Query.Aggregation)
+	assert.Equal(t, []string{"@userIdentity.userName"}, rule.Query.DistinctFields)
+	assert.Equal(t, []string{"@sourceIPAddress"}, rule.Query.GroupByFields)
+	assert.Equal(t, Case{Status: "info", Condition: "sigma > 10"}, rule.Case)
+	assert.Equal(t, []int64{7200, 7200}, []int64{rule.EvaluationWindow, rule.KeepAlive})
+}
+
+func TestConvertValues(t *testing.T) {
+	for detection, expected := range map[string]string{
+		"keywords:\n    - 'mimikatz'\n    - 'sekurlsa::*'\n  condition: keywords":             `mimikatz OR sekurlsa\:\:*`,
+		"selection:\n    - Port|gte: 1024\n    - ip|cidr: 10.0.0.0/8\n  condition: selection": `@Port:>=1024 OR CIDR(@ip,10.0.0.0/8)`,
+		"selection:\n    Path|startswith: 'C:\\Temp\\*'\n  condition: selection":              `@Path:C\:\\Temp\**`,
+		"selection:\n    Token|exists: false\n    Empty: ''\n  condition: selection":          `NOT @Token:* AND @Empty:""`,
+		"a:\n    x: 1\n  b:\n    y: 2\n  c:\n    z: 3\n  condition: a or (b and not c)":       `@x:1 OR (@y:2 AND NOT @z:3)`,
+		"_a:\n    x: 1\n  b:\n    y: 2\n  condition: 1 of them":                               `@y:2`,
+	} {
+		rule, err := Convert("title: t\ndetection:\n  "+detection, nil)
+		if assert.NoError(t, err, detection) {
+			assert.Equal(t, expected, rule.Query.Query, detection)
+		}
+	}
+}
+
+func TestConvertErrors(t *testing.T) {
+	for detection, expected := range map[string]string{
+		"selection:\n    CommandLine|re: 'a.*b'\n    Data|base64offset|contains: x\n  condition: selection": "modifier re of CommandLine in selection; modifier base64offset of Data in selection",
+		"selection:\n    x: 1\n  condition: selection | max(duration) > 10":                                 `aggregation "max(duration) > 10"`,
+		"selection:\n    x: 1\n  timeframe: 2m\n  condition: selection | count() > 1":                       "timeframe 2m, which is not a valid evaluation window",
+		"selection:\n    x: 1\n  condition: selection and other":                                            `unknown search identifier "other"`,
+		"selection:\n    x: 1\n  condition: (selection":                                                     "missing )",
+		"selection:\n    x: 1": "missing detection condition",
+	} {
+		_, err := Convert("title: t\ndetection:\n  "+detection, nil)
+		if assert.Error(t, err, detection) {
+			assert.True(t, strings.Contains(err.Error(), expected), "expected %q in %q", expected, err.Error())
+		}
+	}
+
+	_, err := Convert("title: t\ncorrelation:\n  type: event_count\n", nil)
+	var unsupported *UnsupportedError
+	assert.True(t, errors.As(err, &unsupported))
+	assert.Equal(t, []string{"correlation rules"}, unsupported.Constructs)
+}
@@ -0,0 +1,608 @@
+// Package synthetics contains helpers shared by the Synthetics resources and
+// data sources.
+package synthetics
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV1"
+)
+
+const (
+	LocatorTypeCSS   = "css"
+	LocatorTypeXPath = "xpath"
+)
+
+// Recording is the result of converting a browser recording into browser test
+// steps.
+type Recording struct {
+	// StartURL is the URL of the first navigation of the recording. It is not
+	// converted into a step since browser tests start on their configured URL.
+	StartURL string
+	Steps    []BrowserStep
+	// Warnings describes the parts of the recording that could not be
+	// converted.
+	Warnings []string
+}
+
+// BrowserStep mirrors the `browser_step` block of the `datadog_synthetics_test`
+// resource.
+type BrowserStep struct {
+	Name   string
+	Type   datadogV1.SyntheticsStepType
+	Params BrowserStepParams
+}
+
+// BrowserStepParams mirrors the subset of `browser_step.params` that can be
+// produced from a recording.
+type BrowserStepParams struct {
+	Attribute string
+	Check     datadogV1.SyntheticsCheckType
+	ClickType string
+	Code      string
+	Modifiers []string
+	Value     string
+	X         *int64
+	Y         *int64
+	Element   *ElementLocator
+}
+
+// ElementLocator is a user locator, as set in
+// `browser_step.params.element_user_locator`.
+type ElementLocator struct {
+	Type  string
+	Value string
+}
+
+// ConvertRecording converts a Chrome DevTools Recorder JSON export or a
+// Playwright codegen recording (`--target=jsonl`) into browser test steps. The
+// format is detected from the content.
+func ConvertRecording(recording string) (*Recording, error) {
+	recording = strings.TrimSpace(recording)
+	if recording == "" {
+		return nil, fmt.Errorf("recording is empty")
+	}
+
+	var chrome chromeRecording
+	if err := json.Unmarshal([]byte(recording), &chrome); err == nil && chrome.Steps != nil {
+		return convertChromeRecording(&chrome), nil
+	}
+
+	actions, err := parsePlaywrightJSONL(recording)
+	if err != nil {
+		return nil, fmt.Errorf("recording is neither a Chrome DevTools Recorder JSON export nor a Playwright JSONL recording: %s", err)
+	}
+	return convertPlaywrightRecording(actions), nil
+}
+
+type converter struct {
+	recording Recording
+}
+
+func (c *converter) addStep(step BrowserStep) {
+	c.recording.Steps = append(c.recording.Steps, step)
+}
+
+func (c *converter) warn(index int, kind, reason string) {
+	c.recording.Warnings = append(c.recording.Warnings, fmt.Sprintf("step %d (%s): %s", index+1, kind, reason))
+}
+
+// navigate converts a navigation, keeping the first one as the start URL. It
+// returns false when no step was added.
+func (c *converter) navigate(url string) bool {
+	if c.recording.StartURL == "" && len(c.recording.Steps) == 0 {
+		c.recording.StartURL = url
+		return false
+	}
+	c.addStep(BrowserStep{
+		Name:   fmt.Sprintf("Navigate to %s", url),
+		Type:   datadogV1.SYNTHETICSSTEPTYPE_GO_TO_URL,
+		Params: BrowserStepParams{Value: url},
+	})
+	return true
+}
+
+// Chrome DevTools Recorder
+
+type chromeRecording struct {
+	Title string         `json:"title"`
+	Steps []chromeAction `json:"steps"`
+}
+
+type chromeAction struct {
+	Type           string              `json:"type"`
+	Selectors      []json.RawMessage   `json:"selectors"`
+	URL            string              `json:"url"`
+	Value          string              `json:"value"`
+	Key            string              `json:"key"`
+	Button         string              `json:"button"`
+	X              *int64              `json:"x"`
+	Y              *int64              `json:"y"`
+	Operator       string              `json:"operator"`
+	Count          *int64              `json:"count"`
+	Visible        *bool               `json:"visible"`
+	Expression     string              `json:"expression"`
+	Target         string              `json:"target"`
+	Frame          []int64             `json:"frame"`
+	AssertedEvents []chromeAssertEvent `json:"assertedEvents"`
+}
+
+type chromeAssertEvent struct {
+	Type string `json:"type"`
+	URL  string `json:"url"`
+}
+
+var keyModifiers = []string{"Alt", "Control", "Meta", "Shift"}
+
+func convertChromeRecording(chrome *chromeRecording) *Recording {
+	c := converter{}
+	var heldModifiers []string
+	for i, action := range chrome.Steps {
+		if action.Target != "" && action.Target != "main" {
+			c.warn(i, action.Type, "steps targeting another tab are not supported and were skipped")
+			continue
+		}
+		if len(action.Frame) > 0 {
+			c.warn(i, action.Type, "steps inside iframes are not supported and were skipped")
+			continue
+		}
+
+		switch action.Type {
+		case "navigate":
+			if !c.navigate(action.URL) {
+				// The start URL is checked by the test itself.
+				continue
+			}
+		case "click", "doubleClick":
+			clickType := "primary"
+			if action.Type == "doubleClick" {
+				clickType = "double"
+			} else if action.Button == "secondary" {
+				clickType = "contextual"
+			} else if action.Button != "" && action.Button != "primary" {
+				c.warn(i, action.Type, fmt.Sprintf("%q button clicks are not supported and were skipped", action.Button))
+				continue
+			}
+			if !c.elementStep(i, action, func(element *ElementLocator) BrowserStep {
+				return BrowserStep{
+					Name:   fmt.Sprintf("Click on %s", element.Value),
+					Type:   datadogV1.SYNTHETICSSTEPTYPE_CLICK,
+					Params: BrowserStepParams{ClickType: clickType, Element: element},
+				}
+			}) {
+				continue
+			}
+		case "change":
+			if !c.elementStep(i, action, func(element *ElementLocator) BrowserStep {
+				return BrowserStep{
+					Name:   fmt.Sprintf("Type text on %s", element.Value),
+					Type:   datadogV1.SYNTHETICSSTEPTYPE_TYPE_TEXT,
+					Params: BrowserStepParams{Value: action.Value, Element: element},
+				}
+			}) {
+				continue
+			}
+		case "hover":
+			if !c.elementStep(i, action, func(element *ElementLocator) BrowserStep {
+				return BrowserStep{
+					Name:   fmt.Sprintf("Hover over %s", element.Value),
+					Type:   datadogV1.SYNTHETICSSTEPTYPE_HOVER,
+					Params: BrowserStepParams{Element: element},
+				}
+			}) {
+				continue
+			}
+		case "keyDown":
+			// Modifiers are recorded as separate key presses; they are attached
+			// to the next key instead.
+			if slices.Contains(keyModifiers, action.Key) {
+				if !slices.Contains(heldModifiers, action.Key) {
+					heldModifiers = append(heldModifiers, action.Key)
+				}
+				continue
+			}
+			modifiers := slices.Clone(heldModifiers)
+			slices.Sort(modifiers)
+			c.addStep(BrowserStep{
+				Name:   fmt.Sprintf("Press %s", strings.Join(append(modifiers, action.Key), " + ")),
+				Type:   datadogV1.SYNTHETICSSTEPTYPE_PRESS_KEY,
+				Params: BrowserStepParams{Value: action.Key, Modifiers: modifiers},
+			})
+		case "keyUp":
+			heldModifiers = slices.DeleteFunc(heldModifiers, func(m string) bool { return m == action.Key })
+		case "scroll":
+			step := BrowserStep{
+				Name:   "Scroll",
+				Type:   datadogV1.SYNTHETICSSTEPTYPE_SCROLL,
+				Params: BrowserStepParams{X: action.X, Y: action.Y},
+			}
+			if len(action.Selectors) > 0 {
+				element, err := chromeSelectorsToLocator(action.Selectors)
+				if err != nil {
+					c.warn(i, action.Type, err.Error())
+					continue
+				}
+				step.Name = fmt.Sprintf("Scroll on %s", element.Value)
+				step.Params.Element = element
+			}
+			c.addStep(step)
+		case "waitForElement":
+			if (action.Operator != "" && action.Operator != ">=") || (action.Count != nil && *action.Count != 1) || (action.Visible != nil && !*action.Visible) {
+				c.warn(i, action.Type, "only waiting for a single visible element is supported, the step was skipped")
+				continue
+			}
+			if !c.elementStep(i, action, func(element *ElementLocator) BrowserStep {
+				return BrowserStep{
+					Name:   fmt.Sprintf("Test %s is present", element.Value),
+					Type:   datadogV1.SYNTHETICSSTEPTYPE_ASSERT_ELEMENT_PRESENT,
+					Params: BrowserStepParams{Element: element},
+				}
+			}) {
+				continue
+			}
+		case "waitForExpression":
+			c.addStep(BrowserStep{
+				Name:   "Test custom JavaScript assertion",
+				Type:   datadogV1.SYNTHETICSSTEPTYPE_ASSERT_FROM_JAVASCRIPT,
+				Params: BrowserStepParams{Code: fmt.Sprintf("return Boolean(%s);", action.Expression)},
+			})
+		default:
+			c.warn(i, action.Type, "step type is not supported and was skipped")
+			continue
+		}
+
+		for _, event := range action.AssertedEvents {
+			if event.Type != "navigation" || event.URL == "" {
+				continue
+			}
+			c.addStep(BrowserStep{
+				Name:   "Test current URL",
+				Type:   datadogV1.SYNTHETICSSTEPTYPE_ASSERT_CURRENT_URL,
+				Params: BrowserStepParams{Check: datadogV1.SYNTHETICSCHECKTYPE_EQUALS, Value: event.URL},
+			})
+		}
+	}
+	return &c.recording
+}
+
+// elementStep adds the step built for the element targeted by a recorded
+// action. It returns false when no usable selector was recorded.
+func (c *converter) elementStep(index int, action chromeAction, build func(*ElementLocator) BrowserStep) bool {
+	element, err := chromeSelectorsToLocator(action.Selectors)
+	if err != nil {
+		c.warn(index, action.Type, err.Error())
+		return false
+	}
+	c.addStep(build(element))
+	return true
+}
+
+// chromeSelectorsToLocator picks the most specific selector of a recorded
+// step. Recorder selectors are either strings or chains of strings crossing
+// shadow roots, which user locators cannot express.
+func chromeSelectorsToLocator(selectors []json.RawMessage) (*ElementLocator, error) {
+	var candidates []*ElementLocator
+	for _, raw := range selectors {
+		var selector string
+		if err := json.Unmarshal(raw, &selector); err != nil {
+			var chain []string
+			if err := json.Unmarshal(raw, &chain); err != nil || len(chain) != 1 {
+				continue
+			}
+			selector = chain[0]
+		}
+
+		switch {
+		case strings.HasPrefix(selector, "aria/"):
+			continue
+		case strings.HasPrefix(selector, "xpath/"):
+			candidates = append(candidates, &ElementLocator{Type: LocatorTypeXPath, Value: strings.TrimPrefix(selector, "xpath/")})
+		case strings.HasPrefix(selector, "pierce/"):
+			candidates = append(candidates, &ElementLocator{Type: LocatorTypeCSS, Value: strings.TrimPrefix(selector, "pierce/")})
+		case strings.HasPrefix(selector, "text/"):
+			candidates = append(candidates, &ElementLocator{Type: LocatorTypeXPath, Value: xpathForText(strings.TrimPrefix(selector, "text/"), true)})
+		default:
+			// Plain CSS selectors are the most robust, use them first.
+			return &ElementLocator{Type: LocatorTypeCSS, Value: selector}, nil
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("no CSS or XPath selector outside of a shadow root was recorded, the step was skipped")
+	}
+	return candidates[0], nil
+}
+
+// Playwright codegen
+
+type playwrightAction struct {
+	Name      string   `json:"name"`
+	PageAlias string   `json:"pageAlias"`
+	FramePath []string `json:"framePath"`
+	Selector  string   `json:"selector"`
+	URL       string   `json:"url"`
+	Text      string   `json:"text"`
+	Value     string   `json:"value"`
+	Key       string   `json:"key"`
+	Button    string   `json:"button"`
+	Modifiers int64    `json:"modifiers"`
+	Count     int64    `json:"clickCount"`
+	Options   []string `json:"options"`
+	Substring bool     `json:"substring"`
+	Checked   bool     `json:"checked"`
+}
+
+// playwrightModifiers lists the keyboard modifiers in the order of the bits
+// of Playwright's modifiers mask.
+var playwrightModifiers = []string{"Alt", "Control", "Meta", "Shift"}
+
+func parsePlaywrightJSONL(recording string) ([]playwrightAction, error) {
+	var actions []playwrightAction
+	scanner := bufio.NewScanner(strings.NewReader(recording))
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		var action playwrightAction
+		if err := json.Unmarshal([]byte(text), &action); err != nil {
+			return nil, fmt.Errorf("line %d: %s", line, err)
+		}
+		// The first line holds the browser options and has no action name.
+		if action.Name == "" {
+			continue
+		}
+		actions = append(actions, action)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(actions) == 0 {
+		return nil, fmt.Errorf("no action found")
+	}
+	return actions, nil
+}
+
+func convertPlaywrightRecording(actions []playwrightAction) *Recording {
+	c := converter{}
+	for i, action := range actions {
+		if action.PageAlias != "" && action.PageAlias != "page" {
+			c.warn(i, action.Name, "steps on another page are not supported and were skipped")
+			continue
+		}
+		if len(action.FramePath) > 0 {
+			c.warn(i, action.Name, "steps inside iframes are not supported and were skipped")
+			continue
+		}
+
+		var element *ElementLocator
+		if action.Selector != "" {
+			var err error
+			if element, err = playwrightSelectorToLocator(action.Selector); err != nil {
+				c.warn(i, action.Name, err.Error())
+				continue
+			}
+		}
+
+		switch action.Name {
+		case "openPage", "navigate":
+			if action.URL != "" && action.URL != "about:blank" {
+				c.navigate(action.URL)
+			}
+		case "click", "check", "uncheck":
+			clickType := "primary"
+			switch {
+			case action.Button == "right":
+				clickType = "contextual"
+			case action.Button != "" && action.Button != "left":
+				c.warn(i, action.Name, fmt.Sprintf("%q button clicks are not supported and were skipped", action.Button))
+				continue
+			case action.Count == 2:
+				clickType = "double"
+			}
+			if action.Modifiers != 0 {
+				c.warn(i, action.Name, "clicks with keyboard modifiers are not supported, the modifiers were dropped")
+			}
+			c.addStep(BrowserStep{
+				Name:   fmt.Sprintf("Click on %s", element.Value),
+				Type:   datadogV1.SYNTHETICSSTEPTYPE_CLICK,
+				Params: BrowserStepParams{ClickType: clickType, Element: element},
+			})
+		case "fill":
+			c.addStep(BrowserStep{
+				Name:   fmt.Sprintf("Type text on %s", element.Value),
+				Type:   datadogV1.SYNTHETICSSTEPTYPE_TYPE_TEXT,
+				Params: BrowserStepParams{Value: action.Text, Element: element},
+			})
+		case "press":
+			var modifiers []string
+			for bit, modifier := range playwrightModifiers {
+				if action.Modifiers&(1<<bit) != 0 {
+					modifiers = append(modifiers, modifier)
+				}
+			}
+			c.addStep(BrowserStep{
+				Name:   fmt.Sprintf("Press %s", strings.Join(append(slices.Clone(modifiers), action.Key), " + ")),
+				Type:   datadogV1.SYNTHETICSSTEPTYPE_PRESS_KEY,
+				Params: BrowserStepParams{Value: action.Key, Modifiers: modifiers},
+			})
+		case "select":
+			if len(action.Options) == 0 {
+				c.warn(i, action.Name, "no option was selected, the step was skipped")
+				continue
+			}
+			if len(action.Options) > 1 {
+				c.warn(i, action.Name, "selecting several options is not supported, only the first one was kept")
+			}
+			c.addStep(BrowserStep{
+				Name:   fmt.Sprintf("Select option on %s", element.Value),
+				Type:   datadogV1.SYNTHETICSSTEPTYPE_SELECT_OPTION,
+				Params: BrowserStepParams{Value: action.Options[0], Element: element},
+			})
+		case "assertVisible":
+			c.addStep(BrowserStep{
+				Name:   fmt.Sprintf("Test %s is present", element.Value),
+				Type:   datadogV1.SYNTHETICSSTEPTYPE_ASSERT_ELEMENT_PRESENT,
+				Params: BrowserStepParams{Element: element},
+			})
+		case "assertText":
+			check := datadogV1.SYNTHETICSCHECKTYPE_EQUALS
+			if action.Substring {
+				check = datadogV1.SYNTHETICSCHECKTYPE_CONTAINS
+			}
+			c.addStep(BrowserStep{
+				Name:   fmt.Sprintf("Test %s content", element.Value),
+				Type:   datadogV1.SYNTHETICSSTEPTYPE_ASSERT_ELEMENT_CONTENT,
+				Params: BrowserStepParams{Check: check, Value: action.Text, Element: element},
+			})
+		case "assertValue":
+			c.addStep(BrowserStep{
+				Name:   fmt.Sprintf("Test %s value", element.Value),
+				Type:   datadogV1.SYNTHETICSSTEPTYPE_ASSERT_ELEMENT_ATTRIBUTE,
+				Params: BrowserStepParams{Attribute: "value", Check: datadogV1.SYNTHETICSCHECKTYPE_EQUALS, Value: action.Value, Element: element},
+			})
+		case "assertChecked":
+			c.addStep(BrowserStep{
+				Name:   fmt.Sprintf("Test %s is checked", element.Value),
+				Type:   datadogV1.SYNTHETICSSTEPTYPE_ASSERT_FROM_JAVASCRIPT,
+				Params: BrowserStepParams{Code: fmt.Sprintf("return element.checked === %t;", action.Checked), Element: element},
+			})
+		default:
+			c.warn(i, action.Name, "action is not supported and was skipped")
+		}
+	}
+	return &c.recording
+}
+
+var (
+	playwrightAttrSelectorRegexp = regexp.MustCompile(`^\[([\w-]+)=("(?:[^"\\]|\\.)*")[is]?\]$`)
+	playwrightRoleSelectorRegexp = regexp.MustCompile(`^([a-z]+)(?:\[name=("(?:[^"\\]|\\.)*")([is]?)\])?`)
+)
+
+// playwrightImplicitRoles maps ARIA roles to the elements having them
+// implicitly.
+var playwrightImplicitRoles = map[string]string{
+	"button":   "self::button or self::input[@type='button' or @type='submit']",
+	"checkbox": "self::input[@type='checkbox']",
+	"combobox": "self::select",
+	"heading":  "self::h1 or self::h2 or self::h3 or self::h4 or self::h5 or self::h6",
+	"img":      "self::img",
+	"link":     "self::a[@href]",
+	"listitem": "self::li",
+	"radio":    "self::input[@type='radio']",
+	"textbox":  "self::textarea or self::input[not(@type) or @type='text' or @type='email' or @type='password' or @type='search' or @type='tel' or @type='url']",
+}
+
+// playwrightSelectorToLocator converts the selectors generated by Playwright
+// codegen into CSS or XPath user locators.
+func playwrightSelectorToLocator(selector string) (*ElementLocator, error) {
+	if strings.Contains(selector, " >> ") {
+		return nil, fmt.Errorf("chained selector %q is not supported, the step was skipped", selector)
+	}
+
+	switch {
+	case strings.HasPrefix(selector, "internal:testid="), strings.HasPrefix(selector, "internal:attr="):
+		attr := selector[strings.Index(selector, "=")+1:]
+		m := playwrightAttrSelectorRegexp.FindStringSubmatch(attr)
+		if m == nil {
+			break
+		}
+		return &ElementLocator{Type: LocatorTypeCSS, Value: fmt.Sprintf("[%s=%s]", m[1], m[2])}, nil
+	case strings.HasPrefix(selector, "internal:text="):
+		text, exact, ok := parsePlaywrightText(strings.TrimPrefix(selector, "internal:text="))
+		if !ok {
+			break
+		}
+		return &ElementLocator{Type: LocatorTypeXPath, Value: xpathForText(text, exact)}, nil
+	case strings.HasPrefix(selector, "internal:label="):
+		text, exact, ok := parsePlaywrightText(strings.TrimPrefix(selector, "internal:label="))
+		if !ok {
+			break
+		}
+		label := xpathTextPredicate(text, exact)
+		return &ElementLocator{Type: LocatorTypeXPath, Value: fmt.Sprintf("//*[@id=//label[%s]/@for or @aria-label=%s] | //label[%s]//*[self::input or self::textarea or self::select]", label, xpathLiteral(text), label)}, nil
+	case strings.HasPrefix(selector, "internal:role="):
+		m := playwrightRoleSelectorRegexp.FindStringSubmatch(strings.TrimPrefix(selector, "internal:role="))
+		if m == nil {
+			break
+		}
+		predicate := fmt.Sprintf("@role=%s", xpathLiteral(m[1]))
+		if implicit, ok := playwrightImplicitRoles[m[1]]; ok {
+			predicate += " or " + implicit
+		}
+		xpath := fmt.Sprintf("//*[%s]", predicate)
+		if m[2] != "" {
+			var name string
+			if err := json.Unmarshal([]byte(m[2]), &name); err != nil {
+				break
+			}
+			exact := m[3] == "s"
+			xpath += fmt.Sprintf("[%s or @aria-label=%s or @value=%s]", xpathTextPredicate(name, exact), xpathLiteral(name), xpathLiteral(name))
+		}
+		return &ElementLocator{Type: LocatorTypeXPath, Value: xpath}, nil
+	case strings.HasPrefix(selector, "internal:"):
+	case strings.HasPrefix(selector, "xpath="):
+		return &ElementLocator{Type: LocatorTypeXPath, Value: strings.TrimPrefix(selector, "xpath=")}, nil
+	case strings.HasPrefix(selector, "//"), strings.HasPrefix(selector, "(//"):
+		return &ElementLocator{Type: LocatorTypeXPath, Value: selector}, nil
+	case strings.HasPrefix(selector, "text="):
+		text, exact, ok := parsePlaywrightText(strings.TrimPrefix(selector, "text="))
+		if !ok {
+			text, exact = strings.TrimPrefix(selector, "text="), false
+		}
+		return &ElementLocator{Type: LocatorTypeXPath, Value: xpathForText(text, exact)}, nil
+	default:
+		return &ElementLocator{Type: LocatorTypeCSS, Value: strings.TrimPrefix(selector, "css=")}, nil
+	}
+	return nil, fmt.Errorf("selector %q is not supported, the step was skipped", selector)
+}
+
+// parsePlaywrightText parses a quoted Playwright text selector such as
+// `"Sign in"i`. The `s` suffix requests an exact match.
+func parsePlaywrightText(s string) (string, bool, bool) {
+	exact := false
+	if strings.HasSuffix(s, `"s`) {
+		exact = true
+		s = strings.TrimSuffix(s, "s")
+	} else {
+		s = strings.TrimSuffix(s, "i")
+	}
+	var text string
+	if err := json.Unmarshal([]byte(s), &text); err != nil {
+		return "", false, false
+	}
+	return text, exact, true
+}
+
+func xpathForText(text string, exact bool) string {
+	return fmt.Sprintf("//*[text()[%s]]", xpathTextPredicate(text, exact))
+}
+
+func xpathTextPredicate(text string, exact bool) string {
+	if exact {
+		return fmt.Sprintf("normalize-space(.)=%s", xpathLiteral(text))
+	}
+	return fmt.Sprintf("contains(normalize-space(.), %s)", xpathLiteral(text))
+}
+
+// xpathLiteral quotes a string for XPath 1.0, which has no escape sequences.
+func xpathLiteral(s string) string {
+	if !strings.Contains(s, `"`) {
+		return `"` + s + `"`
+	}
+	if !strings.Contains(s, "'") {
+		return "'" + s + "'"
+	}
+	parts := strings.Split(s, `"`)
+	for i, part := range parts {
+		parts[i] = `"` + part + `"`
+	}
+	return "concat(" + strings.Join(parts, `, '"', `) + ")"
+}
@@ -0,0 +1,108 @@
+package synthetics
+
+import (
+	"testing"
+
+	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertChromeRecording(t *testing.T) {
+	recording, err := ConvertRecording(`{
+		"title": "checkout",
+		"steps": [
+			{"type": "setViewport", "width": 1280, "height": 720},
+			{"type": "navigate", "url": "https://shop.example.com/", "assertedEvents": [{"type": "navigation", "url": "https://shop.example.com/"}]},
+			{"type": "click", "selectors": [["aria/Sign in"], ["#sign-in"], ["xpath///*[@id=\"sign-in\"]"]], "offsetX": 10, "offsetY": 5},
+			{"type": "change", "value": "jdoe@example.com", "selectors": [["aria/Email"], ["pierce/input.email"]]},
+			{"type": "keyDown", "key": "Shift"},
+			{"type": "keyDown", "key": "Tab"},
+			{"type": "keyUp", "key": "Tab"},
+			{"type": "keyUp", "key": "Shift"},
+			{"type": "click", "button": "secondary", "selectors": [["text/Cart"]], "assertedEvents": [{"type": "navigation", "url": "https://shop.example.com/cart"}]},
+			{"type": "waitForElement", "selectors": [["#total"]]},
+			{"type": "click", "selectors": [["#shadow-host", "button"]]}
+		]
+	}`)
+	require.NoError(t, err)
+
+	assert.Equal(t, "https://shop.example.com/", recording.StartURL)
+	require.Len(t, recording.Steps, 6)
+
+	assert.Equal(t, datadogV1.SYNTHETICSSTEPTYPE_CLICK, recording.Steps[0].Type)
+	assert.Equal(t, "primary", recording.Steps[0].Params.ClickType)
+	assert.Equal(t, &ElementLocator{Type: LocatorTypeCSS, Value: "#sign-in"}, recording.Steps[0].Params.Element)
+
+	assert.Equal(t, datadogV1.SYNTHETICSSTEPTYPE_TYPE_TEXT, recording.Steps[1].Type)
+	assert.Equal(t, "jdoe@example.com", recording.Steps[1].Params.Value)
+	assert.Equal(t, &ElementLocator{Type: LocatorTypeCSS, Value: "input.email"}, recording.Steps[1].Params.Element)
+
+	assert.Equal(t, datadogV1.SYNTHETICSSTEPTYPE_PRESS_KEY, recording.Steps[2].Type)
+	assert.Equal(t, "Tab", recording.Steps[2].Params.Value)
+	assert.Equal(t, []string{"Shift"}, recording.Steps[2].Params.Modifiers)
+
+	assert.Equal(t, "contextual", recording.Steps[3].Params.ClickType)
+	assert.Equal(t, &ElementLocator{Type: LocatorTypeXPath, Value: `//*[text()[normalize-space(.)="Cart"]]`}, recording.Steps[3].Params.Element)
+
+	assert.Equal(t, datadogV1.SYNTHETICSSTEPTYPE_ASSERT_CURRENT_URL, recording.Steps[4].Type)
+	assert.Equal(t, "https://shop.example.com/cart", recording.Steps[4].Params.Value)
+
+	assert.Equal(t, datadogV1.SYNTHETICSSTEPTYPE_ASSERT_ELEMENT_PRESENT, recording.Steps[5].Type)
+
+	require.Len(t, recording.Warnings, 2)
+	assert.Contains(t, recording.Warnings[0], "step 1 (setViewport)")
+	assert.Contains(t, recording.Warnings[1], "step 11 (click)")
+}
+
+func TestConvertPlaywrightRecording(t *testing.T) {
+	recording, err := ConvertRecording(`{"browserName":"chromium","launchOptions":{"headless":false},"contextOptions":{}}
+{"name":"openPage","url":"about:blank","signals":[],"pageAlias":"page","framePath":[]}
+{"name":"navigate","url":"https://shop.example.com/","signals":[],"pageAlias":"page","framePath":[]}
+{"name":"click","selector":"internal:role=link[name=\"Sign in\"i]","button":"left","modifiers":0,"clickCount":1,"signals":[],"pageAlias":"page","framePath":[]}
+{"name":"fill","selector":"internal:testid=[data-testid=\"email\"s]","text":"jdoe@example.com","signals":[],"pageAlias":"page","framePath":[]}
+{"name":"press","selector":"internal:label=\"Password\"i","key":"Enter","modifiers":10,"signals":[],"pageAlias":"page","framePath":[]}
+{"name":"assertText","selector":"#welcome","text":"Welcome","substring":true,"signals":[],"pageAlias":"page","framePath":[]}
+{"name":"select","selector":"select#country","options":["fr","de"],"signals":[],"pageAlias":"page","framePath":[]}
+{"name":"setInputFiles","selector":"#avatar","files":["a.png"],"signals":[],"pageAlias":"page","framePath":[]}
+{"name":"click","selector":"#pay","button":"left","modifiers":0,"clickCount":1,"signals":[],"pageAlias":"page1","framePath":[]}
+`)
+	require.NoError(t, err)
+
+	assert.Equal(t, "https://shop.example.com/", recording.StartURL)
+	require.Len(t, recording.Steps, 5)
+
+	assert.Equal(t, datadogV1.SYNTHETICSSTEPTYPE_CLICK, recording.Steps[0].Type)
+	assert.Equal(t, `//*[@role="link" or self::a[@href]][contains(normalize-space(.), "Sign in") or @aria-label="Sign in" or @value="Sign in"]`, recording.Steps[0].Params.Element.Value)
+
+	assert.Equal(t, &ElementLocator{Type: LocatorTypeCSS, Value: `[data-testid="email"]`}, recording.Steps[1].Params.Element)
+
+	assert.Equal(t, datadogV1.SYNTHETICSSTEPTYPE_PRESS_KEY, recording.Steps[2].Type)
+	assert.Equal(t, []string{"Control", "Shift"}, recording.Steps[2].Params.Modifiers)
+	assert.Equal(t, "Press Control + Shift + Enter", recording.Steps[2].Name)
+
+	assert.Equal(t, datadogV1.SYNTHETICSSTEPTYPE_ASSERT_ELEMENT_CONTENT, recording.Steps[3].Type)
+	assert.Equal(t, datadogV1.SYNTHETICSCHECKTYPE_CONTAINS, recording.Steps[3].Params.Check)
+
+	assert.Equal(t, datadogV1.SYNTHETICSSTEPTYPE_SELECT_OPTION, recording.Steps[4].Type)
+	assert.Equal(t, "fr", recording.Steps[4].Params.Value)
+
+	require.Len(t, recording.Warnings, 3)
+	assert.Contains(t, recording.Warnings[0], "step 7 (select)")
+	assert.Contains(t, recording.Warnings[1], "step 8 (setInputFiles)")
+	assert.Contains(t, recording.Warnings[2], "step 9 (click)")
+}
+
+func TestConvertRecordingInvalid(t *testing.T) {
+	_, err := ConvertRecording("")
+	assert.Error(t, err)
+
+	_, err = ConvertRecording("await page.goto('https://example.com');")
+	assert.Error(t, err)
+}
+
+func TestXpathLiteral(t *testing.T) {
+	assert.Equal(t, `"Sign in"`, xpathLiteral("Sign in"))
+	assert.Equal(t, `'say "hi"'`, xpathLiteral(`say "hi"`))
+	assert.Equal(t, `concat("it's ", '"', "quoted", '"', "")`, xpathLiteral(`it's "quoted"`))
+}
@@ -0,0 +1,90 @@
+package utils
+
+import (
+	"fmt"
+	"slices"
+)
+
+// MergePartialOrder places the managed IDs, in the given order, into the
+// current order, without changing the relative order of the other IDs. The
+// managed IDs are placed right after the `after` ID when it is set, otherwise
+// at the position of the first managed ID in the current order.
+func MergePartialOrder(current, managed []string, after string) ([]string, error) {
+	isManaged := make(map[string]bool, len(managed))
+	for _, id := range managed {
+		if isManaged[id] {
+			return nil, fmt.Errorf("%q is listed more than once", id)
+		}
+		if !slices.Contains(current, id) {
+			return nil, fmt.Errorf("%q does not exist, existing IDs are %q", id, current)
+		}
+		isManaged[id] = true
+	}
+	if isManaged[after] {
+		return nil, fmt.Errorf("%q cannot be both listed and used as the anchor", after)
+	}
+	if after != "" && !slices.Contains(current, after) {
+		return nil, fmt.Errorf("anchor %q does not exist, existing IDs are %q", after, current)
+	}
+
+	others := make([]string, 0, len(current))
+	position := -1
+	for _, id := range current {
+		if isManaged[id] {
+			if position < 0 {
+				position = len(others)
+			}
+			continue
+		}
+		others = append(others, id)
+	}
+	if after != "" {
+		position = slices.Index(others, after) + 1
+	}
+	if position < 0 {
+		position = len(others)
+	}
+
+	merged := make([]string, 0, len(current))
+	merged = append(merged, others[:position]...)
+	merged = append(merged, managed...)
+	return append(merged, others[position:]...), nil
+}
+
+// ManagedOrder returns the managed IDs which still exist, in their current
+// relative order, and the ID right before the first of them, empty when it
+// is first or when none of them exist. The other IDs placed between managed
+// IDs are kept, so that a managed block split by another ID differs from the
+// managed IDs.
+func ManagedOrder(current, managed []string) ([]string, string) {
+	first, last := -1, -1
+	for i, id := range current {
+		if !slices.Contains(managed, id) {
+			continue
+		}
+		if first < 0 {
+			first = i
+		}
+		last = i
+	}
+	if first < 0 {
+		return []string{}, ""
+	}
+	predecessor := ""
+	if first > 0 {
+		predecessor = current[first-1]
+	}
+	return slices.Clone(current[first : last+1]), predecessor
+}
+
+// PartialOrderDescription returns the description of the `partial` attribute
+// of the order resources, where attribute lists the ordered items.
+func PartialOrderDescription(attribute, items string) string {
+	return fmt.Sprintf("When `true`, `%[1]s` only lists the %[2]s managed by this resource, and the other %[2]s can be left out. The listed %[2]s are placed together in the listed order, after `after` when it is set and otherwise at the position of the first of them, while the other %[2]s keep their relative order. Only the order of the listed %[2]s is checked for drift, including when other %[2]s are placed between them.", attribute, items)
+}
+
+// PartialOrderAfterDescription returns the description of the `after`
+// attribute of the order resources.
+func PartialOrderAfterDescription(item, items string) string {
+	return fmt.Sprintf("%s placed right before the listed %s. Requires `partial` to be `true`.", item, items)
+}
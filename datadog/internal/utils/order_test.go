@@ -0,0 +1,66 @@
+package utils
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMergePartialOrder(t *testing.T) {
+	current := []string{"a", "b", "c", "d", "e"}
+	cases := map[string]struct {
+		managed  []string
+		after    string
+		expected []string
+		err      string
+	}{
+		"keeps the position of the first managed ID": {managed: []string{"d", "b"}, expected: []string{"a", "d", "b", "c", "e"}},
+		"after an anchor":   {managed: []string{"b", "a"}, after: "d", expected: []string{"c", "d", "b", "a", "e"}},
+		"after the last ID": {managed: []string{"a"}, after: "e", expected: []string{"b", "c", "d", "e", "a"}},
+		"nothing managed":   {expected: current},
+		"unknown ID":        {managed: []string{"a", "x"}, err: `"x" does not exist`},
+		"duplicate ID":      {managed: []string{"a", "a"}, err: `"a" is listed more than once`},
+		"unknown anchor":    {managed: []string{"a"}, after: "x", err: `anchor "x" does not exist`},
+		"managed anchor":    {managed: []string{"a", "b"}, after: "b", err: `"b" cannot be both listed and used as the anchor`},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			merged, err := MergePartialOrder(current, tc.managed, tc.after)
+			if tc.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.err) {
+					t.Fatalf("expected error %q, got %v", tc.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(merged, tc.expected) {
+				t.Errorf("expected %q, got %q", tc.expected, merged)
+			}
+		})
+	}
+}
+
+func TestManagedOrder(t *testing.T) {
+	current := []string{"a", "b", "c", "d"}
+	cases := map[string]struct {
+		managed     []string
+		ordered     []string
+		predecessor string
+	}{
+		"drift in the managed order": {managed: []string{"c", "b"}, ordered: []string{"b", "c"}, predecessor: "a"},
+		"first":                      {managed: []string{"a", "b"}, ordered: []string{"a", "b"}},
+		"split block":                {managed: []string{"b", "d"}, ordered: []string{"b", "c", "d"}, predecessor: "a"},
+		"deleted ID":                 {managed: []string{"c", "x"}, ordered: []string{"c"}, predecessor: "b"},
+		"nothing left":               {managed: []string{"x"}, ordered: []string{}},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ordered, predecessor := ManagedOrder(current, tc.managed)
+			if !reflect.DeepEqual(ordered, tc.ordered) || predecessor != tc.predecessor {
+				t.Errorf("expected %q after %q, got %q after %q", tc.ordered, tc.predecessor, ordered, predecessor)
+			}
+		})
+	}
+}
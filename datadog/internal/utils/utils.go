@@ -115,6 +115,9 @@ var DatadogProvider *schema.Provider
 // IntegrationAwsMutex mutex for AWS Integration resources
 var IntegrationAwsMutex = sync.Mutex{}
 
+// LogsIndexMutex mutex for logs index and logs index exclusion filter resources
+var LogsIndexMutex = sync.Mutex{}
+
 // Resource minimal interface common to ResourceData and ResourceDiff
 type Resource interface {
 	Get(string) interface{}
@@ -0,0 +1,75 @@
+package validators
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/sds"
+)
+
+// ValidateSDSPattern checks that a Sensitive Data Scanner pattern is a valid
+// RE2 regular expression, and warns about patterns matching the empty string.
+func ValidateSDSPattern(v any, p cty.Path) diag.Diagnostics {
+	value, ok := v.(string)
+	if !ok {
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       fmt.Sprintf("not a string: %s", v),
+			AttributePath: p,
+		}}
+	}
+	err := sds.Check(value)
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, sds.ErrMatchesEmpty):
+		return diag.Diagnostics{{
+			Severity:      diag.Warning,
+			Summary:       "Sensitive Data Scanner pattern matches the empty string",
+			Detail:        fmt.Sprintf("%q: %s", value, err),
+			AttributePath: p,
+		}}
+	default:
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       "Invalid Sensitive Data Scanner pattern",
+			Detail:        fmt.Sprintf("%q: %s", value, err),
+			AttributePath: p,
+		}}
+	}
+}
+
+type sdsPatternValidator struct{}
+
+func (sdsPatternValidator) Description(context.Context) string {
+	return "value must be a valid RE2 regular expression"
+}
+
+func (v sdsPatternValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (sdsPatternValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	value := req.ConfigValue.ValueString()
+	err := sds.Check(value)
+	switch {
+	case err == nil:
+	case errors.Is(err, sds.ErrMatchesEmpty):
+		resp.Diagnostics.AddAttributeWarning(req.Path, "Sensitive Data Scanner pattern matches the empty string", fmt.Sprintf("%q: %s", value, err))
+	default:
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Sensitive Data Scanner pattern", fmt.Sprintf("%q: %s", value, err))
+	}
+}
+
+// SDSPatternValidator is the framework equivalent of ValidateSDSPattern.
+func SDSPatternValidator() validator.String {
+	return sdsPatternValidator{}
+}
@@ -0,0 +1,38 @@
+package validators
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestValidateSDSPattern(t *testing.T) {
+	if diags := ValidateSDSPattern(`\d{3}-\d{2}-\d{4}`, cty.Path{}); len(diags) != 0 {
+		t.Errorf("unexpected diagnostics %v", diags)
+	}
+	if diags := ValidateSDSPattern(`(?<=ssn:)\d+`, cty.Path{}); !diags.HasError() {
+		t.Error("expected an error")
+	}
+	if diags := ValidateSDSPattern(`\d*`, cty.Path{}); len(diags) != 1 || diags.HasError() {
+		t.Errorf("expected a warning, got %v", diags)
+	}
+
+	ctx := context.Background()
+	for value, expected := range map[types.String][2]int{
+		types.StringValue(`[a-f0-9]{32}`): {0, 0},
+		types.StringValue(`(a)\1`):        {1, 0},
+		types.StringValue(`x?`):           {0, 1},
+		types.StringNull():                {0, 0},
+		types.StringUnknown():             {0, 0},
+	} {
+		response := validator.StringResponse{}
+		SDSPatternValidator().ValidateString(ctx, validator.StringRequest{Path: path.Root("pattern"), ConfigValue: value}, &response)
+		if response.Diagnostics.ErrorsCount() != expected[0] || response.Diagnostics.WarningsCount() != expected[1] {
+			t.Errorf("expected %d errors and %d warnings for %s, got %v", expected[0], expected[1], value, response.Diagnostics)
+		}
+	}
+}
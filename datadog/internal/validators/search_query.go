@@ -0,0 +1,433 @@
+package validators
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// SearchQuery is a parsed Datadog search query, as used by logs, spans, RUM
+// events and security signals, see
+// https://docs.datadoghq.com/logs/explorer/search_syntax/
+type SearchQuery interface {
+	isSearchQuery()
+}
+
+// SearchQueryAll matches everything, it is the result of `*` and of an empty
+// query.
+type SearchQueryAll struct{}
+
+type SearchQueryAnd struct{ Left, Right SearchQuery }
+
+type SearchQueryOr struct{ Left, Right SearchQuery }
+
+type SearchQueryNot struct{ Operand SearchQuery }
+
+// SearchQueryTerm matches a value, either against a facet or, without facet,
+// against the message. Value is unescaped. When the value contains
+// wildcards, Pattern holds the equivalent regular expression, without
+// anchors.
+type SearchQueryTerm struct {
+	Facet   string
+	Value   string
+	Pattern string
+	Phrase  bool
+}
+
+// SearchQueryRange matches the values of a facet between two bounds, either
+// of which may be `*`.
+type SearchQueryRange struct {
+	Facet                   string
+	Low, High               string
+	IncludeLow, IncludeHigh bool
+}
+
+// SearchQueryComparison matches the values of a facet with one of the `>`,
+// `>=`, `<` and `<=` operators.
+type SearchQueryComparison struct {
+	Facet    string
+	Operator string
+	Value    string
+}
+
+func (SearchQueryAll) isSearchQuery()        {}
+func (SearchQueryAnd) isSearchQuery()        {}
+func (SearchQueryOr) isSearchQuery()         {}
+func (SearchQueryNot) isSearchQuery()        {}
+func (SearchQueryTerm) isSearchQuery()       {}
+func (SearchQueryRange) isSearchQuery()      {}
+func (SearchQueryComparison) isSearchQuery() {}
+
+// ParseSearchQuery parses a search query: terms, phrases and `facet:value`
+// pairs combined with `AND`, `OR`, `NOT`, `-` and parentheses, with `*` and
+// `?` wildcards, `[low TO high]` ranges, comparisons and `\` escapes.
+func ParseSearchQuery(s string) (SearchQuery, error) {
+	p := &searchQueryParser{input: s}
+	p.skipSpaces()
+	if p.done() {
+		return SearchQueryAll{}, nil
+	}
+	q, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpaces()
+	if !p.done() {
+		if p.input[p.pos] == ')' {
+			return nil, fmt.Errorf("unbalanced parenthesis at offset %d", p.pos)
+		}
+		return nil, fmt.Errorf("unexpected %q at offset %d", p.input[p.pos:], p.pos)
+	}
+	return q, nil
+}
+
+type searchQueryParser struct {
+	input string
+	pos   int
+	// facet is set while parsing the values of a `facet:(a OR b)` group.
+	facet string
+}
+
+func (p *searchQueryParser) done() bool { return p.pos >= len(p.input) }
+
+func (p *searchQueryParser) skipSpaces() {
+	for !p.done() && unicode.IsSpace(rune(p.input[p.pos])) {
+		p.pos++
+	}
+}
+
+// keyword consumes an operator if it is the next word.
+func (p *searchQueryParser) keyword(word string) bool {
+	p.skipSpaces()
+	end := p.pos + len(word)
+	if end > len(p.input) || p.input[p.pos:end] != word {
+		return false
+	}
+	if end < len(p.input) && !unicode.IsSpace(rune(p.input[end])) && p.input[end] != '(' {
+		return false
+	}
+	p.pos = end
+	return true
+}
+
+func (p *searchQueryParser) parseOr() (SearchQuery, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("OR") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = SearchQueryOr{left, right}
+	}
+	return left, nil
+}
+
+func (p *searchQueryParser) parseAnd() (SearchQuery, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		p.skipSpaces()
+		if p.done() || p.input[p.pos] == ')' {
+			return left, nil
+		}
+		start := p.pos
+		if p.keyword("OR") {
+			p.pos = start
+			return left, nil
+		}
+		p.keyword("AND")
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = SearchQueryAnd{left, right}
+	}
+}
+
+func (p *searchQueryParser) parseUnary() (SearchQuery, error) {
+	p.skipSpaces()
+	if p.keyword("NOT") {
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return SearchQueryNot{operand}, nil
+	}
+	if !p.done() && (p.input[p.pos] == '-' || p.input[p.pos] == '!') && p.pos+1 < len(p.input) && !unicode.IsSpace(rune(p.input[p.pos+1])) {
+		p.pos++
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return SearchQueryNot{operand}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *searchQueryParser) parsePrimary() (SearchQuery, error) {
+	p.skipSpaces()
+	if p.done() {
+		return nil, fmt.Errorf("unexpected end of query")
+	}
+	switch p.input[p.pos] {
+	case '(':
+		return p.parseGroup()
+	case ')':
+		return nil, fmt.Errorf("unexpected %q at offset %d", ")", p.pos)
+	}
+	for _, operator := range []string{"AND", "OR"} {
+		start := p.pos
+		if p.keyword(operator) {
+			p.pos = start
+			return nil, fmt.Errorf("missing operand before %s at offset %d", operator, start)
+		}
+	}
+	return p.parseTerm()
+}
+
+func (p *searchQueryParser) parseGroup() (SearchQuery, error) {
+	open := p.pos
+	p.pos++
+	q, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpaces()
+	if p.done() || p.input[p.pos] != ')' {
+		return nil, fmt.Errorf("unbalanced parenthesis at offset %d", open)
+	}
+	p.pos++
+	return q, nil
+}
+
+func (p *searchQueryParser) parseTerm() (SearchQuery, error) {
+	if p.input[p.pos] == '"' {
+		value, err := p.readQuoted()
+		if err != nil {
+			return nil, err
+		}
+		return SearchQueryTerm{Facet: p.facet, Value: value, Phrase: true}, nil
+	}
+
+	start := p.pos
+	word, pattern, err := p.readWord(true)
+	if err != nil {
+		return nil, err
+	}
+	if p.done() || p.input[p.pos] != ':' {
+		if word == "" {
+			return nil, fmt.Errorf("unexpected %q at offset %d", p.input[start:start+1], start)
+		}
+		if p.facet == "" && word == "*" {
+			return SearchQueryAll{}, nil
+		}
+		return SearchQueryTerm{Facet: p.facet, Value: word, Pattern: pattern}, nil
+	}
+
+	// facet:value
+	facet := word
+	if strings.TrimLeft(facet, "@") == "" {
+		return nil, fmt.Errorf("missing facet name at offset %d", start)
+	}
+	if !searchQueryFacetRegexp.MatchString(facet) {
+		return nil, fmt.Errorf("invalid facet %q at offset %d", p.input[start:p.pos], start)
+	}
+	if p.facet != "" {
+		return nil, fmt.Errorf("unexpected facet %q in the values of %q", facet, p.facet)
+	}
+	p.pos++
+	if p.done() || unicode.IsSpace(rune(p.input[p.pos])) {
+		return nil, fmt.Errorf("missing value for facet %q at offset %d", facet, start)
+	}
+	switch p.input[p.pos] {
+	case '(':
+		p.facet = facet
+		q, err := p.parseGroup()
+		p.facet = ""
+		return q, err
+	case '[', '{':
+		return p.parseRange(facet)
+	case '>', '<':
+		return p.parseComparison(facet)
+	case '"':
+		value, err := p.readQuoted()
+		if err != nil {
+			return nil, err
+		}
+		return SearchQueryTerm{Facet: facet, Value: value, Phrase: true}, nil
+	}
+	value, pattern, err := p.readWord(false)
+	if err != nil {
+		return nil, err
+	}
+	if value == "" {
+		return nil, fmt.Errorf("missing value for facet %q at offset %d", facet, start)
+	}
+	return SearchQueryTerm{Facet: facet, Value: value, Pattern: pattern}, nil
+}
+
+// searchQueryFacetRegexp matches attributes (`@http.status_code`) and tag
+// keys (`env`, `kube_namespace`, `aws/region`), including wildcard facets.
+var searchQueryFacetRegexp = regexp.MustCompile(`^@?[\p{L}\p{N}_\-@./*]+$`)
+
+// readWord reads an unquoted word, stopping at an unescaped colon when
+// stopAtColon is set. It returns the unescaped word and, when it contains
+// wildcards, the matching regular expression.
+func (p *searchQueryParser) readWord(stopAtColon bool) (string, string, error) {
+	var word, pattern strings.Builder
+	wildcard := false
+	for !p.done() {
+		ch := p.input[p.pos]
+		if unicode.IsSpace(rune(ch)) || ch == '(' || ch == ')' || (stopAtColon && ch == ':') {
+			break
+		}
+		if ch == '"' {
+			return "", "", fmt.Errorf("unexpected quote at offset %d", p.pos)
+		}
+		if ch == '\\' {
+			if p.pos+1 >= len(p.input) {
+				return "", "", fmt.Errorf("dangling escape character at the end of the query")
+			}
+			p.pos++
+			escaped := string(p.input[p.pos])
+			word.WriteString(escaped)
+			pattern.WriteString(regexp.QuoteMeta(escaped))
+			p.pos++
+			continue
+		}
+		switch ch {
+		case '*':
+			wildcard = true
+			pattern.WriteString(".*")
+		case '?':
+			wildcard = true
+			pattern.WriteString(".")
+		default:
+			pattern.WriteString(regexp.QuoteMeta(string(ch)))
+		}
+		word.WriteByte(ch)
+		p.pos++
+	}
+	if !wildcard {
+		return word.String(), "", nil
+	}
+	return word.String(), pattern.String(), nil
+}
+
+func (p *searchQueryParser) readQuoted() (string, error) {
+	start := p.pos
+	p.pos++
+	var value strings.Builder
+	for !p.done() {
+		ch := p.input[p.pos]
+		switch {
+		case ch == '\\' && p.pos+1 < len(p.input):
+			value.WriteByte(p.input[p.pos+1])
+			p.pos += 2
+		case ch == '"':
+			p.pos++
+			return value.String(), nil
+		default:
+			value.WriteByte(ch)
+			p.pos++
+		}
+	}
+	return "", fmt.Errorf("unterminated quote at offset %d", start)
+}
+
+func (p *searchQueryParser) parseRange(facet string) (SearchQuery, error) {
+	start := p.pos
+	includeLow := p.input[p.pos] == '['
+	end := strings.IndexAny(p.input[p.pos:], "]}")
+	if end < 0 {
+		return nil, fmt.Errorf("unterminated range at offset %d", start)
+	}
+	includeHigh := p.input[p.pos+end] == ']'
+	bounds := strings.Fields(p.input[p.pos+1 : p.pos+end])
+	if len(bounds) != 3 || bounds[1] != "TO" {
+		return nil, fmt.Errorf("invalid range %q at offset %d, expected [low TO high]", p.input[start:p.pos+end+1], start)
+	}
+	p.pos += end + 1
+	return SearchQueryRange{Facet: facet, Low: bounds[0], High: bounds[2], IncludeLow: includeLow, IncludeHigh: includeHigh}, nil
+}
+
+func (p *searchQueryParser) parseComparison(facet string) (SearchQuery, error) {
+	start := p.pos
+	operator := p.input[p.pos : p.pos+1]
+	p.pos++
+	if !p.done() && p.input[p.pos] == '=' {
+		operator += "="
+		p.pos++
+	}
+	value, _, err := p.readWord(false)
+	if err != nil {
+		return nil, err
+	}
+	if value == "" {
+		return nil, fmt.Errorf("missing value after %s at offset %d", operator, start)
+	}
+	return SearchQueryComparison{Facet: facet, Operator: operator, Value: value}, nil
+}
+
+var _ schema.SchemaValidateDiagFunc = ValidateSearchQuery
+
+// ValidateSearchQuery ensures a string is a valid search query
+func ValidateSearchQuery(v any, p cty.Path) diag.Diagnostics {
+	value, ok := v.(string)
+	if !ok {
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       fmt.Sprintf("not a string: %s", v),
+			AttributePath: p,
+		}}
+	}
+	if _, err := ParseSearchQuery(value); err != nil {
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       "Invalid search query",
+			Detail:        fmt.Sprintf("%q: %s", value, err),
+			AttributePath: p,
+		}}
+	}
+	return nil
+}
+
+type searchQueryValidator struct{}
+
+func (searchQueryValidator) Description(context.Context) string {
+	return "value must be a valid search query"
+}
+
+func (v searchQueryValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (searchQueryValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	if _, err := ParseSearchQuery(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid search query",
+			fmt.Sprintf("%q: %s", req.ConfigValue.ValueString(), err),
+		)
+	}
+}
+
+// SearchQueryValidator is the framework equivalent of ValidateSearchQuery.
+func SearchQueryValidator() validator.String {
+	return searchQueryValidator{}
+}
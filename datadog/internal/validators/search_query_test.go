@@ -0,0 +1,103 @@
+package validators
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestParseSearchQuery(t *testing.T) {
+	cases := map[string]SearchQuery{
+		"":    SearchQueryAll{},
+		" * ": SearchQueryAll{},
+		"service:web status:error": SearchQueryAnd{
+			SearchQueryTerm{Facet: "service", Value: "web"},
+			SearchQueryTerm{Facet: "status", Value: "error"},
+		},
+		"a OR b AND c": SearchQueryOr{
+			SearchQueryTerm{Value: "a"},
+			SearchQueryAnd{SearchQueryTerm{Value: "b"}, SearchQueryTerm{Value: "c"}},
+		},
+		"-env:prod NOT(team:core)": SearchQueryAnd{
+			SearchQueryNot{SearchQueryTerm{Facet: "env", Value: "prod"}},
+			SearchQueryNot{SearchQueryTerm{Facet: "team", Value: "core"}},
+		},
+		"env:(staging OR prod)": SearchQueryOr{
+			SearchQueryTerm{Facet: "env", Value: "staging"},
+			SearchQueryTerm{Facet: "env", Value: "prod"},
+		},
+		`@http.url:\/api\/v?\*`:         SearchQueryTerm{Facet: "@http.url", Value: "/api/v?*", Pattern: `/api/v.\*`},
+		`@evt.name:"Create User"`:       SearchQueryTerm{Facet: "@evt.name", Value: "Create User", Phrase: true},
+		`"connection \"refused\""`:      SearchQueryTerm{Value: `connection "refused"`, Phrase: true},
+		"@user.arn:arn:aws:iam::1:root": SearchQueryTerm{Facet: "@user.arn", Value: "arn:aws:iam::1:root"},
+		"@http.status_code:[500 TO *}":  SearchQueryRange{Facet: "@http.status_code", Low: "500", High: "*", IncludeLow: true},
+		"@duration:>=1s":                SearchQueryComparison{Facet: "@duration", Operator: ">=", Value: "1s"},
+		"kube_namespace:default @a.*:1": SearchQueryAnd{
+			SearchQueryTerm{Facet: "kube_namespace", Value: "default"},
+			SearchQueryTerm{Facet: "@a.*", Value: "1"},
+		},
+	}
+	for q, expected := range cases {
+		parsed, err := ParseSearchQuery(q)
+		if err != nil {
+			t.Errorf("unexpected error for %q: %v", q, err)
+			continue
+		}
+		if !reflect.DeepEqual(parsed, expected) {
+			t.Errorf("unexpected result for %q: %#v", q, parsed)
+		}
+	}
+
+	errors := map[string]string{
+		"(a":                "unbalanced parenthesis at offset 0",
+		"a)":                "unbalanced parenthesis at offset 1",
+		"env:(a OR b":       "unbalanced parenthesis at offset 4",
+		"@a:":               `missing value for facet "@a"`,
+		"a AND":             "unexpected end of query",
+		"OR a":              "missing operand before OR",
+		`"a`:                "unterminated quote",
+		"@a:[1 TO":          "unterminated range",
+		"@a:[1 2]":          "invalid range",
+		"@a:>":              "missing value after >",
+		"bad facet!:a":      `invalid facet "facet!"`,
+		"env:(a OR team:b)": `unexpected facet "team"`,
+		`a\`:                "dangling escape character",
+		"@:x":               "missing facet name at offset 0",
+		"a @@:x":            "missing facet name at offset 2",
+	}
+	for q, expected := range errors {
+		_, err := ParseSearchQuery(q)
+		if err == nil || !strings.Contains(err.Error(), expected) {
+			t.Errorf("expected %q to fail with %q, got %v", q, expected, err)
+		}
+	}
+}
+
+func TestValidateSearchQuery(t *testing.T) {
+	if diags := ValidateSearchQuery("service:web", cty.Path{}); diags.HasError() {
+		t.Errorf("unexpected error %v", diags)
+	}
+	if diags := ValidateSearchQuery("service:(web", cty.Path{}); !diags.HasError() {
+		t.Error("expected an error")
+	}
+
+	ctx := context.Background()
+	for value, hasError := range map[types.String]bool{
+		types.StringValue("service:web"):  false,
+		types.StringValue("service:(web"): true,
+		types.StringNull():                false,
+		types.StringUnknown():             false,
+	} {
+		response := validator.StringResponse{}
+		SearchQueryValidator().ValidateString(ctx, validator.StringRequest{Path: path.Root("query"), ConfigValue: value}, &response)
+		if response.Diagnostics.HasError() != hasError {
+			t.Errorf("expected error for %s to be %v, got %v", value, hasError, response.Diagnostics)
+		}
+	}
+}
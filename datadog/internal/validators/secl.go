@@ -0,0 +1,82 @@
+package validators
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/secl"
+)
+
+// ValidateSECLExpression checks that a CSM Threats agent rule expression is a
+// valid SECL expression. Fields and types which do not match the Agent's event
+// model are reported as warnings.
+func ValidateSECLExpression(v any, p cty.Path) diag.Diagnostics {
+	value, ok := v.(string)
+	if !ok {
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       fmt.Sprintf("not a string: %s", v),
+			AttributePath: p,
+		}}
+	}
+	_, warnings, err := secl.Check(value)
+	if err != nil {
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       "Invalid SECL expression",
+			Detail:        fmt.Sprintf("%q: %s", value, err),
+			AttributePath: p,
+		}}
+	}
+	var diags diag.Diagnostics
+	for _, warning := range warnings {
+		diags = append(diags, diag.Diagnostic{
+			Severity:      diag.Warning,
+			Summary:       "Unexpected SECL expression",
+			Detail:        fmt.Sprintf("%q: %s", value, warning),
+			AttributePath: p,
+		})
+	}
+	return diags
+}
+
+type seclExpressionValidator struct{}
+
+func (seclExpressionValidator) Description(context.Context) string {
+	return "value must be a valid SECL expression"
+}
+
+func (v seclExpressionValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (seclExpressionValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	_, warnings, err := secl.Check(req.ConfigValue.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid SECL expression",
+			fmt.Sprintf("%q: %s", req.ConfigValue.ValueString(), err),
+		)
+		return
+	}
+	for _, warning := range warnings {
+		resp.Diagnostics.AddAttributeWarning(
+			req.Path,
+			"Unexpected SECL expression",
+			fmt.Sprintf("%q: %s", req.ConfigValue.ValueString(), warning),
+		)
+	}
+}
+
+// SECLExpressionValidator is the framework equivalent of ValidateSECLExpression.
+func SECLExpressionValidator() validator.String {
+	return seclExpressionValidator{}
+}
@@ -0,0 +1,40 @@
+package validators
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestValidateSECLExpression(t *testing.T) {
+	if diags := ValidateSECLExpression(`exec.file.name == "java"`, cty.Path{}); len(diags) > 0 {
+		t.Errorf("unexpected diagnostics %v", diags)
+	}
+	if diags := ValidateSECLExpression(`exec.file.nme == "java"`, cty.Path{}); diags.HasError() || len(diags) != 1 {
+		t.Errorf("expected a warning, got %v", diags)
+	}
+	if diags := ValidateSECLExpression(`exec.file.name == "java`, cty.Path{}); !diags.HasError() {
+		t.Error("expected an error")
+	}
+
+	ctx := context.Background()
+	for value, expected := range map[types.String]struct{ errors, warnings int }{
+		types.StringValue(`open.file.path == "/etc/shadow"`):             {0, 0},
+		types.StringValue(`open.file.path == 1`):                         {0, 1},
+		types.StringValue(`process.ancestors[A].file.name == "java"`):    {0, 0},
+		types.StringValue(`setsockopt.level == 1 && prctl.option == 15`): {0, 1},
+		types.StringValue(`open.file.path == "/etc/shadow" &&`):          {1, 0},
+		types.StringNull():    {0, 0},
+		types.StringUnknown(): {0, 0},
+	} {
+		response := validator.StringResponse{}
+		SECLExpressionValidator().ValidateString(ctx, validator.StringRequest{Path: path.Root("expression"), ConfigValue: value}, &response)
+		if response.Diagnostics.ErrorsCount() != expected.errors || response.Diagnostics.WarningsCount() != expected.warnings {
+			t.Errorf("expected %d errors and %d warnings for %s, got %v", expected.errors, expected.warnings, value, response.Diagnostics)
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/utils"
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/validators"
 
 	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV2"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -43,9 +44,10 @@ func cloudWorkloadSecurityAgentRuleSchema() map[string]*schema.Schema {
 			Description: "Whether the Agent rule is enabled.",
 		},
 		"expression": {
-			Type:        schema.TypeString,
-			Required:    true,
-			Description: "The SECL expression of the Agent rule.",
+			Type:             schema.TypeString,
+			Required:         true,
+			Description:      "The SECL expression of the Agent rule. Syntax errors fail the plan, fields and values which do not match the Agent's event model are reported as warnings.",
+			ValidateDiagFunc: validators.ValidateSECLExpression,
 		},
 		"name": {
 			Type:        schema.TypeString,
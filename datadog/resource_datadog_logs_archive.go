@@ -21,13 +21,14 @@ func resourceDatadogLogsArchive() *schema.Resource {
 		UpdateContext: resourceDatadogLogsArchiveUpdate,
 		ReadContext:   resourceDatadogLogsArchiveRead,
 		DeleteContext: resourceDatadogLogsArchiveDelete,
+		CustomizeDiff: logsArchiveGuardDiff,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 		SchemaFunc: func() map[string]*schema.Schema {
 			return map[string]*schema.Schema{
 				"name":  {Description: "Your archive name.", Type: schema.TypeString, Required: true},
-				"query": {Description: "The archive query/filter. Logs matching this query are included in the archive.", Type: schema.TypeString, Required: true},
+				"query": {Description: "The archive query/filter. Logs matching this query are included in the archive.", Type: schema.TypeString, Required: true, ValidateDiagFunc: validators.ValidateSearchQuery},
 				"s3_archive": {
 					Description: "Definition of an s3 archive.",
 					Type:        schema.TypeList,
@@ -144,11 +145,67 @@ func resourceDatadogLogsArchive() *schema.Resource {
 					Optional:    true,
 					Elem:        &schema.Schema{Type: schema.TypeString},
 				},
+				"guard_destructive_changes": {
+					Description: "If true, changing the destination of the archive (its type, bucket, container, storage account or path) fails at plan time unless `allow_destination_change` is set to true. Acknowledged changes produce no warning: the plan shows them like any other change, with their old and new values.",
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     false,
+				},
+				"allow_destination_change": {
+					Description: "Acknowledges changes of the archive destination when `guard_destructive_changes` is true.",
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     false,
+				},
 			}
 		},
 	}
 }
 
+// logsArchiveGuardDiff fails the plan of an existing archive when
+// `guard_destructive_changes` is set and its destination changes without
+// `allow_destination_change`.
+func logsArchiveGuardDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" || !d.Get("guard_destructive_changes").(bool) || d.Get("allow_destination_change").(bool) {
+		return nil
+	}
+	oldLocation := logsArchiveLocation(func(key string) interface{} {
+		old, _ := d.GetChange(key)
+		return old
+	})
+	newLocation := logsArchiveLocation(d.Get)
+	if oldLocation != newLocation {
+		return fmt.Errorf("logs archive %q has `guard_destructive_changes` enabled, set `allow_destination_change` to acknowledge the destination change %s→%s", d.Id(), oldLocation, newLocation)
+	}
+	return nil
+}
+
+// logsArchiveLocation describes where the archive defined by the given
+// attribute getter stores logs, for example `s3://bucket/path`.
+func logsArchiveLocation(get func(string) interface{}) string {
+	for _, archiveType := range []string{"s3", "gcs", "azure"} {
+		definition, ok := get(archiveType + "_archive").([]interface{})
+		if !ok || len(definition) == 0 || definition[0] == nil {
+			continue
+		}
+		destination := definition[0].(map[string]interface{})
+		var location string
+		switch archiveType {
+		case "s3":
+			location = fmt.Sprintf("s3://%s", destination["bucket"])
+		case "gcs":
+			location = fmt.Sprintf("gs://%s", destination["bucket"])
+		case "azure":
+			location = fmt.Sprintf("azure://%s/%s", destination["storage_account"], destination["container"])
+		}
+		if path, _ := destination["path"].(string); strings.Trim(path, "/") != "" {
+			location += "/" + strings.Trim(path, "/")
+		}
+		return location
+	}
+	return "(none)"
+}
+
 func resourceDatadogLogsArchiveCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	providerConf := meta.(*ProviderConfiguration)
 	apiInstances := providerConf.DatadogApiInstances
@@ -18,6 +18,7 @@ func resourceDatadogLogsArchiveOrder() *schema.Resource {
 		UpdateContext: resourceDatadogLogsArchiveOrderUpdate,
 		ReadContext:   resourceDatadogLogsArchiveOrderRead,
 		DeleteContext: resourceDatadogLogsArchiveOrderDelete,
+		CustomizeDiff: partialOrderDiff,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -30,6 +31,17 @@ func resourceDatadogLogsArchiveOrder() *schema.Resource {
 					Optional:    true,
 					Elem:        &schema.Schema{Type: schema.TypeString},
 				},
+				"partial": {
+					Description: utils.PartialOrderDescription("archive_ids", "archives"),
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     false,
+				},
+				"after": {
+					Description: utils.PartialOrderAfterDescription("ID of the archive", "archives"),
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
 			}
 		},
 	}
@@ -80,7 +92,7 @@ func resourceDatadogLogsArchiveOrderRead(ctx context.Context, d *schema.Resource
 }
 
 func updateLogsArchiveOrderState(d *schema.ResourceData, order *datadogV2.LogsArchiveOrder) diag.Diagnostics {
-	if err := d.Set("archive_ids", order.Data.Attributes.ArchiveIds); err != nil {
+	if err := setPartialOrderState(d, "archive_ids", order.Data.Attributes.ArchiveIds); err != nil {
 		return diag.FromErr(err)
 	}
 	return nil
@@ -95,6 +107,17 @@ func resourceDatadogLogsArchiveOrderUpdate(ctx context.Context, d *schema.Resour
 	providerConf := meta.(*ProviderConfiguration)
 	apiInstances := providerConf.DatadogApiInstances
 	auth := providerConf.Auth
+	if d.Get("partial").(bool) {
+		currentOrder, httpResponse, err := apiInstances.GetLogsArchivesApiV2().GetLogsArchiveOrder(auth)
+		if err != nil {
+			return utils.TranslateClientErrorDiag(err, httpResponse, "error getting logs archive order")
+		}
+		merged, err := utils.MergePartialOrder(currentOrder.Data.Attributes.ArchiveIds, ddArchiveList.Data.Attributes.GetArchiveIds(), d.Get("after").(string))
+		if err != nil {
+			return diag.Errorf("error merging logs archive order: %s", err)
+		}
+		ddArchiveList.Data.Attributes.SetArchiveIds(merged)
+	}
 	updatedOrder, httpResponse, err := apiInstances.GetLogsArchivesApiV2().UpdateLogsArchiveOrder(auth, *ddArchiveList)
 	if err != nil {
 		// Cannot map archives to existing ones
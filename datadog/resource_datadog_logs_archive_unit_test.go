@@ -0,0 +1,20 @@
+package datadog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogsArchiveLocation(t *testing.T) {
+	cases := map[string]map[string]interface{}{
+		"s3://bucket":                   {"s3_archive": []interface{}{map[string]interface{}{"bucket": "bucket", "path": ""}}},
+		"gs://bucket/logs/prod":         {"gcs_archive": []interface{}{map[string]interface{}{"bucket": "bucket", "path": "/logs/prod/"}}},
+		"azure://account/container/dir": {"azure_archive": []interface{}{map[string]interface{}{"storage_account": "account", "container": "container", "path": "dir"}}},
+		"(none)":                        {},
+	}
+	for expected, attributes := range cases {
+		get := func(key string) interface{} { return attributes[key] }
+		assert.Equal(t, expected, logsArchiveLocation(get))
+	}
+}
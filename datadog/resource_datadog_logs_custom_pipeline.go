@@ -7,10 +7,12 @@ import (
 	"strings"
 	"sync"
 
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/grok"
 	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/utils"
 	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/validators"
 
 	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV1"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -500,7 +502,7 @@ var grokParser = &schema.Schema{
 			"is_enabled": {Description: "If the processor is enabled or not.", Type: schema.TypeBool, Optional: true},
 			"source":     {Description: "Name of the log attribute to parse.", Type: schema.TypeString, Required: true},
 			"samples": {
-				Description: "List of sample logs for this parser. It can save up to 5 samples. Each sample takes up to 5000 characters.",
+				Description: "List of sample logs for this parser. It can save up to 5 samples. Each sample takes up to 5000 characters. Samples matched by none of the match rules are reported as warnings during plan.",
 				Type:        schema.TypeList,
 				Optional:    true,
 				Elem: &schema.Schema{
@@ -875,9 +877,93 @@ func resourceDatadogLogsCustomPipeline() *schema.Resource {
 		SchemaFunc: func() map[string]*schema.Schema {
 			return getPipelineSchema(false)
 		},
+		ValidateRawResourceConfigFuncs: []schema.ValidateRawResourceConfigFunc{validateGrokParsersConfig},
 	}
 }
 
+// validateGrokParsersConfig checks that the rules of the grok parsers
+// compile. Samples matched by none of the match rules are only reported as
+// warnings, as the API accepts them.
+func validateGrokParsersConfig(_ context.Context, request schema.ValidateResourceConfigFuncRequest, response *schema.ValidateResourceConfigFuncResponse) {
+	response.Diagnostics = append(response.Diagnostics, validateGrokParsers(request.RawConfig, "")...)
+}
+
+func validateGrokParsers(pipeline cty.Value, prefix string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for i, processor := range ctyBlocks(pipeline, "processor") {
+		processorPath := fmt.Sprintf("%sprocessor.%d", prefix, i)
+		for _, nested := range ctyBlocks(processor, tfNestedPipelineProcessor) {
+			diags = append(diags, validateGrokParsers(nested, processorPath+"."+tfNestedPipelineProcessor+".0.")...)
+		}
+		for _, parser := range ctyBlocks(processor, tfGrokParserProcessor) {
+			diags = append(diags, validateGrokParser(parser, fmt.Sprintf("%s.%s.0", processorPath, tfGrokParserProcessor))...)
+		}
+	}
+	return diags
+}
+
+func validateGrokParser(parser cty.Value, parserPath string) diag.Diagnostics {
+	rules := ctyBlocks(parser, "grok")
+	if len(rules) == 0 {
+		return nil
+	}
+	matchRules, ok := ctyKnownString(rules[0], "match_rules")
+	if !ok {
+		return nil
+	}
+	supportRules, ok := ctyKnownString(rules[0], "support_rules")
+	if !ok {
+		return nil
+	}
+	compiled, err := grok.Compile(matchRules, supportRules)
+	if err != nil {
+		return diag.Errorf("%s: %s", parserPath, err)
+	}
+	// Samples can only be reported as unmatched when every rule could be
+	// evaluated locally.
+	if !compiled.Complete() {
+		return nil
+	}
+
+	var diags diag.Diagnostics
+	for i, sample := range ctyBlocks(parser, "samples") {
+		if !sample.IsKnown() || sample.IsNull() {
+			continue
+		}
+		if _, _, matched := compiled.Parse(sample.AsString()); !matched {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("%s: samples.%d is not matched by any match rule: %q", parserPath, i, sample.AsString()),
+			})
+		}
+	}
+	return diags
+}
+
+// ctyBlocks returns the elements of a list attribute of an object, ignoring
+// null and unknown values.
+func ctyBlocks(value cty.Value, attr string) []cty.Value {
+	if !value.IsKnown() || value.IsNull() || !value.Type().IsObjectType() || !value.Type().HasAttribute(attr) {
+		return nil
+	}
+	list := value.GetAttr(attr)
+	if !list.IsKnown() || list.IsNull() || !list.CanIterateElements() {
+		return nil
+	}
+	return list.AsValueSlice()
+}
+
+func ctyKnownString(value cty.Value, attr string) (string, bool) {
+	if !value.Type().IsObjectType() || !value.Type().HasAttribute(attr) {
+		return "", false
+	}
+	s := value.GetAttr(attr)
+	if !s.IsKnown() || s.IsNull() || s.Type() != cty.String {
+		return "", false
+	}
+	return s.AsString(), true
+}
+
 func resourceDatadogLogsPipelineCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	providerConf := meta.(*ProviderConfiguration)
 	apiInstances := providerConf.DatadogApiInstances
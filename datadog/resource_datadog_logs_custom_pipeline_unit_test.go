@@ -0,0 +1,102 @@
+package datadog
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/stretchr/testify/assert"
+)
+
+func grokParserConfig(matchRules, supportRules string, samples ...string) cty.Value {
+	sampleValues := cty.ListValEmpty(cty.String)
+	if len(samples) > 0 {
+		values := make([]cty.Value, 0, len(samples))
+		for _, sample := range samples {
+			values = append(values, cty.StringVal(sample))
+		}
+		sampleValues = cty.ListVal(values)
+	}
+	return cty.ObjectVal(map[string]cty.Value{
+		"grok_parser": cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+			"samples": sampleValues,
+			"grok": cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+				"match_rules":   cty.StringVal(matchRules),
+				"support_rules": cty.StringVal(supportRules),
+			})}),
+		})}),
+	})
+}
+
+func TestValidateGrokParsers(t *testing.T) {
+	tests := []struct {
+		name       string
+		processors []cty.Value
+		wantErrs   []string
+		wantWarns  []string
+	}{
+		{
+			name:       "matching samples",
+			processors: []cty.Value{grokParserConfig(`rule %{word:user} %{integer:code}`, "", "john 200", "jane 404")},
+		},
+		{
+			name:       "unmatched sample",
+			processors: []cty.Value{grokParserConfig(`rule %{word:user} %{integer:code}`, "", "john 200", "john doe")},
+			wantWarns:  []string{`processor.0.grok_parser.0: samples.1 is not matched by any match rule: "john doe"`},
+		},
+		{
+			name:       "undefined support rule",
+			processors: []cty.Value{grokParserConfig(`rule %{_user}`, `_other %{word}`, "john")},
+			wantErrs:   []string{`processor.0.grok_parser.0: match rule "rule": undefined support rule or matcher "_user"`},
+		},
+		{
+			name:       "unsupported regular expression skips samples",
+			processors: []cty.Value{grokParserConfig(`rule (?<=a)%{word}`, "", "john doe")},
+		},
+		{
+			name: "nested pipeline",
+			processors: []cty.Value{
+				grokParserConfig(`rule %{word}`, "", "john"),
+				cty.ObjectVal(map[string]cty.Value{
+					"pipeline": cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+						"processor": cty.ListVal([]cty.Value{grokParserConfig(`rule %{integer}`, "", "john")}),
+					})}),
+				}),
+			},
+			wantWarns: []string{`processor.1.pipeline.0.processor.0.grok_parser.0: samples.0 is not matched by any match rule: "john"`},
+		},
+		{
+			name: "unknown rules",
+			processors: []cty.Value{cty.ObjectVal(map[string]cty.Value{
+				"grok_parser": cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+					"samples": cty.ListVal([]cty.Value{cty.StringVal("john")}),
+					"grok": cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+						"match_rules":   cty.UnknownVal(cty.String),
+						"support_rules": cty.StringVal(""),
+					})}),
+				})}),
+			})},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := cty.ObjectVal(map[string]cty.Value{"processor": cty.TupleVal(tt.processors)})
+			var errs, warns []string
+			for _, d := range validateGrokParsers(config, "") {
+				if d.Severity == diag.Error {
+					errs = append(errs, d.Summary)
+				} else {
+					warns = append(warns, d.Summary)
+				}
+			}
+			assert.Equal(t, len(tt.wantErrs), len(errs), errs)
+			for i, want := range tt.wantErrs {
+				if i < len(errs) {
+					assert.Contains(t, errs[i], want)
+				}
+			}
+			assert.Equal(t, tt.wantWarns, warns)
+		})
+	}
+}
@@ -2,20 +2,21 @@ package datadog
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"regexp"
-	"sync"
+	"strings"
 
 	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/utils"
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/validators"
 
 	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV1"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
-var logsIndexMutex = sync.Mutex{}
-
 var indexSchema = map[string]*schema.Schema{
 	"name": {
 		Description: "The name of the index. Index names cannot be modified after creation. If this value is changed, a new index will be created.",
@@ -92,9 +93,10 @@ var indexSchema = map[string]*schema.Schema{
 		Elem: &schema.Resource{
 			Schema: map[string]*schema.Schema{
 				"query": {
-					Description: "Logs filter criteria. Only logs matching this filter criteria are considered for this index.",
-					Type:        schema.TypeString,
-					Required:    true,
+					Description:      "Logs filter criteria. Only logs matching this filter criteria are considered for this index.",
+					Type:             schema.TypeString,
+					Required:         true,
+					ValidateDiagFunc: validators.ValidateSearchQuery,
 				},
 			},
 		},
@@ -107,6 +109,36 @@ var indexSchema = map[string]*schema.Schema{
 			Schema: exclusionFilterSchema,
 		},
 	},
+	"ignore_unmanaged_exclusion_filters": {
+		Description: "If true, exclusion filters of the index which are not listed in `exclusion_filter`, such as the ones managed with `datadog_logs_index_exclusion_filter` resources, are ignored and preserved on update. The listed exclusion filters are identified by their name, which must be set and unique.",
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+	},
+	"guard_destructive_changes": {
+		Description: "If true, changes which can irreversibly drop logs or sharply increase costs fail at plan time unless acknowledged: decreasing `retention_days` or `flex_retention_days` requires `allow_retention_decrease`, changing `filter.query` requires `allow_filter_change`, and deleting or replacing the index requires `allow_deletion` to be set to true beforehand. Acknowledged changes produce no warning: the plan shows them like any other change, with their old and new values.",
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+	},
+	"allow_retention_decrease": {
+		Description: "Acknowledges decreases of `retention_days` or `flex_retention_days` when `guard_destructive_changes` is true.",
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+	},
+	"allow_filter_change": {
+		Description: "Acknowledges changes of `filter.query` when `guard_destructive_changes` is true.",
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+	},
+	"allow_deletion": {
+		Description: "Allows the index to be deleted or replaced when `guard_destructive_changes` is true. It must be applied before the index is deleted.",
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+	},
 	"tags": {
 		Description: "A list of tags for this index. Tags must be in `key:value` format. If default tags are present at the provider level, they will be added to this resource.",
 		Type:        schema.TypeSet,
@@ -133,9 +165,10 @@ var exclusionFilterSchema = map[string]*schema.Schema{
 		Elem: &schema.Resource{
 			Schema: map[string]*schema.Schema{
 				"query": {
-					Description: "Only logs matching the filter criteria and the query of the parent index will be considered for this exclusion filter.",
-					Type:        schema.TypeString,
-					Optional:    true,
+					Description:      "Only logs matching the filter criteria and the query of the parent index will be considered for this exclusion filter.",
+					Type:             schema.TypeString,
+					Optional:         true,
+					ValidateDiagFunc: validators.ValidateSearchQuery,
 				},
 				"sample_attribute": {
 					Description: "The log attribute used as the sampling key. When present, logs sharing the same value are excluded or kept together at the configured sample rate (a single attribute path, e.g. `@lambda.request_id`).",
@@ -143,9 +176,10 @@ var exclusionFilterSchema = map[string]*schema.Schema{
 					Optional:    true,
 				},
 				"sample_rate": {
-					Description: "The fraction of logs excluded by the exclusion filter, when active.",
-					Type:        schema.TypeFloat,
-					Optional:    true,
+					Description:  "The fraction of logs excluded by the exclusion filter, when active.",
+					Type:         schema.TypeFloat,
+					Optional:     true,
+					ValidateFunc: validation.FloatBetween(0, 1),
 				},
 			},
 		},
@@ -161,6 +195,39 @@ func suppressDiffWhenDisabledDailyLimit(_, old, new string, d *schema.ResourceDa
 	return false
 }
 
+// logsIndexGuardDiff fails the plan of an existing index when
+// `guard_destructive_changes` is set and it contains changes which were not
+// acknowledged with the matching `allow_*` attribute.
+func logsIndexGuardDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" || !d.Get("guard_destructive_changes").(bool) {
+		return nil
+	}
+	var changes []string
+	if !d.Get("allow_retention_decrease").(bool) {
+		for _, key := range []string{"retention_days", "flex_retention_days"} {
+			if old, new := d.GetChange(key); d.NewValueKnown(key) && new.(int) < old.(int) {
+				changes = append(changes, fmt.Sprintf("%s %d→%d days (set `allow_retention_decrease`)", key, old, new))
+			}
+		}
+	}
+	if !d.Get("allow_filter_change").(bool) && d.HasChange("filter.0.query") {
+		old, new := d.GetChange("filter.0.query")
+		newQuery := fmt.Sprintf("%q", new)
+		if !d.NewValueKnown("filter.0.query") {
+			newQuery = "(known after apply)"
+		}
+		changes = append(changes, fmt.Sprintf("filter.query %q→%s (set `allow_filter_change`)", old, newQuery))
+	}
+	if !d.Get("allow_deletion").(bool) && d.HasChange("name") {
+		old, new := d.GetChange("name")
+		changes = append(changes, fmt.Sprintf("name %q→%q replaces the index and deletes its logs (set `allow_deletion`)", old, new))
+	}
+	if len(changes) > 0 {
+		return fmt.Errorf("logs index %q has `guard_destructive_changes` enabled, the following changes must be acknowledged: %s", d.Id(), strings.Join(changes, "; "))
+	}
+	return nil
+}
+
 func resourceDatadogLogsIndex() *schema.Resource {
 	return &schema.Resource{
 		Description:   "Provides a Datadog Logs Index API resource. This can be used to create and manage Datadog logs indexes.",
@@ -168,7 +235,7 @@ func resourceDatadogLogsIndex() *schema.Resource {
 		UpdateContext: resourceDatadogLogsIndexUpdate,
 		ReadContext:   resourceDatadogLogsIndexRead,
 		DeleteContext: resourceDatadogLogsIndexDelete,
-		CustomizeDiff: tagDiff,
+		CustomizeDiff: customdiff.All(tagDiff, logsIndexGuardDiff),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -184,8 +251,8 @@ func resourceDatadogLogsIndexCreate(ctx context.Context, d *schema.ResourceData,
 	apiInstances := providerConf.DatadogApiInstances
 	auth := providerConf.Auth
 
-	logsIndexMutex.Lock()
-	defer logsIndexMutex.Unlock()
+	utils.LogsIndexMutex.Lock()
+	defer utils.LogsIndexMutex.Unlock()
 
 	ddIndex := buildDatadogIndexCreateRequest(d)
 	createdIndex, httpResponse, err := apiInstances.GetLogsIndexesApiV1().CreateLogsIndex(auth, *ddIndex)
@@ -231,7 +298,11 @@ func updateLogsIndexState(d *schema.ResourceData, index *datadogV1.LogsIndex) di
 	if err := d.Set("filter", buildTerraformIndexFilter(index.GetFilter())); err != nil {
 		return diag.FromErr(err)
 	}
-	if err := d.Set("exclusion_filter", buildTerraformExclusionFilters(index.GetExclusionFilters())); err != nil {
+	exclusionFilters := index.GetExclusionFilters()
+	if d.Get("ignore_unmanaged_exclusion_filters").(bool) {
+		exclusionFilters = managedExclusionFilters(exclusionFilters, d.Get("exclusion_filter").([]interface{}))
+	}
+	if err := d.Set("exclusion_filter", buildTerraformExclusionFilters(exclusionFilters)); err != nil {
 		return diag.FromErr(err)
 	}
 	if err := d.Set("tags", index.GetTags()); err != nil {
@@ -264,11 +335,29 @@ func resourceDatadogLogsIndexUpdate(ctx context.Context, d *schema.ResourceData,
 	apiInstances := providerConf.DatadogApiInstances
 	auth := providerConf.Auth
 
-	logsIndexMutex.Lock()
-	defer logsIndexMutex.Unlock()
+	utils.LogsIndexMutex.Lock()
+	defer utils.LogsIndexMutex.Unlock()
 
 	ddIndex := buildDatadogIndexUpdateRequest(d)
 	tfName := d.Get("name").(string)
+	if d.Get("ignore_unmanaged_exclusion_filters").(bool) {
+		currentIndex, httpResponse, err := apiInstances.GetLogsIndexesApiV1().GetLogsIndex(auth, tfName)
+		if err != nil {
+			return utils.TranslateClientErrorDiag(err, httpResponse, "error getting logs index")
+		}
+		// Filters removed from the configuration are only deleted when they
+		// were already managed by this resource.
+		var previous []interface{}
+		if wasIgnoring, _ := d.GetChange("ignore_unmanaged_exclusion_filters"); wasIgnoring.(bool) {
+			oldFilters, _ := d.GetChange("exclusion_filter")
+			previous = oldFilters.([]interface{})
+		}
+		exclusionFilters, err := mergeUnmanagedExclusionFilters(currentIndex.GetExclusionFilters(), ddIndex.ExclusionFilters, previous)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		ddIndex.ExclusionFilters = exclusionFilters
+	}
 	updatedIndex, httpResponse, err := apiInstances.GetLogsIndexesApiV1().UpdateLogsIndex(auth, tfName, *ddIndex)
 	if err != nil {
 		return utils.TranslateClientErrorDiag(err, httpResponse, "error updating logs index")
@@ -284,8 +373,12 @@ func resourceDatadogLogsIndexDelete(ctx context.Context, d *schema.ResourceData,
 	apiInstances := providerConf.DatadogApiInstances
 	auth := providerConf.Auth
 
-	logsIndexMutex.Lock()
-	defer logsIndexMutex.Unlock()
+	if d.Get("guard_destructive_changes").(bool) && !d.Get("allow_deletion").(bool) {
+		return diag.Errorf("logs index %q has `guard_destructive_changes` enabled, set `allow_deletion` to true and apply it before deleting the index", d.Id())
+	}
+
+	utils.LogsIndexMutex.Lock()
+	defer utils.LogsIndexMutex.Unlock()
 	httpResponse, err := apiInstances.GetLogsIndexesApiV1().DeleteLogsIndex(auth, d.Id())
 	if err != nil {
 		return utils.TranslateClientErrorDiag(err, httpResponse, "error deleting logs index")
@@ -453,3 +546,65 @@ func buildTerraformExclusionFilter(ddEFilter datadogV1.LogsExclusion) *map[strin
 	tfEFilter["is_enabled"] = ddEFilter.GetIsEnabled()
 	return &tfEFilter
 }
+
+func exclusionFilterNames(tfEFilters []interface{}) map[string]bool {
+	names := make(map[string]bool, len(tfEFilters))
+	for _, tfEFilter := range tfEFilters {
+		if v, ok := tfEFilter.(map[string]interface{}); ok {
+			names[v["name"].(string)] = true
+		}
+	}
+	return names
+}
+
+// managedExclusionFilters returns the exclusion filters listed in the
+// configuration, in the order of the index.
+func managedExclusionFilters(ddEFilters []datadogV1.LogsExclusion, tfEFilters []interface{}) []datadogV1.LogsExclusion {
+	names := exclusionFilterNames(tfEFilters)
+	managed := make([]datadogV1.LogsExclusion, 0, len(tfEFilters))
+	for _, ddEFilter := range ddEFilters {
+		if names[ddEFilter.GetName()] {
+			managed = append(managed, ddEFilter)
+		}
+	}
+	return managed
+}
+
+// mergeUnmanagedExclusionFilters places the configured exclusion filters at
+// the position of the first of them in the index, or at the end, and keeps
+// the other filters of the index except the previously managed ones.
+func mergeUnmanagedExclusionFilters(current, configured []datadogV1.LogsExclusion, previous []interface{}) ([]datadogV1.LogsExclusion, error) {
+	configuredNames := make(map[string]bool, len(configured))
+	for _, ddEFilter := range configured {
+		name := ddEFilter.GetName()
+		if name == "" {
+			return nil, fmt.Errorf("exclusion filters must have a name when `ignore_unmanaged_exclusion_filters` is true")
+		}
+		if configuredNames[name] {
+			return nil, fmt.Errorf("exclusion filter names must be unique when `ignore_unmanaged_exclusion_filters` is true, %q is used more than once", name)
+		}
+		configuredNames[name] = true
+	}
+	previousNames := exclusionFilterNames(previous)
+
+	merged := make([]datadogV1.LogsExclusion, 0, len(current)+len(configured))
+	inserted := false
+	for _, ddEFilter := range current {
+		name := ddEFilter.GetName()
+		if configuredNames[name] {
+			if !inserted {
+				merged = append(merged, configured...)
+				inserted = true
+			}
+			continue
+		}
+		if previousNames[name] {
+			continue
+		}
+		merged = append(merged, ddEFilter)
+	}
+	if !inserted {
+		merged = append(merged, configured...)
+	}
+	return merged, nil
+}
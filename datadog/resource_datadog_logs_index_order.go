@@ -17,6 +17,7 @@ func resourceDatadogLogsIndexOrder() *schema.Resource {
 		UpdateContext: resourceDatadogLogsIndexOrderUpdate,
 		ReadContext:   resourceDatadogLogsIndexOrderRead,
 		DeleteContext: resourceDatadogLogsIndexOrderDelete,
+		CustomizeDiff: partialOrderDiff,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -34,6 +35,17 @@ func resourceDatadogLogsIndexOrder() *schema.Resource {
 					Required:    true,
 					Elem:        &schema.Schema{Type: schema.TypeString},
 				},
+				"partial": {
+					Description: utils.PartialOrderDescription("indexes", "indexes"),
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     false,
+				},
+				"after": {
+					Description: utils.PartialOrderAfterDescription("Name of the index", "indexes"),
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
 			}
 		},
 	}
@@ -50,7 +62,6 @@ func resourceDatadogLogsIndexOrderUpdate(ctx context.Context, d *schema.Resource
 	for i, tfName := range tfList {
 		ddList[i] = tfName.(string)
 	}
-	ddIndexList.IndexNames = ddList
 	var tfID = "logs_index_order"
 	if name, exists := d.GetOk("name"); exists {
 		tfID = name.(string)
@@ -58,6 +69,17 @@ func resourceDatadogLogsIndexOrderUpdate(ctx context.Context, d *schema.Resource
 	providerConf := meta.(*ProviderConfiguration)
 	apiInstances := providerConf.DatadogApiInstances
 	auth := providerConf.Auth
+	if d.Get("partial").(bool) {
+		currentOrder, httpResponse, err := apiInstances.GetLogsIndexesApiV1().GetLogsIndexOrder(auth)
+		if err != nil {
+			return utils.TranslateClientErrorDiag(err, httpResponse, "error getting logs index list")
+		}
+		ddList, err = utils.MergePartialOrder(currentOrder.GetIndexNames(), ddList, d.Get("after").(string))
+		if err != nil {
+			return diag.Errorf("error merging logs index order: %s", err)
+		}
+	}
+	ddIndexList.IndexNames = ddList
 
 	updatedOrder, httpResponse, err := apiInstances.GetLogsIndexesApiV1().UpdateLogsIndexOrder(auth, ddIndexList)
 	if err != nil {
@@ -71,7 +93,7 @@ func resourceDatadogLogsIndexOrderUpdate(ctx context.Context, d *schema.Resource
 }
 
 func updateLogsIndexOrderState(d *schema.ResourceData, order *datadogV1.LogsIndexesOrder) diag.Diagnostics {
-	if err := d.Set("indexes", order.GetIndexNames()); err != nil {
+	if err := setPartialOrderState(d, "indexes", order.GetIndexNames()); err != nil {
 		return diag.FromErr(err)
 	}
 	if _, ok := d.GetOk("name"); !ok {
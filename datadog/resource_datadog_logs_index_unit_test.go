@@ -0,0 +1,161 @@
+package datadog
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV1"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func exclusionFilter(name string, sampleRate float64) datadogV1.LogsExclusion {
+	filter := datadogV1.NewLogsExclusion(name)
+	filter.SetFilter(*datadogV1.NewLogsExclusionFilter(sampleRate))
+	return *filter
+}
+
+func exclusionFilterNamesOf(filters []datadogV1.LogsExclusion) []string {
+	names := make([]string, len(filters))
+	for i, filter := range filters {
+		names[i] = filter.GetName()
+	}
+	return names
+}
+
+func TestMergeUnmanagedExclusionFilters(t *testing.T) {
+	current := []datadogV1.LogsExclusion{
+		exclusionFilter("team-a", 1),
+		exclusionFilter("platform-1", 0.5),
+		exclusionFilter("team-b", 1),
+		exclusionFilter("platform-2", 0.5),
+		exclusionFilter("platform-old", 0.5),
+	}
+	previous := []interface{}{
+		map[string]interface{}{"name": "platform-1"},
+		map[string]interface{}{"name": "platform-2"},
+		map[string]interface{}{"name": "platform-old"},
+	}
+
+	cases := map[string]struct {
+		configured []datadogV1.LogsExclusion
+		previous   []interface{}
+		expected   []string
+		err        string
+	}{
+		"update, reorder and remove managed filters": {
+			configured: []datadogV1.LogsExclusion{exclusionFilter("platform-2", 0.1), exclusionFilter("platform-1", 0.2)},
+			previous:   previous,
+			expected:   []string{"team-a", "platform-2", "platform-1", "team-b"},
+		},
+		"filters not managed before are kept": {
+			configured: []datadogV1.LogsExclusion{exclusionFilter("platform-1", 0.2)},
+			expected:   []string{"team-a", "platform-1", "team-b", "platform-2", "platform-old"},
+		},
+		"unmanaged filters keep their position": {
+			configured: []datadogV1.LogsExclusion{exclusionFilter("platform-1", 0.2), exclusionFilter("platform-2", 0.2), exclusionFilter("platform-old", 0.2)},
+			previous:   previous,
+			expected:   []string{"team-a", "platform-1", "platform-2", "platform-old", "team-b"},
+		},
+		"new filters are added at the end": {
+			configured: []datadogV1.LogsExclusion{exclusionFilter("platform-new", 0.2)},
+			previous:   previous,
+			expected:   []string{"team-a", "team-b", "platform-new"},
+		},
+		"missing name": {
+			configured: []datadogV1.LogsExclusion{exclusionFilter("", 0.2)},
+			err:        "exclusion filters must have a name when `ignore_unmanaged_exclusion_filters` is true",
+		},
+		"duplicate name": {
+			configured: []datadogV1.LogsExclusion{exclusionFilter("a", 0.2), exclusionFilter("a", 0.3)},
+			err:        `exclusion filter names must be unique when ` + "`ignore_unmanaged_exclusion_filters`" + ` is true, "a" is used more than once`,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			merged, err := mergeUnmanagedExclusionFilters(current, tc.configured, tc.previous)
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, exclusionFilterNamesOf(merged))
+			for _, filter := range merged {
+				if filter.GetName() == "platform-1" && len(tc.configured) > 0 {
+					assert.Equal(t, 0.2, filter.Filter.GetSampleRate())
+				}
+			}
+		})
+	}
+}
+
+func TestManagedExclusionFilters(t *testing.T) {
+	current := []datadogV1.LogsExclusion{exclusionFilter("b", 1), exclusionFilter("other", 1), exclusionFilter("a", 1)}
+	configured := []interface{}{
+		map[string]interface{}{"name": "a"},
+		map[string]interface{}{"name": "b"},
+	}
+	assert.Equal(t, []string{"b", "a"}, exclusionFilterNamesOf(managedExclusionFilters(current, configured)))
+}
+
+func TestLogsIndexGuardDiff(t *testing.T) {
+	state := &terraform.InstanceState{
+		ID: "main",
+		Attributes: map[string]string{
+			"id":                        "main",
+			"name":                      "main",
+			"retention_days":            "30",
+			"flex_retention_days":       "90",
+			"filter.#":                  "1",
+			"filter.0.query":            "*",
+			"guard_destructive_changes": "true",
+		},
+	}
+	config := func(extra map[string]interface{}) *terraform.ResourceConfig {
+		raw := map[string]interface{}{
+			"name":                      "main",
+			"retention_days":            30,
+			"flex_retention_days":       90,
+			"filter":                    []interface{}{map[string]interface{}{"query": "*"}},
+			"guard_destructive_changes": true,
+		}
+		for k, v := range extra {
+			raw[k] = v
+		}
+		return terraform.NewResourceConfigRaw(raw)
+	}
+
+	cases := map[string]struct {
+		config map[string]interface{}
+		err    string
+	}{
+		"retention increase": {config: map[string]interface{}{"retention_days": 45}},
+		"retention decrease": {
+			config: map[string]interface{}{"retention_days": 7, "flex_retention_days": 30},
+			err:    `logs index "main" has ` + "`guard_destructive_changes`" + ` enabled, the following changes must be acknowledged: retention_days 30→7 days (set ` + "`allow_retention_decrease`" + `); flex_retention_days 90→30 days (set ` + "`allow_retention_decrease`" + `)`,
+		},
+		"acknowledged retention decrease": {config: map[string]interface{}{"retention_days": 7, "allow_retention_decrease": true}},
+		"filter change": {
+			config: map[string]interface{}{"filter": []interface{}{map[string]interface{}{"query": "service:web"}}},
+			err:    `filter.query "*"→"service:web" (set ` + "`allow_filter_change`" + `)`,
+		},
+		"acknowledged filter change": {config: map[string]interface{}{"filter": []interface{}{map[string]interface{}{"query": "service:web"}}, "allow_filter_change": true}},
+		"replacement": {
+			config: map[string]interface{}{"name": "other"},
+			err:    `name "main"→"other" replaces the index and deletes its logs`,
+		},
+		"guard disabled": {config: map[string]interface{}{"retention_days": 7, "guard_destructive_changes": false}},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := resourceDatadogLogsIndex().Diff(context.Background(), state, config(tc.config), &ProviderConfiguration{})
+			if tc.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.err)
+		})
+	}
+}
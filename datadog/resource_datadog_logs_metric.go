@@ -66,9 +66,10 @@ func resourceDatadogLogsMetric() *schema.Resource {
 						Schema: map[string]*schema.Schema{
 
 							"query": {
-								Type:        schema.TypeString,
-								Required:    true,
-								Description: "The search query - following the log search syntax.",
+								Type:             schema.TypeString,
+								Required:         true,
+								Description:      "The search query - following the log search syntax.",
+								ValidateDiagFunc: validators.ValidateSearchQuery,
 							},
 						},
 					},
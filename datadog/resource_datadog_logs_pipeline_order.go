@@ -2,6 +2,7 @@ package datadog
 
 import (
 	"context"
+	"fmt"
 	"strings"
 
 	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/utils"
@@ -18,6 +19,7 @@ func resourceDatadogLogsPipelineOrder() *schema.Resource {
 		UpdateContext: resourceDatadogLogsPipelineOrderUpdate,
 		ReadContext:   resourceDatadogLogsPipelineOrderRead,
 		DeleteContext: resourceDatadogLogsPipelineOrderDelete,
+		CustomizeDiff: partialOrderDiff,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -34,6 +36,17 @@ func resourceDatadogLogsPipelineOrder() *schema.Resource {
 					Required:    true,
 					Elem:        &schema.Schema{Type: schema.TypeString},
 				},
+				"partial": {
+					Description: utils.PartialOrderDescription("pipelines", "pipelines"),
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     false,
+				},
+				"after": {
+					Description: utils.PartialOrderAfterDescription("ID of the pipeline", "pipelines"),
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
 			}
 		},
 	}
@@ -44,7 +57,7 @@ func resourceDatadogLogsPipelineOrderCreate(ctx context.Context, d *schema.Resou
 }
 
 func updateLogsPipelineOrderState(d *schema.ResourceData, order *datadogV1.LogsPipelinesOrder) diag.Diagnostics {
-	if err := d.Set("pipelines", order.PipelineIds); err != nil {
+	if err := setPartialOrderState(d, "pipelines", order.PipelineIds); err != nil {
 		return diag.FromErr(err)
 	}
 	return nil
@@ -73,7 +86,6 @@ func resourceDatadogLogsPipelineOrderUpdate(ctx context.Context, d *schema.Resou
 	for i, id := range tfList {
 		ddList[i] = id.(string)
 	}
-	ddPipelineList.PipelineIds = ddList
 	var tfID string
 	if name, exists := d.GetOk("name"); exists {
 		tfID = name.(string)
@@ -81,6 +93,18 @@ func resourceDatadogLogsPipelineOrderUpdate(ctx context.Context, d *schema.Resou
 	providerConf := meta.(*ProviderConfiguration)
 	apiInstances := providerConf.DatadogApiInstances
 	auth := providerConf.Auth
+	if d.Get("partial").(bool) {
+		currentOrder, httpResponse, err := apiInstances.GetLogsPipelinesApiV1().
+			GetLogsPipelineOrder(auth)
+		if err != nil {
+			return utils.TranslateClientErrorDiag(err, httpResponse, "error getting logs pipeline order")
+		}
+		ddList, err = utils.MergePartialOrder(currentOrder.PipelineIds, ddList, d.Get("after").(string))
+		if err != nil {
+			return diag.Errorf("error merging logs pipeline order: %s", err)
+		}
+	}
+	ddPipelineList.PipelineIds = ddList
 	updatedOrder, httpResponse, err := apiInstances.GetLogsPipelinesApiV1().
 		UpdateLogsPipelineOrder(auth, ddPipelineList)
 	if err != nil {
@@ -110,3 +134,28 @@ func resourceDatadogLogsPipelineOrderDelete(_ context.Context, _ *schema.Resourc
 
 	return nil
 }
+
+// partialOrderDiff rejects `after` outside of the partial mode of the order
+// resources.
+func partialOrderDiff(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	if diff.Get("after").(string) != "" && !diff.Get("partial").(bool) {
+		return fmt.Errorf("`after` can only be set when `partial` is `true`")
+	}
+	return nil
+}
+
+// setPartialOrderState sets the order of an order resource. In partial mode,
+// only the managed IDs and the IDs splitting them are kept, and `after`, when set, is updated to the ID
+// placed before them so that moves of the block are reported as drift.
+func setPartialOrderState(d *schema.ResourceData, key string, order []string) error {
+	if !d.Get("partial").(bool) {
+		return d.Set(key, order)
+	}
+	managed, predecessor := utils.ManagedOrder(order, utils.GetStringSlice(d, key))
+	if _, ok := d.GetOk("after"); ok {
+		if err := d.Set("after", predecessor); err != nil {
+			return err
+		}
+	}
+	return d.Set(key, managed)
+}
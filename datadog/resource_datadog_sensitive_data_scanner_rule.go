@@ -3,6 +3,7 @@ package datadog
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV2"
 	"github.com/hashicorp/go-cty/cty"
@@ -10,6 +11,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/sds"
 	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/utils"
 	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/validators"
 )
@@ -69,9 +71,10 @@ func resourceDatadogSensitiveDataScannerRule() *schema.Resource {
 					Description: "Whether or not the rule is enabled.",
 				},
 				"pattern": {
-					Type:        schema.TypeString,
-					Optional:    true,
-					Description: "Not included if there is a relationship to a standard pattern.",
+					Type:             schema.TypeString,
+					Optional:         true,
+					Description:      "Not included if there is a relationship to a standard pattern. The pattern must be a valid RE2 regular expression: lookaround assertions and backreferences are not supported.",
+					ValidateDiagFunc: validators.ValidateSDSPattern,
 				},
 				"tags": {
 					Type:        schema.TypeList,
@@ -177,12 +180,38 @@ func resourceDatadogSensitiveDataScannerRule() *schema.Resource {
 						},
 					},
 				},
+				"test_samples": {
+					Type:        schema.TypeList,
+					Optional:    true,
+					MaxItems:    1,
+					Description: "Samples to test the rule against at plan time. The samples are only stored in the Terraform state. They are evaluated locally with the `pattern`, the `included_keyword_configuration` and the `suppressions` of the rule, so they can't be used with `standard_pattern_id`.",
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"match": {
+								Type:        schema.TypeList,
+								Optional:    true,
+								Elem:        &schema.Schema{Type: schema.TypeString},
+								Description: "Samples the rule must match.",
+							},
+							"no_match": {
+								Type:        schema.TypeList,
+								Optional:    true,
+								Elem:        &schema.Schema{Type: schema.TypeString},
+								Description: "Samples the rule must not match.",
+							},
+						},
+					},
+				},
 			}
 		},
 	}
 }
 
 func resourceDatadogSensitiveDataScannerRuleCustomizeDiff(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	if err := testSensitiveDataScannerRuleSamples(diff); err != nil {
+		return err
+	}
+
 	if _, ok := diff.GetOk("text_replacement"); ok {
 		// Only allow should_save_match when type == "replacement_string"
 		if typeValRaw, ok := diff.GetOk("text_replacement.0.type"); ok {
@@ -212,6 +241,46 @@ func resourceDatadogSensitiveDataScannerRuleCustomizeDiff(_ context.Context, dif
 	return diff.Clear("description")
 }
 
+// testSensitiveDataScannerRuleSamples evaluates the test samples of a rule
+// with its pattern, keywords and suppressions, once they are all known.
+func testSensitiveDataScannerRuleSamples(diff *schema.ResourceDiff) error {
+	if _, ok := diff.GetOk("test_samples"); !ok {
+		return nil
+	}
+	for _, key := range []string{"pattern", "standard_pattern_id", "included_keyword_configuration", "suppressions", "test_samples"} {
+		if !diff.NewValueKnown(key) {
+			return nil
+		}
+	}
+	if _, ok := diff.GetOk("standard_pattern_id"); ok {
+		return fmt.Errorf("test_samples can't be used with standard_pattern_id")
+	}
+
+	rule := sds.Rule{
+		Pattern: diff.Get("pattern").(string),
+		Suppressions: sds.Suppressions{
+			StartsWith: buildSensitiveDataScannerStringList(diff.Get("suppressions.0.starts_with").([]interface{})),
+			EndsWith:   buildSensitiveDataScannerStringList(diff.Get("suppressions.0.ends_with").([]interface{})),
+			ExactMatch: buildSensitiveDataScannerStringList(diff.Get("suppressions.0.exact_match").([]interface{})),
+		},
+	}
+	if _, ok := diff.GetOk("included_keyword_configuration"); ok {
+		rule.Keywords = buildSensitiveDataScannerStringList(diff.Get("included_keyword_configuration.0.keywords").([]interface{}))
+		rule.CharacterCount = diff.Get("included_keyword_configuration.0.character_count").(int)
+	}
+	failures, err := rule.TestSamples(
+		buildSensitiveDataScannerStringList(diff.Get("test_samples.0.match").([]interface{})),
+		buildSensitiveDataScannerStringList(diff.Get("test_samples.0.no_match").([]interface{})),
+	)
+	if err != nil {
+		return fmt.Errorf("invalid pattern: %s", err)
+	}
+	if len(failures) > 0 {
+		return fmt.Errorf("test_samples failed:\n%s", strings.Join(failures, "\n"))
+	}
+	return nil
+}
+
 func isSensitiveDataScannerRuleDescriptionConfigured(diff *schema.ResourceDiff) bool {
 	val, diags := diff.GetRawConfigAt(cty.GetAttrPath("description"))
 	return !diags.HasError() && !val.IsNull()
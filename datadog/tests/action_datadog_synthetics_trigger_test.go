@@ -0,0 +1,96 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccSyntheticsTriggerAction(t *testing.T) {
+	t.Parallel()
+	ctx, providers, accProviders := testAccFrameworkMuxProviders(context.Background(), t)
+	uniq := uniqueEntityName(ctx, t)
+	tag := "team:" + strings.ToLower(uniq)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: accProviders,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_14_0),
+		},
+		CheckDestroy: testAccCheckDatadogSyntheticsTestJSONDestroy(providers.frameworkProvider),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckDatadogSyntheticsTriggerAction(uniq, tag, "v1", `public_ids = [datadog_synthetics_test_json.foo.id]`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDatadogSyntheticsTestJSONExists(providers.frameworkProvider),
+					resource.TestCheckResourceAttr("terraform_data.deployment", "output", "v1"),
+				),
+			},
+			{
+				Config: testAccCheckDatadogSyntheticsTriggerAction(uniq, tag, "v2", fmt.Sprintf(`search = "tag:%s"`, tag)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("terraform_data.deployment", "output", "v2"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckDatadogSyntheticsTriggerAction(uniq, tag, release, selection string) string {
+	return fmt.Sprintf(`
+resource "datadog_synthetics_test_json" "foo" {
+  json = jsonencode({
+    name      = "%[1]s"
+    type      = "api"
+    subtype   = "http"
+    status    = "live"
+    message   = "Notify @datadog.user"
+    tags      = ["%[2]s"]
+    locations = ["aws:eu-central-1"]
+    config = {
+      request = {
+        method = "GET"
+        url    = "https://www.datadoghq.com"
+      }
+      assertions = [{
+        type     = "statusCode"
+        operator = "is"
+        target   = 200
+      }]
+    }
+    options = {
+      tick_every = 900
+      ci = {
+        executionRule = "blocking"
+      }
+    }
+  })
+}
+
+action "datadog_synthetics_trigger" "smoke_tests" {
+  config {
+    %[4]s
+    timeout_seconds = 600
+    variables = {
+      RELEASE = "%[3]s"
+    }
+  }
+}
+
+resource "terraform_data" "deployment" {
+  input = "%[3]s"
+
+  depends_on = [datadog_synthetics_test_json.foo]
+
+  lifecycle {
+    action_trigger {
+      events  = [after_create, after_update]
+      actions = [action.datadog_synthetics_trigger.smoke_tests]
+    }
+  }
+}`, uniq, tag, release, selection)
+}
@@ -0,0 +1,107 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccDatadogLogsPipelineSimulationDatasource(t *testing.T) {
+	t.Parallel()
+	ctx, providers, accProviders := testAccFrameworkMuxProviders(context.Background(), t)
+	uniq := uniqueEntityName(ctx, t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: accProviders,
+		CheckDestroy: func(s *terraform.State) error {
+			return pipelineDestroyHelper(providers.frameworkProvider.Auth, s, providers.frameworkProvider.DatadogApiInstances)
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDatasourceLogsPipelineSimulationConfig(uniq),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.datadog_logs_pipeline_simulation.nginx", "results.#", "3"),
+					resource.TestCheckResourceAttr("data.datadog_logs_pipeline_simulation.nginx", "results.0.matched", "true"),
+					resource.TestCheckResourceAttr("data.datadog_logs_pipeline_simulation.nginx", "results.1.matched", "true"),
+					resource.TestCheckResourceAttr("data.datadog_logs_pipeline_simulation.nginx", "results.2.matched", "false"),
+					resource.TestCheckResourceAttrWith("data.datadog_logs_pipeline_simulation.nginx", "results.0.log", testAccCheckSimulatedStatusCategory("Error")),
+					resource.TestCheckResourceAttrWith("data.datadog_logs_pipeline_simulation.nginx", "results.1.log", testAccCheckSimulatedStatusCategory("OK")),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckSimulatedStatusCategory checks the category assigned to a
+// processed log by the nginx pipeline.
+func testAccCheckSimulatedStatusCategory(expected string) resource.CheckResourceAttrWithFunc {
+	return func(value string) error {
+		var log struct {
+			HTTP struct {
+				StatusCategory string `json:"status_category"`
+			} `json:"http"`
+		}
+		if err := json.Unmarshal([]byte(value), &log); err != nil {
+			return err
+		}
+		if log.HTTP.StatusCategory != expected {
+			return fmt.Errorf("expected status category %q, got %q in %s", expected, log.HTTP.StatusCategory, value)
+		}
+		return nil
+	}
+}
+
+func testAccDatasourceLogsPipelineSimulationConfig(uniq string) string {
+	return fmt.Sprintf(`
+resource "datadog_logs_custom_pipeline" "nginx" {
+  name       = "%s"
+  is_enabled = true
+  filter {
+    query = "source:nginx"
+  }
+  processor {
+    grok_parser {
+      name       = "Parse access logs"
+      is_enabled = true
+      source     = "message"
+      grok {
+        support_rules = ""
+        match_rules   = "access %%%%{ipOrHost:network.client.ip} %%%%{word:http.method} %%%%{notSpace:http.url} %%%%{integer:http.status_code}"
+      }
+    }
+  }
+  processor {
+    category_processor {
+      name       = "Categorize status codes"
+      is_enabled = true
+      target     = "http.status_category"
+      category {
+        name = "OK"
+        filter {
+          query = "@http.status_code:[200 TO 299]"
+        }
+      }
+      category {
+        name = "Error"
+        filter {
+          query = "@http.status_code:[500 TO 599]"
+        }
+      }
+    }
+  }
+}
+
+data "datadog_logs_pipeline_simulation" "nginx" {
+  pipeline = datadog_logs_custom_pipeline.nginx
+  logs = [
+    jsonencode({ ddsource = "nginx", message = "10.0.0.1 GET /api 503" }),
+    jsonencode({ ddsource = "nginx", message = "10.0.0.2 GET /health 200" }),
+    jsonencode({ ddsource = "redis", message = "10.0.0.3 GET /api 200" }),
+  ]
+}`, uniq)
+}
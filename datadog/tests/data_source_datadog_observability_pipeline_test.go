@@ -0,0 +1,55 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDatadogObservabilityPipelineDatasource(t *testing.T) {
+	t.Parallel()
+	ctx, providers, accProviders := testAccFrameworkMuxProviders(context.Background(), t)
+	uniq := uniqueEntityName(ctx, t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: accProviders,
+		CheckDestroy:             testAccCheckDatadogObservabilityPipelineJSONDestroy(providers.frameworkProvider),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDatasourceObservabilityPipelineConfig(uniq),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(
+						"data.datadog_observability_pipeline.by_name", "id", "datadog_observability_pipeline_json.foo", "id"),
+					resource.TestCheckResourceAttr("data.datadog_observability_pipeline.by_name", "pipeline_type", "logs"),
+					resource.TestCheckResourceAttr("data.datadog_observability_pipeline.by_id", "name", uniq),
+					resource.TestCheckResourceAttrPair(
+						"data.datadog_observability_pipeline.by_id", "config_json", "data.datadog_observability_pipeline.by_name", "config_json"),
+					resource.TestCheckResourceAttr("datadog_observability_pipeline_json.copy", "name", uniq+" (copy)"),
+					resource.TestCheckResourceAttrPair(
+						"datadog_observability_pipeline_json.copy", "config_json", "data.datadog_observability_pipeline.by_name", "config_json"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDatasourceObservabilityPipelineConfig(uniq string) string {
+	return fmt.Sprintf(`%s
+
+data "datadog_observability_pipeline" "by_id" {
+  id = datadog_observability_pipeline_json.foo.id
+}
+
+data "datadog_observability_pipeline" "by_name" {
+  depends_on = [datadog_observability_pipeline_json.foo]
+  name       = "%s"
+}
+
+resource "datadog_observability_pipeline_json" "copy" {
+  name        = "${data.datadog_observability_pipeline.by_name.name} (copy)"
+  config_json = data.datadog_observability_pipeline.by_name.config_json
+}`, testAccCheckDatadogObservabilityPipelineJSON(uniq, "service:my-service"), uniq)
+}
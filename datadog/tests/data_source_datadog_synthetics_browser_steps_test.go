@@ -0,0 +1,45 @@
+package test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDatadogSyntheticsBrowserStepsDatasource(t *testing.T) {
+	t.Parallel()
+	_, _, accProviders := testAccFrameworkMuxProviders(context.Background(), t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: accProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDatasourceSyntheticsBrowserStepsConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.datadog_synthetics_browser_steps.foo", "start_url", "https://example.com"),
+					resource.TestCheckResourceAttr("data.datadog_synthetics_browser_steps.foo", "browser_step.#", "2"),
+					resource.TestCheckResourceAttr("data.datadog_synthetics_browser_steps.foo", "browser_step.0.type", "click"),
+					resource.TestCheckResourceAttr("data.datadog_synthetics_browser_steps.foo", "browser_step.0.params.click_type", "primary"),
+					resource.TestCheckResourceAttr("data.datadog_synthetics_browser_steps.foo", "browser_step.0.params.element_user_locator.value.type", "css"),
+					resource.TestCheckResourceAttr("data.datadog_synthetics_browser_steps.foo", "browser_step.0.params.element_user_locator.value.value", "#submit"),
+					resource.TestCheckResourceAttr("data.datadog_synthetics_browser_steps.foo", "browser_step.1.type", "scroll"),
+					resource.TestCheckResourceAttr("data.datadog_synthetics_browser_steps.foo", "browser_step.1.params.y", "400"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDatasourceSyntheticsBrowserStepsConfig = `
+data "datadog_synthetics_browser_steps" "foo" {
+  recording = jsonencode({
+    title = "Checkout"
+    steps = [
+      { type = "navigate", url = "https://example.com" },
+      { type = "click", selectors = [["#submit"]] },
+      { type = "scroll", x = 0, y = 400 },
+    ]
+  })
+}`
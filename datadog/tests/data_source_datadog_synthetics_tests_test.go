@@ -0,0 +1,100 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDatadogSyntheticsTestsDatasource(t *testing.T) {
+	t.Parallel()
+	ctx, providers, accProviders := testAccFrameworkMuxProviders(context.Background(), t)
+	uniq := uniqueEntityName(ctx, t)
+	tag := "team:" + strings.ToLower(uniq)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: accProviders,
+		CheckDestroy:             testAccCheckDatadogSyntheticsTestJSONDestroy(providers.frameworkProvider),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDatasourceSyntheticsTestsConfig(uniq, tag),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.datadog_synthetics_tests.all", "public_ids.#", "2"),
+					resource.TestCheckResourceAttr("data.datadog_synthetics_tests.all", "monitor_ids.#", "2"),
+					resource.TestCheckResourceAttr("data.datadog_synthetics_tests.all", "tests.#", "2"),
+					resource.TestCheckResourceAttr("data.datadog_synthetics_tests.prod", "public_ids.#", "1"),
+					resource.TestCheckResourceAttrPair(
+						"data.datadog_synthetics_tests.prod", "public_ids.0", "datadog_synthetics_test_json.prod", "id"),
+					resource.TestCheckResourceAttrPair(
+						"data.datadog_synthetics_tests.prod", "monitor_ids.0", "datadog_synthetics_test_json.prod", "monitor_id"),
+					resource.TestCheckResourceAttr("data.datadog_synthetics_tests.prod", "tests.0.name", uniq+"-prod"),
+					resource.TestCheckResourceAttr("data.datadog_synthetics_tests.prod", "tests.0.type", "api"),
+					resource.TestCheckResourceAttr("data.datadog_synthetics_tests.prod", "tests.0.subtype", "http"),
+					resource.TestCheckResourceAttr("data.datadog_synthetics_tests.prod", "tests.0.status", "live"),
+					resource.TestCheckResourceAttr("data.datadog_synthetics_tests.prod", "tests.0.tags.#", "2"),
+					resource.TestCheckResourceAttr("data.datadog_synthetics_tests.prod", "tests.0.locations.0", "aws:eu-central-1"),
+					resource.TestCheckResourceAttr("data.datadog_synthetics_tests.none", "public_ids.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDatasourceSyntheticsTestsConfig(uniq, tag string) string {
+	return fmt.Sprintf(`
+resource "datadog_synthetics_test_json" "prod" {
+  json = jsonencode({
+    name      = "%[1]s-prod"
+    type      = "api"
+    subtype   = "http"
+    status    = "live"
+    tags      = ["%[2]s", "env:prod"]
+    locations = ["aws:eu-central-1"]
+    config = {
+      request    = { method = "GET", url = "https://www.datadoghq.com" }
+      assertions = [{ type = "statusCode", operator = "is", target = 200 }]
+    }
+    options = { tick_every = 900 }
+  })
+}
+
+resource "datadog_synthetics_test_json" "staging" {
+  json = jsonencode({
+    name      = "%[1]s-staging"
+    type      = "api"
+    subtype   = "http"
+    status    = "live"
+    tags      = ["%[2]s", "env:staging"]
+    locations = ["aws:eu-central-1"]
+    config = {
+      request    = { method = "GET", url = "https://staging.datadoghq.com" }
+      assertions = [{ type = "statusCode", operator = "is", target = 200 }]
+    }
+    options = { tick_every = 900 }
+  })
+}
+
+data "datadog_synthetics_tests" "all" {
+  depends_on  = [datadog_synthetics_test_json.prod, datadog_synthetics_test_json.staging]
+  tags_filter = ["%[2]s"]
+}
+
+data "datadog_synthetics_tests" "prod" {
+  depends_on  = [datadog_synthetics_test_json.prod, datadog_synthetics_test_json.staging]
+  name_filter = "%[1]s"
+  tags_filter = ["%[2]s", "env:prod"]
+  type        = "api"
+  status      = "live"
+  location    = "aws:eu-central-1"
+}
+
+data "datadog_synthetics_tests" "none" {
+  depends_on  = [datadog_synthetics_test_json.prod, datadog_synthetics_test_json.staging]
+  tags_filter = ["%[2]s"]
+  subtype     = "ssl"
+}`, uniq, tag)
+}
@@ -54,6 +54,7 @@ var allowedHeaders = map[string]string{"Accept": "", "Content-Type": ""}
 
 var testFiles2EndpointTags = map[string]string{
 	"resource_datadog_dashboard_widget_time_test.go":                                     "dashboard",
+	"tests/action_datadog_synthetics_trigger_test":                                       "synthetics",
 	"tests/data_source_datadog_api_key_test":                                             "api_keys",
 	"tests/data_source_datadog_apm_retention_filters_order_test":                         "apm_retention_filters_order",
 	"tests/data_source_datadog_app_builder_app_test":                                     "app_builder_app",
@@ -78,6 +79,7 @@ var testFiles2EndpointTags = map[string]string{
 	"tests/data_source_datadog_logs_archives_order_test":                                 "logs-archive",
 	"tests/data_source_datadog_logs_indexes_order_test":                                  "logs-index",
 	"tests/data_source_datadog_logs_indexes_test":                                        "logs-index",
+	"tests/data_source_datadog_logs_pipeline_simulation_test":                            "logs-pipelines",
 	"tests/data_source_datadog_logs_pipelines_order_test":                                "logs-pipelines",
 	"tests/data_source_datadog_logs_pipelines_test":                                      "logs-pipelines",
 	"tests/data_source_datadog_monitor_config_policies_test":                             "monitor-config-policies",
@@ -88,6 +90,7 @@ var testFiles2EndpointTags = map[string]string{
 	"tests/data_source_datadog_metrics_test":                                             "metrics",
 	"tests/data_source_datadog_monitor_test":                                             "monitors",
 	"tests/data_source_datadog_monitors_test":                                            "monitors",
+	"tests/data_source_datadog_observability_pipeline_test":                              "observability-pipelines",
 	"tests/data_source_datadog_organization_settings_test":                               "organization",
 	"tests/data_source_datadog_permissions_test":                                         "permissions",
 	"tests/data_source_datadog_powerpack_test":                                           "powerpacks",
@@ -111,7 +114,9 @@ var testFiles2EndpointTags = map[string]string{
 	"tests/data_source_datadog_software_catalog_test":                                    "software-catalog",
 	"tests/data_source_datadog_synthetics_global_variable_test":                          "synthetics",
 	"tests/data_source_datadog_synthetics_locations_test":                                "synthetics",
+	"tests/data_source_datadog_synthetics_browser_steps_test":                            "synthetics",
 	"tests/data_source_datadog_synthetics_test_test":                                     "synthetics",
+	"tests/data_source_datadog_synthetics_tests_test":                                    "synthetics",
 	"tests/data_source_datadog_team_memberships_test":                                    "team",
 	"tests/data_source_datadog_team_test":                                                "team",
 	"tests/data_source_datadog_teams_test":                                               "teams",
@@ -214,6 +219,8 @@ var testFiles2EndpointTags = map[string]string{
 	"tests/resource_datadog_domain_allowlist_test":                                       "domain-allowlist",
 	"tests/resource_datadog_security_notification_rule_test":                             "security_notification_rule",
 	"tests/resource_datadog_observability_pipeline_test":                                 "observability-pipelines",
+	"tests/resource_datadog_observability_pipeline_json_test":                            "observability-pipelines",
+	"tests/resource_datadog_observability_pipeline_processor_group_test":                 "observability-pipelines",
 	"tests/resource_datadog_openapi_api_test":                                            "apimanagement",
 	"tests/resource_datadog_powerpack_test":                                              "powerpacks",
 	"tests/resource_datadog_powerpack_alert_graph_test":                                  "powerpacks",
@@ -275,6 +282,7 @@ var testFiles2EndpointTags = map[string]string{
 	"tests/resource_datadog_logs_archive_test":                                           "logs-archive",
 	"tests/resource_datadog_logs_custom_destination_test":                                "logs-custom-destination",
 	"tests/resource_datadog_logs_custom_pipeline_test":                                   "logs-pipelines",
+	"tests/resource_datadog_logs_index_exclusion_filter_test":                            "logs-index",
 	"tests/resource_datadog_logs_index_test":                                             "logs-index",
 	"tests/resource_datadog_logs_metric_test":                                            "logs-metric",
 	"tests/resource_datadog_logs_restriction_query_test":                                 "logs-restriction-queries",
@@ -304,8 +312,11 @@ var testFiles2EndpointTags = map[string]string{
 	"tests/data_source_datadog_org_group_policies_test":                                  "org_group_policy",
 	"tests/data_source_datadog_org_group_policy_overrides_test":                          "org_group_policy_override",
 	"tests/resource_datadog_reference_table_test":                                        "reference-tables",
+	"tests/resource_datadog_restriction_policy_binding_test":                             "restriction-policy",
 	"tests/resource_datadog_restriction_policy_test":                                     "restriction-policy",
+	"tests/resource_datadog_role_permissions_test":                                       "roles",
 	"tests/resource_datadog_role_test":                                                   "roles",
+	"tests/resource_datadog_role_users_test":                                             "roles",
 	"tests/resource_datadog_rum_application_test":                                        "rum-application",
 	"tests/resource_datadog_rum_metric_test":                                             "rum-metric",
 	"tests/resource_datadog_rum_retention_filter_test":                                   "rum-retention-filter",
@@ -319,6 +330,7 @@ var testFiles2EndpointTags = map[string]string{
 	"tests/resource_datadog_security_findings_ticket_creation_rules_order_test":          "security-monitoring",
 	"tests/resource_datadog_security_monitoring_default_rule_migration_test":             "security-monitoring",
 	"tests/resource_datadog_security_monitoring_default_rule_test":                       "security-monitoring",
+	"tests/resource_datadog_security_monitoring_default_rules_test":                      "security-monitoring",
 	"tests/resource_datadog_security_monitoring_filter_test":                             "security-monitoring",
 	"tests/resource_datadog_security_monitoring_filter_migration_test":                   "security-monitoring",
 	"tests/resource_datadog_security_monitoring_rule_json_test":                          "security-monitoring",
@@ -342,8 +354,10 @@ var testFiles2EndpointTags = map[string]string{
 	"tests/resource_datadog_synthetics_private_location_test":                            "synthetics",
 	"tests/resource_datadog_synthetics_suite_test":                                       "synthetics",
 	"tests/resource_datadog_synthetics_test_test":                                        "synthetics",
+	"tests/resource_datadog_synthetics_test_json_test":                                   "synthetics",
 	"tests/resource_datadog_team_link_test":                                              "team",
 	"tests/resource_datadog_team_membership_test":                                        "team",
+	"tests/resource_datadog_team_memberships_test":                                       "team",
 	"tests/resource_datadog_team_permission_setting_test":                                "team",
 	"tests/resource_datadog_team_test":                                                   "team",
 	"tests/resource_datadog_team_hierarchy_links_test":                                   "team",
@@ -355,6 +369,7 @@ var testFiles2EndpointTags = map[string]string{
 	"tests/resource_datadog_timeboard_test":                                              "dashboards",
 	"tests/resource_datadog_user_test":                                                   "users",
 	"tests/resource_datadog_user_role_test":                                              "roles",
+	"tests/resource_datadog_users_test":                                                  "users",
 	"tests/resource_datadog_webhook_custom_variable_test":                                "webhook_custom_variable",
 	"tests/resource_datadog_webhook_oauth2_client_credentials_test":                      "webhook_oauth2_client_credentials",
 	"tests/resource_datadog_webhook_test":                                                "webhook",
@@ -0,0 +1,139 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/fwprovider"
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/utils"
+)
+
+func TestAccLogsIndexExclusionFilterBasic(t *testing.T) {
+	t.Parallel()
+	ctx, providers, accProviders := testAccFrameworkMuxProviders(context.Background(), t)
+	uniq := strings.ToLower(strings.ReplaceAll(uniqueEntityName(ctx, t), "_", "-"))
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: accProviders,
+		CheckDestroy:             testAccCheckDatadogLogsIndexExclusionFilterDestroy(providers.frameworkProvider),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckDatadogLogsIndexExclusionFilter(uniq, "app:coredns", "0.5"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDatadogLogsIndexExclusionFilterExists(providers.frameworkProvider, "Filter healthchecks", "Filter coredns logs"),
+					resource.TestCheckResourceAttr(
+						"datadog_logs_index_exclusion_filter.foo", "id", uniq+":Filter coredns logs"),
+					resource.TestCheckResourceAttr(
+						"datadog_logs_index_exclusion_filter.foo", "is_enabled", "true"),
+					resource.TestCheckResourceAttr(
+						"datadog_logs_index_exclusion_filter.foo", "query", "app:coredns"),
+					resource.TestCheckResourceAttr(
+						"datadog_logs_index_exclusion_filter.foo", "sample_rate", "0.5"),
+					resource.TestCheckResourceAttr(
+						"datadog_logs_index_exclusion_filter.foo", "sample_attribute", "@request_id"),
+					resource.TestCheckResourceAttr(
+						"datadog_logs_index.foo", "exclusion_filter.#", "1"),
+				),
+			},
+			{
+				Config: testAccCheckDatadogLogsIndexExclusionFilter(uniq, "app:coredns status:info", "0.97"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDatadogLogsIndexExclusionFilterExists(providers.frameworkProvider, "Filter healthchecks", "Filter coredns logs"),
+					resource.TestCheckResourceAttr(
+						"datadog_logs_index_exclusion_filter.foo", "query", "app:coredns status:info"),
+					resource.TestCheckResourceAttr(
+						"datadog_logs_index_exclusion_filter.foo", "sample_rate", "0.97"),
+				),
+			},
+			{
+				ResourceName:      "datadog_logs_index_exclusion_filter.foo",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckDatadogLogsIndexExclusionFilter(uniq, query, sampleRate string) string {
+	return fmt.Sprintf(`
+resource "datadog_logs_index" "foo" {
+  name                               = "%[1]s"
+  retention_days                     = 15
+  ignore_unmanaged_exclusion_filters = true
+  filter {
+    query = "non-existent-query"
+  }
+  exclusion_filter {
+    name       = "Filter healthchecks"
+    is_enabled = true
+    filter {
+      query       = "@http.url:/health"
+      sample_rate = 1
+    }
+  }
+}
+
+resource "datadog_logs_index_exclusion_filter" "foo" {
+  index            = datadog_logs_index.foo.name
+  name             = "Filter coredns logs"
+  is_enabled       = true
+  query            = "%[2]s"
+  sample_rate      = %[3]s
+  sample_attribute = "@request_id"
+}`, uniq, query, sampleRate)
+}
+
+func testAccCheckDatadogLogsIndexExclusionFilterDestroy(accProvider *fwprovider.FrameworkProvider) func(*terraform.State) error {
+	return func(s *terraform.State) error {
+		apiInstances := accProvider.DatadogApiInstances
+		auth := accProvider.Auth
+
+		for _, r := range s.RootModule().Resources {
+			if r.Type != "datadog_logs_index_exclusion_filter" {
+				continue
+			}
+			index, httpResp, err := apiInstances.GetLogsIndexesApiV1().GetLogsIndex(auth, r.Primary.Attributes["index"])
+			if err != nil {
+				if httpResp != nil && httpResp.StatusCode == 404 {
+					continue
+				}
+				return utils.TranslateClientError(err, httpResp, "error retrieving logs index")
+			}
+			for _, filter := range index.GetExclusionFilters() {
+				if filter.GetName() == r.Primary.Attributes["name"] {
+					return fmt.Errorf("exclusion filter %q still exists", filter.GetName())
+				}
+			}
+		}
+		return nil
+	}
+}
+
+func testAccCheckDatadogLogsIndexExclusionFilterExists(accProvider *fwprovider.FrameworkProvider, names ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		apiInstances := accProvider.DatadogApiInstances
+		auth := accProvider.Auth
+
+		r, ok := s.RootModule().Resources["datadog_logs_index_exclusion_filter.foo"]
+		if !ok {
+			return fmt.Errorf("datadog_logs_index_exclusion_filter.foo not found in state")
+		}
+		index, httpResp, err := apiInstances.GetLogsIndexesApiV1().GetLogsIndex(auth, r.Primary.Attributes["index"])
+		if err != nil {
+			return utils.TranslateClientError(err, httpResp, "error retrieving logs index")
+		}
+		var filters []string
+		for _, filter := range index.GetExclusionFilters() {
+			filters = append(filters, filter.GetName())
+		}
+		if strings.Join(filters, ",") != strings.Join(names, ",") {
+			return fmt.Errorf("exclusion filters are %v, expected %v", filters, names)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,137 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/fwprovider"
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/utils"
+)
+
+func TestAccDatadogObservabilityPipelineJSONBasic(t *testing.T) {
+	t.Parallel()
+	ctx, providers, accProviders := testAccFrameworkMuxProviders(context.Background(), t)
+	uniq := uniqueEntityName(ctx, t)
+	resourceName := "datadog_observability_pipeline_json.foo"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: accProviders,
+		CheckDestroy:             testAccCheckDatadogObservabilityPipelineJSONDestroy(providers.frameworkProvider),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckDatadogObservabilityPipelineJSON(uniq, "service:my-service"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDatadogObservabilityPipelineJSONExists(providers.frameworkProvider),
+					resource.TestCheckResourceAttr(resourceName, "name", uniq),
+					resource.TestCheckResourceAttrSet(resourceName, "id"),
+				),
+			},
+			{
+				Config: testAccCheckDatadogObservabilityPipelineJSON(uniq+"-updated", "service:updated-service"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDatadogObservabilityPipelineJSONExists(providers.frameworkProvider),
+					resource.TestCheckResourceAttr(resourceName, "name", uniq+"-updated"),
+					resource.TestCheckResourceAttrWith(resourceName, "config_json", func(value string) error {
+						if value != testAccObservabilityPipelineConfigJSON("service:updated-service") {
+							return fmt.Errorf("unexpected config_json %s", value)
+						}
+						return nil
+					}),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"config_json"},
+			},
+		},
+	})
+}
+
+// testAccObservabilityPipelineConfigJSON returns a pipeline config in the
+// normalized form kept in the state.
+func testAccObservabilityPipelineConfigJSON(include string) string {
+	return fmt.Sprintf(`{"destinations":[{"id":"destination-1","inputs":["parser-group-1"],"type":"datadog_logs"}],`+
+		`"processor_groups":[{"display_name":"processor group","enabled":true,"id":"parser-group-1","include":"%[1]s","inputs":["source-1"],`+
+		`"processors":[{"display_name":"json parser","enabled":true,"field":"message","id":"parser-1","include":"%[1]s","type":"parse_json"}]}],`+
+		`"sources":[{"id":"source-1","type":"datadog_agent"}]}`, include)
+}
+
+func testAccCheckDatadogObservabilityPipelineJSON(name, include string) string {
+	return fmt.Sprintf(`
+resource "datadog_observability_pipeline_json" "foo" {
+  name = "%s"
+  config_json = jsonencode({
+    sources = [{
+      id   = "source-1"
+      type = "datadog_agent"
+    }]
+    processor_groups = [{
+      id           = "parser-group-1"
+      display_name = "processor group"
+      enabled      = true
+      include      = "%[2]s"
+      inputs       = ["source-1"]
+      processors = [{
+        id           = "parser-1"
+        display_name = "json parser"
+        type         = "parse_json"
+        enabled      = true
+        include      = "%[2]s"
+        field        = "message"
+      }]
+    }]
+    destinations = [{
+      id     = "destination-1"
+      type   = "datadog_logs"
+      inputs = ["parser-group-1"]
+    }]
+  })
+}`, name, include)
+}
+
+func testAccCheckDatadogObservabilityPipelineJSONDestroy(accProvider *fwprovider.FrameworkProvider) func(*terraform.State) error {
+	return func(s *terraform.State) error {
+		apiInstances := accProvider.DatadogApiInstances
+		auth := accProvider.Auth
+
+		for _, r := range s.RootModule().Resources {
+			if r.Type != "datadog_observability_pipeline_json" {
+				continue
+			}
+			_, httpResp, err := apiInstances.GetObsPipelinesV2().GetPipeline(auth, r.Primary.ID)
+			if err != nil {
+				if httpResp != nil && httpResp.StatusCode == 404 {
+					continue
+				}
+				return fmt.Errorf("received an error retrieving Observability Pipeline %s", err)
+			}
+			return fmt.Errorf("Observability Pipeline still exists")
+		}
+		return nil
+	}
+}
+
+func testAccCheckDatadogObservabilityPipelineJSONExists(accProvider *fwprovider.FrameworkProvider) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		apiInstances := accProvider.DatadogApiInstances
+		auth := accProvider.Auth
+
+		for _, r := range s.RootModule().Resources {
+			if r.Type != "datadog_observability_pipeline_json" {
+				continue
+			}
+			_, httpResp, err := apiInstances.GetObsPipelinesV2().GetPipeline(auth, r.Primary.ID)
+			if err != nil {
+				return utils.TranslateClientError(err, httpResp, "error retrieving Observability Pipeline")
+			}
+		}
+		return nil
+	}
+}
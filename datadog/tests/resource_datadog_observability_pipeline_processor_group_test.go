@@ -0,0 +1,144 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/fwprovider"
+)
+
+func TestAccDatadogObservabilityPipelineProcessorGroupBasic(t *testing.T) {
+	t.Parallel()
+	ctx, providers, accProviders := testAccFrameworkMuxProviders(context.Background(), t)
+	uniq := uniqueEntityName(ctx, t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: accProviders,
+		CheckDestroy:             testAccCheckDatadogObservabilityPipelineProcessorGroupDestroy(providers.frameworkProvider),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckDatadogObservabilityPipelineProcessorGroup(uniq, "NOT status:debug"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDatadogObservabilityPipelineProcessorGroupUsed(providers.frameworkProvider, "NOT status:debug"),
+					resource.TestCheckResourceAttrSet("datadog_observability_pipeline_processor_group.parsing", "id"),
+					resource.TestCheckResourceAttr("datadog_observability_pipeline_processor_group.parsing", "processor.#", "2"),
+					resource.TestCheckResourceAttrPair(
+						"datadog_observability_pipeline.web", "config.0.processor_group.0.processors_json",
+						"datadog_observability_pipeline_processor_group.parsing", "processors_json"),
+				),
+			},
+			{
+				Config: testAccCheckDatadogObservabilityPipelineProcessorGroup(uniq, "NOT status:(debug OR trace)"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDatadogObservabilityPipelineProcessorGroupUsed(providers.frameworkProvider, "NOT status:(debug OR trace)"),
+					resource.TestCheckResourceAttrPair(
+						"datadog_observability_pipeline.web", "config.0.processor_group.0.processors_json",
+						"datadog_observability_pipeline_processor_group.parsing", "processors_json"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckDatadogObservabilityPipelineProcessorGroup(uniq, filterInclude string) string {
+	return fmt.Sprintf(`
+resource "datadog_observability_pipeline_processor_group" "parsing" {
+  processor {
+    id      = "parse-json"
+    enabled = true
+    include = "*"
+    parse_json {
+      field = "message"
+    }
+  }
+
+  processor {
+    id      = "drop-debug"
+    enabled = true
+    include = "%s"
+    filter {}
+  }
+}
+
+resource "datadog_observability_pipeline" "web" {
+  name = "%s"
+
+  config {
+    source {
+      id = "agent"
+      datadog_agent {}
+    }
+
+    processor_group {
+      id              = "parsing"
+      enabled         = true
+      include         = "service:web"
+      inputs          = ["agent"]
+      processors_json = datadog_observability_pipeline_processor_group.parsing.processors_json
+    }
+
+    destination {
+      id     = "datadog"
+      inputs = ["parsing"]
+      datadog_logs {}
+    }
+  }
+}`, filterInclude, uniq)
+}
+
+// testAccCheckDatadogObservabilityPipelineProcessorGroupUsed checks that the
+// pipeline sent to Datadog contains the processors of the template.
+func testAccCheckDatadogObservabilityPipelineProcessorGroupUsed(accProvider *fwprovider.FrameworkProvider, filterInclude string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		apiInstances := accProvider.DatadogApiInstances
+		auth := accProvider.Auth
+
+		r := s.RootModule().Resources["datadog_observability_pipeline.web"]
+		res, _, err := apiInstances.GetObsPipelinesV2().GetPipeline(auth, r.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("received an error retrieving Observability Pipeline %s", err)
+		}
+		config := res.Data.Attributes.Config
+		if len(config.ProcessorGroups) != 1 {
+			return fmt.Errorf("expected 1 processor group, got %d", len(config.ProcessorGroups))
+		}
+		processors := config.ProcessorGroups[0].Processors
+		if len(processors) != 2 {
+			return fmt.Errorf("expected 2 processors, got %d", len(processors))
+		}
+		if processors[0].ObservabilityPipelineParseJSONProcessor == nil || processors[1].ObservabilityPipelineFilterProcessor == nil {
+			return fmt.Errorf("unexpected processors %v", processors)
+		}
+		if include := processors[1].ObservabilityPipelineFilterProcessor.Include; include != filterInclude {
+			return fmt.Errorf("expected the filter processor to include %q, got %q", filterInclude, include)
+		}
+		return nil
+	}
+}
+
+func testAccCheckDatadogObservabilityPipelineProcessorGroupDestroy(accProvider *fwprovider.FrameworkProvider) func(*terraform.State) error {
+	return func(s *terraform.State) error {
+		apiInstances := accProvider.DatadogApiInstances
+		auth := accProvider.Auth
+
+		for _, r := range s.RootModule().Resources {
+			if r.Type != "datadog_observability_pipeline" {
+				continue
+			}
+			_, httpResp, err := apiInstances.GetObsPipelinesV2().GetPipeline(auth, r.Primary.ID)
+			if err != nil {
+				if httpResp != nil && httpResp.StatusCode == 404 {
+					continue
+				}
+				return fmt.Errorf("received an error retrieving Observability Pipeline %s", err)
+			}
+			return fmt.Errorf("Observability Pipeline still exists")
+		}
+		return nil
+	}
+}
@@ -0,0 +1,187 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV2"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/fwprovider"
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/utils"
+)
+
+func TestAccRestrictionPolicyBindingBasic(t *testing.T) {
+	t.Parallel()
+	ctx, providers, accProviders := testAccFrameworkMuxProviders(context.Background(), t)
+	uniq := uniqueEntityName(ctx, t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: accProviders,
+		CheckDestroy:             testAccCheckDatadogRestrictionPolicyBindingDestroy(providers.frameworkProvider),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckDatadogRestrictionPolicyBinding(uniq, "editor"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDatadogRestrictionPolicyBindingExists(providers.frameworkProvider, "datadog_restriction_policy_binding.team"),
+					testAccCheckDatadogRestrictionPolicyBindingExists(providers.frameworkProvider, "datadog_restriction_policy_binding.role"),
+					resource.TestCheckResourceAttr(
+						"datadog_restriction_policy_binding.team", "relation", "editor"),
+					resource.TestMatchResourceAttr(
+						"datadog_restriction_policy_binding.team", "principal", regexp.MustCompile("^team:")),
+					resource.TestCheckResourceAttr(
+						"datadog_restriction_policy_binding.role", "relation", "viewer"),
+				),
+			},
+			{
+				Config: testAccCheckDatadogRestrictionPolicyBinding(uniq, "viewer"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDatadogRestrictionPolicyBindingExists(providers.frameworkProvider, "datadog_restriction_policy_binding.team"),
+					testAccCheckDatadogRestrictionPolicyBindingExists(providers.frameworkProvider, "datadog_restriction_policy_binding.role"),
+					testAccCheckDatadogRestrictionPolicyBindingCount(providers.frameworkProvider, "datadog_restriction_policy_binding.team", 1),
+					resource.TestCheckResourceAttr(
+						"datadog_restriction_policy_binding.team", "relation", "viewer"),
+				),
+			},
+			{
+				ResourceName:      "datadog_restriction_policy_binding.team",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckDatadogRestrictionPolicyBinding(uniq, relation string) string {
+	return fmt.Sprintf(`
+resource "datadog_team" "foo" {
+	description = "Team for restriction policy bindings"
+	handle      = "%[1]s"
+	name        = "%[1]s"
+}
+
+resource "datadog_role" "foo" {
+	name = "%[1]s"
+}
+
+resource "datadog_dashboard_json" "foo" {
+	dashboard = jsonencode({
+		title                     = "%[1]s"
+		layout_type               = "ordered"
+		notify_list               = []
+		template_variables        = []
+		template_variable_presets = []
+		widgets = [{
+			definition = {
+				type    = "note"
+				content = "restricted"
+			}
+		}]
+	})
+}
+
+resource "datadog_restriction_policy_binding" "team" {
+	resource_id = "dashboard:${datadog_dashboard_json.foo.id}"
+	relation    = "%[2]s"
+	principal   = "team:${datadog_team.foo.id}"
+}
+
+resource "datadog_restriction_policy_binding" "role" {
+	resource_id = "dashboard:${datadog_dashboard_json.foo.id}"
+	relation    = "viewer"
+	principal   = "role:${datadog_role.foo.id}"
+}`, uniq, relation)
+}
+
+// testAccCheckDatadogRestrictionPolicyBindingCount checks the number of
+// bindings of the restriction policy that include the principal.
+func testAccCheckDatadogRestrictionPolicyBindingCount(accProvider *fwprovider.FrameworkProvider, name string, count int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		r, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("%s not found in state", name)
+		}
+		bindings, err := getRestrictionPolicyBindings(accProvider, r.Primary.Attributes["resource_id"])
+		if err != nil {
+			return err
+		}
+		found := 0
+		for _, binding := range bindings {
+			for _, principal := range binding.GetPrincipals() {
+				if principal == r.Primary.Attributes["principal"] {
+					found++
+				}
+			}
+		}
+		if found != count {
+			return fmt.Errorf("expected %d bindings for %s, got %d", count, r.Primary.Attributes["principal"], found)
+		}
+		return nil
+	}
+}
+
+func testAccCheckDatadogRestrictionPolicyBindingExists(accProvider *fwprovider.FrameworkProvider, name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		r, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("%s not found in state", name)
+		}
+		bindings, err := getRestrictionPolicyBindings(accProvider, r.Primary.Attributes["resource_id"])
+		if err != nil {
+			return err
+		}
+		if !hasRestrictionPolicyBinding(bindings, r.Primary.Attributes["relation"], r.Primary.Attributes["principal"]) {
+			return fmt.Errorf("binding %s not found in the restriction policy", r.Primary.ID)
+		}
+		return nil
+	}
+}
+
+func testAccCheckDatadogRestrictionPolicyBindingDestroy(accProvider *fwprovider.FrameworkProvider) func(*terraform.State) error {
+	return func(s *terraform.State) error {
+		for _, r := range s.RootModule().Resources {
+			if r.Type != "datadog_restriction_policy_binding" {
+				continue
+			}
+			bindings, err := getRestrictionPolicyBindings(accProvider, r.Primary.Attributes["resource_id"])
+			if err != nil {
+				return err
+			}
+			if hasRestrictionPolicyBinding(bindings, r.Primary.Attributes["relation"], r.Primary.Attributes["principal"]) {
+				return fmt.Errorf("binding %s still exists", r.Primary.ID)
+			}
+		}
+		return nil
+	}
+}
+
+func getRestrictionPolicyBindings(accProvider *fwprovider.FrameworkProvider, resourceID string) ([]datadogV2.RestrictionPolicyBinding, error) {
+	apiInstances := accProvider.DatadogApiInstances
+	auth := accProvider.Auth
+
+	resp, httpResp, err := apiInstances.GetRestrictionPoliciesApiV2().GetRestrictionPolicy(auth, resourceID)
+	if err != nil {
+		if httpResp != nil && httpResp.StatusCode == 404 {
+			return nil, nil
+		}
+		return nil, utils.TranslateClientError(err, httpResp, "error retrieving restriction policy")
+	}
+	return resp.Data.Attributes.GetBindings(), nil
+}
+
+func hasRestrictionPolicyBinding(bindings []datadogV2.RestrictionPolicyBinding, relation, principal string) bool {
+	for _, binding := range bindings {
+		if binding.GetRelation() != relation {
+			continue
+		}
+		for _, p := range binding.GetPrincipals() {
+			if p == principal {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,132 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/fwprovider"
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/utils"
+)
+
+func TestAccRolePermissionsBasic(t *testing.T) {
+	t.Parallel()
+	ctx, providers, accProviders := testAccFrameworkMuxProviders(context.Background(), t)
+	uniq := uniqueEntityName(ctx, t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: accProviders,
+		CheckDestroy:             testAccCheckDatadogRolePermissionsDestroy(providers.frameworkProvider),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckDatadogRolePermissions(uniq, `["logs_read_data", "logs_read_index_data", "logs_live_tail"]`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDatadogRolePermissionsExists(providers.frameworkProvider, "logs_read_data", "logs_read_index_data", "logs_live_tail"),
+					resource.TestCheckResourceAttrPair(
+						"datadog_role_permissions.foo", "id", "datadog_role.foo", "id"),
+					resource.TestCheckResourceAttr(
+						"datadog_role_permissions.foo", "permissions.#", "3"),
+					resource.TestCheckTypeSetElemAttr(
+						"datadog_role_permissions.foo", "permissions.*", "logs_live_tail"),
+					resource.TestCheckResourceAttr(
+						"datadog_role_permissions.foo", "exclusive", "true"),
+				),
+			},
+			{
+				Config: testAccCheckDatadogRolePermissions(uniq, `["logs_read_data", "dashboards_write"]`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDatadogRolePermissionsExists(providers.frameworkProvider, "logs_read_data", "dashboards_write"),
+					resource.TestCheckResourceAttr(
+						"datadog_role_permissions.foo", "permissions.#", "2"),
+					resource.TestCheckTypeSetElemAttr(
+						"datadog_role_permissions.foo", "permissions.*", "dashboards_write"),
+				),
+			},
+			{
+				ResourceName:      "datadog_role_permissions.foo",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckDatadogRolePermissions(uniq, permissions string) string {
+	return fmt.Sprintf(`
+resource "datadog_role" "foo" {
+	name = "%s"
+	lifecycle {
+		ignore_changes = [permission]
+	}
+}
+
+resource "datadog_role_permissions" "foo" {
+	role_id     = datadog_role.foo.id
+	permissions = %s
+}`, uniq, permissions)
+}
+
+func testAccCheckDatadogRolePermissionsDestroy(accProvider *fwprovider.FrameworkProvider) func(*terraform.State) error {
+	return func(s *terraform.State) error {
+		apiInstances := accProvider.DatadogApiInstances
+		auth := accProvider.Auth
+
+		for _, r := range s.RootModule().Resources {
+			if r.Type != "datadog_role" {
+				continue
+			}
+			_, httpResp, err := apiInstances.GetRolesApiV2().GetRole(auth, r.Primary.ID)
+			if err != nil {
+				if httpResp != nil && httpResp.StatusCode == 404 {
+					continue
+				}
+				return fmt.Errorf("received an error retrieving role %s", err)
+			}
+			return fmt.Errorf("role still exists")
+		}
+		return nil
+	}
+}
+
+// testAccCheckDatadogRolePermissionsExists checks the permissions of the role,
+// ignoring the default permissions granted by Datadog.
+func testAccCheckDatadogRolePermissionsExists(accProvider *fwprovider.FrameworkProvider, names ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		apiInstances := accProvider.DatadogApiInstances
+		auth := accProvider.Auth
+
+		r, ok := s.RootModule().Resources["datadog_role_permissions.foo"]
+		if !ok {
+			return fmt.Errorf("datadog_role_permissions.foo not found in state")
+		}
+		resp, httpResp, err := apiInstances.GetRolesApiV2().ListRolePermissions(auth, r.Primary.ID)
+		if err != nil {
+			return utils.TranslateClientError(err, httpResp, "error retrieving role permissions")
+		}
+		granted := map[string]bool{}
+		for _, permission := range resp.GetData() {
+			attributes := permission.GetAttributes()
+			if !attributes.GetRestricted() {
+				granted[attributes.GetName()] = true
+			}
+		}
+		for _, name := range names {
+			if !granted[name] {
+				return fmt.Errorf("permission %s is not granted to the role", name)
+			}
+			delete(granted, name)
+		}
+		if len(granted) > 0 {
+			var extra []string
+			for name := range granted {
+				extra = append(extra, name)
+			}
+			return fmt.Errorf("unexpected permissions granted to the role: %s", strings.Join(extra, ", "))
+		}
+		return nil
+	}
+}
@@ -0,0 +1,137 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV2"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/fwprovider"
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/utils"
+)
+
+func TestAccRoleUsersBasic(t *testing.T) {
+	t.Parallel()
+	ctx, providers, accProviders := testAccFrameworkMuxProviders(context.Background(), t)
+	uniq := strings.ToLower(uniqueEntityName(ctx, t))
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: accProviders,
+		CheckDestroy:             testAccCheckDatadogRoleUsersDestroy(providers.frameworkProvider),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckDatadogRoleUsers(uniq, `[datadog_user.foo.email, datadog_user.bar.email]`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDatadogRoleUsersExists(providers.frameworkProvider, uniq+"-foo@example.com", uniq+"-bar@example.com"),
+					resource.TestCheckResourceAttrPair(
+						"datadog_role_users.foo", "id", "datadog_role.foo", "id"),
+					resource.TestCheckResourceAttr(
+						"datadog_role_users.foo", "users.#", "2"),
+					resource.TestCheckTypeSetElemAttr(
+						"datadog_role_users.foo", "users.*", uniq+"-foo@example.com"),
+					resource.TestCheckTypeSetElemAttr(
+						"datadog_role_users.foo", "users.*", uniq+"-bar@example.com"),
+					resource.TestCheckResourceAttr(
+						"datadog_role_users.foo", "exclusive", "true"),
+				),
+			},
+			{
+				Config: testAccCheckDatadogRoleUsers(uniq, `[datadog_user.foo.email]`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDatadogRoleUsersExists(providers.frameworkProvider, uniq+"-foo@example.com"),
+					resource.TestCheckResourceAttr(
+						"datadog_role_users.foo", "users.#", "1"),
+					resource.TestCheckTypeSetElemAttr(
+						"datadog_role_users.foo", "users.*", uniq+"-foo@example.com"),
+				),
+			},
+			{
+				ResourceName:      "datadog_role_users.foo",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckDatadogRoleUsers(uniq, users string) string {
+	return fmt.Sprintf(`
+resource "datadog_role" "foo" {
+	name = "%[1]s"
+}
+
+resource "datadog_user" "foo" {
+	email = "%[1]s-foo@example.com"
+}
+
+resource "datadog_user" "bar" {
+	email = "%[1]s-bar@example.com"
+}
+
+resource "datadog_role_users" "foo" {
+	role_id = datadog_role.foo.id
+	users   = %[2]s
+}`, uniq, users)
+}
+
+func testAccCheckDatadogRoleUsersDestroy(accProvider *fwprovider.FrameworkProvider) func(*terraform.State) error {
+	return func(s *terraform.State) error {
+		apiInstances := accProvider.DatadogApiInstances
+		auth := accProvider.Auth
+
+		for _, r := range s.RootModule().Resources {
+			if r.Type != "datadog_role_users" {
+				continue
+			}
+			err := utils.Retry(2, 10, func() error {
+				resp, httpResp, err := apiInstances.GetRolesApiV2().ListRoleUsers(auth, r.Primary.ID)
+				if err != nil {
+					if httpResp != nil && httpResp.StatusCode == 404 {
+						return nil
+					}
+					return &utils.RetryableError{Prob: fmt.Sprintf("received an error retrieving role users %s", err)}
+				}
+				if len(resp.GetData()) > 0 {
+					return &utils.RetryableError{Prob: "role users still exist"}
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func testAccCheckDatadogRoleUsersExists(accProvider *fwprovider.FrameworkProvider, emails ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		apiInstances := accProvider.DatadogApiInstances
+		auth := accProvider.Auth
+
+		r, ok := s.RootModule().Resources["datadog_role_users.foo"]
+		if !ok {
+			return fmt.Errorf("datadog_role_users.foo not found in state")
+		}
+		resp, httpResp, err := apiInstances.GetRolesApiV2().ListRoleUsers(auth, r.Primary.ID, *datadogV2.NewListRoleUsersOptionalParameters().WithPageSize(100))
+		if err != nil {
+			return utils.TranslateClientError(err, httpResp, "error retrieving role users")
+		}
+		var users []string
+		for _, user := range resp.GetData() {
+			attributes := user.GetAttributes()
+			users = append(users, attributes.GetEmail())
+		}
+		sort.Strings(users)
+		sort.Strings(emails)
+		if strings.Join(users, ",") != strings.Join(emails, ",") {
+			return fmt.Errorf("role users are %v, expected %v", users, emails)
+		}
+		return nil
+	}
+}
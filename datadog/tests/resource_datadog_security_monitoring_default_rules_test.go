@@ -0,0 +1,191 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV2"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/fwprovider"
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/utils"
+)
+
+const tfSecurityMonitoringDefaultRulesName = "datadog_security_monitoring_default_rules.acceptance_test"
+
+func TestAccDatadogSecurityMonitoringDefaultRules_Basic(t *testing.T) {
+	ctx, providers, accProviders := testAccFrameworkMuxProviders(context.Background(), t)
+	uniq := uniqueEntityName(ctx, t)
+	notification := fmt.Sprintf("@slack-%s", uniq)
+	firstFilter := fmt.Sprintf("@usr.name:%s-first", uniq)
+	secondFilter := fmt.Sprintf("@usr.name:%s-second", uniq)
+	var firstRuleIDs []string
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: accProviders,
+		CheckDestroy:             testAccCheckDatadogSecurityMonitoringDefaultRulesDestroy(providers.frameworkProvider),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckDatadogSecurityMonitoringDefaultRulesConfig(`["cloudtrail", "okta"]`, notification, firstFilter),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDatadogSecurityMonitoringDefaultRulesApplied(providers.frameworkProvider, []string{"cloudtrail", "okta"}, notification, firstFilter),
+					testAccCheckDatadogSecurityMonitoringDefaultRulesCapture(&firstRuleIDs),
+					resource.TestCheckResourceAttrSet(tfSecurityMonitoringDefaultRulesName, "id"),
+					resource.TestCheckResourceAttr(tfSecurityMonitoringDefaultRulesName, "filter.#", "1"),
+				),
+			},
+			{
+				Config: testAccCheckDatadogSecurityMonitoringDefaultRulesConfig(`["okta"]`, notification, secondFilter),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDatadogSecurityMonitoringDefaultRulesApplied(providers.frameworkProvider, []string{"okta"}, notification, secondFilter),
+					testAccCheckDatadogSecurityMonitoringDefaultRulesFilterRemoved(providers.frameworkProvider, &firstRuleIDs, firstFilter),
+					resource.TestCheckResourceAttr(tfSecurityMonitoringDefaultRulesName, "filter.0.query", secondFilter),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckDatadogSecurityMonitoringDefaultRulesConfig(sources, notification, filterQuery string) string {
+	return fmt.Sprintf(`
+resource "datadog_security_monitoring_default_rules" "acceptance_test" {
+	sources       = %s
+	tags          = ["security:attack"]
+	enabled       = true
+	notifications = ["%s"]
+
+	filter {
+		action = "suppress"
+		query  = "%s"
+	}
+}
+`, sources, notification, filterQuery)
+}
+
+// testAccCheckDatadogSecurityMonitoringDefaultRulesApplied checks that the
+// policy was applied to a non-empty selection of default rules, all matching
+// the sources and tags of the resource.
+func testAccCheckDatadogSecurityMonitoringDefaultRulesApplied(accProvider *fwprovider.FrameworkProvider, sources []string, notification, filterQuery string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		ruleIDs, err := securityMonitoringDefaultRulesIDs(s)
+		if err != nil {
+			return err
+		}
+		if len(ruleIDs) == 0 {
+			return fmt.Errorf("the policy was not applied to any default rule")
+		}
+		for _, ruleID := range ruleIDs {
+			rule, err := getSecurityMonitoringDefaultRule(accProvider, ruleID)
+			if err != nil {
+				return err
+			}
+			if !rule.GetIsDefault() || !slices.Contains(rule.GetTags(), "security:attack") {
+				return fmt.Errorf("rule %s does not match the selection", ruleID)
+			}
+			if !slices.ContainsFunc(sources, func(source string) bool { return slices.Contains(rule.GetTags(), "source:"+source) }) {
+				return fmt.Errorf("rule %s does not match the sources %v", ruleID, sources)
+			}
+			if !rule.GetIsEnabled() {
+				return fmt.Errorf("rule %s is not enabled", ruleID)
+			}
+			for _, ruleCase := range rule.GetCases() {
+				if !slices.Equal(ruleCase.GetNotifications(), []string{notification}) {
+					return fmt.Errorf("rule %s notifies %v instead of %s", ruleID, ruleCase.GetNotifications(), notification)
+				}
+			}
+			if !hasSecurityMonitoringFilter(rule, filterQuery) {
+				return fmt.Errorf("rule %s does not have the filter %s", ruleID, filterQuery)
+			}
+		}
+		return nil
+	}
+}
+
+func testAccCheckDatadogSecurityMonitoringDefaultRulesCapture(ruleIDs *[]string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		ids, err := securityMonitoringDefaultRulesIDs(s)
+		*ruleIDs = ids
+		return err
+	}
+}
+
+// testAccCheckDatadogSecurityMonitoringDefaultRulesFilterRemoved checks that a
+// filter of a prior policy is no longer set on the rules.
+func testAccCheckDatadogSecurityMonitoringDefaultRulesFilterRemoved(accProvider *fwprovider.FrameworkProvider, ruleIDs *[]string, filterQuery string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, ruleID := range *ruleIDs {
+			rule, err := getSecurityMonitoringDefaultRule(accProvider, ruleID)
+			if err != nil {
+				return err
+			}
+			if hasSecurityMonitoringFilter(rule, filterQuery) {
+				return fmt.Errorf("rule %s still has the filter %s", ruleID, filterQuery)
+			}
+		}
+		return nil
+	}
+}
+
+func testAccCheckDatadogSecurityMonitoringDefaultRulesDestroy(accProvider *fwprovider.FrameworkProvider) func(*terraform.State) error {
+	return func(s *terraform.State) error {
+		for _, r := range s.RootModule().Resources {
+			if r.Type != "datadog_security_monitoring_default_rules" {
+				continue
+			}
+			ruleIDs, err := securityMonitoringDefaultRulesIDs(s)
+			if err != nil {
+				return err
+			}
+			filterQuery := r.Primary.Attributes["filter.0.query"]
+			for _, ruleID := range ruleIDs {
+				rule, err := getSecurityMonitoringDefaultRule(accProvider, ruleID)
+				if err != nil {
+					return err
+				}
+				if hasSecurityMonitoringFilter(rule, filterQuery) {
+					return fmt.Errorf("rule %s still has the filter %s", ruleID, filterQuery)
+				}
+			}
+		}
+		return nil
+	}
+}
+
+func securityMonitoringDefaultRulesIDs(s *terraform.State) ([]string, error) {
+	r, ok := s.RootModule().Resources[tfSecurityMonitoringDefaultRulesName]
+	if !ok {
+		return nil, fmt.Errorf("%s not found in state", tfSecurityMonitoringDefaultRulesName)
+	}
+	var ruleIDs []string
+	for key, value := range r.Primary.Attributes {
+		if strings.HasPrefix(key, "rule_ids.") && key != "rule_ids.#" {
+			ruleIDs = append(ruleIDs, value)
+		}
+	}
+	slices.Sort(ruleIDs)
+	return ruleIDs, nil
+}
+
+func getSecurityMonitoringDefaultRule(accProvider *fwprovider.FrameworkProvider, ruleID string) (*datadogV2.SecurityMonitoringStandardRuleResponse, error) {
+	apiInstances := accProvider.DatadogApiInstances
+	auth := accProvider.Auth
+
+	resp, httpResp, err := apiInstances.GetSecurityMonitoringApiV2().GetSecurityMonitoringRule(auth, ruleID)
+	if err != nil {
+		return nil, utils.TranslateClientError(err, httpResp, "error retrieving security monitoring rule")
+	}
+	if resp.SecurityMonitoringStandardRuleResponse == nil {
+		return nil, fmt.Errorf("rule %s is not a standard rule", ruleID)
+	}
+	return resp.SecurityMonitoringStandardRuleResponse, nil
+}
+
+func hasSecurityMonitoringFilter(rule *datadogV2.SecurityMonitoringStandardRuleResponse, filterQuery string) bool {
+	return slices.ContainsFunc(rule.GetFilters(), func(f datadogV2.SecurityMonitoringFilter) bool {
+		return f.GetAction() == datadogV2.SECURITYMONITORINGFILTERACTION_SUPPRESS && f.GetQuery() == filterQuery
+	})
+}
@@ -0,0 +1,129 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/fwprovider"
+)
+
+func TestAccSyntheticsTestJSONBasic(t *testing.T) {
+	t.Parallel()
+	ctx, providers, accProviders := testAccFrameworkMuxProviders(context.Background(), t)
+	uniq := uniqueEntityName(ctx, t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: accProviders,
+		CheckDestroy:             testAccCheckDatadogSyntheticsTestJSONDestroy(providers.frameworkProvider),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckDatadogSyntheticsTestJSON(uniq, 900),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDatadogSyntheticsTestJSONExists(providers.frameworkProvider),
+					resource.TestCheckResourceAttrSet(
+						"datadog_synthetics_test_json.foo", "monitor_id"),
+					resource.TestCheckResourceAttr(
+						"datadog_synthetics_test_json.foo", "json", testAccSyntheticsTestJSON(uniq, 900)),
+				),
+			},
+			{
+				Config: testAccCheckDatadogSyntheticsTestJSON(uniq+"-updated", 300),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDatadogSyntheticsTestJSONExists(providers.frameworkProvider),
+					resource.TestCheckResourceAttr(
+						"datadog_synthetics_test_json.foo", "json", testAccSyntheticsTestJSON(uniq+"-updated", 300)),
+				),
+			},
+			{
+				ResourceName:            "datadog_synthetics_test_json.foo",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"json"},
+			},
+		},
+	})
+}
+
+// testAccSyntheticsTestJSON returns a test as exported from the UI, with the
+// computed fields that the resource ignores.
+func testAccSyntheticsTestJSON(name string, tickEvery int) string {
+	return fmt.Sprintf(`{
+  "public_id": "abc-def-ghi",
+  "monitor_id": 12345,
+  "name": "%s",
+  "type": "api",
+  "subtype": "http",
+  "status": "paused",
+  "message": "Notify @datadog.user",
+  "tags": ["foo:bar", "baz"],
+  "locations": ["aws:eu-central-1"],
+  "config": {
+    "request": {
+      "method": "GET",
+      "url": "https://www.datadoghq.com"
+    },
+    "assertions": [
+      {
+        "type": "statusCode",
+        "operator": "is",
+        "target": 200
+      }
+    ]
+  },
+  "options": {
+    "tick_every": %d
+  }
+}
+`, name, tickEvery)
+}
+
+func testAccCheckDatadogSyntheticsTestJSON(name string, tickEvery int) string {
+	return fmt.Sprintf(`
+resource "datadog_synthetics_test_json" "foo" {
+  json = <<-EOF
+%sEOF
+}`, testAccSyntheticsTestJSON(name, tickEvery))
+}
+
+func testAccCheckDatadogSyntheticsTestJSONDestroy(accProvider *fwprovider.FrameworkProvider) func(*terraform.State) error {
+	return func(s *terraform.State) error {
+		apiInstances := accProvider.DatadogApiInstances
+		auth := accProvider.Auth
+
+		for _, r := range s.RootModule().Resources {
+			if r.Type != "datadog_synthetics_test_json" {
+				continue
+			}
+			if _, _, err := apiInstances.GetSyntheticsApiV1().GetTest(auth, r.Primary.ID); err != nil {
+				if strings.Contains(err.Error(), "404 Not Found") {
+					continue
+				}
+				return fmt.Errorf("received an error retrieving synthetics test %s", err)
+			}
+			return fmt.Errorf("synthetics test still exists")
+		}
+		return nil
+	}
+}
+
+func testAccCheckDatadogSyntheticsTestJSONExists(accProvider *fwprovider.FrameworkProvider) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		apiInstances := accProvider.DatadogApiInstances
+		auth := accProvider.Auth
+
+		for _, r := range s.RootModule().Resources {
+			if r.Type != "datadog_synthetics_test_json" {
+				continue
+			}
+			if _, _, err := apiInstances.GetSyntheticsApiV1().GetTest(auth, r.Primary.ID); err != nil {
+				return fmt.Errorf("received an error retrieving synthetics test %s", err)
+			}
+		}
+		return nil
+	}
+}
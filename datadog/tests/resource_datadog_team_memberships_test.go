@@ -0,0 +1,141 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV2"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/fwprovider"
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/utils"
+)
+
+func TestAccTeamMembershipsBasic(t *testing.T) {
+	t.Parallel()
+	ctx, providers, accProviders := testAccFrameworkMuxProviders(context.Background(), t)
+	uniq := strings.ToLower(uniqueEntityName(ctx, t))
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: accProviders,
+		CheckDestroy:             testAccCheckDatadogTeamMembershipsDestroy(providers.frameworkProvider),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckDatadogTeamMemberships(uniq, `[datadog_user.foo.email, datadog_user.bar.email]`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDatadogTeamMembershipsExists(providers.frameworkProvider, uniq+"-foo@example.com", uniq+"-bar@example.com"),
+					resource.TestCheckResourceAttrPair(
+						"datadog_team_memberships.foo", "id", "datadog_team.foo", "id"),
+					resource.TestCheckResourceAttr(
+						"datadog_team_memberships.foo", "members.#", "2"),
+					resource.TestCheckTypeSetElemAttr(
+						"datadog_team_memberships.foo", "members.*", uniq+"-foo@example.com"),
+					resource.TestCheckTypeSetElemAttr(
+						"datadog_team_memberships.foo", "members.*", uniq+"-bar@example.com"),
+					resource.TestCheckResourceAttr(
+						"datadog_team_memberships.foo", "exclusive", "true"),
+				),
+			},
+			{
+				Config: testAccCheckDatadogTeamMemberships(uniq, `[datadog_user.foo.email]`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDatadogTeamMembershipsExists(providers.frameworkProvider, uniq+"-foo@example.com"),
+					resource.TestCheckResourceAttr(
+						"datadog_team_memberships.foo", "members.#", "1"),
+					resource.TestCheckTypeSetElemAttr(
+						"datadog_team_memberships.foo", "members.*", uniq+"-foo@example.com"),
+				),
+			},
+			{
+				ResourceName:      "datadog_team_memberships.foo",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckDatadogTeamMemberships(uniq, members string) string {
+	return fmt.Sprintf(`
+resource "datadog_team" "foo" {
+	description = "Example team"
+	handle      = "%[1]s"
+	name        = "%[1]s"
+}
+
+resource "datadog_user" "foo" {
+	email = "%[1]s-foo@example.com"
+}
+
+resource "datadog_user" "bar" {
+	email = "%[1]s-bar@example.com"
+}
+
+resource "datadog_team_memberships" "foo" {
+	team_id = datadog_team.foo.id
+	members = %[2]s
+}`, uniq, members)
+}
+
+func testAccCheckDatadogTeamMembershipsDestroy(accProvider *fwprovider.FrameworkProvider) func(*terraform.State) error {
+	return func(s *terraform.State) error {
+		apiInstances := accProvider.DatadogApiInstances
+		auth := accProvider.Auth
+
+		for _, r := range s.RootModule().Resources {
+			if r.Type != "datadog_team_memberships" {
+				continue
+			}
+			err := utils.Retry(2, 10, func() error {
+				resp, httpResp, err := apiInstances.GetTeamsApiV2().GetTeamMemberships(auth, r.Primary.ID)
+				if err != nil {
+					if httpResp != nil && httpResp.StatusCode == 404 {
+						return nil
+					}
+					return &utils.RetryableError{Prob: fmt.Sprintf("received an error retrieving team memberships %s", err)}
+				}
+				if len(resp.GetData()) > 0 {
+					return &utils.RetryableError{Prob: "team memberships still exist"}
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func testAccCheckDatadogTeamMembershipsExists(accProvider *fwprovider.FrameworkProvider, emails ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		apiInstances := accProvider.DatadogApiInstances
+		auth := accProvider.Auth
+
+		r, ok := s.RootModule().Resources["datadog_team_memberships.foo"]
+		if !ok {
+			return fmt.Errorf("datadog_team_memberships.foo not found in state")
+		}
+		resp, httpResp, err := apiInstances.GetTeamsApiV2().GetTeamMemberships(auth, r.Primary.ID, *datadogV2.NewGetTeamMembershipsOptionalParameters().WithPageSize(100))
+		if err != nil {
+			return utils.TranslateClientError(err, httpResp, "error retrieving team memberships")
+		}
+		var members []string
+		for _, included := range resp.GetIncluded() {
+			if included.User != nil {
+				attributes := included.User.GetAttributes()
+				members = append(members, attributes.GetEmail())
+			}
+		}
+		sort.Strings(members)
+		sort.Strings(emails)
+		if strings.Join(members, ",") != strings.Join(emails, ",") {
+			return fmt.Errorf("team members are %v, expected %v", members, emails)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,157 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV2"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/fwprovider"
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/utils"
+)
+
+func TestAccUsersBasic(t *testing.T) {
+	t.Parallel()
+	ctx, providers, accProviders := testAccFrameworkMuxProviders(context.Background(), t)
+	uniq := strings.ToLower(uniqueEntityName(ctx, t))
+	alice, bob, carol := uniq+"-alice@example.com", uniq+"-bob@example.com", uniq+"-carol@example.com"
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: accProviders,
+		CheckDestroy:             testAccCheckDatadogUsersDestroy(providers.frameworkProvider),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckDatadogUsers(uniq, fmt.Sprintf(`
+  user {
+    email = "%s"
+    name  = "Alice"
+    roles = [datadog_role.foo.id]
+    teams = [datadog_team.foo.id]
+  }
+  user {
+    email    = "%s"
+    disabled = true
+  }
+  user {
+    email = "%s"
+  }`, alice, bob, carol)),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDatadogUsersDisabled(providers.frameworkProvider, map[string]bool{alice: false, bob: true, carol: false}),
+					resource.TestCheckResourceAttr("datadog_users.foo", "user_ids.%", "3"),
+					resource.TestCheckResourceAttr("datadog_users.foo", "failures.%", "0"),
+					resource.TestCheckTypeSetElemNestedAttrs("datadog_users.foo", "user.*", map[string]string{
+						"email": alice, "name": "Alice", "roles.#": "1", "teams.#": "1",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs("datadog_users.foo", "user.*", map[string]string{
+						"email": bob, "disabled": "true",
+					}),
+					resource.TestCheckTypeSetElemAttrPair("datadog_users.foo", "user.*.roles.*", "datadog_role.foo", "id"),
+					resource.TestCheckTypeSetElemAttrPair("datadog_users.foo", "user.*.teams.*", "datadog_team.foo", "id"),
+				),
+			},
+			{
+				Config: testAccCheckDatadogUsers(uniq, fmt.Sprintf(`
+  user {
+    email = "%s"
+    name  = "Alice Updated"
+    roles = [datadog_role.foo.id]
+    teams = []
+  }
+  user {
+    email = "%s"
+  }`, alice, bob)),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDatadogUsersDisabled(providers.frameworkProvider, map[string]bool{alice: false, bob: false, carol: true}),
+					resource.TestCheckResourceAttr("datadog_users.foo", "user_ids.%", "2"),
+					resource.TestCheckResourceAttr("datadog_users.foo", "failures.%", "0"),
+					resource.TestCheckTypeSetElemNestedAttrs("datadog_users.foo", "user.*", map[string]string{
+						"email": alice, "name": "Alice Updated", "roles.#": "1", "teams.#": "0",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckDatadogUsers(uniq, users string) string {
+	return fmt.Sprintf(`
+resource "datadog_role" "foo" {
+  name = "%[1]s"
+}
+
+resource "datadog_team" "foo" {
+  description = "Example team"
+  handle      = "%[1]s"
+  name        = "%[1]s"
+}
+
+resource "datadog_users" "foo" {
+  send_user_invitation = false
+%[2]s
+}`, uniq, users)
+}
+
+// testAccCheckDatadogUsersDisabled checks the disabled status of the users by
+// email.
+func testAccCheckDatadogUsersDisabled(accProvider *fwprovider.FrameworkProvider, disabled map[string]bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		apiInstances := accProvider.DatadogApiInstances
+		auth := accProvider.Auth
+
+		for email, expected := range disabled {
+			resp, httpResp, err := apiInstances.GetUsersApiV2().ListUsers(auth, *datadogV2.NewListUsersOptionalParameters().WithFilter(email))
+			if err != nil {
+				return utils.TranslateClientError(err, httpResp, "error searching user")
+			}
+			found := false
+			for _, user := range resp.GetData() {
+				attributes := user.GetAttributes()
+				if !strings.EqualFold(attributes.GetEmail(), email) {
+					continue
+				}
+				found = true
+				if attributes.GetDisabled() != expected {
+					return fmt.Errorf("user %s disabled is %t, expected %t", email, attributes.GetDisabled(), expected)
+				}
+			}
+			if !found {
+				return fmt.Errorf("user %s not found", email)
+			}
+		}
+		return nil
+	}
+}
+
+func testAccCheckDatadogUsersDestroy(accProvider *fwprovider.FrameworkProvider) func(*terraform.State) error {
+	return func(s *terraform.State) error {
+		apiInstances := accProvider.DatadogApiInstances
+		auth := accProvider.Auth
+
+		for _, r := range s.RootModule().Resources {
+			if r.Type != "datadog_users" {
+				continue
+			}
+			for key, userID := range r.Primary.Attributes {
+				if !strings.HasPrefix(key, "user_ids.") || key == "user_ids.%" {
+					continue
+				}
+				resp, httpResp, err := apiInstances.GetUsersApiV2().GetUser(auth, userID)
+				if err != nil {
+					if httpResp != nil && httpResp.StatusCode == 404 {
+						continue
+					}
+					return utils.TranslateClientError(err, httpResp, "error retrieving user")
+				}
+				data := resp.GetData()
+				if attributes := data.GetAttributes(); !attributes.GetDisabled() {
+					return fmt.Errorf("user %s is not disabled", attributes.GetEmail())
+				}
+			}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,23 @@
+# Run the checkout smoke tests against the freshly deployed application and
+# fail the apply if any blocking test fails.
+action "datadog_synthetics_trigger" "smoke_tests" {
+  config {
+    search          = "tag:team:checkout"
+    start_url       = "https://staging.example.com"
+    timeout_seconds = 900
+    variables = {
+      RELEASE = "v1.2.3"
+    }
+  }
+}
+
+resource "terraform_data" "deployment" {
+  input = "v1.2.3"
+
+  lifecycle {
+    action_trigger {
+      events  = [after_create, after_update]
+      actions = [action.datadog_synthetics_trigger.smoke_tests]
+    }
+  }
+}
@@ -0,0 +1,51 @@
+resource "datadog_logs_custom_pipeline" "nginx" {
+  name       = "Nginx"
+  is_enabled = true
+  filter {
+    query = "source:nginx"
+  }
+  processor {
+    grok_parser {
+      name       = "Parse access logs"
+      is_enabled = true
+      source     = "message"
+      grok {
+        support_rules = ""
+        match_rules   = "access %%{ipOrHost:network.client.ip} %%{word:http.method} %%{notSpace:http.url} %%{integer:http.status_code}"
+      }
+    }
+  }
+  processor {
+    category_processor {
+      name       = "Categorize status codes"
+      is_enabled = true
+      target     = "http.status_category"
+      category {
+        name = "OK"
+        filter {
+          query = "@http.status_code:[200 TO 299]"
+        }
+      }
+      category {
+        name = "Error"
+        filter {
+          query = "@http.status_code:[500 TO 599]"
+        }
+      }
+    }
+  }
+}
+
+data "datadog_logs_pipeline_simulation" "nginx" {
+  pipeline = datadog_logs_custom_pipeline.nginx
+  logs = [
+    jsonencode({ ddsource = "nginx", message = "10.0.0.1 GET /api 503" }),
+  ]
+}
+
+check "nginx_status_category" {
+  assert {
+    condition     = jsondecode(data.datadog_logs_pipeline_simulation.nginx.results[0].log).http.status_category == "Error"
+    error_message = "5xx responses must be categorized as errors."
+  }
+}
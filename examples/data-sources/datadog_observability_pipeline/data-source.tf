@@ -0,0 +1,9 @@
+data "datadog_observability_pipeline" "main" {
+  name = "main"
+}
+
+# Copy an existing pipeline, including components the typed resource does not support yet.
+resource "datadog_observability_pipeline_json" "copy" {
+  name        = "${data.datadog_observability_pipeline.main.name} (copy)"
+  config_json = data.datadog_observability_pipeline.main.config_json
+}
@@ -0,0 +1,51 @@
+# Convert a recording exported from the Chrome DevTools Recorder panel, or
+# generated with `npx playwright codegen --target=jsonl`
+data "datadog_synthetics_browser_steps" "checkout" {
+  recording = file("${path.module}/checkout.json")
+}
+
+resource "datadog_synthetics_test" "checkout" {
+  name       = "Checkout"
+  type       = "browser"
+  status     = "live"
+  device_ids = ["laptop_large"]
+  locations  = ["aws:eu-central-1"]
+
+  request_definition {
+    method = "GET"
+    url    = data.datadog_synthetics_browser_steps.checkout.start_url
+  }
+
+  dynamic "browser_step" {
+    for_each = data.datadog_synthetics_browser_steps.checkout.browser_step
+    content {
+      name = browser_step.value.name
+      type = browser_step.value.type
+      params {
+        attribute  = browser_step.value.params.attribute
+        check      = browser_step.value.params.check
+        click_type = browser_step.value.params.click_type
+        code       = browser_step.value.params.code
+        modifiers  = browser_step.value.params.modifiers
+        value      = browser_step.value.params.value
+        x          = browser_step.value.params.x
+        y          = browser_step.value.params.y
+
+        dynamic "element_user_locator" {
+          for_each = browser_step.value.params.element_user_locator[*]
+          content {
+            fail_test_on_cannot_locate = element_user_locator.value.fail_test_on_cannot_locate
+            value {
+              type  = element_user_locator.value.value.type
+              value = element_user_locator.value.value.value
+            }
+          }
+        }
+      }
+    }
+  }
+
+  options_list {
+    tick_every = 3600
+  }
+}
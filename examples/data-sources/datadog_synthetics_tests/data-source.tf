@@ -0,0 +1,16 @@
+data "datadog_synthetics_tests" "checkout" {
+  tags_filter = ["team:checkout"]
+  type        = "api"
+  status      = "live"
+}
+
+resource "datadog_synthetics_suite" "checkout" {
+  name = "Checkout"
+
+  dynamic "tests" {
+    for_each = data.datadog_synthetics_tests.checkout.public_ids
+    content {
+      public_id = tests.value
+    }
+  }
+}
@@ -0,0 +1,39 @@
+# Render the pipeline as the Worker configuration, to review it in plans and
+# run an on-prem Worker locally against the same definition.
+resource "datadog_observability_pipeline" "main" {
+  name = "main"
+
+  config {
+    source {
+      id = "agent"
+      datadog_agent {}
+    }
+
+    processor_group {
+      id      = "parsing"
+      enabled = true
+      include = "service:web"
+      inputs  = ["agent"]
+
+      processor {
+        id      = "parse-json"
+        enabled = true
+        include = "*"
+        parse_json {
+          field = "message"
+        }
+      }
+    }
+
+    destination {
+      id     = "datadog"
+      inputs = ["parsing"]
+      datadog_logs {}
+    }
+  }
+}
+
+resource "local_file" "worker_config" {
+  filename = "${path.module}/worker/pipeline.yaml"
+  content  = provider::datadog::observability_pipeline_worker_config(datadog_observability_pipeline.main)
+}
@@ -0,0 +1,52 @@
+# Manage a library of Sigma rules as Datadog detection rules.
+locals {
+  sigma_field_mappings = {
+    "linux" = {
+      logsource = "source:(auditd OR sysmon)"
+      User      = "@usr.name"
+    }
+    "linux/process_creation" = {
+      Image       = "@process.executable.path"
+      CommandLine = "@process.cmdline"
+    }
+  }
+
+  sigma_rules = {
+    for file in fileset("${path.module}/sigma", "*.yml") :
+    trimsuffix(file, ".yml") => provider::datadog::sigma_to_rule(file("${path.module}/sigma/${file}"), local.sigma_field_mappings)
+  }
+}
+
+resource "datadog_security_monitoring_rule" "sigma" {
+  for_each = local.sigma_rules
+
+  name    = each.value.name
+  message = each.value.message
+  tags    = concat(each.value.tags, ["team:detection"])
+
+  dynamic "query" {
+    for_each = each.value.query
+    content {
+      name            = query.value.name
+      query           = query.value.query
+      aggregation     = query.value.aggregation
+      group_by_fields = query.value.group_by_fields
+      distinct_fields = query.value.distinct_fields
+    }
+  }
+
+  dynamic "case" {
+    for_each = each.value.case
+    content {
+      status    = case.value.status
+      condition = case.value.condition
+    }
+  }
+
+  options {
+    detection_method    = each.value.options.detection_method
+    evaluation_window   = each.value.options.evaluation_window
+    keep_alive          = each.value.options.keep_alive
+    max_signal_duration = each.value.options.max_signal_duration
+  }
+}
@@ -2,3 +2,10 @@
 resource "datadog_api_key" "foo" {
   name = "foo-application"
 }
+
+# Create a new Datadog API Key rotated every 90 days, keeping the previous key for 7 days
+resource "datadog_api_key" "rotated" {
+  name            = "rotated-application"
+  rotation_period = "90d"
+  grace_period    = "7d"
+}
@@ -0,0 +1,2 @@
+# Exclusion filters can be imported using the name of the index and the name of the filter, separated by a colon.
+terraform import datadog_logs_index_exclusion_filter.debug_logs "main:Exclude debug logs of the checkout service"
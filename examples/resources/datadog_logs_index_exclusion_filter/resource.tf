@@ -0,0 +1,8 @@
+# Exclude most of the debug logs of a service from an index managed elsewhere
+resource "datadog_logs_index_exclusion_filter" "debug_logs" {
+  index       = "main"
+  name        = "Exclude debug logs of the checkout service"
+  is_enabled  = true
+  query       = "service:checkout status:debug"
+  sample_rate = 0.9
+}
@@ -7,3 +7,15 @@ resource "datadog_logs_pipeline_order" "sample_pipeline_order" {
   ]
 }
 
+
+# Only order the pipelines of a team, right after a shared pipeline
+resource "datadog_logs_pipeline_order" "team_pipeline_order" {
+  name    = "team_pipeline_order"
+  partial = true
+  after   = datadog_logs_integration_pipeline.python.id
+
+  pipelines = [
+    datadog_logs_custom_pipeline.team_parsing.id,
+    datadog_logs_custom_pipeline.team_enrichment.id
+  ]
+}
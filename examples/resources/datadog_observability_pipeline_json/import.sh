@@ -0,0 +1 @@
+terraform import datadog_observability_pipeline_json.main 00000000-0000-0000-0000-000000000000
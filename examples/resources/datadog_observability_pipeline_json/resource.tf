@@ -0,0 +1,27 @@
+resource "datadog_observability_pipeline_json" "main" {
+  name = "main"
+  config_json = jsonencode({
+    sources = [{
+      id   = "agent"
+      type = "datadog_agent"
+    }]
+    processor_groups = [{
+      id      = "parsing"
+      enabled = true
+      include = "service:web"
+      inputs  = ["agent"]
+      processors = [{
+        id      = "parse-json"
+        type    = "parse_json"
+        enabled = true
+        include = "*"
+        field   = "message"
+      }]
+    }]
+    destinations = [{
+      id     = "datadog"
+      type   = "datadog_logs"
+      inputs = ["parsing"]
+    }]
+  })
+}
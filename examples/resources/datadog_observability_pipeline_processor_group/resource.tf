@@ -0,0 +1,43 @@
+# Define a processor stack once and use it in several pipelines.
+resource "datadog_observability_pipeline_processor_group" "parsing" {
+  processor {
+    id      = "parse-json"
+    enabled = true
+    include = "*"
+    parse_json {
+      field = "message"
+    }
+  }
+
+  processor {
+    id      = "drop-debug"
+    enabled = true
+    include = "NOT status:debug"
+    filter {}
+  }
+}
+
+resource "datadog_observability_pipeline" "web" {
+  name = "web"
+
+  config {
+    source {
+      id = "agent"
+      datadog_agent {}
+    }
+
+    processor_group {
+      id              = "parsing"
+      enabled         = true
+      include         = "service:web"
+      inputs          = ["agent"]
+      processors_json = datadog_observability_pipeline_processor_group.parsing.processors_json
+    }
+
+    destination {
+      id     = "datadog"
+      inputs = ["parsing"]
+      datadog_logs {}
+    }
+  }
+}
@@ -0,0 +1,3 @@
+# This resource is imported using resource_id, relation and principal separated by `:`.
+
+terraform import datadog_restriction_policy_binding.security_editor "dashboard:abc-def-ghi:editor:team:00000000-0000-1111-0000-000000000000"
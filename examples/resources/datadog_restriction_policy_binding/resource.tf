@@ -0,0 +1,13 @@
+# Grant a team edit access to a dashboard, without managing the other bindings of its restriction policy
+
+resource "datadog_team" "security" {
+  description = "Central security team"
+  handle      = "security"
+  name        = "Security"
+}
+
+resource "datadog_restriction_policy_binding" "security_editor" {
+  resource_id = "dashboard:abc-def-ghi"
+  relation    = "editor"
+  principal   = "team:${datadog_team.security.id}"
+}
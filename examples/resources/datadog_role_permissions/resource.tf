@@ -0,0 +1,22 @@
+resource "datadog_role" "log_reader" {
+  name = "Log Reader"
+
+  # The permissions of the role are managed by datadog_role_permissions
+  lifecycle {
+    ignore_changes = [permission]
+  }
+}
+
+# Grant permissions by name, and revoke the other permissions of the role
+resource "datadog_role_permissions" "log_reader" {
+  role_id     = datadog_role.log_reader.id
+  permissions = ["logs_read_data", "logs_read_index_data", "logs_live_tail"]
+}
+
+# Only manage the listed permissions of an existing role
+resource "datadog_role_permissions" "key_managers" {
+  role_id     = "00000000-0000-1111-0000-000000000000"
+  exclusive   = false
+  restricted  = false
+  permissions = ["api_keys_read", "api_keys_write"]
+}
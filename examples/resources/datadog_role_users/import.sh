@@ -0,0 +1,3 @@
+# This resource is imported using the role ID, the imported resource is exclusive.
+
+terraform import datadog_role_users.on_call "${role_id}"
@@ -0,0 +1,16 @@
+resource "datadog_role" "on_call" {
+  name = "On-call"
+}
+
+# Set the users of the role, and remove the other users
+resource "datadog_role_users" "on_call" {
+  role_id = datadog_role.on_call.id
+  users   = ["alice@example.com", "bob@example.com"]
+}
+
+# Only manage the listed users of an existing role
+resource "datadog_role_users" "admins" {
+  role_id   = "00000000-0000-1111-0000-000000000000"
+  exclusive = false
+  users     = ["carol@example.com"]
+}
@@ -0,0 +1,14 @@
+# Enable all the CloudTrail and Okta default rules, suppress the signals of
+# the test accounts and send them to the security team.
+resource "datadog_security_monitoring_default_rules" "identity" {
+  sources          = ["cloudtrail", "okta"]
+  exclude_rule_ids = [datadog_security_monitoring_default_rule.tuned.id]
+
+  enabled       = true
+  notifications = ["@slack-security-alerts"]
+
+  filter {
+    action = "suppress"
+    query  = "@usr.name:test-*"
+  }
+}
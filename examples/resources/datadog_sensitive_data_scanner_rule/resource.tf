@@ -33,6 +33,11 @@ resource "datadog_sensitive_data_scanner_rule" "myrule" {
     character_count = 30
   }
   priority = 1
+  // Evaluated at plan time, the samples are not sent to Datadog
+  test_samples {
+    match    = ["credit card: myregex"]
+    no_match = ["myregex"]
+  }
 }
 
 data "datadog_sensitive_data_scanner_standard_pattern" "aws_sp" {
@@ -3,3 +3,17 @@ resource "datadog_synthetics_private_location" "private_location" {
   description = "Description of the private location"
   tags        = ["foo:bar", "env:test"]
 }
+
+# Regenerate the private location credentials every 90 days
+resource "time_rotating" "private_location" {
+  rotation_days = 90
+}
+
+resource "datadog_synthetics_private_location" "rotated_private_location" {
+  name             = "Rotated private location"
+  rotation_trigger = time_rotating.private_location.id
+
+  lifecycle {
+    create_before_destroy = true
+  }
+}
@@ -0,0 +1,2 @@
+# Synthetics tests can be imported using their public string ID, e.g.
+terraform import datadog_synthetics_test_json.test_json abc-def-ghi
@@ -0,0 +1,40 @@
+# Example Usage (Synthetics API test exported from the Datadog UI)
+resource "datadog_synthetics_test_json" "test_json" {
+  json = <<-EOF
+{
+  "public_id": "abc-def-ghi",
+  "name": "An API test on example.org",
+  "type": "api",
+  "subtype": "http",
+  "status": "live",
+  "message": "Notify @pagerduty",
+  "tags": ["foo:bar", "env:test"],
+  "locations": ["aws:eu-central-1"],
+  "config": {
+    "request": {
+      "method": "GET",
+      "url": "https://www.example.org"
+    },
+    "assertions": [
+      {
+        "type": "statusCode",
+        "operator": "is",
+        "target": 200
+      }
+    ],
+    "variables": [
+      {
+        "name": "PASSWORD",
+        "type": "text",
+        "secure": true,
+        "example": "{{ GLOBAL_PASSWORD }}",
+        "pattern": "{{ GLOBAL_PASSWORD }}"
+      }
+    ]
+  },
+  "options": {
+    "tick_every": 900
+  }
+}
+EOF
+}
@@ -0,0 +1,3 @@
+# This resource is imported using the team ID, the imported resource is exclusive.
+
+terraform import datadog_team_memberships.sre "${team_id}"
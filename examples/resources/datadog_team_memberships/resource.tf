@@ -0,0 +1,11 @@
+resource "datadog_team" "sre" {
+  name        = "SRE"
+  handle      = "sre"
+  description = "Site reliability engineering"
+}
+
+# Set the members of the team, and remove the other members
+resource "datadog_team_memberships" "sre" {
+  team_id = datadog_team.sre.id
+  members = ["alice@example.com", "bob@example.com"]
+}
@@ -0,0 +1,28 @@
+# people.csv, exported from the identity source:
+# email,name,team,disabled
+# alice@example.com,Alice,sre,false
+# bob@example.com,Bob,sre,true
+locals {
+  people = csvdecode(file("${path.module}/people.csv"))
+}
+
+data "datadog_role" "standard" {
+  filter = "Datadog Standard Role"
+}
+
+data "datadog_team" "sre" {
+  filter_keyword = "sre"
+}
+
+resource "datadog_users" "people" {
+  dynamic "user" {
+    for_each = local.people
+    content {
+      email    = user.value.email
+      name     = user.value.name
+      roles    = [data.datadog_role.standard.id]
+      teams    = user.value.team == "sre" ? [data.datadog_team.sre.id] : []
+      disabled = tobool(user.value.disabled)
+    }
+  }
+}